@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS coin_listings;
+DROP TABLE IF EXISTS price_gaps;
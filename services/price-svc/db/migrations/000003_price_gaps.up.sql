@@ -0,0 +1,16 @@
+CREATE TABLE price_gaps (
+    coin_id text NOT NULL,
+    day_utc timestamptz NOT NULL,
+    reason text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (coin_id, day_utc)
+);
+
+CREATE INDEX idx_price_gaps_expires_at ON price_gaps (expires_at);
+
+CREATE TABLE coin_listings (
+    coin_id text PRIMARY KEY,
+    first_price_at timestamptz NOT NULL,
+    checked_at timestamptz NOT NULL DEFAULT now()
+);
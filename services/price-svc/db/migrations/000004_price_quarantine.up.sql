@@ -0,0 +1,15 @@
+CREATE TABLE price_quarantine (
+    id bigserial PRIMARY KEY,
+    coin_id text NOT NULL,
+    bucket_start_utc timestamptz NOT NULL,
+    granularity_seconds int4 NOT NULL,
+    raw_price numeric NOT NULL,
+    substituted_price numeric,
+    neighbour_median numeric,
+    reason text NOT NULL,
+    detected_at timestamptz NOT NULL DEFAULT now(),
+    reviewed_at timestamptz,
+    UNIQUE (coin_id, bucket_start_utc, granularity_seconds)
+);
+
+CREATE INDEX idx_price_quarantine_unreviewed ON price_quarantine (detected_at) WHERE reviewed_at IS NULL;
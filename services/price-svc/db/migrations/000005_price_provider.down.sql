@@ -0,0 +1 @@
+ALTER TABLE historical_prices DROP COLUMN IF EXISTS provider;
@@ -0,0 +1 @@
+ALTER TABLE historical_prices ADD COLUMN provider text NOT NULL DEFAULT 'coingecko';
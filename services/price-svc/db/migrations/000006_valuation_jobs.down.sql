@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS valuation_job_items;
+DROP TABLE IF EXISTS valuation_jobs;
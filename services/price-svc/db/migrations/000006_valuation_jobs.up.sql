@@ -0,0 +1,25 @@
+CREATE TABLE valuation_jobs (
+    id uuid PRIMARY KEY,
+    tenant_id text NOT NULL DEFAULT '',
+    status text NOT NULL,
+    request bytea NOT NULL,
+    total integer NOT NULL,
+    processed integer NOT NULL DEFAULT 0,
+    error text,
+    lease_until timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    started_at timestamptz,
+    finished_at timestamptz
+);
+
+CREATE INDEX idx_valuation_jobs_pending ON valuation_jobs (created_at)
+    WHERE status IN ('queued', 'running');
+
+CREATE TABLE valuation_job_items (
+    job_id uuid NOT NULL REFERENCES valuation_jobs (id) ON DELETE CASCADE,
+    seq integer NOT NULL,
+    tx bytea NOT NULL,
+    result bytea,
+    PRIMARY KEY (job_id, seq)
+);
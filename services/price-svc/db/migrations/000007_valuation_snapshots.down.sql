@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS snapshot_valuations;
+DROP TABLE IF EXISTS valuation_snapshots;
@@ -0,0 +1,29 @@
+CREATE TABLE valuation_snapshots (
+    id text PRIMARY KEY,
+    fiat_currency text NOT NULL,
+    price_point integer NOT NULL,
+    lookup_nearest boolean NOT NULL,
+    lookup_tolerance_seconds integer NOT NULL,
+    lookup_accept_coarser boolean NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- Rows are written once and never updated: re-valuing with a snapshot returns exactly these values.
+CREATE TABLE snapshot_valuations (
+    snapshot_id text NOT NULL REFERENCES valuation_snapshots (id) ON DELETE CASCADE,
+    kind text NOT NULL, -- coin | fiat
+    asset text NOT NULL, -- coin ID or ISO-4217 code
+    at_utc timestamptz NOT NULL, -- requested tx time (coin) or day (fiat)
+    fiat_value numeric NOT NULL,
+    method integer NOT NULL,
+    lower_precision boolean NOT NULL,
+    bucket_start_utc timestamptz,
+    granularity_seconds integer,
+    provider text NOT NULL,
+    price_usd numeric,
+    fetched_at timestamptz,
+    fx_rate numeric NOT NULL,
+    fx_effective_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (snapshot_id, kind, asset, at_utc)
+);
@@ -0,0 +1,23 @@
+ALTER TABLE valuation_snapshots DROP COLUMN IF EXISTS lookup_as_of;
+
+DROP VIEW IF EXISTS historical_prices;
+
+CREATE TABLE historical_prices (
+    coin_id text NOT NULL,
+    bucket_start_utc timestamptz NOT NULL,
+    price_usd numeric NOT NULL,
+    granularity_seconds integer NOT NULL DEFAULT 86400,
+    fetched_at timestamptz NOT NULL DEFAULT now(),
+    provider text NOT NULL DEFAULT 'coingecko',
+    PRIMARY KEY (coin_id, bucket_start_utc)
+);
+
+CREATE INDEX idx_prices_bucket ON historical_prices (bucket_start_utc DESC);
+
+INSERT INTO historical_prices (coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at, provider)
+SELECT DISTINCT ON (coin_id, bucket_start_utc)
+    coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at, provider
+FROM historical_price_revisions
+ORDER BY coin_id, bucket_start_utc, fetched_at DESC;
+
+DROP TABLE IF EXISTS historical_price_revisions;
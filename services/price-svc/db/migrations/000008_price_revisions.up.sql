@@ -0,0 +1,32 @@
+-- Bitemporal price history: bucket_start_utc is the valid time, fetched_at the transaction time.
+-- Revisions are only appended; historical_prices is the latest revision of every bucket.
+CREATE TABLE historical_price_revisions (
+    coin_id text NOT NULL,
+    bucket_start_utc timestamptz NOT NULL,
+    price_usd numeric NOT NULL,
+    granularity_seconds integer NOT NULL,
+    provider text NOT NULL DEFAULT 'coingecko',
+    fetched_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (coin_id, bucket_start_utc, fetched_at)
+);
+
+CREATE INDEX idx_price_revisions_bucket ON historical_price_revisions (bucket_start_utc DESC);
+
+INSERT INTO historical_price_revisions (coin_id, bucket_start_utc, price_usd, granularity_seconds, provider, fetched_at)
+SELECT coin_id, bucket_start_utc, price_usd, granularity_seconds, provider, fetched_at
+FROM historical_prices;
+
+DROP TABLE historical_prices;
+
+CREATE VIEW historical_prices AS
+SELECT DISTINCT ON (coin_id, bucket_start_utc)
+    coin_id,
+    bucket_start_utc,
+    price_usd,
+    granularity_seconds,
+    fetched_at,
+    provider
+FROM historical_price_revisions
+ORDER BY coin_id, bucket_start_utc, fetched_at DESC;
+
+ALTER TABLE valuation_snapshots ADD COLUMN lookup_as_of timestamptz;
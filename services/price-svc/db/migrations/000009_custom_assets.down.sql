@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS custom_asset_prices;
+DROP TABLE IF EXISTS custom_assets;
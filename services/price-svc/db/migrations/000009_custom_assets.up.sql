@@ -0,0 +1,20 @@
+-- Tenant-defined assets the provider does not list, priced from manually supplied series.
+CREATE TABLE custom_assets (
+    id uuid PRIMARY KEY,
+    tenant_id uuid NOT NULL,
+    symbol text NOT NULL,
+    name text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, symbol)
+);
+
+CREATE TABLE custom_asset_prices (
+    asset_id uuid NOT NULL REFERENCES custom_assets (id) ON DELETE CASCADE,
+    at_utc timestamptz NOT NULL,
+    price numeric NOT NULL,
+    currency text NOT NULL, -- ISO-4217 code the price is quoted in
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (asset_id, at_utc)
+);
@@ -0,0 +1,10 @@
+-- Token contracts per chain from the provider's coin list, so on-chain assets resolve by address.
+CREATE TABLE coin_contracts (
+    platform text NOT NULL, -- provider platform ID, e.g. ethereum, binance-smart-chain
+    contract_address text NOT NULL, -- 0x addresses lower-cased, others as listed
+    coin_id text NOT NULL,
+    synced_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (platform, contract_address)
+);
+
+CREATE INDEX coin_contracts_address_idx ON coin_contracts (contract_address);
@@ -0,0 +1,15 @@
+-- keep the latest mapping of every symbol
+DELETE FROM tenant_symbols t
+USING tenant_symbols newer
+WHERE newer.tenant_id = t.tenant_id
+  AND newer.source = t.source
+  AND newer.symbol = t.symbol
+  AND newer.valid_from > t.valid_from;
+
+ALTER TABLE tenant_symbols DROP CONSTRAINT tenant_symbols_pkey;
+ALTER TABLE tenant_symbols ADD PRIMARY KEY (tenant_id, source, symbol);
+
+ALTER TABLE tenant_symbols
+    DROP CONSTRAINT tenant_symbols_valid_range,
+    DROP COLUMN valid_to,
+    DROP COLUMN valid_from;
@@ -0,0 +1,9 @@
+-- A symbol may map to different coins over time (ticker migrations, forks): every mapping holds
+-- for [valid_from, valid_to), and the infinities mark open bounds.
+ALTER TABLE tenant_symbols
+    ADD COLUMN valid_from timestamptz NOT NULL DEFAULT '-infinity',
+    ADD COLUMN valid_to timestamptz NOT NULL DEFAULT 'infinity',
+    ADD CONSTRAINT tenant_symbols_valid_range CHECK (valid_from < valid_to);
+
+ALTER TABLE tenant_symbols DROP CONSTRAINT tenant_symbols_pkey;
+ALTER TABLE tenant_symbols ADD PRIMARY KEY (tenant_id, source, symbol, valid_from);
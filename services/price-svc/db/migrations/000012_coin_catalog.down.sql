@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS coin_catalog_changes;
+DROP TABLE IF EXISTS coins;
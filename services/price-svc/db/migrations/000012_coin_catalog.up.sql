@@ -0,0 +1,26 @@
+-- Local copy of the provider's coin list; coins it stops listing are kept and flagged delisted.
+CREATE TABLE coins (
+    id text PRIMARY KEY,
+    symbol text NOT NULL,
+    name text NOT NULL,
+    platforms jsonb NOT NULL DEFAULT '{}', -- platform ID -> contract address
+    first_seen timestamptz NOT NULL DEFAULT now(),
+    last_seen timestamptz NOT NULL DEFAULT now(),
+    delisted boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX coins_symbol_idx ON coins (lower(symbol));
+
+-- What every catalog sync changed: added, removed (delisted), relisted or renamed coins.
+CREATE TABLE coin_catalog_changes (
+    id bigserial PRIMARY KEY,
+    synced_at timestamptz NOT NULL,
+    coin_id text NOT NULL,
+    change text NOT NULL, -- added | removed | relisted | renamed
+    old_symbol text NOT NULL DEFAULT '',
+    old_name text NOT NULL DEFAULT '',
+    new_symbol text NOT NULL DEFAULT '',
+    new_name text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX coin_catalog_changes_coin_idx ON coin_catalog_changes (coin_id, synced_at);
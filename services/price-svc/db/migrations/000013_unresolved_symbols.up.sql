@@ -0,0 +1,15 @@
+-- Symbols valuations could not resolve, per tenant and source, for support to review.
+-- Entries are cleared when a mapping is written for them through the resolve action.
+CREATE TABLE unresolved_symbols (
+    id bigserial NOT NULL UNIQUE,
+    tenant_id uuid NOT NULL,
+    source text NOT NULL,
+    symbol text NOT NULL,
+    canonical_symbol text NOT NULL,
+    reason text NOT NULL, -- unknown | ambiguous
+    message text NOT NULL,
+    occurrences bigint NOT NULL,
+    first_seen timestamptz NOT NULL DEFAULT now(),
+    last_seen timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, source, symbol)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS tenant_symbols_source_symbol_idx;
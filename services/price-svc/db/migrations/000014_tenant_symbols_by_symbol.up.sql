@@ -0,0 +1,2 @@
+-- Cross-tenant lookups count how every tenant maps a source's symbol.
+CREATE INDEX IF NOT EXISTS tenant_symbols_source_symbol_idx ON tenant_symbols (source, symbol);
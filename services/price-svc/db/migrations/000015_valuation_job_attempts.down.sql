@@ -0,0 +1,3 @@
+ALTER TABLE valuation_jobs
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS attempts;
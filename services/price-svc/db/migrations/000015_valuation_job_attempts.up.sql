@@ -0,0 +1,4 @@
+-- Failed attempts of a job; a retried job is not claimed again before next_attempt_at.
+ALTER TABLE valuation_jobs
+    ADD COLUMN attempts integer NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at timestamptz;
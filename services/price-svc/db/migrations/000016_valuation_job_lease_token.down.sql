@@ -0,0 +1 @@
+ALTER TABLE valuation_jobs DROP COLUMN IF EXISTS lease_token;
@@ -0,0 +1,2 @@
+-- Token of the worker holding a running job's lease; writes from a worker whose lease was taken over match no rows.
+ALTER TABLE valuation_jobs ADD COLUMN lease_token uuid;
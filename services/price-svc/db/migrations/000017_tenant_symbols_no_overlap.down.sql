@@ -0,0 +1 @@
+ALTER TABLE tenant_symbols DROP CONSTRAINT IF EXISTS tenant_symbols_no_overlap;
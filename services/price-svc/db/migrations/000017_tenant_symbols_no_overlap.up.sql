@@ -0,0 +1,6 @@
+-- Validity windows of one symbol must not overlap. The usecase checks this before writing;
+-- the constraint also covers concurrent writers.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE tenant_symbols ADD CONSTRAINT tenant_symbols_no_overlap
+    EXCLUDE USING gist (tenant_id WITH =, source WITH =, symbol WITH =, tstzrange(valid_from, valid_to) WITH &&);
@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS snapshot_resolutions;
+
+ALTER TABLE snapshot_valuations DROP CONSTRAINT snapshot_valuations_tenant_id_snapshot_id_fkey;
+ALTER TABLE snapshot_valuations DROP CONSTRAINT snapshot_valuations_pkey;
+ALTER TABLE valuation_snapshots DROP CONSTRAINT valuation_snapshots_pkey;
+
+-- IDs reused across tenants cannot be kept under a global key
+DELETE FROM valuation_snapshots
+WHERE tenant_id <> '00000000-0000-0000-0000-000000000000'
+  AND id IN (SELECT id FROM valuation_snapshots GROUP BY id HAVING count(*) > 1);
+DELETE FROM snapshot_valuations v
+WHERE NOT EXISTS (
+  SELECT 1 FROM valuation_snapshots s WHERE s.tenant_id = v.tenant_id AND s.id = v.snapshot_id
+);
+
+ALTER TABLE valuation_snapshots DROP COLUMN tenant_id;
+ALTER TABLE valuation_snapshots ADD PRIMARY KEY (id);
+
+ALTER TABLE snapshot_valuations DROP COLUMN tenant_id;
+ALTER TABLE snapshot_valuations ADD PRIMARY KEY (snapshot_id, kind, asset, at_utc);
+ALTER TABLE snapshot_valuations ADD FOREIGN KEY (snapshot_id)
+    REFERENCES valuation_snapshots (id) ON DELETE CASCADE;
@@ -0,0 +1,34 @@
+-- Snapshot IDs are chosen by callers, so they are only unique within a tenant. Snapshots taken
+-- without a tenant keep the nil UUID.
+ALTER TABLE snapshot_valuations DROP CONSTRAINT snapshot_valuations_snapshot_id_fkey;
+ALTER TABLE snapshot_valuations DROP CONSTRAINT snapshot_valuations_pkey;
+ALTER TABLE valuation_snapshots DROP CONSTRAINT valuation_snapshots_pkey;
+
+ALTER TABLE valuation_snapshots
+    ADD COLUMN tenant_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE valuation_snapshots ADD PRIMARY KEY (tenant_id, id);
+
+ALTER TABLE snapshot_valuations
+    ADD COLUMN tenant_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE snapshot_valuations ADD PRIMARY KEY (tenant_id, snapshot_id, kind, asset, at_utc);
+ALTER TABLE snapshot_valuations ADD FOREIGN KEY (tenant_id, snapshot_id)
+    REFERENCES valuation_snapshots (tenant_id, id) ON DELETE CASCADE;
+
+-- The coin each leg resolved to when first valued under the snapshot, so later calls price the
+-- same coin even after the symbol mappings change. Rows are written once and never updated.
+CREATE TABLE snapshot_resolutions (
+    tenant_id uuid NOT NULL,
+    snapshot_id text NOT NULL,
+    source text NOT NULL,
+    symbol text NOT NULL,
+    chain text NOT NULL,
+    contract_address text NOT NULL,
+    at_utc timestamptz NOT NULL, -- leg time the mapping was resolved at
+    coin_id text NOT NULL,
+    canonical_symbol text NOT NULL,
+    quote text NOT NULL,
+    consensus boolean NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, snapshot_id, source, symbol, chain, contract_address, at_utc),
+    FOREIGN KEY (tenant_id, snapshot_id) REFERENCES valuation_snapshots (tenant_id, id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS coins_id_trgm_idx;
+DROP INDEX IF EXISTS coins_name_trgm_idx;
+DROP INDEX IF EXISTS coins_symbol_trgm_idx;
@@ -0,0 +1,7 @@
+-- Catalog search matches substrings of symbols, names and IDs; trigram indexes keep it off a
+-- full scan of the catalog for queries of three characters or more.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX coins_symbol_trgm_idx ON coins USING gin (lower(symbol) gin_trgm_ops);
+CREATE INDEX coins_name_trgm_idx ON coins USING gin (lower(name) gin_trgm_ops);
+CREATE INDEX coins_id_trgm_idx ON coins USING gin (lower(id) gin_trgm_ops);
@@ -0,0 +1,21 @@
+-- name: UpsertCoinContracts :exec
+INSERT INTO coin_contracts (platform, contract_address, coin_id, synced_at)
+SELECT p.platform, a.contract_address, c.coin_id, sqlc.arg(synced_at)::timestamptz
+FROM unnest(sqlc.arg(platforms)::text[])          WITH ORDINALITY AS p(platform, ord)
+JOIN unnest(sqlc.arg(contract_addresses)::text[]) WITH ORDINALITY AS a(contract_address, ord) USING (ord)
+JOIN unnest(sqlc.arg(coin_ids)::text[])           WITH ORDINALITY AS c(coin_id, ord) USING (ord)
+ON CONFLICT (platform, contract_address)
+DO UPDATE SET coin_id = EXCLUDED.coin_id, synced_at = EXCLUDED.synced_at;
+
+-- name: DeleteCoinContractsSyncedBefore :execrows
+DELETE FROM coin_contracts
+WHERE synced_at < $1;
+
+-- name: GetCoinContractsByAddresses :many
+SELECT platform, contract_address, coin_id, synced_at
+FROM coin_contracts
+WHERE contract_address = ANY($1::text[]);
+
+-- name: GetCoinContractsLastSync :one
+SELECT max(synced_at)::timestamptz AS synced_at
+FROM coin_contracts;
@@ -0,0 +1,77 @@
+-- name: UpsertCoins :exec
+INSERT INTO coins (id, symbol, name, platforms, first_seen, last_seen, delisted)
+SELECT i.id, s.symbol, n.name, p.platforms, sqlc.arg(synced_at)::timestamptz, sqlc.arg(synced_at)::timestamptz, false
+FROM unnest(sqlc.arg(ids)::text[])         WITH ORDINALITY AS i(id, ord)
+JOIN unnest(sqlc.arg(symbols)::text[])     WITH ORDINALITY AS s(symbol, ord) USING (ord)
+JOIN unnest(sqlc.arg(names)::text[])       WITH ORDINALITY AS n(name, ord) USING (ord)
+JOIN unnest(sqlc.arg(platforms)::jsonb[])  WITH ORDINALITY AS p(platforms, ord) USING (ord)
+ON CONFLICT (id)
+DO UPDATE SET symbol = EXCLUDED.symbol,
+              name = EXCLUDED.name,
+              platforms = EXCLUDED.platforms,
+              last_seen = EXCLUDED.last_seen,
+              delisted = false;
+
+-- name: MarkCoinsDelisted :execrows
+UPDATE coins
+SET delisted = true
+WHERE last_seen < $1
+  AND NOT delisted;
+
+-- name: InsertCoinCatalogChanges :exec
+INSERT INTO coin_catalog_changes (synced_at, coin_id, change, old_symbol, old_name, new_symbol, new_name)
+SELECT sqlc.arg(synced_at)::timestamptz, c.coin_id, k.change, os.old_symbol, onm.old_name, ns.new_symbol, nn.new_name
+FROM unnest(sqlc.arg(coin_ids)::text[])     WITH ORDINALITY AS c(coin_id, ord)
+JOIN unnest(sqlc.arg(changes)::text[])      WITH ORDINALITY AS k(change, ord) USING (ord)
+JOIN unnest(sqlc.arg(old_symbols)::text[])  WITH ORDINALITY AS os(old_symbol, ord) USING (ord)
+JOIN unnest(sqlc.arg(old_names)::text[])    WITH ORDINALITY AS onm(old_name, ord) USING (ord)
+JOIN unnest(sqlc.arg(new_symbols)::text[])  WITH ORDINALITY AS ns(new_symbol, ord) USING (ord)
+JOIN unnest(sqlc.arg(new_names)::text[])    WITH ORDINALITY AS nn(new_name, ord) USING (ord);
+
+-- name: ListCoins :many
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+ORDER BY id ASC;
+
+-- name: GetCoinsByIDs :many
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE id = ANY($1::text[]);
+
+-- name: GetActiveCoinsBySymbols :many
+-- Symbols match case-insensitively; the provider lists them lower-case.
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE lower(symbol) = ANY($1::text[])
+  AND NOT delisted
+ORDER BY id ASC;
+
+-- name: GetCoinCatalogLastSync :one
+SELECT max(last_seen)::timestamptz AS synced_at
+FROM coins;
+
+-- name: GetCoin :one
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE id = $1;
+
+-- name: SearchCoins :many
+-- Ranks exact symbol matches first, then symbol prefixes, name prefixes and substrings;
+-- delisted coins come after listed ones of the same rank. The trigram indexes serve queries of
+-- three characters or more; shorter ones scan the catalog.
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE lower(symbol) LIKE sqlc.arg(contains)::text
+   OR lower(name) LIKE sqlc.arg(contains)::text
+   OR lower(id) LIKE sqlc.arg(contains)::text
+ORDER BY
+  CASE
+    WHEN lower(symbol) = sqlc.arg(query)::text THEN 0
+    WHEN lower(symbol) LIKE sqlc.arg(prefix)::text THEN 1
+    WHEN lower(name) LIKE sqlc.arg(prefix)::text THEN 2
+    ELSE 3
+  END,
+  delisted,
+  length(symbol),
+  id
+LIMIT sqlc.arg(max_results);
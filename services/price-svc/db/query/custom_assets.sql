@@ -0,0 +1,79 @@
+-- name: UpsertCustomAsset :one
+INSERT INTO custom_assets (id, tenant_id, symbol, name)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, symbol)
+DO UPDATE SET name = EXCLUDED.name, updated_at = now()
+RETURNING id, tenant_id, symbol, name, created_at, updated_at;
+
+-- name: EnsureCustomAssets :exec
+-- Creates missing assets; existing ones keep their name.
+INSERT INTO custom_assets (id, tenant_id, symbol, name)
+SELECT i.id, sqlc.arg(tenant_id)::uuid, s.symbol, s.symbol
+FROM unnest(sqlc.arg(ids)::uuid[])       WITH ORDINALITY AS i(id, ord)
+JOIN unnest(sqlc.arg(symbols)::text[])   WITH ORDINALITY AS s(symbol, ord) USING (ord)
+ON CONFLICT (tenant_id, symbol) DO NOTHING;
+
+-- name: GetCustomAsset :one
+SELECT id, tenant_id, symbol, name, created_at, updated_at
+FROM custom_assets
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: ListCustomAssets :many
+SELECT id, tenant_id, symbol, name, created_at, updated_at
+FROM custom_assets
+WHERE tenant_id = $1
+ORDER BY symbol ASC;
+
+-- name: GetCustomAssetsBySymbols :many
+SELECT id, tenant_id, symbol, name, created_at, updated_at
+FROM custom_assets
+WHERE tenant_id = $1
+  AND symbol = ANY($2::text[]);
+
+-- name: DeleteCustomAsset :execrows
+DELETE FROM custom_assets
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: UpsertCustomAssetPrices :exec
+INSERT INTO custom_asset_prices (asset_id, at_utc, price, currency)
+SELECT a.asset_id, t.at_utc, p.price, c.currency
+FROM unnest(sqlc.arg(asset_ids)::uuid[])      WITH ORDINALITY AS a(asset_id, ord)
+JOIN unnest(sqlc.arg(at_utc)::timestamptz[])  WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+JOIN unnest(sqlc.arg(prices)::numeric[])      WITH ORDINALITY AS p(price, ord) USING (ord)
+JOIN unnest(sqlc.arg(currencies)::text[])     WITH ORDINALITY AS c(currency, ord) USING (ord)
+ON CONFLICT (asset_id, at_utc)
+DO UPDATE SET price = EXCLUDED.price, currency = EXCLUDED.currency, updated_at = now();
+
+-- name: ListCustomAssetPrices :many
+SELECT asset_id, at_utc, price, currency, created_at, updated_at
+FROM custom_asset_prices
+WHERE asset_id = sqlc.arg(asset_id)
+  AND at_utc >= sqlc.arg(from_utc)
+  AND at_utc < sqlc.arg(to_utc)
+ORDER BY at_utc ASC;
+
+-- name: GetCustomAssetPricesAt :many
+-- Latest point at or before each requested time; price is NULL when there is none.
+WITH keys AS (
+  SELECT a.asset_id, t.at_utc, a.ord
+  FROM unnest($1::uuid[]) WITH ORDINALITY AS a(asset_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS t(at_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.asset_id::uuid                                   AS asset_id,
+  COALESCE(p.at_utc, k.at_utc)::timestamptz          AS at_utc,
+  p.price                                            AS price,
+  COALESCE(p.currency, '')::text                     AS currency
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT cp.at_utc, cp.price, cp.currency
+  FROM custom_asset_prices cp
+  WHERE cp.asset_id = k.asset_id
+    AND cp.at_utc <= k.at_utc
+  ORDER BY cp.at_utc DESC
+  LIMIT 1
+) p ON true
+ORDER BY k.ord;
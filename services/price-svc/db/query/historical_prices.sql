@@ -1,53 +1,79 @@
--- name: UpsertHistoricalPrice :exec
-INSERT INTO historical_prices (coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at)
-VALUES ($1, $2, $3, $4, now())
-ON CONFLICT (coin_id, bucket_start_utc)
-DO UPDATE SET
-  price_usd = EXCLUDED.price_usd,
-  granularity_seconds = EXCLUDED.granularity_seconds,
-  fetched_at = now()
-WHERE EXCLUDED.granularity_seconds < historical_prices.granularity_seconds;
+-- name: InsertHistoricalPriceRevision :exec
+-- A revision is appended unless the latest one at the same or a finer granularity is finer or
+-- has the same price: a new bucket, a finer price and a corrected re-fetch are kept, while an
+-- unchanged re-fetch or a coarser price adds nothing.
+INSERT INTO historical_price_revisions (coin_id, bucket_start_utc, price_usd, granularity_seconds, provider, fetched_at)
+SELECT $1, $2, $3, $4, $5, now()
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM (
+    SELECT h.price_usd, h.granularity_seconds
+    FROM historical_price_revisions h
+    WHERE h.coin_id = $1
+      AND h.bucket_start_utc = $2
+      AND h.granularity_seconds <= $4
+    ORDER BY h.fetched_at DESC
+    LIMIT 1
+  ) latest
+  WHERE latest.granularity_seconds < $4
+     OR latest.price_usd = $3
+)
+ON CONFLICT (coin_id, bucket_start_utc, fetched_at) DO NOTHING;
 
--- name: UpsertHistoricalPricesBatch :exec
+-- name: InsertHistoricalPriceRevisionsBatch :exec
 WITH rows AS (
   SELECT
     c.coin_id,
     b.bucket_start_utc,
     p.price_usd,
-    g.granularity_seconds
+    g.granularity_seconds,
+    v.provider
   FROM unnest($1::text[])        WITH ORDINALITY AS c(coin_id, ord)
   JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS b(bucket_start_utc, ord) USING (ord)
   JOIN unnest($3::numeric[])     WITH ORDINALITY AS p(price_usd, ord) USING (ord)
   JOIN unnest($4::int4[])        WITH ORDINALITY AS g(granularity_seconds, ord) USING (ord)
+  JOIN unnest($5::text[])        WITH ORDINALITY AS v(provider, ord) USING (ord)
 )
-INSERT INTO historical_prices (
+INSERT INTO historical_price_revisions (
   coin_id,
   bucket_start_utc,
   price_usd,
   granularity_seconds,
+  provider,
   fetched_at
 )
 SELECT
-  coin_id,
-  bucket_start_utc,
-  price_usd,
-  granularity_seconds,
+  r.coin_id,
+  r.bucket_start_utc,
+  r.price_usd,
+  r.granularity_seconds,
+  r.provider,
   now()
-FROM rows
-ON CONFLICT (coin_id, bucket_start_utc)
-DO UPDATE SET
-  price_usd = EXCLUDED.price_usd,
-  granularity_seconds = EXCLUDED.granularity_seconds,
-  fetched_at = now()
-WHERE EXCLUDED.granularity_seconds < historical_prices.granularity_seconds;
+FROM rows r
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM (
+    SELECT h.price_usd, h.granularity_seconds
+    FROM historical_price_revisions h
+    WHERE h.coin_id = r.coin_id
+      AND h.bucket_start_utc = r.bucket_start_utc
+      AND h.granularity_seconds <= r.granularity_seconds
+    ORDER BY h.fetched_at DESC
+    LIMIT 1
+  ) latest
+  WHERE latest.granularity_seconds < r.granularity_seconds
+     OR latest.price_usd = r.price_usd
+)
+ON CONFLICT (coin_id, bucket_start_utc, fetched_at) DO NOTHING;
 
 -- name: GetHistoricalPrice :one
-SELECT coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at
+SELECT coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at, provider
 FROM historical_prices
 WHERE coin_id = $1
   AND bucket_start_utc = $2;
 
 -- name: GetHistoricalPricesBatch :many
+-- $3 is the as-of transaction time; NULL reads the latest revisions.
 WITH keys AS (
   SELECT c.coin_id, b.bucket_start_utc, c.ord
   FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
@@ -58,10 +84,103 @@ SELECT
   k.coin_id::text                        AS coin_id,
   k.bucket_start_utc::timestamptz        AS bucket_start_utc,
   hp.price_usd                           AS price_usd,
-  hp.granularity_seconds                 AS granularity_seconds,
-  hp.fetched_at                          AS fetched_at
+  COALESCE(hp.granularity_seconds, 0)::int4 AS granularity_seconds,
+  hp.fetched_at                          AS fetched_at,
+  COALESCE(hp.provider, '')::text        AS provider
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT h.price_usd, h.granularity_seconds, h.fetched_at, h.provider
+  FROM historical_price_revisions h
+  WHERE h.coin_id = k.coin_id
+    AND h.bucket_start_utc = k.bucket_start_utc
+    AND h.fetched_at <= COALESCE($3::timestamptz, 'infinity')
+  ORDER BY h.fetched_at DESC
+  LIMIT 1
+) hp ON true
+ORDER BY k.ord;
+
+-- name: GetDailyAveragePricesBatch :many
+-- $3 is the as-of transaction time; NULL reads the latest revisions.
+WITH keys AS (
+  SELECT c.coin_id, d.day_start_utc, c.ord
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS d(day_start_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.coin_id::text                        AS coin_id,
+  k.day_start_utc::timestamptz           AS day_start_utc,
+  a.price_usd::numeric                   AS price_usd
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT avg(rev.price_usd) AS price_usd
+  FROM (
+    SELECT DISTINCT ON (h.bucket_start_utc) h.price_usd
+    FROM historical_price_revisions h
+    WHERE h.coin_id = k.coin_id
+      AND h.bucket_start_utc >= k.day_start_utc
+      AND h.bucket_start_utc < k.day_start_utc + interval '1 day'
+      AND h.fetched_at <= COALESCE($3::timestamptz, 'infinity')
+    ORDER BY h.bucket_start_utc, h.fetched_at DESC
+  ) rev
+) a ON true
+ORDER BY k.ord;
+
+
+-- name: GetNearestHistoricalPricesBatch :many
+-- $4 is the as-of transaction time; NULL reads the latest revisions.
+WITH keys AS (
+  SELECT c.coin_id, b.bucket_start_utc, c.ord
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS b(bucket_start_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.coin_id::text                                                AS coin_id,
+  COALESCE(hp.bucket_start_utc, k.bucket_start_utc)::timestamptz AS bucket_start_utc,
+  hp.price_usd                                                   AS price_usd,
+  COALESCE(hp.granularity_seconds, 0)::int4                      AS granularity_seconds,
+  hp.fetched_at                                                  AS fetched_at,
+  COALESCE(hp.provider, '')::text                                AS provider
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT h.bucket_start_utc, h.price_usd, h.granularity_seconds, h.fetched_at, h.provider
+  FROM historical_price_revisions h
+  WHERE h.coin_id = k.coin_id
+    AND h.bucket_start_utc <= k.bucket_start_utc
+    AND h.bucket_start_utc >= k.bucket_start_utc - make_interval(secs => $3::int)
+    AND h.fetched_at <= COALESCE($4::timestamptz, 'infinity')
+  ORDER BY h.bucket_start_utc DESC, h.fetched_at DESC
+  LIMIT 1
+) hp ON true
+ORDER BY k.ord;
+
+-- name: GetContainingHistoricalPricesBatch :many
+-- Per key the latest-starting bucket of any granularity that contains it, e.g. the daily bucket
+-- for an hourly key; $3 is the as-of transaction time, NULL reads the latest revisions.
+WITH keys AS (
+  SELECT c.coin_id, b.bucket_start_utc, c.ord
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS b(bucket_start_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.coin_id::text                                                AS coin_id,
+  COALESCE(hp.bucket_start_utc, k.bucket_start_utc)::timestamptz AS bucket_start_utc,
+  hp.price_usd                                                   AS price_usd,
+  COALESCE(hp.granularity_seconds, 0)::int4                      AS granularity_seconds,
+  hp.fetched_at                                                  AS fetched_at,
+  COALESCE(hp.provider, '')::text                                AS provider
 FROM keys k
-LEFT JOIN historical_prices hp
-  ON hp.coin_id = k.coin_id
- AND hp.bucket_start_utc = k.bucket_start_utc
-ORDER BY k.ord;
\ No newline at end of file
+LEFT JOIN LATERAL (
+  SELECT h.bucket_start_utc, h.price_usd, h.granularity_seconds, h.fetched_at, h.provider
+  FROM historical_price_revisions h
+  WHERE h.coin_id = k.coin_id
+    AND h.bucket_start_utc <= k.bucket_start_utc
+    AND h.bucket_start_utc >= k.bucket_start_utc - interval '1 day'
+    AND h.bucket_start_utc + make_interval(secs => h.granularity_seconds) > k.bucket_start_utc
+    AND h.fetched_at <= COALESCE($3::timestamptz, 'infinity')
+  ORDER BY h.bucket_start_utc DESC, h.fetched_at DESC
+  LIMIT 1
+) hp ON true
+ORDER BY k.ord;
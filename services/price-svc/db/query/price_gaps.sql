@@ -0,0 +1,34 @@
+-- name: UpsertPriceGap :exec
+INSERT INTO price_gaps (coin_id, day_utc, reason, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (coin_id, day_utc)
+DO UPDATE SET reason = EXCLUDED.reason, expires_at = EXCLUDED.expires_at;
+
+-- name: GetActivePriceGaps :many
+WITH keys AS (
+  SELECT c.coin_id, d.day_utc
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS d(day_utc, ord)
+    USING (ord)
+)
+SELECT g.coin_id, g.day_utc, g.reason, g.created_at, g.expires_at
+FROM price_gaps g
+JOIN keys k
+  ON k.coin_id = g.coin_id
+ AND k.day_utc = g.day_utc
+WHERE g.expires_at > now();
+
+-- name: DeleteExpiredPriceGaps :execrows
+DELETE FROM price_gaps
+WHERE expires_at <= now();
+
+-- name: UpsertCoinListing :exec
+INSERT INTO coin_listings (coin_id, first_price_at, checked_at)
+VALUES ($1, $2, now())
+ON CONFLICT (coin_id)
+DO UPDATE SET first_price_at = EXCLUDED.first_price_at, checked_at = now();
+
+-- name: GetCoinListings :many
+SELECT coin_id, first_price_at, checked_at
+FROM coin_listings
+WHERE coin_id = ANY($1::text[]);
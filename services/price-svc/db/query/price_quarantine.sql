@@ -0,0 +1,44 @@
+-- name: InsertQuarantinedPricesBatch :exec
+WITH rows AS (
+  SELECT
+    c.coin_id,
+    b.bucket_start_utc,
+    g.granularity_seconds,
+    r.raw_price,
+    s.substituted_price,
+    m.neighbour_median,
+    n.reason
+  FROM unnest($1::text[])        WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS b(bucket_start_utc, ord) USING (ord)
+  JOIN unnest($3::int4[])        WITH ORDINALITY AS g(granularity_seconds, ord) USING (ord)
+  JOIN unnest($4::numeric[])     WITH ORDINALITY AS r(raw_price, ord) USING (ord)
+  JOIN unnest($5::numeric[])     WITH ORDINALITY AS s(substituted_price, ord) USING (ord)
+  JOIN unnest($6::numeric[])     WITH ORDINALITY AS m(neighbour_median, ord) USING (ord)
+  JOIN unnest($7::text[])        WITH ORDINALITY AS n(reason, ord) USING (ord)
+)
+INSERT INTO price_quarantine (
+  coin_id,
+  bucket_start_utc,
+  granularity_seconds,
+  raw_price,
+  substituted_price,
+  neighbour_median,
+  reason
+)
+SELECT
+  coin_id,
+  bucket_start_utc,
+  granularity_seconds,
+  raw_price,
+  substituted_price,
+  neighbour_median,
+  reason
+FROM rows
+ON CONFLICT (coin_id, bucket_start_utc, granularity_seconds)
+DO UPDATE SET
+  raw_price = EXCLUDED.raw_price,
+  substituted_price = EXCLUDED.substituted_price,
+  neighbour_median = EXCLUDED.neighbour_median,
+  reason = EXCLUDED.reason,
+  detected_at = now(),
+  reviewed_at = NULL;
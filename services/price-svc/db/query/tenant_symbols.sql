@@ -1,23 +1,46 @@
 -- name: UpsertTenantSymbol :exec
-INSERT INTO tenant_symbols (tenant_id, source, symbol, coin_id)
-VALUES ($1, $2, $3, $4)
-ON CONFLICT (tenant_id, source, symbol)
-DO UPDATE SET coin_id = EXCLUDED.coin_id, updated_at = now();
+INSERT INTO tenant_symbols (tenant_id, source, symbol, coin_id, valid_from, valid_to)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tenant_id, source, symbol, valid_from)
+DO UPDATE SET coin_id = EXCLUDED.coin_id, valid_to = EXCLUDED.valid_to, updated_at = now();
 
 -- name: GetTenantSymbols :many
-SELECT tenant_id, source, symbol, coin_id, created_at, updated_at
+SELECT tenant_id, source, symbol, coin_id, created_at, updated_at, valid_from, valid_to
 FROM tenant_symbols
 WHERE tenant_id = $1
   AND source = $2
-  AND symbol = ANY($3::text[]);
+  AND symbol = ANY($3::text[])
+ORDER BY symbol ASC, valid_from ASC;
 
 -- name: ListTenantSymbolsBySource :many
-SELECT tenant_id, source, symbol, coin_id, created_at, updated_at
+SELECT tenant_id, source, symbol, coin_id, created_at, updated_at, valid_from, valid_to
 FROM tenant_symbols
 WHERE tenant_id = $1
   AND source = $2
-ORDER BY symbol ASC;
+ORDER BY symbol ASC, valid_from ASC;
+
+-- name: ListTenantSymbols :many
+SELECT tenant_id, source, symbol, coin_id, created_at, updated_at, valid_from, valid_to
+FROM tenant_symbols
+WHERE tenant_id = $1
+ORDER BY source ASC, symbol ASC, valid_from ASC;
 
 -- name: DeleteTenantSymbol :execrows
+-- Deletes every validity window of the symbol.
 DELETE FROM tenant_symbols
 WHERE tenant_id = $1 AND source = $2 AND symbol = $3;
+
+-- name: CountTenantSymbolMappings :many
+-- Counts per symbol and coin the tenants other than the given one that currently map the symbol
+-- to the coin. Windows do not overlap, so every tenant votes at most once per symbol. Custom
+-- assets belong to their tenant and never count towards another tenant's consensus.
+SELECT symbol, coin_id, count(DISTINCT tenant_id)::int AS tenants
+FROM tenant_symbols
+WHERE source = sqlc.arg(source)
+  AND symbol = ANY(sqlc.arg(symbols)::text[])
+  AND tenant_id <> sqlc.arg(exclude_tenant_id)
+  AND coin_id NOT LIKE 'custom:%'
+  AND valid_from <= now()
+  AND valid_to > now()
+GROUP BY symbol, coin_id
+ORDER BY symbol ASC, tenants DESC, coin_id ASC;
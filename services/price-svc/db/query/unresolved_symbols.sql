@@ -0,0 +1,28 @@
+-- name: RecordUnresolvedSymbols :exec
+INSERT INTO unresolved_symbols (tenant_id, source, symbol, canonical_symbol, reason, message, occurrences)
+SELECT sqlc.arg(tenant_id)::uuid, sqlc.arg(source)::text, s.symbol, c.canonical_symbol, r.reason, m.message, o.occurrences
+FROM unnest(sqlc.arg(symbols)::text[])            WITH ORDINALITY AS s(symbol, ord)
+JOIN unnest(sqlc.arg(canonical_symbols)::text[])  WITH ORDINALITY AS c(canonical_symbol, ord) USING (ord)
+JOIN unnest(sqlc.arg(reasons)::text[])            WITH ORDINALITY AS r(reason, ord) USING (ord)
+JOIN unnest(sqlc.arg(messages)::text[])           WITH ORDINALITY AS m(message, ord) USING (ord)
+JOIN unnest(sqlc.arg(occurrences)::bigint[])      WITH ORDINALITY AS o(occurrences, ord) USING (ord)
+ON CONFLICT (tenant_id, source, symbol)
+DO UPDATE SET canonical_symbol = EXCLUDED.canonical_symbol,
+              reason = EXCLUDED.reason,
+              message = EXCLUDED.message,
+              occurrences = unresolved_symbols.occurrences + EXCLUDED.occurrences,
+              last_seen = now();
+
+-- name: ListUnresolvedSymbols :many
+-- Filters are optional: a nil tenant or empty source matches all.
+SELECT id, tenant_id, source, symbol, canonical_symbol, reason, message, occurrences, first_seen, last_seen
+FROM unresolved_symbols
+WHERE (sqlc.narg(tenant_id)::uuid IS NULL OR tenant_id = sqlc.narg(tenant_id)::uuid)
+  AND (sqlc.arg(source)::text = '' OR source = sqlc.arg(source)::text)
+  AND id > sqlc.arg(after_id)::bigint
+ORDER BY id ASC
+LIMIT sqlc.arg(max_results);
+
+-- name: DeleteUnresolvedSymbol :execrows
+DELETE FROM unresolved_symbols
+WHERE tenant_id = $1 AND source = $2 AND symbol = $3;
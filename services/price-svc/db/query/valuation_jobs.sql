@@ -0,0 +1,103 @@
+-- name: CreateValuationJob :exec
+INSERT INTO valuation_jobs (id, tenant_id, status, request, total)
+VALUES ($1, $2, 'queued', $3, $4);
+
+-- name: InsertValuationJobItems :exec
+INSERT INTO valuation_job_items (job_id, seq, tx)
+SELECT $1::uuid, s.seq, t.tx
+FROM unnest($2::int4[]) WITH ORDINALITY AS s(seq, ord)
+JOIN unnest($3::bytea[]) WITH ORDINALITY AS t(tx, ord) USING (ord);
+
+-- name: GetValuationJob :one
+SELECT id, tenant_id, status, request, total, processed, error, lease_until, created_at, updated_at, started_at, finished_at, attempts, next_attempt_at, lease_token
+FROM valuation_jobs
+WHERE id = $1
+  AND tenant_id = $2;
+
+-- name: ClaimValuationJob :one
+UPDATE valuation_jobs
+SET status = 'running',
+    lease_until = now() + make_interval(secs => sqlc.arg(lease_seconds)::int),
+    lease_token = sqlc.arg(lease_token)::uuid,
+    started_at = COALESCE(started_at, now()),
+    updated_at = now()
+WHERE id = (
+  SELECT j.id
+  FROM valuation_jobs j
+  WHERE (j.status = 'queued' AND (j.next_attempt_at IS NULL OR j.next_attempt_at <= now()))
+     OR (j.status = 'running' AND j.lease_until < now())
+  ORDER BY j.created_at
+  FOR UPDATE SKIP LOCKED
+  LIMIT 1
+)
+RETURNING id, tenant_id, status, request, total, processed, error, lease_until, created_at, updated_at, started_at, finished_at, attempts, next_attempt_at, lease_token;
+
+-- name: ListPendingValuationJobItems :many
+SELECT seq, tx
+FROM valuation_job_items
+WHERE job_id = $1
+  AND result IS NULL
+ORDER BY seq
+LIMIT $2;
+
+-- name: SaveValuationJobResults :execrows
+UPDATE valuation_job_items i
+SET result = r.result
+FROM (
+  SELECT s.seq, b.result
+  FROM unnest($2::int4[]) WITH ORDINALITY AS s(seq, ord)
+  JOIN unnest($3::bytea[]) WITH ORDINALITY AS b(result, ord) USING (ord)
+) r
+WHERE i.job_id = $1
+  AND i.seq = r.seq
+  AND i.result IS NULL;
+
+-- name: UpdateValuationJobProgress :execrows
+UPDATE valuation_jobs
+SET processed = processed + sqlc.arg(saved)::int,
+    lease_until = now() + make_interval(secs => sqlc.arg(lease_seconds)::int),
+    updated_at = now()
+WHERE id = sqlc.arg(job_id)
+  AND lease_token = sqlc.arg(lease_token)::uuid;
+
+-- name: FinishValuationJob :execrows
+UPDATE valuation_jobs
+SET status = $2,
+    error = $3,
+    lease_until = NULL,
+    lease_token = NULL,
+    updated_at = now(),
+    finished_at = now()
+WHERE id = $1
+  AND lease_token = sqlc.arg(lease_token)::uuid;
+
+-- name: ReleaseValuationJob :execrows
+UPDATE valuation_jobs
+SET status = 'queued',
+    lease_until = NULL,
+    lease_token = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND lease_token = sqlc.arg(lease_token)::uuid;
+
+-- name: RetryValuationJob :execrows
+UPDATE valuation_jobs
+SET status = 'queued',
+    attempts = attempts + 1,
+    next_attempt_at = now() + make_interval(secs => sqlc.arg(delay_seconds)::int),
+    lease_until = NULL,
+    lease_token = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND lease_token = sqlc.arg(lease_token)::uuid;
+
+-- name: ListValuationJobResults :many
+SELECT seq, result
+FROM valuation_job_items
+WHERE job_id = $1
+  AND seq > $2
+  AND result IS NOT NULL
+ORDER BY seq
+LIMIT $3;
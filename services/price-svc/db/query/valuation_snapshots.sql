@@ -0,0 +1,140 @@
+-- name: CreateValuationSnapshot :exec
+INSERT INTO valuation_snapshots (
+  tenant_id,
+  id,
+  fiat_currency,
+  price_point,
+  lookup_nearest,
+  lookup_tolerance_seconds,
+  lookup_accept_coarser,
+  lookup_as_of
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (tenant_id, id) DO NOTHING;
+
+-- name: GetValuationSnapshot :one
+SELECT id, fiat_currency, price_point, lookup_nearest, lookup_tolerance_seconds, lookup_accept_coarser, created_at, lookup_as_of, tenant_id
+FROM valuation_snapshots
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: GetSnapshotValuations :many
+WITH keys AS (
+  SELECT a.asset, t.at_utc
+  FROM unnest(sqlc.arg(assets)::text[]) WITH ORDINALITY AS a(asset, ord)
+  JOIN unnest(sqlc.arg(at_utc)::timestamptz[]) WITH ORDINALITY AS t(at_utc, ord)
+    USING (ord)
+)
+SELECT sv.snapshot_id, sv.kind, sv.asset, sv.at_utc, sv.fiat_value, sv.method, sv.lower_precision,
+       sv.bucket_start_utc, sv.granularity_seconds, sv.provider, sv.price_usd, sv.fetched_at,
+       sv.fx_rate, sv.fx_effective_at, sv.created_at, sv.tenant_id
+FROM snapshot_valuations sv
+JOIN keys k
+  ON k.asset = sv.asset
+ AND k.at_utc = sv.at_utc
+WHERE sv.tenant_id = sqlc.arg(tenant_id)
+  AND sv.snapshot_id = sqlc.arg(snapshot_id)
+  AND sv.kind = sqlc.arg(kind);
+
+-- name: InsertSnapshotValuations :exec
+INSERT INTO snapshot_valuations (
+  tenant_id,
+  snapshot_id,
+  kind,
+  asset,
+  at_utc,
+  fiat_value,
+  method,
+  lower_precision,
+  bucket_start_utc,
+  granularity_seconds,
+  provider,
+  price_usd,
+  fetched_at,
+  fx_rate,
+  fx_effective_at
+)
+SELECT
+  sqlc.arg(tenant_id)::uuid,
+  sqlc.arg(snapshot_id)::text,
+  sqlc.arg(kind)::text,
+  a.asset,
+  t.at_utc,
+  v.fiat_value,
+  m.method,
+  l.lower_precision,
+  b.bucket_start_utc,
+  NULLIF(g.granularity_seconds, 0),
+  p.provider,
+  u.price_usd,
+  f.fetched_at,
+  r.fx_rate,
+  e.fx_effective_at
+FROM unnest(sqlc.arg(assets)::text[])                  WITH ORDINALITY AS a(asset, ord)
+JOIN unnest(sqlc.arg(at_utc)::timestamptz[])           WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+JOIN unnest(sqlc.arg(fiat_values)::numeric[])          WITH ORDINALITY AS v(fiat_value, ord) USING (ord)
+JOIN unnest(sqlc.arg(methods)::int4[])                 WITH ORDINALITY AS m(method, ord) USING (ord)
+JOIN unnest(sqlc.arg(lower_precision)::bool[])         WITH ORDINALITY AS l(lower_precision, ord) USING (ord)
+JOIN unnest(sqlc.arg(bucket_starts)::timestamptz[])    WITH ORDINALITY AS b(bucket_start_utc, ord) USING (ord)
+JOIN unnest(sqlc.arg(granularities)::int4[])           WITH ORDINALITY AS g(granularity_seconds, ord) USING (ord)
+JOIN unnest(sqlc.arg(providers)::text[])               WITH ORDINALITY AS p(provider, ord) USING (ord)
+JOIN unnest(sqlc.arg(prices_usd)::numeric[])           WITH ORDINALITY AS u(price_usd, ord) USING (ord)
+JOIN unnest(sqlc.arg(fetched_at)::timestamptz[])       WITH ORDINALITY AS f(fetched_at, ord) USING (ord)
+JOIN unnest(sqlc.arg(fx_rates)::numeric[])             WITH ORDINALITY AS r(fx_rate, ord) USING (ord)
+JOIN unnest(sqlc.arg(fx_effective_at)::timestamptz[])  WITH ORDINALITY AS e(fx_effective_at, ord) USING (ord)
+ON CONFLICT (tenant_id, snapshot_id, kind, asset, at_utc) DO NOTHING;
+
+-- name: GetSnapshotResolutions :many
+WITH keys AS (
+  SELECT s.symbol, c.chain, a.contract_address, t.at_utc
+  FROM unnest(sqlc.arg(symbols)::text[]) WITH ORDINALITY AS s(symbol, ord)
+  JOIN unnest(sqlc.arg(chains)::text[]) WITH ORDINALITY AS c(chain, ord) USING (ord)
+  JOIN unnest(sqlc.arg(contract_addresses)::text[]) WITH ORDINALITY AS a(contract_address, ord) USING (ord)
+  JOIN unnest(sqlc.arg(at_utc)::timestamptz[]) WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+)
+SELECT sr.symbol, sr.chain, sr.contract_address, sr.at_utc, sr.coin_id, sr.canonical_symbol, sr.quote, sr.consensus
+FROM snapshot_resolutions sr
+JOIN keys k
+  ON k.symbol = sr.symbol
+ AND k.chain = sr.chain
+ AND k.contract_address = sr.contract_address
+ AND k.at_utc = sr.at_utc
+WHERE sr.tenant_id = sqlc.arg(tenant_id)
+  AND sr.snapshot_id = sqlc.arg(snapshot_id)
+  AND sr.source = sqlc.arg(source);
+
+-- name: InsertSnapshotResolutions :exec
+INSERT INTO snapshot_resolutions (
+  tenant_id,
+  snapshot_id,
+  source,
+  symbol,
+  chain,
+  contract_address,
+  at_utc,
+  coin_id,
+  canonical_symbol,
+  quote,
+  consensus
+)
+SELECT
+  sqlc.arg(tenant_id)::uuid,
+  sqlc.arg(snapshot_id)::text,
+  sqlc.arg(source)::text,
+  s.symbol,
+  c.chain,
+  a.contract_address,
+  t.at_utc,
+  i.coin_id,
+  n.canonical_symbol,
+  q.quote,
+  k.consensus
+FROM unnest(sqlc.arg(symbols)::text[])                 WITH ORDINALITY AS s(symbol, ord)
+JOIN unnest(sqlc.arg(chains)::text[])                  WITH ORDINALITY AS c(chain, ord) USING (ord)
+JOIN unnest(sqlc.arg(contract_addresses)::text[])      WITH ORDINALITY AS a(contract_address, ord) USING (ord)
+JOIN unnest(sqlc.arg(at_utc)::timestamptz[])           WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+JOIN unnest(sqlc.arg(coin_ids)::text[])                WITH ORDINALITY AS i(coin_id, ord) USING (ord)
+JOIN unnest(sqlc.arg(canonical_symbols)::text[])       WITH ORDINALITY AS n(canonical_symbol, ord) USING (ord)
+JOIN unnest(sqlc.arg(quotes)::text[])                  WITH ORDINALITY AS q(quote, ord) USING (ord)
+JOIN unnest(sqlc.arg(consensus)::bool[])               WITH ORDINALITY AS k(consensus, ord) USING (ord)
+ON CONFLICT (tenant_id, snapshot_id, source, symbol, chain, contract_address, at_utc) DO NOTHING;
@@ -0,0 +1,95 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: coin_contracts.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteCoinContractsSyncedBefore = `-- name: DeleteCoinContractsSyncedBefore :execrows
+DELETE FROM coin_contracts
+WHERE synced_at < $1
+`
+
+func (q *Queries) DeleteCoinContractsSyncedBefore(ctx context.Context, syncedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCoinContractsSyncedBefore, syncedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCoinContractsByAddresses = `-- name: GetCoinContractsByAddresses :many
+SELECT platform, contract_address, coin_id, synced_at
+FROM coin_contracts
+WHERE contract_address = ANY($1::text[])
+`
+
+func (q *Queries) GetCoinContractsByAddresses(ctx context.Context, dollar_1 []string) ([]CoinContract, error) {
+	rows, err := q.db.Query(ctx, getCoinContractsByAddresses, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinContract
+	for rows.Next() {
+		var i CoinContract
+		if err := rows.Scan(
+			&i.Platform,
+			&i.ContractAddress,
+			&i.CoinID,
+			&i.SyncedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCoinContractsLastSync = `-- name: GetCoinContractsLastSync :one
+SELECT max(synced_at)::timestamptz AS synced_at
+FROM coin_contracts
+`
+
+func (q *Queries) GetCoinContractsLastSync(ctx context.Context) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getCoinContractsLastSync)
+	var synced_at pgtype.Timestamptz
+	err := row.Scan(&synced_at)
+	return synced_at, err
+}
+
+const upsertCoinContracts = `-- name: UpsertCoinContracts :exec
+INSERT INTO coin_contracts (platform, contract_address, coin_id, synced_at)
+SELECT p.platform, a.contract_address, c.coin_id, $1::timestamptz
+FROM unnest($2::text[])          WITH ORDINALITY AS p(platform, ord)
+JOIN unnest($3::text[]) WITH ORDINALITY AS a(contract_address, ord) USING (ord)
+JOIN unnest($4::text[])           WITH ORDINALITY AS c(coin_id, ord) USING (ord)
+ON CONFLICT (platform, contract_address)
+DO UPDATE SET coin_id = EXCLUDED.coin_id, synced_at = EXCLUDED.synced_at
+`
+
+type UpsertCoinContractsParams struct {
+	SyncedAt          pgtype.Timestamptz `json:"syncedAt"`
+	Platforms         []string           `json:"platforms"`
+	ContractAddresses []string           `json:"contractAddresses"`
+	CoinIds           []string           `json:"coinIds"`
+}
+
+func (q *Queries) UpsertCoinContracts(ctx context.Context, arg UpsertCoinContractsParams) error {
+	_, err := q.db.Exec(ctx, upsertCoinContracts,
+		arg.SyncedAt,
+		arg.Platforms,
+		arg.ContractAddresses,
+		arg.CoinIds,
+	)
+	return err
+}
@@ -0,0 +1,295 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: coins.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getActiveCoinsBySymbols = `-- name: GetActiveCoinsBySymbols :many
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE lower(symbol) = ANY($1::text[])
+  AND NOT delisted
+ORDER BY id ASC
+`
+
+// Symbols match case-insensitively; the provider lists them lower-case.
+func (q *Queries) GetActiveCoinsBySymbols(ctx context.Context, dollar_1 []string) ([]Coin, error) {
+	rows, err := q.db.Query(ctx, getActiveCoinsBySymbols, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Coin
+	for rows.Next() {
+		var i Coin
+		if err := rows.Scan(
+			&i.ID,
+			&i.Symbol,
+			&i.Name,
+			&i.Platforms,
+			&i.FirstSeen,
+			&i.LastSeen,
+			&i.Delisted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCoin = `-- name: GetCoin :one
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE id = $1
+`
+
+func (q *Queries) GetCoin(ctx context.Context, id string) (Coin, error) {
+	row := q.db.QueryRow(ctx, getCoin, id)
+	var i Coin
+	err := row.Scan(
+		&i.ID,
+		&i.Symbol,
+		&i.Name,
+		&i.Platforms,
+		&i.FirstSeen,
+		&i.LastSeen,
+		&i.Delisted,
+	)
+	return i, err
+}
+
+const getCoinCatalogLastSync = `-- name: GetCoinCatalogLastSync :one
+SELECT max(last_seen)::timestamptz AS synced_at
+FROM coins
+`
+
+func (q *Queries) GetCoinCatalogLastSync(ctx context.Context) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getCoinCatalogLastSync)
+	var synced_at pgtype.Timestamptz
+	err := row.Scan(&synced_at)
+	return synced_at, err
+}
+
+const getCoinsByIDs = `-- name: GetCoinsByIDs :many
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE id = ANY($1::text[])
+`
+
+func (q *Queries) GetCoinsByIDs(ctx context.Context, dollar_1 []string) ([]Coin, error) {
+	rows, err := q.db.Query(ctx, getCoinsByIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Coin
+	for rows.Next() {
+		var i Coin
+		if err := rows.Scan(
+			&i.ID,
+			&i.Symbol,
+			&i.Name,
+			&i.Platforms,
+			&i.FirstSeen,
+			&i.LastSeen,
+			&i.Delisted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertCoinCatalogChanges = `-- name: InsertCoinCatalogChanges :exec
+INSERT INTO coin_catalog_changes (synced_at, coin_id, change, old_symbol, old_name, new_symbol, new_name)
+SELECT $1::timestamptz, c.coin_id, k.change, os.old_symbol, onm.old_name, ns.new_symbol, nn.new_name
+FROM unnest($2::text[])     WITH ORDINALITY AS c(coin_id, ord)
+JOIN unnest($3::text[])      WITH ORDINALITY AS k(change, ord) USING (ord)
+JOIN unnest($4::text[])  WITH ORDINALITY AS os(old_symbol, ord) USING (ord)
+JOIN unnest($5::text[])    WITH ORDINALITY AS onm(old_name, ord) USING (ord)
+JOIN unnest($6::text[])  WITH ORDINALITY AS ns(new_symbol, ord) USING (ord)
+JOIN unnest($7::text[])    WITH ORDINALITY AS nn(new_name, ord) USING (ord)
+`
+
+type InsertCoinCatalogChangesParams struct {
+	SyncedAt   pgtype.Timestamptz `json:"syncedAt"`
+	CoinIds    []string           `json:"coinIds"`
+	Changes    []string           `json:"changes"`
+	OldSymbols []string           `json:"oldSymbols"`
+	OldNames   []string           `json:"oldNames"`
+	NewSymbols []string           `json:"newSymbols"`
+	NewNames   []string           `json:"newNames"`
+}
+
+func (q *Queries) InsertCoinCatalogChanges(ctx context.Context, arg InsertCoinCatalogChangesParams) error {
+	_, err := q.db.Exec(ctx, insertCoinCatalogChanges,
+		arg.SyncedAt,
+		arg.CoinIds,
+		arg.Changes,
+		arg.OldSymbols,
+		arg.OldNames,
+		arg.NewSymbols,
+		arg.NewNames,
+	)
+	return err
+}
+
+const listCoins = `-- name: ListCoins :many
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+ORDER BY id ASC
+`
+
+func (q *Queries) ListCoins(ctx context.Context) ([]Coin, error) {
+	rows, err := q.db.Query(ctx, listCoins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Coin
+	for rows.Next() {
+		var i Coin
+		if err := rows.Scan(
+			&i.ID,
+			&i.Symbol,
+			&i.Name,
+			&i.Platforms,
+			&i.FirstSeen,
+			&i.LastSeen,
+			&i.Delisted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markCoinsDelisted = `-- name: MarkCoinsDelisted :execrows
+UPDATE coins
+SET delisted = true
+WHERE last_seen < $1
+  AND NOT delisted
+`
+
+func (q *Queries) MarkCoinsDelisted(ctx context.Context, lastSeen pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, markCoinsDelisted, lastSeen)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchCoins = `-- name: SearchCoins :many
+SELECT id, symbol, name, platforms, first_seen, last_seen, delisted
+FROM coins
+WHERE lower(symbol) LIKE $1::text
+   OR lower(name) LIKE $1::text
+   OR lower(id) LIKE $1::text
+ORDER BY
+  CASE
+    WHEN lower(symbol) = $2::text THEN 0
+    WHEN lower(symbol) LIKE $3::text THEN 1
+    WHEN lower(name) LIKE $3::text THEN 2
+    ELSE 3
+  END,
+  delisted,
+  length(symbol),
+  id
+LIMIT $4
+`
+
+type SearchCoinsParams struct {
+	Contains   string `json:"contains"`
+	Query      string `json:"query"`
+	Prefix     string `json:"prefix"`
+	MaxResults int32  `json:"maxResults"`
+}
+
+// Ranks exact symbol matches first, then symbol prefixes, name prefixes and substrings;
+// delisted coins come after listed ones of the same rank. The trigram indexes serve queries of
+// three characters or more; shorter ones scan the catalog.
+func (q *Queries) SearchCoins(ctx context.Context, arg SearchCoinsParams) ([]Coin, error) {
+	rows, err := q.db.Query(ctx, searchCoins,
+		arg.Contains,
+		arg.Query,
+		arg.Prefix,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Coin
+	for rows.Next() {
+		var i Coin
+		if err := rows.Scan(
+			&i.ID,
+			&i.Symbol,
+			&i.Name,
+			&i.Platforms,
+			&i.FirstSeen,
+			&i.LastSeen,
+			&i.Delisted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCoins = `-- name: UpsertCoins :exec
+INSERT INTO coins (id, symbol, name, platforms, first_seen, last_seen, delisted)
+SELECT i.id, s.symbol, n.name, p.platforms, $1::timestamptz, $1::timestamptz, false
+FROM unnest($2::text[])         WITH ORDINALITY AS i(id, ord)
+JOIN unnest($3::text[])     WITH ORDINALITY AS s(symbol, ord) USING (ord)
+JOIN unnest($4::text[])       WITH ORDINALITY AS n(name, ord) USING (ord)
+JOIN unnest($5::jsonb[])  WITH ORDINALITY AS p(platforms, ord) USING (ord)
+ON CONFLICT (id)
+DO UPDATE SET symbol = EXCLUDED.symbol,
+              name = EXCLUDED.name,
+              platforms = EXCLUDED.platforms,
+              last_seen = EXCLUDED.last_seen,
+              delisted = false
+`
+
+type UpsertCoinsParams struct {
+	SyncedAt  pgtype.Timestamptz `json:"syncedAt"`
+	Ids       []string           `json:"ids"`
+	Symbols   []string           `json:"symbols"`
+	Names     []string           `json:"names"`
+	Platforms [][]byte           `json:"platforms"`
+}
+
+func (q *Queries) UpsertCoins(ctx context.Context, arg UpsertCoinsParams) error {
+	_, err := q.db.Exec(ctx, upsertCoins,
+		arg.SyncedAt,
+		arg.Ids,
+		arg.Symbols,
+		arg.Names,
+		arg.Platforms,
+	)
+	return err
+}
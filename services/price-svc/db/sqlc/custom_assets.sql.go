@@ -0,0 +1,317 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: custom_assets.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteCustomAsset = `-- name: DeleteCustomAsset :execrows
+DELETE FROM custom_assets
+WHERE tenant_id = $1
+  AND id = $2
+`
+
+type DeleteCustomAssetParams struct {
+	TenantID uuid.UUID `json:"tenantId"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) DeleteCustomAsset(ctx context.Context, arg DeleteCustomAssetParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCustomAsset, arg.TenantID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ensureCustomAssets = `-- name: EnsureCustomAssets :exec
+INSERT INTO custom_assets (id, tenant_id, symbol, name)
+SELECT i.id, $1::uuid, s.symbol, s.symbol
+FROM unnest($2::uuid[])       WITH ORDINALITY AS i(id, ord)
+JOIN unnest($3::text[])   WITH ORDINALITY AS s(symbol, ord) USING (ord)
+ON CONFLICT (tenant_id, symbol) DO NOTHING
+`
+
+type EnsureCustomAssetsParams struct {
+	TenantID uuid.UUID   `json:"tenantId"`
+	Ids      []uuid.UUID `json:"ids"`
+	Symbols  []string    `json:"symbols"`
+}
+
+// Creates missing assets; existing ones keep their name.
+func (q *Queries) EnsureCustomAssets(ctx context.Context, arg EnsureCustomAssetsParams) error {
+	_, err := q.db.Exec(ctx, ensureCustomAssets, arg.TenantID, arg.Ids, arg.Symbols)
+	return err
+}
+
+const getCustomAsset = `-- name: GetCustomAsset :one
+SELECT id, tenant_id, symbol, name, created_at, updated_at
+FROM custom_assets
+WHERE tenant_id = $1
+  AND id = $2
+`
+
+type GetCustomAssetParams struct {
+	TenantID uuid.UUID `json:"tenantId"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) GetCustomAsset(ctx context.Context, arg GetCustomAssetParams) (CustomAsset, error) {
+	row := q.db.QueryRow(ctx, getCustomAsset, arg.TenantID, arg.ID)
+	var i CustomAsset
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Symbol,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCustomAssetPricesAt = `-- name: GetCustomAssetPricesAt :many
+WITH keys AS (
+  SELECT a.asset_id, t.at_utc, a.ord
+  FROM unnest($1::uuid[]) WITH ORDINALITY AS a(asset_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS t(at_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.asset_id::uuid                                   AS asset_id,
+  COALESCE(p.at_utc, k.at_utc)::timestamptz          AS at_utc,
+  p.price                                            AS price,
+  COALESCE(p.currency, '')::text                     AS currency
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT cp.at_utc, cp.price, cp.currency
+  FROM custom_asset_prices cp
+  WHERE cp.asset_id = k.asset_id
+    AND cp.at_utc <= k.at_utc
+  ORDER BY cp.at_utc DESC
+  LIMIT 1
+) p ON true
+ORDER BY k.ord
+`
+
+type GetCustomAssetPricesAtParams struct {
+	Column1 []uuid.UUID          `json:"column1"`
+	Column2 []pgtype.Timestamptz `json:"column2"`
+}
+
+type GetCustomAssetPricesAtRow struct {
+	AssetID  uuid.UUID          `json:"assetId"`
+	AtUtc    pgtype.Timestamptz `json:"atUtc"`
+	Price    pgtype.Numeric     `json:"price"`
+	Currency string             `json:"currency"`
+}
+
+// Latest point at or before each requested time; price is NULL when there is none.
+func (q *Queries) GetCustomAssetPricesAt(ctx context.Context, arg GetCustomAssetPricesAtParams) ([]GetCustomAssetPricesAtRow, error) {
+	rows, err := q.db.Query(ctx, getCustomAssetPricesAt, arg.Column1, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCustomAssetPricesAtRow
+	for rows.Next() {
+		var i GetCustomAssetPricesAtRow
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.AtUtc,
+			&i.Price,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCustomAssetsBySymbols = `-- name: GetCustomAssetsBySymbols :many
+SELECT id, tenant_id, symbol, name, created_at, updated_at
+FROM custom_assets
+WHERE tenant_id = $1
+  AND symbol = ANY($2::text[])
+`
+
+type GetCustomAssetsBySymbolsParams struct {
+	TenantID uuid.UUID `json:"tenantId"`
+	Column2  []string  `json:"column2"`
+}
+
+func (q *Queries) GetCustomAssetsBySymbols(ctx context.Context, arg GetCustomAssetsBySymbolsParams) ([]CustomAsset, error) {
+	rows, err := q.db.Query(ctx, getCustomAssetsBySymbols, arg.TenantID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CustomAsset
+	for rows.Next() {
+		var i CustomAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Symbol,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomAssetPrices = `-- name: ListCustomAssetPrices :many
+SELECT asset_id, at_utc, price, currency, created_at, updated_at
+FROM custom_asset_prices
+WHERE asset_id = $1
+  AND at_utc >= $2
+  AND at_utc < $3
+ORDER BY at_utc ASC
+`
+
+type ListCustomAssetPricesParams struct {
+	AssetID uuid.UUID          `json:"assetId"`
+	FromUtc pgtype.Timestamptz `json:"fromUtc"`
+	ToUtc   pgtype.Timestamptz `json:"toUtc"`
+}
+
+func (q *Queries) ListCustomAssetPrices(ctx context.Context, arg ListCustomAssetPricesParams) ([]CustomAssetPrice, error) {
+	rows, err := q.db.Query(ctx, listCustomAssetPrices, arg.AssetID, arg.FromUtc, arg.ToUtc)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CustomAssetPrice
+	for rows.Next() {
+		var i CustomAssetPrice
+		if err := rows.Scan(
+			&i.AssetID,
+			&i.AtUtc,
+			&i.Price,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomAssets = `-- name: ListCustomAssets :many
+SELECT id, tenant_id, symbol, name, created_at, updated_at
+FROM custom_assets
+WHERE tenant_id = $1
+ORDER BY symbol ASC
+`
+
+func (q *Queries) ListCustomAssets(ctx context.Context, tenantID uuid.UUID) ([]CustomAsset, error) {
+	rows, err := q.db.Query(ctx, listCustomAssets, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CustomAsset
+	for rows.Next() {
+		var i CustomAsset
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Symbol,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCustomAsset = `-- name: UpsertCustomAsset :one
+INSERT INTO custom_assets (id, tenant_id, symbol, name)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, symbol)
+DO UPDATE SET name = EXCLUDED.name, updated_at = now()
+RETURNING id, tenant_id, symbol, name, created_at, updated_at
+`
+
+type UpsertCustomAssetParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenantId"`
+	Symbol   string    `json:"symbol"`
+	Name     string    `json:"name"`
+}
+
+func (q *Queries) UpsertCustomAsset(ctx context.Context, arg UpsertCustomAssetParams) (CustomAsset, error) {
+	row := q.db.QueryRow(ctx, upsertCustomAsset,
+		arg.ID,
+		arg.TenantID,
+		arg.Symbol,
+		arg.Name,
+	)
+	var i CustomAsset
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Symbol,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertCustomAssetPrices = `-- name: UpsertCustomAssetPrices :exec
+INSERT INTO custom_asset_prices (asset_id, at_utc, price, currency)
+SELECT a.asset_id, t.at_utc, p.price, c.currency
+FROM unnest($1::uuid[])      WITH ORDINALITY AS a(asset_id, ord)
+JOIN unnest($2::timestamptz[])  WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+JOIN unnest($3::numeric[])      WITH ORDINALITY AS p(price, ord) USING (ord)
+JOIN unnest($4::text[])     WITH ORDINALITY AS c(currency, ord) USING (ord)
+ON CONFLICT (asset_id, at_utc)
+DO UPDATE SET price = EXCLUDED.price, currency = EXCLUDED.currency, updated_at = now()
+`
+
+type UpsertCustomAssetPricesParams struct {
+	AssetIds   []uuid.UUID          `json:"assetIds"`
+	AtUtc      []pgtype.Timestamptz `json:"atUtc"`
+	Prices     []pgtype.Numeric     `json:"prices"`
+	Currencies []string             `json:"currencies"`
+}
+
+func (q *Queries) UpsertCustomAssetPrices(ctx context.Context, arg UpsertCustomAssetPricesParams) error {
+	_, err := q.db.Exec(ctx, upsertCustomAssetPrices,
+		arg.AssetIds,
+		arg.AtUtc,
+		arg.Prices,
+		arg.Currencies,
+	)
+	return err
+}
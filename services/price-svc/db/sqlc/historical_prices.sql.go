@@ -11,8 +11,141 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getContainingHistoricalPricesBatch = `-- name: GetContainingHistoricalPricesBatch :many
+WITH keys AS (
+  SELECT c.coin_id, b.bucket_start_utc, c.ord
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS b(bucket_start_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.coin_id::text                                                AS coin_id,
+  COALESCE(hp.bucket_start_utc, k.bucket_start_utc)::timestamptz AS bucket_start_utc,
+  hp.price_usd                                                   AS price_usd,
+  COALESCE(hp.granularity_seconds, 0)::int4                      AS granularity_seconds,
+  hp.fetched_at                                                  AS fetched_at,
+  COALESCE(hp.provider, '')::text                                AS provider
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT h.bucket_start_utc, h.price_usd, h.granularity_seconds, h.fetched_at, h.provider
+  FROM historical_price_revisions h
+  WHERE h.coin_id = k.coin_id
+    AND h.bucket_start_utc <= k.bucket_start_utc
+    AND h.bucket_start_utc >= k.bucket_start_utc - interval '1 day'
+    AND h.bucket_start_utc + make_interval(secs => h.granularity_seconds) > k.bucket_start_utc
+    AND h.fetched_at <= COALESCE($3::timestamptz, 'infinity')
+  ORDER BY h.bucket_start_utc DESC, h.fetched_at DESC
+  LIMIT 1
+) hp ON true
+ORDER BY k.ord
+`
+
+type GetContainingHistoricalPricesBatchParams struct {
+	Column1 []string             `json:"column1"`
+	Column2 []pgtype.Timestamptz `json:"column2"`
+	Column3 pgtype.Timestamptz   `json:"column3"`
+}
+
+type GetContainingHistoricalPricesBatchRow struct {
+	CoinID             string             `json:"coinId"`
+	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
+	PriceUsd           pgtype.Numeric     `json:"priceUsd"`
+	GranularitySeconds int32              `json:"granularitySeconds"`
+	FetchedAt          pgtype.Timestamptz `json:"fetchedAt"`
+	Provider           string             `json:"provider"`
+}
+
+// Per key the latest-starting bucket of any granularity that contains it, e.g. the daily bucket
+// for an hourly key; $3 is the as-of transaction time, NULL reads the latest revisions.
+func (q *Queries) GetContainingHistoricalPricesBatch(ctx context.Context, arg GetContainingHistoricalPricesBatchParams) ([]GetContainingHistoricalPricesBatchRow, error) {
+	rows, err := q.db.Query(ctx, getContainingHistoricalPricesBatch, arg.Column1, arg.Column2, arg.Column3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetContainingHistoricalPricesBatchRow
+	for rows.Next() {
+		var i GetContainingHistoricalPricesBatchRow
+		if err := rows.Scan(
+			&i.CoinID,
+			&i.BucketStartUtc,
+			&i.PriceUsd,
+			&i.GranularitySeconds,
+			&i.FetchedAt,
+			&i.Provider,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyAveragePricesBatch = `-- name: GetDailyAveragePricesBatch :many
+WITH keys AS (
+  SELECT c.coin_id, d.day_start_utc, c.ord
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS d(day_start_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.coin_id::text                        AS coin_id,
+  k.day_start_utc::timestamptz           AS day_start_utc,
+  a.price_usd::numeric                   AS price_usd
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT avg(rev.price_usd) AS price_usd
+  FROM (
+    SELECT DISTINCT ON (h.bucket_start_utc) h.price_usd
+    FROM historical_price_revisions h
+    WHERE h.coin_id = k.coin_id
+      AND h.bucket_start_utc >= k.day_start_utc
+      AND h.bucket_start_utc < k.day_start_utc + interval '1 day'
+      AND h.fetched_at <= COALESCE($3::timestamptz, 'infinity')
+    ORDER BY h.bucket_start_utc, h.fetched_at DESC
+  ) rev
+) a ON true
+ORDER BY k.ord
+`
+
+type GetDailyAveragePricesBatchParams struct {
+	Column1 []string             `json:"column1"`
+	Column2 []pgtype.Timestamptz `json:"column2"`
+	Column3 pgtype.Timestamptz   `json:"column3"`
+}
+
+type GetDailyAveragePricesBatchRow struct {
+	CoinID      string             `json:"coinId"`
+	DayStartUtc pgtype.Timestamptz `json:"dayStartUtc"`
+	PriceUsd    pgtype.Numeric     `json:"priceUsd"`
+}
+
+// $3 is the as-of transaction time; NULL reads the latest revisions.
+func (q *Queries) GetDailyAveragePricesBatch(ctx context.Context, arg GetDailyAveragePricesBatchParams) ([]GetDailyAveragePricesBatchRow, error) {
+	rows, err := q.db.Query(ctx, getDailyAveragePricesBatch, arg.Column1, arg.Column2, arg.Column3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDailyAveragePricesBatchRow
+	for rows.Next() {
+		var i GetDailyAveragePricesBatchRow
+		if err := rows.Scan(&i.CoinID, &i.DayStartUtc, &i.PriceUsd); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getHistoricalPrice = `-- name: GetHistoricalPrice :one
-SELECT coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at
+SELECT coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at, provider
 FROM historical_prices
 WHERE coin_id = $1
   AND bucket_start_utc = $2
@@ -32,6 +165,7 @@ func (q *Queries) GetHistoricalPrice(ctx context.Context, arg GetHistoricalPrice
 		&i.PriceUsd,
 		&i.GranularitySeconds,
 		&i.FetchedAt,
+		&i.Provider,
 	)
 	return i, err
 }
@@ -47,30 +181,40 @@ SELECT
   k.coin_id::text                        AS coin_id,
   k.bucket_start_utc::timestamptz        AS bucket_start_utc,
   hp.price_usd                           AS price_usd,
-  hp.granularity_seconds                 AS granularity_seconds,
-  hp.fetched_at                          AS fetched_at
+  COALESCE(hp.granularity_seconds, 0)::int4 AS granularity_seconds,
+  hp.fetched_at                          AS fetched_at,
+  COALESCE(hp.provider, '')::text        AS provider
 FROM keys k
-LEFT JOIN historical_prices hp
-  ON hp.coin_id = k.coin_id
- AND hp.bucket_start_utc = k.bucket_start_utc
+LEFT JOIN LATERAL (
+  SELECT h.price_usd, h.granularity_seconds, h.fetched_at, h.provider
+  FROM historical_price_revisions h
+  WHERE h.coin_id = k.coin_id
+    AND h.bucket_start_utc = k.bucket_start_utc
+    AND h.fetched_at <= COALESCE($3::timestamptz, 'infinity')
+  ORDER BY h.fetched_at DESC
+  LIMIT 1
+) hp ON true
 ORDER BY k.ord
 `
 
 type GetHistoricalPricesBatchParams struct {
 	Column1 []string             `json:"column1"`
 	Column2 []pgtype.Timestamptz `json:"column2"`
+	Column3 pgtype.Timestamptz   `json:"column3"`
 }
 
 type GetHistoricalPricesBatchRow struct {
 	CoinID             string             `json:"coinId"`
 	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
 	PriceUsd           pgtype.Numeric     `json:"priceUsd"`
-	GranularitySeconds *int32             `json:"granularitySeconds"`
+	GranularitySeconds int32              `json:"granularitySeconds"`
 	FetchedAt          pgtype.Timestamptz `json:"fetchedAt"`
+	Provider           string             `json:"provider"`
 }
 
+// $3 is the as-of transaction time; NULL reads the latest revisions.
 func (q *Queries) GetHistoricalPricesBatch(ctx context.Context, arg GetHistoricalPricesBatchParams) ([]GetHistoricalPricesBatchRow, error) {
-	rows, err := q.db.Query(ctx, getHistoricalPricesBatch, arg.Column1, arg.Column2)
+	rows, err := q.db.Query(ctx, getHistoricalPricesBatch, arg.Column1, arg.Column2, arg.Column3)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +228,7 @@ func (q *Queries) GetHistoricalPricesBatch(ctx context.Context, arg GetHistorica
 			&i.PriceUsd,
 			&i.GranularitySeconds,
 			&i.FetchedAt,
+			&i.Provider,
 		); err != nil {
 			return nil, err
 		}
@@ -95,81 +240,187 @@ func (q *Queries) GetHistoricalPricesBatch(ctx context.Context, arg GetHistorica
 	return items, nil
 }
 
-const upsertHistoricalPrice = `-- name: UpsertHistoricalPrice :exec
-INSERT INTO historical_prices (coin_id, bucket_start_utc, price_usd, granularity_seconds, fetched_at)
-VALUES ($1, $2, $3, $4, now())
-ON CONFLICT (coin_id, bucket_start_utc)
-DO UPDATE SET
-  price_usd = EXCLUDED.price_usd,
-  granularity_seconds = EXCLUDED.granularity_seconds,
-  fetched_at = now()
-WHERE EXCLUDED.granularity_seconds < historical_prices.granularity_seconds
+const getNearestHistoricalPricesBatch = `-- name: GetNearestHistoricalPricesBatch :many
+WITH keys AS (
+  SELECT c.coin_id, b.bucket_start_utc, c.ord
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS b(bucket_start_utc, ord)
+    USING (ord)
+)
+SELECT
+  k.coin_id::text                                                AS coin_id,
+  COALESCE(hp.bucket_start_utc, k.bucket_start_utc)::timestamptz AS bucket_start_utc,
+  hp.price_usd                                                   AS price_usd,
+  COALESCE(hp.granularity_seconds, 0)::int4                      AS granularity_seconds,
+  hp.fetched_at                                                  AS fetched_at,
+  COALESCE(hp.provider, '')::text                                AS provider
+FROM keys k
+LEFT JOIN LATERAL (
+  SELECT h.bucket_start_utc, h.price_usd, h.granularity_seconds, h.fetched_at, h.provider
+  FROM historical_price_revisions h
+  WHERE h.coin_id = k.coin_id
+    AND h.bucket_start_utc <= k.bucket_start_utc
+    AND h.bucket_start_utc >= k.bucket_start_utc - make_interval(secs => $3::int)
+    AND h.fetched_at <= COALESCE($4::timestamptz, 'infinity')
+  ORDER BY h.bucket_start_utc DESC, h.fetched_at DESC
+  LIMIT 1
+) hp ON true
+ORDER BY k.ord
 `
 
-type UpsertHistoricalPriceParams struct {
+type GetNearestHistoricalPricesBatchParams struct {
+	Column1 []string             `json:"column1"`
+	Column2 []pgtype.Timestamptz `json:"column2"`
+	Column3 int32                `json:"column3"`
+	Column4 pgtype.Timestamptz   `json:"column4"`
+}
+
+type GetNearestHistoricalPricesBatchRow struct {
 	CoinID             string             `json:"coinId"`
 	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
 	PriceUsd           pgtype.Numeric     `json:"priceUsd"`
 	GranularitySeconds int32              `json:"granularitySeconds"`
+	FetchedAt          pgtype.Timestamptz `json:"fetchedAt"`
+	Provider           string             `json:"provider"`
 }
 
-func (q *Queries) UpsertHistoricalPrice(ctx context.Context, arg UpsertHistoricalPriceParams) error {
-	_, err := q.db.Exec(ctx, upsertHistoricalPrice,
+// $4 is the as-of transaction time; NULL reads the latest revisions.
+func (q *Queries) GetNearestHistoricalPricesBatch(ctx context.Context, arg GetNearestHistoricalPricesBatchParams) ([]GetNearestHistoricalPricesBatchRow, error) {
+	rows, err := q.db.Query(ctx, getNearestHistoricalPricesBatch,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNearestHistoricalPricesBatchRow
+	for rows.Next() {
+		var i GetNearestHistoricalPricesBatchRow
+		if err := rows.Scan(
+			&i.CoinID,
+			&i.BucketStartUtc,
+			&i.PriceUsd,
+			&i.GranularitySeconds,
+			&i.FetchedAt,
+			&i.Provider,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertHistoricalPriceRevision = `-- name: InsertHistoricalPriceRevision :exec
+INSERT INTO historical_price_revisions (coin_id, bucket_start_utc, price_usd, granularity_seconds, provider, fetched_at)
+SELECT $1, $2, $3, $4, $5, now()
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM (
+    SELECT h.price_usd, h.granularity_seconds
+    FROM historical_price_revisions h
+    WHERE h.coin_id = $1
+      AND h.bucket_start_utc = $2
+      AND h.granularity_seconds <= $4
+    ORDER BY h.fetched_at DESC
+    LIMIT 1
+  ) latest
+  WHERE latest.granularity_seconds < $4
+     OR latest.price_usd = $3
+)
+ON CONFLICT (coin_id, bucket_start_utc, fetched_at) DO NOTHING
+`
+
+type InsertHistoricalPriceRevisionParams struct {
+	CoinID             string             `json:"coinId"`
+	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
+	PriceUsd           pgtype.Numeric     `json:"priceUsd"`
+	GranularitySeconds int32              `json:"granularitySeconds"`
+	Provider           string             `json:"provider"`
+}
+
+// A revision is appended unless the latest one at the same or a finer granularity is finer or
+// has the same price: a new bucket, a finer price and a corrected re-fetch are kept, while an
+// unchanged re-fetch or a coarser price adds nothing.
+func (q *Queries) InsertHistoricalPriceRevision(ctx context.Context, arg InsertHistoricalPriceRevisionParams) error {
+	_, err := q.db.Exec(ctx, insertHistoricalPriceRevision,
 		arg.CoinID,
 		arg.BucketStartUtc,
 		arg.PriceUsd,
 		arg.GranularitySeconds,
+		arg.Provider,
 	)
 	return err
 }
 
-const upsertHistoricalPricesBatch = `-- name: UpsertHistoricalPricesBatch :exec
+const insertHistoricalPriceRevisionsBatch = `-- name: InsertHistoricalPriceRevisionsBatch :exec
 WITH rows AS (
   SELECT
     c.coin_id,
     b.bucket_start_utc,
     p.price_usd,
-    g.granularity_seconds
+    g.granularity_seconds,
+    v.provider
   FROM unnest($1::text[])        WITH ORDINALITY AS c(coin_id, ord)
   JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS b(bucket_start_utc, ord) USING (ord)
   JOIN unnest($3::numeric[])     WITH ORDINALITY AS p(price_usd, ord) USING (ord)
   JOIN unnest($4::int4[])        WITH ORDINALITY AS g(granularity_seconds, ord) USING (ord)
+  JOIN unnest($5::text[])        WITH ORDINALITY AS v(provider, ord) USING (ord)
 )
-INSERT INTO historical_prices (
+INSERT INTO historical_price_revisions (
   coin_id,
   bucket_start_utc,
   price_usd,
   granularity_seconds,
+  provider,
   fetched_at
 )
 SELECT
-  coin_id,
-  bucket_start_utc,
-  price_usd,
-  granularity_seconds,
+  r.coin_id,
+  r.bucket_start_utc,
+  r.price_usd,
+  r.granularity_seconds,
+  r.provider,
   now()
-FROM rows
-ON CONFLICT (coin_id, bucket_start_utc)
-DO UPDATE SET
-  price_usd = EXCLUDED.price_usd,
-  granularity_seconds = EXCLUDED.granularity_seconds,
-  fetched_at = now()
-WHERE EXCLUDED.granularity_seconds < historical_prices.granularity_seconds
+FROM rows r
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM (
+    SELECT h.price_usd, h.granularity_seconds
+    FROM historical_price_revisions h
+    WHERE h.coin_id = r.coin_id
+      AND h.bucket_start_utc = r.bucket_start_utc
+      AND h.granularity_seconds <= r.granularity_seconds
+    ORDER BY h.fetched_at DESC
+    LIMIT 1
+  ) latest
+  WHERE latest.granularity_seconds < r.granularity_seconds
+     OR latest.price_usd = r.price_usd
+)
+ON CONFLICT (coin_id, bucket_start_utc, fetched_at) DO NOTHING
 `
 
-type UpsertHistoricalPricesBatchParams struct {
+type InsertHistoricalPriceRevisionsBatchParams struct {
 	Column1 []string             `json:"column1"`
 	Column2 []pgtype.Timestamptz `json:"column2"`
 	Column3 []pgtype.Numeric     `json:"column3"`
 	Column4 []int32              `json:"column4"`
+	Column5 []string             `json:"column5"`
 }
 
-func (q *Queries) UpsertHistoricalPricesBatch(ctx context.Context, arg UpsertHistoricalPricesBatchParams) error {
-	_, err := q.db.Exec(ctx, upsertHistoricalPricesBatch,
+func (q *Queries) InsertHistoricalPriceRevisionsBatch(ctx context.Context, arg InsertHistoricalPriceRevisionsBatchParams) error {
+	_, err := q.db.Exec(ctx, insertHistoricalPriceRevisionsBatch,
 		arg.Column1,
 		arg.Column2,
 		arg.Column3,
 		arg.Column4,
+		arg.Column5,
 	)
 	return err
 }
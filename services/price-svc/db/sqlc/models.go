@@ -9,12 +9,129 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Coin struct {
+	ID        string             `json:"id"`
+	Symbol    string             `json:"symbol"`
+	Name      string             `json:"name"`
+	Platforms []byte             `json:"platforms"`
+	FirstSeen pgtype.Timestamptz `json:"firstSeen"`
+	LastSeen  pgtype.Timestamptz `json:"lastSeen"`
+	Delisted  bool               `json:"delisted"`
+}
+
+type CoinCatalogChange struct {
+	ID        int64              `json:"id"`
+	SyncedAt  pgtype.Timestamptz `json:"syncedAt"`
+	CoinID    string             `json:"coinId"`
+	Change    string             `json:"change"`
+	OldSymbol string             `json:"oldSymbol"`
+	OldName   string             `json:"oldName"`
+	NewSymbol string             `json:"newSymbol"`
+	NewName   string             `json:"newName"`
+}
+
+type CoinContract struct {
+	Platform        string             `json:"platform"`
+	ContractAddress string             `json:"contractAddress"`
+	CoinID          string             `json:"coinId"`
+	SyncedAt        pgtype.Timestamptz `json:"syncedAt"`
+}
+
+type CoinListing struct {
+	CoinID       string             `json:"coinId"`
+	FirstPriceAt pgtype.Timestamptz `json:"firstPriceAt"`
+	CheckedAt    pgtype.Timestamptz `json:"checkedAt"`
+}
+
+type CustomAsset struct {
+	ID        uuid.UUID          `json:"id"`
+	TenantID  uuid.UUID          `json:"tenantId"`
+	Symbol    string             `json:"symbol"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt pgtype.Timestamptz `json:"updatedAt"`
+}
+
+type CustomAssetPrice struct {
+	AssetID   uuid.UUID          `json:"assetId"`
+	AtUtc     pgtype.Timestamptz `json:"atUtc"`
+	Price     pgtype.Numeric     `json:"price"`
+	Currency  string             `json:"currency"`
+	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt pgtype.Timestamptz `json:"updatedAt"`
+}
+
 type HistoricalPrice struct {
 	CoinID             string             `json:"coinId"`
 	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
 	PriceUsd           pgtype.Numeric     `json:"priceUsd"`
 	GranularitySeconds int32              `json:"granularitySeconds"`
 	FetchedAt          pgtype.Timestamptz `json:"fetchedAt"`
+	Provider           string             `json:"provider"`
+}
+
+type HistoricalPriceRevision struct {
+	CoinID             string             `json:"coinId"`
+	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
+	PriceUsd           pgtype.Numeric     `json:"priceUsd"`
+	GranularitySeconds int32              `json:"granularitySeconds"`
+	Provider           string             `json:"provider"`
+	FetchedAt          pgtype.Timestamptz `json:"fetchedAt"`
+}
+
+type PriceGap struct {
+	CoinID    string             `json:"coinId"`
+	DayUtc    pgtype.Timestamptz `json:"dayUtc"`
+	Reason    string             `json:"reason"`
+	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+	ExpiresAt pgtype.Timestamptz `json:"expiresAt"`
+}
+
+type PriceQuarantine struct {
+	ID                 int64              `json:"id"`
+	CoinID             string             `json:"coinId"`
+	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
+	GranularitySeconds int32              `json:"granularitySeconds"`
+	RawPrice           pgtype.Numeric     `json:"rawPrice"`
+	SubstitutedPrice   pgtype.Numeric     `json:"substitutedPrice"`
+	NeighbourMedian    pgtype.Numeric     `json:"neighbourMedian"`
+	Reason             string             `json:"reason"`
+	DetectedAt         pgtype.Timestamptz `json:"detectedAt"`
+	ReviewedAt         pgtype.Timestamptz `json:"reviewedAt"`
+}
+
+type SnapshotResolution struct {
+	TenantID        uuid.UUID          `json:"tenantId"`
+	SnapshotID      string             `json:"snapshotId"`
+	Source          string             `json:"source"`
+	Symbol          string             `json:"symbol"`
+	Chain           string             `json:"chain"`
+	ContractAddress string             `json:"contractAddress"`
+	AtUtc           pgtype.Timestamptz `json:"atUtc"`
+	CoinID          string             `json:"coinId"`
+	CanonicalSymbol string             `json:"canonicalSymbol"`
+	Quote           string             `json:"quote"`
+	Consensus       bool               `json:"consensus"`
+	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
+}
+
+type SnapshotValuation struct {
+	SnapshotID         string             `json:"snapshotId"`
+	Kind               string             `json:"kind"`
+	Asset              string             `json:"asset"`
+	AtUtc              pgtype.Timestamptz `json:"atUtc"`
+	FiatValue          pgtype.Numeric     `json:"fiatValue"`
+	Method             int32              `json:"method"`
+	LowerPrecision     bool               `json:"lowerPrecision"`
+	BucketStartUtc     pgtype.Timestamptz `json:"bucketStartUtc"`
+	GranularitySeconds *int32             `json:"granularitySeconds"`
+	Provider           string             `json:"provider"`
+	PriceUsd           pgtype.Numeric     `json:"priceUsd"`
+	FetchedAt          pgtype.Timestamptz `json:"fetchedAt"`
+	FxRate             pgtype.Numeric     `json:"fxRate"`
+	FxEffectiveAt      pgtype.Timestamptz `json:"fxEffectiveAt"`
+	CreatedAt          pgtype.Timestamptz `json:"createdAt"`
+	TenantID           uuid.UUID          `json:"tenantId"`
 }
 
 type TenantSymbol struct {
@@ -24,4 +141,56 @@ type TenantSymbol struct {
 	CoinID    string             `json:"coinId"`
 	CreatedAt pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt pgtype.Timestamptz `json:"updatedAt"`
+	ValidFrom pgtype.Timestamptz `json:"validFrom"`
+	ValidTo   pgtype.Timestamptz `json:"validTo"`
+}
+
+type UnresolvedSymbol struct {
+	ID              int64              `json:"id"`
+	TenantID        uuid.UUID          `json:"tenantId"`
+	Source          string             `json:"source"`
+	Symbol          string             `json:"symbol"`
+	CanonicalSymbol string             `json:"canonicalSymbol"`
+	Reason          string             `json:"reason"`
+	Message         string             `json:"message"`
+	Occurrences     int64              `json:"occurrences"`
+	FirstSeen       pgtype.Timestamptz `json:"firstSeen"`
+	LastSeen        pgtype.Timestamptz `json:"lastSeen"`
+}
+
+type ValuationJob struct {
+	ID            uuid.UUID          `json:"id"`
+	TenantID      string             `json:"tenantId"`
+	Status        string             `json:"status"`
+	Request       []byte             `json:"request"`
+	Total         int32              `json:"total"`
+	Processed     int32              `json:"processed"`
+	Error         *string            `json:"error"`
+	LeaseUntil    pgtype.Timestamptz `json:"leaseUntil"`
+	CreatedAt     pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamptz `json:"updatedAt"`
+	StartedAt     pgtype.Timestamptz `json:"startedAt"`
+	FinishedAt    pgtype.Timestamptz `json:"finishedAt"`
+	Attempts      int32              `json:"attempts"`
+	NextAttemptAt pgtype.Timestamptz `json:"nextAttemptAt"`
+	LeaseToken    *uuid.UUID         `json:"leaseToken"`
+}
+
+type ValuationJobItem struct {
+	JobID  uuid.UUID `json:"jobId"`
+	Seq    int32     `json:"seq"`
+	Tx     []byte    `json:"tx"`
+	Result []byte    `json:"result"`
+}
+
+type ValuationSnapshot struct {
+	ID                     string             `json:"id"`
+	FiatCurrency           string             `json:"fiatCurrency"`
+	PricePoint             int32              `json:"pricePoint"`
+	LookupNearest          bool               `json:"lookupNearest"`
+	LookupToleranceSeconds int32              `json:"lookupToleranceSeconds"`
+	LookupAcceptCoarser    bool               `json:"lookupAcceptCoarser"`
+	CreatedAt              pgtype.Timestamptz `json:"createdAt"`
+	LookupAsOf             pgtype.Timestamptz `json:"lookupAsOf"`
+	TenantID               uuid.UUID          `json:"tenantId"`
 }
@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: price_gaps.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteExpiredPriceGaps = `-- name: DeleteExpiredPriceGaps :execrows
+DELETE FROM price_gaps
+WHERE expires_at <= now()
+`
+
+func (q *Queries) DeleteExpiredPriceGaps(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredPriceGaps)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getActivePriceGaps = `-- name: GetActivePriceGaps :many
+WITH keys AS (
+  SELECT c.coin_id, d.day_utc
+  FROM unnest($1::text[]) WITH ORDINALITY AS c(coin_id, ord)
+  JOIN unnest($2::timestamptz[]) WITH ORDINALITY AS d(day_utc, ord)
+    USING (ord)
+)
+SELECT g.coin_id, g.day_utc, g.reason, g.created_at, g.expires_at
+FROM price_gaps g
+JOIN keys k
+  ON k.coin_id = g.coin_id
+ AND k.day_utc = g.day_utc
+WHERE g.expires_at > now()
+`
+
+type GetActivePriceGapsParams struct {
+	Column1 []string             `json:"column1"`
+	Column2 []pgtype.Timestamptz `json:"column2"`
+}
+
+func (q *Queries) GetActivePriceGaps(ctx context.Context, arg GetActivePriceGapsParams) ([]PriceGap, error) {
+	rows, err := q.db.Query(ctx, getActivePriceGaps, arg.Column1, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PriceGap
+	for rows.Next() {
+		var i PriceGap
+		if err := rows.Scan(
+			&i.CoinID,
+			&i.DayUtc,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCoinListings = `-- name: GetCoinListings :many
+SELECT coin_id, first_price_at, checked_at
+FROM coin_listings
+WHERE coin_id = ANY($1::text[])
+`
+
+func (q *Queries) GetCoinListings(ctx context.Context, dollar_1 []string) ([]CoinListing, error) {
+	rows, err := q.db.Query(ctx, getCoinListings, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinListing
+	for rows.Next() {
+		var i CoinListing
+		if err := rows.Scan(&i.CoinID, &i.FirstPriceAt, &i.CheckedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCoinListing = `-- name: UpsertCoinListing :exec
+INSERT INTO coin_listings (coin_id, first_price_at, checked_at)
+VALUES ($1, $2, now())
+ON CONFLICT (coin_id)
+DO UPDATE SET first_price_at = EXCLUDED.first_price_at, checked_at = now()
+`
+
+type UpsertCoinListingParams struct {
+	CoinID       string             `json:"coinId"`
+	FirstPriceAt pgtype.Timestamptz `json:"firstPriceAt"`
+}
+
+func (q *Queries) UpsertCoinListing(ctx context.Context, arg UpsertCoinListingParams) error {
+	_, err := q.db.Exec(ctx, upsertCoinListing, arg.CoinID, arg.FirstPriceAt)
+	return err
+}
+
+const upsertPriceGap = `-- name: UpsertPriceGap :exec
+INSERT INTO price_gaps (coin_id, day_utc, reason, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (coin_id, day_utc)
+DO UPDATE SET reason = EXCLUDED.reason, expires_at = EXCLUDED.expires_at
+`
+
+type UpsertPriceGapParams struct {
+	CoinID    string             `json:"coinId"`
+	DayUtc    pgtype.Timestamptz `json:"dayUtc"`
+	Reason    string             `json:"reason"`
+	ExpiresAt pgtype.Timestamptz `json:"expiresAt"`
+}
+
+func (q *Queries) UpsertPriceGap(ctx context.Context, arg UpsertPriceGapParams) error {
+	_, err := q.db.Exec(ctx, upsertPriceGap,
+		arg.CoinID,
+		arg.DayUtc,
+		arg.Reason,
+		arg.ExpiresAt,
+	)
+	return err
+}
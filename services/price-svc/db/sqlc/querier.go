@@ -6,16 +6,91 @@ package db
 
 import (
 	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	ClaimValuationJob(ctx context.Context, arg ClaimValuationJobParams) (ValuationJob, error)
+	// Counts per symbol and coin the tenants other than the given one that currently map the symbol
+	// to the coin. Windows do not overlap, so every tenant votes at most once per symbol. Custom
+	// assets belong to their tenant and never count towards another tenant's consensus.
+	CountTenantSymbolMappings(ctx context.Context, arg CountTenantSymbolMappingsParams) ([]CountTenantSymbolMappingsRow, error)
+	CreateValuationJob(ctx context.Context, arg CreateValuationJobParams) error
+	CreateValuationSnapshot(ctx context.Context, arg CreateValuationSnapshotParams) error
+	DeleteCoinContractsSyncedBefore(ctx context.Context, syncedAt pgtype.Timestamptz) (int64, error)
+	DeleteCustomAsset(ctx context.Context, arg DeleteCustomAssetParams) (int64, error)
+	DeleteExpiredPriceGaps(ctx context.Context) (int64, error)
+	// Deletes every validity window of the symbol.
 	DeleteTenantSymbol(ctx context.Context, arg DeleteTenantSymbolParams) (int64, error)
+	DeleteUnresolvedSymbol(ctx context.Context, arg DeleteUnresolvedSymbolParams) (int64, error)
+	// Creates missing assets; existing ones keep their name.
+	EnsureCustomAssets(ctx context.Context, arg EnsureCustomAssetsParams) error
+	FinishValuationJob(ctx context.Context, arg FinishValuationJobParams) (int64, error)
+	// Symbols match case-insensitively; the provider lists them lower-case.
+	GetActiveCoinsBySymbols(ctx context.Context, dollar_1 []string) ([]Coin, error)
+	GetActivePriceGaps(ctx context.Context, arg GetActivePriceGapsParams) ([]PriceGap, error)
+	GetCoin(ctx context.Context, id string) (Coin, error)
+	GetCoinCatalogLastSync(ctx context.Context) (pgtype.Timestamptz, error)
+	GetCoinContractsByAddresses(ctx context.Context, dollar_1 []string) ([]CoinContract, error)
+	GetCoinContractsLastSync(ctx context.Context) (pgtype.Timestamptz, error)
+	GetCoinListings(ctx context.Context, dollar_1 []string) ([]CoinListing, error)
+	GetCoinsByIDs(ctx context.Context, dollar_1 []string) ([]Coin, error)
+	// Per key the latest-starting bucket of any granularity that contains it, e.g. the daily bucket
+	// for an hourly key; $3 is the as-of transaction time, NULL reads the latest revisions.
+	GetContainingHistoricalPricesBatch(ctx context.Context, arg GetContainingHistoricalPricesBatchParams) ([]GetContainingHistoricalPricesBatchRow, error)
+	GetCustomAsset(ctx context.Context, arg GetCustomAssetParams) (CustomAsset, error)
+	// Latest point at or before each requested time; price is NULL when there is none.
+	GetCustomAssetPricesAt(ctx context.Context, arg GetCustomAssetPricesAtParams) ([]GetCustomAssetPricesAtRow, error)
+	GetCustomAssetsBySymbols(ctx context.Context, arg GetCustomAssetsBySymbolsParams) ([]CustomAsset, error)
+	// $3 is the as-of transaction time; NULL reads the latest revisions.
+	GetDailyAveragePricesBatch(ctx context.Context, arg GetDailyAveragePricesBatchParams) ([]GetDailyAveragePricesBatchRow, error)
 	GetHistoricalPrice(ctx context.Context, arg GetHistoricalPriceParams) (HistoricalPrice, error)
+	// $3 is the as-of transaction time; NULL reads the latest revisions.
 	GetHistoricalPricesBatch(ctx context.Context, arg GetHistoricalPricesBatchParams) ([]GetHistoricalPricesBatchRow, error)
+	// $4 is the as-of transaction time; NULL reads the latest revisions.
+	GetNearestHistoricalPricesBatch(ctx context.Context, arg GetNearestHistoricalPricesBatchParams) ([]GetNearestHistoricalPricesBatchRow, error)
+	GetSnapshotResolutions(ctx context.Context, arg GetSnapshotResolutionsParams) ([]GetSnapshotResolutionsRow, error)
+	GetSnapshotValuations(ctx context.Context, arg GetSnapshotValuationsParams) ([]SnapshotValuation, error)
 	GetTenantSymbols(ctx context.Context, arg GetTenantSymbolsParams) ([]TenantSymbol, error)
+	GetValuationJob(ctx context.Context, arg GetValuationJobParams) (ValuationJob, error)
+	GetValuationSnapshot(ctx context.Context, arg GetValuationSnapshotParams) (ValuationSnapshot, error)
+	InsertCoinCatalogChanges(ctx context.Context, arg InsertCoinCatalogChangesParams) error
+	// A revision is appended unless the latest one at the same or a finer granularity is finer or
+	// has the same price: a new bucket, a finer price and a corrected re-fetch are kept, while an
+	// unchanged re-fetch or a coarser price adds nothing.
+	InsertHistoricalPriceRevision(ctx context.Context, arg InsertHistoricalPriceRevisionParams) error
+	InsertHistoricalPriceRevisionsBatch(ctx context.Context, arg InsertHistoricalPriceRevisionsBatchParams) error
+	InsertQuarantinedPricesBatch(ctx context.Context, arg InsertQuarantinedPricesBatchParams) error
+	InsertSnapshotResolutions(ctx context.Context, arg InsertSnapshotResolutionsParams) error
+	InsertSnapshotValuations(ctx context.Context, arg InsertSnapshotValuationsParams) error
+	InsertValuationJobItems(ctx context.Context, arg InsertValuationJobItemsParams) error
+	ListCoins(ctx context.Context) ([]Coin, error)
+	ListCustomAssetPrices(ctx context.Context, arg ListCustomAssetPricesParams) ([]CustomAssetPrice, error)
+	ListCustomAssets(ctx context.Context, tenantID uuid.UUID) ([]CustomAsset, error)
+	ListPendingValuationJobItems(ctx context.Context, arg ListPendingValuationJobItemsParams) ([]ListPendingValuationJobItemsRow, error)
+	ListTenantSymbols(ctx context.Context, tenantID uuid.UUID) ([]TenantSymbol, error)
 	ListTenantSymbolsBySource(ctx context.Context, arg ListTenantSymbolsBySourceParams) ([]TenantSymbol, error)
-	UpsertHistoricalPrice(ctx context.Context, arg UpsertHistoricalPriceParams) error
-	UpsertHistoricalPricesBatch(ctx context.Context, arg UpsertHistoricalPricesBatchParams) error
+	// Filters are optional: a nil tenant or empty source matches all.
+	ListUnresolvedSymbols(ctx context.Context, arg ListUnresolvedSymbolsParams) ([]UnresolvedSymbol, error)
+	ListValuationJobResults(ctx context.Context, arg ListValuationJobResultsParams) ([]ListValuationJobResultsRow, error)
+	MarkCoinsDelisted(ctx context.Context, lastSeen pgtype.Timestamptz) (int64, error)
+	RecordUnresolvedSymbols(ctx context.Context, arg RecordUnresolvedSymbolsParams) error
+	ReleaseValuationJob(ctx context.Context, arg ReleaseValuationJobParams) (int64, error)
+	RetryValuationJob(ctx context.Context, arg RetryValuationJobParams) (int64, error)
+	SaveValuationJobResults(ctx context.Context, arg SaveValuationJobResultsParams) (int64, error)
+	// Ranks exact symbol matches first, then symbol prefixes, name prefixes and substrings;
+	// delisted coins come after listed ones of the same rank. The trigram indexes serve queries of
+	// three characters or more; shorter ones scan the catalog.
+	SearchCoins(ctx context.Context, arg SearchCoinsParams) ([]Coin, error)
+	UpdateValuationJobProgress(ctx context.Context, arg UpdateValuationJobProgressParams) (int64, error)
+	UpsertCoinContracts(ctx context.Context, arg UpsertCoinContractsParams) error
+	UpsertCoinListing(ctx context.Context, arg UpsertCoinListingParams) error
+	UpsertCoins(ctx context.Context, arg UpsertCoinsParams) error
+	UpsertCustomAsset(ctx context.Context, arg UpsertCustomAssetParams) (CustomAsset, error)
+	UpsertCustomAssetPrices(ctx context.Context, arg UpsertCustomAssetPricesParams) error
+	UpsertPriceGap(ctx context.Context, arg UpsertPriceGapParams) error
 	UpsertTenantSymbol(ctx context.Context, arg UpsertTenantSymbolParams) error
 }
 
@@ -11,7 +11,14 @@ import (
 // Store defines all functions to execute db queries and transactions
 type Store interface {
 	Querier
-	// SomeTx(ctx context.Context, arg SomeTxParams) (SomeTxResult, error) // by execTx
+	CreateValuationJobTx(ctx context.Context, arg CreateValuationJobTxParams) error
+	SaveValuationJobChunkTx(ctx context.Context, arg SaveValuationJobChunkTxParams) error
+	ImportCustomAssetPricesTx(ctx context.Context, arg ImportCustomAssetPricesTxParams) error
+	ReplaceCoinContractsTx(ctx context.Context, arg ReplaceCoinContractsTxParams) (int64, error)
+	SyncCoinCatalogTx(ctx context.Context, arg SyncCoinCatalogTxParams) (int64, error)
+	ResolveUnresolvedSymbolTx(ctx context.Context, arg UpsertTenantSymbolParams) error
+	UpsertTenantSymbolTx(ctx context.Context, arg UpsertTenantSymbolParams) error
+	ImportTenantSymbolsTx(ctx context.Context, args []UpsertTenantSymbolParams) error
 }
 
 type SQLStore struct {
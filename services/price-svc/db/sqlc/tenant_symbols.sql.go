@@ -9,8 +9,57 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTenantSymbolMappings = `-- name: CountTenantSymbolMappings :many
+SELECT symbol, coin_id, count(DISTINCT tenant_id)::int AS tenants
+FROM tenant_symbols
+WHERE source = $1
+  AND symbol = ANY($2::text[])
+  AND tenant_id <> $3
+  AND coin_id NOT LIKE 'custom:%'
+  AND valid_from <= now()
+  AND valid_to > now()
+GROUP BY symbol, coin_id
+ORDER BY symbol ASC, tenants DESC, coin_id ASC
+`
+
+type CountTenantSymbolMappingsParams struct {
+	Source          string    `json:"source"`
+	Symbols         []string  `json:"symbols"`
+	ExcludeTenantID uuid.UUID `json:"excludeTenantId"`
+}
+
+type CountTenantSymbolMappingsRow struct {
+	Symbol  string `json:"symbol"`
+	CoinID  string `json:"coinId"`
+	Tenants int32  `json:"tenants"`
+}
+
+// Counts per symbol and coin the tenants other than the given one that currently map the symbol
+// to the coin. Windows do not overlap, so every tenant votes at most once per symbol. Custom
+// assets belong to their tenant and never count towards another tenant's consensus.
+func (q *Queries) CountTenantSymbolMappings(ctx context.Context, arg CountTenantSymbolMappingsParams) ([]CountTenantSymbolMappingsRow, error) {
+	rows, err := q.db.Query(ctx, countTenantSymbolMappings, arg.Source, arg.Symbols, arg.ExcludeTenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountTenantSymbolMappingsRow
+	for rows.Next() {
+		var i CountTenantSymbolMappingsRow
+		if err := rows.Scan(&i.Symbol, &i.CoinID, &i.Tenants); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deleteTenantSymbol = `-- name: DeleteTenantSymbol :execrows
 DELETE FROM tenant_symbols
 WHERE tenant_id = $1 AND source = $2 AND symbol = $3
@@ -22,6 +71,7 @@ type DeleteTenantSymbolParams struct {
 	Symbol   string    `json:"symbol"`
 }
 
+// Deletes every validity window of the symbol.
 func (q *Queries) DeleteTenantSymbol(ctx context.Context, arg DeleteTenantSymbolParams) (int64, error) {
 	result, err := q.db.Exec(ctx, deleteTenantSymbol, arg.TenantID, arg.Source, arg.Symbol)
 	if err != nil {
@@ -31,11 +81,12 @@ func (q *Queries) DeleteTenantSymbol(ctx context.Context, arg DeleteTenantSymbol
 }
 
 const getTenantSymbols = `-- name: GetTenantSymbols :many
-SELECT tenant_id, source, symbol, coin_id, created_at, updated_at
+SELECT tenant_id, source, symbol, coin_id, created_at, updated_at, valid_from, valid_to
 FROM tenant_symbols
 WHERE tenant_id = $1
   AND source = $2
   AND symbol = ANY($3::text[])
+ORDER BY symbol ASC, valid_from ASC
 `
 
 type GetTenantSymbolsParams struct {
@@ -60,6 +111,44 @@ func (q *Queries) GetTenantSymbols(ctx context.Context, arg GetTenantSymbolsPara
 			&i.CoinID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ValidFrom,
+			&i.ValidTo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTenantSymbols = `-- name: ListTenantSymbols :many
+SELECT tenant_id, source, symbol, coin_id, created_at, updated_at, valid_from, valid_to
+FROM tenant_symbols
+WHERE tenant_id = $1
+ORDER BY source ASC, symbol ASC, valid_from ASC
+`
+
+func (q *Queries) ListTenantSymbols(ctx context.Context, tenantID uuid.UUID) ([]TenantSymbol, error) {
+	rows, err := q.db.Query(ctx, listTenantSymbols, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TenantSymbol
+	for rows.Next() {
+		var i TenantSymbol
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.Source,
+			&i.Symbol,
+			&i.CoinID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ValidFrom,
+			&i.ValidTo,
 		); err != nil {
 			return nil, err
 		}
@@ -72,11 +161,11 @@ func (q *Queries) GetTenantSymbols(ctx context.Context, arg GetTenantSymbolsPara
 }
 
 const listTenantSymbolsBySource = `-- name: ListTenantSymbolsBySource :many
-SELECT tenant_id, source, symbol, coin_id, created_at, updated_at
+SELECT tenant_id, source, symbol, coin_id, created_at, updated_at, valid_from, valid_to
 FROM tenant_symbols
 WHERE tenant_id = $1
   AND source = $2
-ORDER BY symbol ASC
+ORDER BY symbol ASC, valid_from ASC
 `
 
 type ListTenantSymbolsBySourceParams struct {
@@ -100,6 +189,8 @@ func (q *Queries) ListTenantSymbolsBySource(ctx context.Context, arg ListTenantS
 			&i.CoinID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ValidFrom,
+			&i.ValidTo,
 		); err != nil {
 			return nil, err
 		}
@@ -112,17 +203,19 @@ func (q *Queries) ListTenantSymbolsBySource(ctx context.Context, arg ListTenantS
 }
 
 const upsertTenantSymbol = `-- name: UpsertTenantSymbol :exec
-INSERT INTO tenant_symbols (tenant_id, source, symbol, coin_id)
-VALUES ($1, $2, $3, $4)
-ON CONFLICT (tenant_id, source, symbol)
-DO UPDATE SET coin_id = EXCLUDED.coin_id, updated_at = now()
+INSERT INTO tenant_symbols (tenant_id, source, symbol, coin_id, valid_from, valid_to)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tenant_id, source, symbol, valid_from)
+DO UPDATE SET coin_id = EXCLUDED.coin_id, valid_to = EXCLUDED.valid_to, updated_at = now()
 `
 
 type UpsertTenantSymbolParams struct {
-	TenantID uuid.UUID `json:"tenantId"`
-	Source   string    `json:"source"`
-	Symbol   string    `json:"symbol"`
-	CoinID   string    `json:"coinId"`
+	TenantID  uuid.UUID          `json:"tenantId"`
+	Source    string             `json:"source"`
+	Symbol    string             `json:"symbol"`
+	CoinID    string             `json:"coinId"`
+	ValidFrom pgtype.Timestamptz `json:"validFrom"`
+	ValidTo   pgtype.Timestamptz `json:"validTo"`
 }
 
 func (q *Queries) UpsertTenantSymbol(ctx context.Context, arg UpsertTenantSymbolParams) error {
@@ -131,6 +224,8 @@ func (q *Queries) UpsertTenantSymbol(ctx context.Context, arg UpsertTenantSymbol
 		arg.Source,
 		arg.Symbol,
 		arg.CoinID,
+		arg.ValidFrom,
+		arg.ValidTo,
 	)
 	return err
 }
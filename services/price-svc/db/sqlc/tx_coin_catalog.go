@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SyncCoinCatalogTxParams struct {
+	SyncedAt pgtype.Timestamptz
+	Coins    UpsertCoinsParams
+	Changes  InsertCoinCatalogChangesParams
+}
+
+// SyncCoinCatalogTx applies one provider coin list: listed coins are upserted, the rest are
+// flagged delisted and the diff is logged, all or nothing.
+// It returns how many coins were delisted.
+func (store *SQLStore) SyncCoinCatalogTx(ctx context.Context, arg SyncCoinCatalogTxParams) (int64, error) {
+	var delisted int64
+	err := store.execTx(ctx, func(q *Queries) error {
+		arg.Coins.SyncedAt = arg.SyncedAt
+		if err := q.UpsertCoins(ctx, arg.Coins); err != nil {
+			return err
+		}
+
+		n, err := q.MarkCoinsDelisted(ctx, arg.SyncedAt)
+		if err != nil {
+			return err
+		}
+		delisted = n
+
+		if len(arg.Changes.CoinIds) > 0 {
+			arg.Changes.SyncedAt = arg.SyncedAt
+			if err := q.InsertCoinCatalogChanges(ctx, arg.Changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return delisted, err
+}
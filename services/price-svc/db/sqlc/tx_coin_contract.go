@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ReplaceCoinContractsTxParams struct {
+	SyncedAt          pgtype.Timestamptz
+	Platforms         []string
+	ContractAddresses []string
+	CoinIds           []string
+}
+
+// ReplaceCoinContractsTx stores a full copy of the provider's contracts and drops the ones
+// it no longer lists, so readers never see a half-synced table.
+func (store *SQLStore) ReplaceCoinContractsTx(ctx context.Context, arg ReplaceCoinContractsTxParams) (int64, error) {
+	var removed int64
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.UpsertCoinContracts(ctx, UpsertCoinContractsParams{
+			SyncedAt:          arg.SyncedAt,
+			Platforms:         arg.Platforms,
+			ContractAddresses: arg.ContractAddresses,
+			CoinIds:           arg.CoinIds,
+		}); err != nil {
+			return err
+		}
+
+		n, err := q.DeleteCoinContractsSyncedBefore(ctx, arg.SyncedAt)
+		if err != nil {
+			return err
+		}
+		removed = n
+		return nil
+	})
+	return removed, err
+}
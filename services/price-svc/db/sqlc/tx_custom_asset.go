@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ImportCustomAssetPricesTxParams struct {
+	TenantID   uuid.UUID
+	Symbols    []string // asset symbol of every price point
+	AtUtc      []pgtype.Timestamptz
+	Prices     []pgtype.Numeric
+	Currencies []string
+}
+
+// ImportCustomAssetPricesTx creates the tenant's assets that do not exist yet and upserts
+// all price points, so an upload is applied completely or not at all.
+func (store *SQLStore) ImportCustomAssetPricesTx(ctx context.Context, arg ImportCustomAssetPricesTxParams) error {
+	var symbols []string
+	seen := make(map[string]struct{}, len(arg.Symbols))
+	for _, s := range arg.Symbols {
+		if _, ok := seen[s]; !ok {
+			seen[s] = struct{}{}
+			symbols = append(symbols, s)
+		}
+	}
+
+	ids := make([]uuid.UUID, len(symbols))
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	return store.execTx(ctx, func(q *Queries) error {
+		if err := q.EnsureCustomAssets(ctx, EnsureCustomAssetsParams{
+			TenantID: arg.TenantID,
+			Ids:      ids,
+			Symbols:  symbols,
+		}); err != nil {
+			return err
+		}
+
+		assets, err := q.GetCustomAssetsBySymbols(ctx, GetCustomAssetsBySymbolsParams{
+			TenantID: arg.TenantID,
+			Column2:  symbols,
+		})
+		if err != nil {
+			return err
+		}
+		bySymbol := make(map[string]uuid.UUID, len(assets))
+		for _, a := range assets {
+			bySymbol[a.Symbol] = a.ID
+		}
+
+		assetIDs := make([]uuid.UUID, len(arg.Symbols))
+		for i, s := range arg.Symbols {
+			id, ok := bySymbol[s]
+			if !ok {
+				return fmt.Errorf("custom asset %q was not created", s)
+			}
+			assetIDs[i] = id
+		}
+
+		return q.UpsertCustomAssetPrices(ctx, UpsertCustomAssetPricesParams{
+			AssetIds:   assetIDs,
+			AtUtc:      arg.AtUtc,
+			Prices:     arg.Prices,
+			Currencies: arg.Currencies,
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// UpsertTenantSymbolTx writes a mapping and clears the symbol's unresolved queue entry, if any.
+func (store *SQLStore) UpsertTenantSymbolTx(ctx context.Context, arg UpsertTenantSymbolParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		return writeTenantSymbol(ctx, q, arg)
+	})
+}
+
+// ImportRowError tells which mapping of an import failed; Row is 1-based.
+type ImportRowError struct {
+	Row    int
+	Source string
+	Symbol string
+	Err    error
+}
+
+func (e *ImportRowError) Error() string {
+	return fmt.Sprintf("mapping %d (%s %s): %v", e.Row, e.Source, e.Symbol, e.Err)
+}
+
+func (e *ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportTenantSymbolsTx upserts every mapping of a bulk import, so an import is applied
+// completely or not at all. A failing mapping is reported as *ImportRowError.
+func (store *SQLStore) ImportTenantSymbolsTx(ctx context.Context, args []UpsertTenantSymbolParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		for i, arg := range args {
+			if err := writeTenantSymbol(ctx, q, arg); err != nil {
+				return &ImportRowError{Row: i + 1, Source: arg.Source, Symbol: arg.Symbol, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// writeTenantSymbol upserts a mapping; a symbol that now has one no longer needs review.
+func writeTenantSymbol(ctx context.Context, q *Queries, arg UpsertTenantSymbolParams) error {
+	if err := q.UpsertTenantSymbol(ctx, arg); err != nil {
+		return err
+	}
+	_, err := q.DeleteUnresolvedSymbol(ctx, DeleteUnresolvedSymbolParams{
+		TenantID: arg.TenantID,
+		Source:   arg.Source,
+		Symbol:   arg.Symbol,
+	})
+	return err
+}
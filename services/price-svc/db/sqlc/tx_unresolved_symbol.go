@@ -0,0 +1,27 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ResolveUnresolvedSymbolTx writes the tenant mapping for a queued symbol and clears the queue
+// entry. It fails with pgx.ErrNoRows, writing nothing, when the symbol is not queued.
+func (store *SQLStore) ResolveUnresolvedSymbolTx(ctx context.Context, arg UpsertTenantSymbolParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		n, err := q.DeleteUnresolvedSymbol(ctx, DeleteUnresolvedSymbolParams{
+			TenantID: arg.TenantID,
+			Source:   arg.Source,
+			Symbol:   arg.Symbol,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return q.UpsertTenantSymbol(ctx, arg)
+	})
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrJobLeaseLost is returned when a job's lease token no longer matches, i.e. another worker took the job over.
+var ErrJobLeaseLost = errors.New("valuation job lease lost")
+
+type CreateValuationJobTxParams struct {
+	Job CreateValuationJobParams
+	Seq []int32
+	Txs [][]byte
+}
+
+// CreateValuationJobTx stores a job together with all of its transactions.
+func (store *SQLStore) CreateValuationJobTx(ctx context.Context, arg CreateValuationJobTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if err := q.CreateValuationJob(ctx, arg.Job); err != nil {
+			return err
+		}
+		return q.InsertValuationJobItems(ctx, InsertValuationJobItemsParams{
+			Column1: arg.Job.ID,
+			Column2: arg.Seq,
+			Column3: arg.Txs,
+		})
+	})
+}
+
+type SaveValuationJobChunkTxParams struct {
+	JobID        uuid.UUID
+	Seq          []int32
+	Results      [][]byte
+	LeaseSeconds int32
+	LeaseToken   uuid.UUID
+}
+
+// SaveValuationJobChunkTx stores a priced chunk and advances the job's progress and lease atomically,
+// so a restarted worker resumes right after the last saved chunk. Nothing is saved when the lease
+// token no longer matches: the chunk is rolled back and ErrJobLeaseLost is returned.
+func (store *SQLStore) SaveValuationJobChunkTx(ctx context.Context, arg SaveValuationJobChunkTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		// items saved by an earlier run of the chunk are left alone and not counted twice
+		saved, err := q.SaveValuationJobResults(ctx, SaveValuationJobResultsParams{
+			JobID:   arg.JobID,
+			Column2: arg.Seq,
+			Column3: arg.Results,
+		})
+		if err != nil {
+			return err
+		}
+		n, err := q.UpdateValuationJobProgress(ctx, UpdateValuationJobProgressParams{
+			Saved:        int32(saved),
+			JobID:        arg.JobID,
+			LeaseSeconds: arg.LeaseSeconds,
+			LeaseToken:   arg.LeaseToken,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrJobLeaseLost
+		}
+		return nil
+	})
+}
@@ -0,0 +1,124 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: unresolved_symbols.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const deleteUnresolvedSymbol = `-- name: DeleteUnresolvedSymbol :execrows
+DELETE FROM unresolved_symbols
+WHERE tenant_id = $1 AND source = $2 AND symbol = $3
+`
+
+type DeleteUnresolvedSymbolParams struct {
+	TenantID uuid.UUID `json:"tenantId"`
+	Source   string    `json:"source"`
+	Symbol   string    `json:"symbol"`
+}
+
+func (q *Queries) DeleteUnresolvedSymbol(ctx context.Context, arg DeleteUnresolvedSymbolParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUnresolvedSymbol, arg.TenantID, arg.Source, arg.Symbol)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listUnresolvedSymbols = `-- name: ListUnresolvedSymbols :many
+SELECT id, tenant_id, source, symbol, canonical_symbol, reason, message, occurrences, first_seen, last_seen
+FROM unresolved_symbols
+WHERE ($1::uuid IS NULL OR tenant_id = $1::uuid)
+  AND ($2::text = '' OR source = $2::text)
+  AND id > $3::bigint
+ORDER BY id ASC
+LIMIT $4
+`
+
+type ListUnresolvedSymbolsParams struct {
+	TenantID   *uuid.UUID `json:"tenantId"`
+	Source     string     `json:"source"`
+	AfterID    int64      `json:"afterId"`
+	MaxResults int32      `json:"maxResults"`
+}
+
+// Filters are optional: a nil tenant or empty source matches all.
+func (q *Queries) ListUnresolvedSymbols(ctx context.Context, arg ListUnresolvedSymbolsParams) ([]UnresolvedSymbol, error) {
+	rows, err := q.db.Query(ctx, listUnresolvedSymbols,
+		arg.TenantID,
+		arg.Source,
+		arg.AfterID,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnresolvedSymbol
+	for rows.Next() {
+		var i UnresolvedSymbol
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Source,
+			&i.Symbol,
+			&i.CanonicalSymbol,
+			&i.Reason,
+			&i.Message,
+			&i.Occurrences,
+			&i.FirstSeen,
+			&i.LastSeen,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordUnresolvedSymbols = `-- name: RecordUnresolvedSymbols :exec
+INSERT INTO unresolved_symbols (tenant_id, source, symbol, canonical_symbol, reason, message, occurrences)
+SELECT $1::uuid, $2::text, s.symbol, c.canonical_symbol, r.reason, m.message, o.occurrences
+FROM unnest($3::text[])            WITH ORDINALITY AS s(symbol, ord)
+JOIN unnest($4::text[])  WITH ORDINALITY AS c(canonical_symbol, ord) USING (ord)
+JOIN unnest($5::text[])            WITH ORDINALITY AS r(reason, ord) USING (ord)
+JOIN unnest($6::text[])           WITH ORDINALITY AS m(message, ord) USING (ord)
+JOIN unnest($7::bigint[])      WITH ORDINALITY AS o(occurrences, ord) USING (ord)
+ON CONFLICT (tenant_id, source, symbol)
+DO UPDATE SET canonical_symbol = EXCLUDED.canonical_symbol,
+              reason = EXCLUDED.reason,
+              message = EXCLUDED.message,
+              occurrences = unresolved_symbols.occurrences + EXCLUDED.occurrences,
+              last_seen = now()
+`
+
+type RecordUnresolvedSymbolsParams struct {
+	TenantID         uuid.UUID `json:"tenantId"`
+	Source           string    `json:"source"`
+	Symbols          []string  `json:"symbols"`
+	CanonicalSymbols []string  `json:"canonicalSymbols"`
+	Reasons          []string  `json:"reasons"`
+	Messages         []string  `json:"messages"`
+	Occurrences      []int64   `json:"occurrences"`
+}
+
+func (q *Queries) RecordUnresolvedSymbols(ctx context.Context, arg RecordUnresolvedSymbolsParams) error {
+	_, err := q.db.Exec(ctx, recordUnresolvedSymbols,
+		arg.TenantID,
+		arg.Source,
+		arg.Symbols,
+		arg.CanonicalSymbols,
+		arg.Reasons,
+		arg.Messages,
+		arg.Occurrences,
+	)
+	return err
+}
@@ -0,0 +1,353 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: valuation_jobs.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const claimValuationJob = `-- name: ClaimValuationJob :one
+UPDATE valuation_jobs
+SET status = 'running',
+    lease_until = now() + make_interval(secs => $1::int),
+    lease_token = $2::uuid,
+    started_at = COALESCE(started_at, now()),
+    updated_at = now()
+WHERE id = (
+  SELECT j.id
+  FROM valuation_jobs j
+  WHERE (j.status = 'queued' AND (j.next_attempt_at IS NULL OR j.next_attempt_at <= now()))
+     OR (j.status = 'running' AND j.lease_until < now())
+  ORDER BY j.created_at
+  FOR UPDATE SKIP LOCKED
+  LIMIT 1
+)
+RETURNING id, tenant_id, status, request, total, processed, error, lease_until, created_at, updated_at, started_at, finished_at, attempts, next_attempt_at, lease_token
+`
+
+type ClaimValuationJobParams struct {
+	LeaseSeconds int32     `json:"leaseSeconds"`
+	LeaseToken   uuid.UUID `json:"leaseToken"`
+}
+
+func (q *Queries) ClaimValuationJob(ctx context.Context, arg ClaimValuationJobParams) (ValuationJob, error) {
+	row := q.db.QueryRow(ctx, claimValuationJob, arg.LeaseSeconds, arg.LeaseToken)
+	var i ValuationJob
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Status,
+		&i.Request,
+		&i.Total,
+		&i.Processed,
+		&i.Error,
+		&i.LeaseUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LeaseToken,
+	)
+	return i, err
+}
+
+const createValuationJob = `-- name: CreateValuationJob :exec
+INSERT INTO valuation_jobs (id, tenant_id, status, request, total)
+VALUES ($1, $2, 'queued', $3, $4)
+`
+
+type CreateValuationJobParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID string    `json:"tenantId"`
+	Request  []byte    `json:"request"`
+	Total    int32     `json:"total"`
+}
+
+func (q *Queries) CreateValuationJob(ctx context.Context, arg CreateValuationJobParams) error {
+	_, err := q.db.Exec(ctx, createValuationJob,
+		arg.ID,
+		arg.TenantID,
+		arg.Request,
+		arg.Total,
+	)
+	return err
+}
+
+const finishValuationJob = `-- name: FinishValuationJob :execrows
+UPDATE valuation_jobs
+SET status = $2,
+    error = $3,
+    lease_until = NULL,
+    lease_token = NULL,
+    updated_at = now(),
+    finished_at = now()
+WHERE id = $1
+  AND lease_token = $4::uuid
+`
+
+type FinishValuationJobParams struct {
+	ID         uuid.UUID `json:"id"`
+	Status     string    `json:"status"`
+	Error      *string   `json:"error"`
+	LeaseToken uuid.UUID `json:"leaseToken"`
+}
+
+func (q *Queries) FinishValuationJob(ctx context.Context, arg FinishValuationJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, finishValuationJob,
+		arg.ID,
+		arg.Status,
+		arg.Error,
+		arg.LeaseToken,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getValuationJob = `-- name: GetValuationJob :one
+SELECT id, tenant_id, status, request, total, processed, error, lease_until, created_at, updated_at, started_at, finished_at, attempts, next_attempt_at, lease_token
+FROM valuation_jobs
+WHERE id = $1
+  AND tenant_id = $2
+`
+
+type GetValuationJobParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID string    `json:"tenantId"`
+}
+
+func (q *Queries) GetValuationJob(ctx context.Context, arg GetValuationJobParams) (ValuationJob, error) {
+	row := q.db.QueryRow(ctx, getValuationJob, arg.ID, arg.TenantID)
+	var i ValuationJob
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Status,
+		&i.Request,
+		&i.Total,
+		&i.Processed,
+		&i.Error,
+		&i.LeaseUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LeaseToken,
+	)
+	return i, err
+}
+
+const insertValuationJobItems = `-- name: InsertValuationJobItems :exec
+INSERT INTO valuation_job_items (job_id, seq, tx)
+SELECT $1::uuid, s.seq, t.tx
+FROM unnest($2::int4[]) WITH ORDINALITY AS s(seq, ord)
+JOIN unnest($3::bytea[]) WITH ORDINALITY AS t(tx, ord) USING (ord)
+`
+
+type InsertValuationJobItemsParams struct {
+	Column1 uuid.UUID `json:"column1"`
+	Column2 []int32   `json:"column2"`
+	Column3 [][]byte  `json:"column3"`
+}
+
+func (q *Queries) InsertValuationJobItems(ctx context.Context, arg InsertValuationJobItemsParams) error {
+	_, err := q.db.Exec(ctx, insertValuationJobItems, arg.Column1, arg.Column2, arg.Column3)
+	return err
+}
+
+const listPendingValuationJobItems = `-- name: ListPendingValuationJobItems :many
+SELECT seq, tx
+FROM valuation_job_items
+WHERE job_id = $1
+  AND result IS NULL
+ORDER BY seq
+LIMIT $2
+`
+
+type ListPendingValuationJobItemsParams struct {
+	JobID uuid.UUID `json:"jobId"`
+	Limit int32     `json:"limit"`
+}
+
+type ListPendingValuationJobItemsRow struct {
+	Seq int32  `json:"seq"`
+	Tx  []byte `json:"tx"`
+}
+
+func (q *Queries) ListPendingValuationJobItems(ctx context.Context, arg ListPendingValuationJobItemsParams) ([]ListPendingValuationJobItemsRow, error) {
+	rows, err := q.db.Query(ctx, listPendingValuationJobItems, arg.JobID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingValuationJobItemsRow
+	for rows.Next() {
+		var i ListPendingValuationJobItemsRow
+		if err := rows.Scan(&i.Seq, &i.Tx); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listValuationJobResults = `-- name: ListValuationJobResults :many
+SELECT seq, result
+FROM valuation_job_items
+WHERE job_id = $1
+  AND seq > $2
+  AND result IS NOT NULL
+ORDER BY seq
+LIMIT $3
+`
+
+type ListValuationJobResultsParams struct {
+	JobID uuid.UUID `json:"jobId"`
+	Seq   int32     `json:"seq"`
+	Limit int32     `json:"limit"`
+}
+
+type ListValuationJobResultsRow struct {
+	Seq    int32  `json:"seq"`
+	Result []byte `json:"result"`
+}
+
+func (q *Queries) ListValuationJobResults(ctx context.Context, arg ListValuationJobResultsParams) ([]ListValuationJobResultsRow, error) {
+	rows, err := q.db.Query(ctx, listValuationJobResults, arg.JobID, arg.Seq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListValuationJobResultsRow
+	for rows.Next() {
+		var i ListValuationJobResultsRow
+		if err := rows.Scan(&i.Seq, &i.Result); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseValuationJob = `-- name: ReleaseValuationJob :execrows
+UPDATE valuation_jobs
+SET status = 'queued',
+    lease_until = NULL,
+    lease_token = NULL,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'running'
+  AND lease_token = $2::uuid
+`
+
+type ReleaseValuationJobParams struct {
+	ID         uuid.UUID `json:"id"`
+	LeaseToken uuid.UUID `json:"leaseToken"`
+}
+
+func (q *Queries) ReleaseValuationJob(ctx context.Context, arg ReleaseValuationJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseValuationJob, arg.ID, arg.LeaseToken)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const retryValuationJob = `-- name: RetryValuationJob :execrows
+UPDATE valuation_jobs
+SET status = 'queued',
+    attempts = attempts + 1,
+    next_attempt_at = now() + make_interval(secs => $1::int),
+    lease_until = NULL,
+    lease_token = NULL,
+    updated_at = now()
+WHERE id = $2
+  AND status = 'running'
+  AND lease_token = $3::uuid
+`
+
+type RetryValuationJobParams struct {
+	DelaySeconds int32     `json:"delaySeconds"`
+	ID           uuid.UUID `json:"id"`
+	LeaseToken   uuid.UUID `json:"leaseToken"`
+}
+
+func (q *Queries) RetryValuationJob(ctx context.Context, arg RetryValuationJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, retryValuationJob, arg.DelaySeconds, arg.ID, arg.LeaseToken)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const saveValuationJobResults = `-- name: SaveValuationJobResults :execrows
+UPDATE valuation_job_items i
+SET result = r.result
+FROM (
+  SELECT s.seq, b.result
+  FROM unnest($2::int4[]) WITH ORDINALITY AS s(seq, ord)
+  JOIN unnest($3::bytea[]) WITH ORDINALITY AS b(result, ord) USING (ord)
+) r
+WHERE i.job_id = $1
+  AND i.seq = r.seq
+  AND i.result IS NULL
+`
+
+type SaveValuationJobResultsParams struct {
+	JobID   uuid.UUID `json:"jobId"`
+	Column2 []int32   `json:"column2"`
+	Column3 [][]byte  `json:"column3"`
+}
+
+func (q *Queries) SaveValuationJobResults(ctx context.Context, arg SaveValuationJobResultsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, saveValuationJobResults, arg.JobID, arg.Column2, arg.Column3)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateValuationJobProgress = `-- name: UpdateValuationJobProgress :execrows
+UPDATE valuation_jobs
+SET processed = processed + $1::int,
+    lease_until = now() + make_interval(secs => $2::int),
+    updated_at = now()
+WHERE id = $3
+  AND lease_token = $4::uuid
+`
+
+type UpdateValuationJobProgressParams struct {
+	Saved        int32     `json:"saved"`
+	LeaseSeconds int32     `json:"leaseSeconds"`
+	JobID        uuid.UUID `json:"jobId"`
+	LeaseToken   uuid.UUID `json:"leaseToken"`
+}
+
+func (q *Queries) UpdateValuationJobProgress(ctx context.Context, arg UpdateValuationJobProgressParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateValuationJobProgress,
+		arg.Saved,
+		arg.LeaseSeconds,
+		arg.JobID,
+		arg.LeaseToken,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
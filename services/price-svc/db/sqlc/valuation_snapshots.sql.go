@@ -0,0 +1,386 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: valuation_snapshots.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createValuationSnapshot = `-- name: CreateValuationSnapshot :exec
+INSERT INTO valuation_snapshots (
+  tenant_id,
+  id,
+  fiat_currency,
+  price_point,
+  lookup_nearest,
+  lookup_tolerance_seconds,
+  lookup_accept_coarser,
+  lookup_as_of
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (tenant_id, id) DO NOTHING
+`
+
+type CreateValuationSnapshotParams struct {
+	TenantID               uuid.UUID          `json:"tenantId"`
+	ID                     string             `json:"id"`
+	FiatCurrency           string             `json:"fiatCurrency"`
+	PricePoint             int32              `json:"pricePoint"`
+	LookupNearest          bool               `json:"lookupNearest"`
+	LookupToleranceSeconds int32              `json:"lookupToleranceSeconds"`
+	LookupAcceptCoarser    bool               `json:"lookupAcceptCoarser"`
+	LookupAsOf             pgtype.Timestamptz `json:"lookupAsOf"`
+}
+
+func (q *Queries) CreateValuationSnapshot(ctx context.Context, arg CreateValuationSnapshotParams) error {
+	_, err := q.db.Exec(ctx, createValuationSnapshot,
+		arg.TenantID,
+		arg.ID,
+		arg.FiatCurrency,
+		arg.PricePoint,
+		arg.LookupNearest,
+		arg.LookupToleranceSeconds,
+		arg.LookupAcceptCoarser,
+		arg.LookupAsOf,
+	)
+	return err
+}
+
+const getSnapshotResolutions = `-- name: GetSnapshotResolutions :many
+WITH keys AS (
+  SELECT s.symbol, c.chain, a.contract_address, t.at_utc
+  FROM unnest($4::text[]) WITH ORDINALITY AS s(symbol, ord)
+  JOIN unnest($5::text[]) WITH ORDINALITY AS c(chain, ord) USING (ord)
+  JOIN unnest($6::text[]) WITH ORDINALITY AS a(contract_address, ord) USING (ord)
+  JOIN unnest($7::timestamptz[]) WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+)
+SELECT sr.symbol, sr.chain, sr.contract_address, sr.at_utc, sr.coin_id, sr.canonical_symbol, sr.quote, sr.consensus
+FROM snapshot_resolutions sr
+JOIN keys k
+  ON k.symbol = sr.symbol
+ AND k.chain = sr.chain
+ AND k.contract_address = sr.contract_address
+ AND k.at_utc = sr.at_utc
+WHERE sr.tenant_id = $1
+  AND sr.snapshot_id = $2
+  AND sr.source = $3
+`
+
+type GetSnapshotResolutionsParams struct {
+	TenantID          uuid.UUID            `json:"tenantId"`
+	SnapshotID        string               `json:"snapshotId"`
+	Source            string               `json:"source"`
+	Symbols           []string             `json:"symbols"`
+	Chains            []string             `json:"chains"`
+	ContractAddresses []string             `json:"contractAddresses"`
+	AtUtc             []pgtype.Timestamptz `json:"atUtc"`
+}
+
+type GetSnapshotResolutionsRow struct {
+	Symbol          string             `json:"symbol"`
+	Chain           string             `json:"chain"`
+	ContractAddress string             `json:"contractAddress"`
+	AtUtc           pgtype.Timestamptz `json:"atUtc"`
+	CoinID          string             `json:"coinId"`
+	CanonicalSymbol string             `json:"canonicalSymbol"`
+	Quote           string             `json:"quote"`
+	Consensus       bool               `json:"consensus"`
+}
+
+func (q *Queries) GetSnapshotResolutions(ctx context.Context, arg GetSnapshotResolutionsParams) ([]GetSnapshotResolutionsRow, error) {
+	rows, err := q.db.Query(ctx, getSnapshotResolutions,
+		arg.TenantID,
+		arg.SnapshotID,
+		arg.Source,
+		arg.Symbols,
+		arg.Chains,
+		arg.ContractAddresses,
+		arg.AtUtc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSnapshotResolutionsRow
+	for rows.Next() {
+		var i GetSnapshotResolutionsRow
+		if err := rows.Scan(
+			&i.Symbol,
+			&i.Chain,
+			&i.ContractAddress,
+			&i.AtUtc,
+			&i.CoinID,
+			&i.CanonicalSymbol,
+			&i.Quote,
+			&i.Consensus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSnapshotValuations = `-- name: GetSnapshotValuations :many
+WITH keys AS (
+  SELECT a.asset, t.at_utc
+  FROM unnest($4::text[]) WITH ORDINALITY AS a(asset, ord)
+  JOIN unnest($5::timestamptz[]) WITH ORDINALITY AS t(at_utc, ord)
+    USING (ord)
+)
+SELECT sv.snapshot_id, sv.kind, sv.asset, sv.at_utc, sv.fiat_value, sv.method, sv.lower_precision,
+       sv.bucket_start_utc, sv.granularity_seconds, sv.provider, sv.price_usd, sv.fetched_at,
+       sv.fx_rate, sv.fx_effective_at, sv.created_at, sv.tenant_id
+FROM snapshot_valuations sv
+JOIN keys k
+  ON k.asset = sv.asset
+ AND k.at_utc = sv.at_utc
+WHERE sv.tenant_id = $1
+  AND sv.snapshot_id = $2
+  AND sv.kind = $3
+`
+
+type GetSnapshotValuationsParams struct {
+	TenantID   uuid.UUID            `json:"tenantId"`
+	SnapshotID string               `json:"snapshotId"`
+	Kind       string               `json:"kind"`
+	Assets     []string             `json:"assets"`
+	AtUtc      []pgtype.Timestamptz `json:"atUtc"`
+}
+
+func (q *Queries) GetSnapshotValuations(ctx context.Context, arg GetSnapshotValuationsParams) ([]SnapshotValuation, error) {
+	rows, err := q.db.Query(ctx, getSnapshotValuations,
+		arg.TenantID,
+		arg.SnapshotID,
+		arg.Kind,
+		arg.Assets,
+		arg.AtUtc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SnapshotValuation
+	for rows.Next() {
+		var i SnapshotValuation
+		if err := rows.Scan(
+			&i.SnapshotID,
+			&i.Kind,
+			&i.Asset,
+			&i.AtUtc,
+			&i.FiatValue,
+			&i.Method,
+			&i.LowerPrecision,
+			&i.BucketStartUtc,
+			&i.GranularitySeconds,
+			&i.Provider,
+			&i.PriceUsd,
+			&i.FetchedAt,
+			&i.FxRate,
+			&i.FxEffectiveAt,
+			&i.CreatedAt,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getValuationSnapshot = `-- name: GetValuationSnapshot :one
+SELECT id, fiat_currency, price_point, lookup_nearest, lookup_tolerance_seconds, lookup_accept_coarser, created_at, lookup_as_of, tenant_id
+FROM valuation_snapshots
+WHERE tenant_id = $1
+  AND id = $2
+`
+
+type GetValuationSnapshotParams struct {
+	TenantID uuid.UUID `json:"tenantId"`
+	ID       string    `json:"id"`
+}
+
+func (q *Queries) GetValuationSnapshot(ctx context.Context, arg GetValuationSnapshotParams) (ValuationSnapshot, error) {
+	row := q.db.QueryRow(ctx, getValuationSnapshot, arg.TenantID, arg.ID)
+	var i ValuationSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.FiatCurrency,
+		&i.PricePoint,
+		&i.LookupNearest,
+		&i.LookupToleranceSeconds,
+		&i.LookupAcceptCoarser,
+		&i.CreatedAt,
+		&i.LookupAsOf,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const insertSnapshotResolutions = `-- name: InsertSnapshotResolutions :exec
+INSERT INTO snapshot_resolutions (
+  tenant_id,
+  snapshot_id,
+  source,
+  symbol,
+  chain,
+  contract_address,
+  at_utc,
+  coin_id,
+  canonical_symbol,
+  quote,
+  consensus
+)
+SELECT
+  $1::uuid,
+  $2::text,
+  $3::text,
+  s.symbol,
+  c.chain,
+  a.contract_address,
+  t.at_utc,
+  i.coin_id,
+  n.canonical_symbol,
+  q.quote,
+  k.consensus
+FROM unnest($4::text[])                 WITH ORDINALITY AS s(symbol, ord)
+JOIN unnest($5::text[])                  WITH ORDINALITY AS c(chain, ord) USING (ord)
+JOIN unnest($6::text[])      WITH ORDINALITY AS a(contract_address, ord) USING (ord)
+JOIN unnest($7::timestamptz[])           WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+JOIN unnest($8::text[])                WITH ORDINALITY AS i(coin_id, ord) USING (ord)
+JOIN unnest($9::text[])       WITH ORDINALITY AS n(canonical_symbol, ord) USING (ord)
+JOIN unnest($10::text[])                  WITH ORDINALITY AS q(quote, ord) USING (ord)
+JOIN unnest($11::bool[])               WITH ORDINALITY AS k(consensus, ord) USING (ord)
+ON CONFLICT (tenant_id, snapshot_id, source, symbol, chain, contract_address, at_utc) DO NOTHING
+`
+
+type InsertSnapshotResolutionsParams struct {
+	TenantID          uuid.UUID            `json:"tenantId"`
+	SnapshotID        string               `json:"snapshotId"`
+	Source            string               `json:"source"`
+	Symbols           []string             `json:"symbols"`
+	Chains            []string             `json:"chains"`
+	ContractAddresses []string             `json:"contractAddresses"`
+	AtUtc             []pgtype.Timestamptz `json:"atUtc"`
+	CoinIds           []string             `json:"coinIds"`
+	CanonicalSymbols  []string             `json:"canonicalSymbols"`
+	Quotes            []string             `json:"quotes"`
+	Consensus         []bool               `json:"consensus"`
+}
+
+func (q *Queries) InsertSnapshotResolutions(ctx context.Context, arg InsertSnapshotResolutionsParams) error {
+	_, err := q.db.Exec(ctx, insertSnapshotResolutions,
+		arg.TenantID,
+		arg.SnapshotID,
+		arg.Source,
+		arg.Symbols,
+		arg.Chains,
+		arg.ContractAddresses,
+		arg.AtUtc,
+		arg.CoinIds,
+		arg.CanonicalSymbols,
+		arg.Quotes,
+		arg.Consensus,
+	)
+	return err
+}
+
+const insertSnapshotValuations = `-- name: InsertSnapshotValuations :exec
+INSERT INTO snapshot_valuations (
+  tenant_id,
+  snapshot_id,
+  kind,
+  asset,
+  at_utc,
+  fiat_value,
+  method,
+  lower_precision,
+  bucket_start_utc,
+  granularity_seconds,
+  provider,
+  price_usd,
+  fetched_at,
+  fx_rate,
+  fx_effective_at
+)
+SELECT
+  $1::uuid,
+  $2::text,
+  $3::text,
+  a.asset,
+  t.at_utc,
+  v.fiat_value,
+  m.method,
+  l.lower_precision,
+  b.bucket_start_utc,
+  NULLIF(g.granularity_seconds, 0),
+  p.provider,
+  u.price_usd,
+  f.fetched_at,
+  r.fx_rate,
+  e.fx_effective_at
+FROM unnest($4::text[])                  WITH ORDINALITY AS a(asset, ord)
+JOIN unnest($5::timestamptz[])           WITH ORDINALITY AS t(at_utc, ord) USING (ord)
+JOIN unnest($6::numeric[])          WITH ORDINALITY AS v(fiat_value, ord) USING (ord)
+JOIN unnest($7::int4[])                 WITH ORDINALITY AS m(method, ord) USING (ord)
+JOIN unnest($8::bool[])         WITH ORDINALITY AS l(lower_precision, ord) USING (ord)
+JOIN unnest($9::timestamptz[])    WITH ORDINALITY AS b(bucket_start_utc, ord) USING (ord)
+JOIN unnest($10::int4[])           WITH ORDINALITY AS g(granularity_seconds, ord) USING (ord)
+JOIN unnest($11::text[])               WITH ORDINALITY AS p(provider, ord) USING (ord)
+JOIN unnest($12::numeric[])           WITH ORDINALITY AS u(price_usd, ord) USING (ord)
+JOIN unnest($13::timestamptz[])       WITH ORDINALITY AS f(fetched_at, ord) USING (ord)
+JOIN unnest($14::numeric[])             WITH ORDINALITY AS r(fx_rate, ord) USING (ord)
+JOIN unnest($15::timestamptz[])  WITH ORDINALITY AS e(fx_effective_at, ord) USING (ord)
+ON CONFLICT (tenant_id, snapshot_id, kind, asset, at_utc) DO NOTHING
+`
+
+type InsertSnapshotValuationsParams struct {
+	TenantID       uuid.UUID            `json:"tenantId"`
+	SnapshotID     string               `json:"snapshotId"`
+	Kind           string               `json:"kind"`
+	Assets         []string             `json:"assets"`
+	AtUtc          []pgtype.Timestamptz `json:"atUtc"`
+	FiatValues     []pgtype.Numeric     `json:"fiatValues"`
+	Methods        []int32              `json:"methods"`
+	LowerPrecision []bool               `json:"lowerPrecision"`
+	BucketStarts   []pgtype.Timestamptz `json:"bucketStarts"`
+	Granularities  []int32              `json:"granularities"`
+	Providers      []string             `json:"providers"`
+	PricesUsd      []pgtype.Numeric     `json:"pricesUsd"`
+	FetchedAt      []pgtype.Timestamptz `json:"fetchedAt"`
+	FxRates        []pgtype.Numeric     `json:"fxRates"`
+	FxEffectiveAt  []pgtype.Timestamptz `json:"fxEffectiveAt"`
+}
+
+func (q *Queries) InsertSnapshotValuations(ctx context.Context, arg InsertSnapshotValuationsParams) error {
+	_, err := q.db.Exec(ctx, insertSnapshotValuations,
+		arg.TenantID,
+		arg.SnapshotID,
+		arg.Kind,
+		arg.Assets,
+		arg.AtUtc,
+		arg.FiatValues,
+		arg.Methods,
+		arg.LowerPrecision,
+		arg.BucketStarts,
+		arg.Granularities,
+		arg.Providers,
+		arg.PricesUsd,
+		arg.FetchedAt,
+		arg.FxRates,
+		arg.FxEffectiveAt,
+	)
+	return err
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
 	inmemory "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/infra/in-memory"
 	repository "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/infra/repo"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/metrics"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/pricing"
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/resolver"
 	grpcserver "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/server"
 	usecase "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/usecases"
@@ -68,8 +70,22 @@ func Run(cfg *config.Config) {
 
 	tenantSymbolRepo := repository.NewTenantSymbolRepo(db)
 	historicalPriceRepo := repository.NewHistoricalPriceRepo(db)
+	priceGapRepo := repository.NewPriceGapRepo(db)
+	quarantineRepo := repository.NewQuarantineRepo(db)
+	snapshotRepo := repository.NewSnapshotRepo(db)
+	customAssetRepo := repository.NewCustomAssetRepo(db)
+	coinContractRepo := repository.NewCoinContractRepo(db)
+	coinCatalogRepo := repository.NewCoinCatalogRepo(db)
 
-	tenantSymbolUC := usecase.NewTenantSymbolUC(tenantSymbolRepo, time.Second*5)
+	// mappings are checked against the coin catalog only while it is kept in sync
+	var symbolCatalog domain.CoinCatalogRepo
+	if cfg.Resolver.CatalogSyncInterval > 0 {
+		symbolCatalog = coinCatalogRepo
+	}
+	tenantSymbolUC := usecase.NewTenantSymbolUC(tenantSymbolRepo, symbolCatalog, customAssetRepo, time.Second*5)
+	unresolvedSymbolUC := usecase.NewUnresolvedSymbolUC(repository.NewUnresolvedSymbolRepo(db), tenantSymbolRepo, symbolCatalog, customAssetRepo, time.Second*5)
+	valuationJobUC := usecase.NewValuationJobUC(repository.NewValuationJobRepo(db), time.Second*5)
+	customAssetUC := usecase.NewCustomAssetUC(customAssetRepo, time.Second*30)
 
 	httpClient := &http.Client{
 		Timeout: 10 * time.Second,
@@ -84,20 +100,99 @@ func Run(cfg *config.Config) {
 		log.Fatal("cannot connect to redis: %v", err)
 	}
 
-	cgClient, err := coingecko.NewCGClient(cfg.CG)
+	var cgOpts []coingecko.Option
+	if cfg.CG.SharedRateLimit {
+		cgOpts = append(cgOpts, coingecko.WithLimiter(
+			coingecko.NewSharedLimiter(log, redis, coingecko.SharedRateLimitKey, cfg.CG.RateLimitPerMin),
+		))
+	}
+
+	cgClient, err := coingecko.NewCGClient(cfg.CG, cgOpts...)
 	if err != nil {
 		log.Fatal("cannot create coingecko client: %v", err)
 	}
 
-	historicalPriceUC := usecase.NewHistoricalPriceUC(log, historicalPriceRepo, fxProvider, cgClient, time.Second*5)
+	pegTable, err := pricing.NewPegTable(cfg.Pricing.PeggedAssets)
+	if err != nil {
+		log.Fatal("cannot create peg table: %v", err)
+	}
+
+	derivativeRegistry, err := pricing.NewDerivativeRegistry(cfg.Pricing.Derivatives)
+	if err != nil {
+		log.Fatal("cannot create derivative registry: %v", err)
+	}
+
+	sanitizer, err := pricing.NewPriceSanitizer(cfg.Pricing.Sanity)
+	if err != nil {
+		log.Fatal("cannot create price sanitizer: %v", err)
+	}
+
+	roundingPolicy, err := pricing.NewRoundingPolicy(cfg.Pricing.Rounding)
+	if err != nil {
+		log.Fatal("cannot create rounding policy: %v", err)
+	}
+
+	historicalPriceUC := usecase.NewHistoricalPriceUC(usecase.HistoricalPriceDeps{
+		Logger:         log,
+		Repo:           historicalPriceRepo,
+		GapRepo:        priceGapRepo,
+		QuarantineRepo: quarantineRepo,
+		FX:             fxProvider,
+		CGClient:       cgClient,
+		Pegs:           pegTable,
+		Derivatives:    derivativeRegistry,
+		Sanitizer:      sanitizer,
+		SnapshotRepo:   snapshotRepo,
+		CustomRepo:     customAssetRepo,
+	}, cfg.Pricing.NegativeCacheTTL, cfg.Pricing.Timeout)
+	runGapCleanup(ctx, waitGroup, log, historicalPriceUC, cfg.Pricing.GapCleanupInterval)
 
 	coinIdCache, err := inmemory.NewCoinIdCache(cfg.Resolver.Path)
 	if err != nil {
 		log.Fatal("cannot create coinIdCache: %v", err)
 	}
-	resolver := resolver.NewCoinIdResolver(tenantSymbolRepo, coinIdCache)
+	normalizer, err := resolver.NewSymbolNormalizer(cfg.Resolver.Sources)
+	if err != nil {
+		log.Fatal("invalid resolver config: %v", err)
+	}
+	pairParser, err := resolver.NewPairParser(log, cgClient, cfg.Resolver.Pairs)
+	if err != nil {
+		log.Fatal("invalid resolver pairs config: %v", err)
+	}
+	runPairRefresh(ctx, waitGroup, pairParser, cfg.Resolver.PairsRefreshInterval)
+	coinContractUC := usecase.NewCoinContractUC(coinContractRepo, cgClient, time.Minute*2)
+	runContractSync(ctx, waitGroup, log, coinContractUC, cfg.Resolver.ContractsSyncInterval)
+	coinCatalogUC := usecase.NewCoinCatalogUC(coinCatalogRepo, cgClient, time.Minute*2)
+	runCatalogSync(ctx, waitGroup, log, coinCatalogUC, cfg.Resolver.CatalogSyncInterval)
+	resolver, err := resolver.NewCoinIdResolver(resolver.Deps{
+		TenantSymbolRepo: tenantSymbolRepo,
+		CustomAssetRepo:  customAssetRepo,
+		ContractRepo:     coinContractRepo,
+		CatalogRepo:      coinCatalogRepo,
+		CoinIdCache:      coinIdCache,
+		Normalizer:       normalizer,
+		Pairs:            pairParser,
+	}, cfg.Resolver.Chains, cfg.Resolver.Consensus, cfg.Resolver.CatalogFallback)
+	if err != nil {
+		log.Fatal("invalid resolver config: %v", err)
+	}
+	coinIdReloader := inmemory.NewCoinIdReloader(log, cfg.Resolver.Path, coinIdCache)
+	runCoinMapReload(ctx, waitGroup, log, coinIdReloader, cfg.Resolver.ReloadInterval)
 
-	runGrpcServer(ctx, waitGroup, &cfg.GRPC, log, resolver, historicalPriceUC, tenantSymbolUC)
+	runMetricsServer(ctx, waitGroup, &cfg.Metrics, log)
+	runGrpcServer(ctx, waitGroup, &cfg.GRPC, &cfg.Jobs, log, grpcserver.PriceServerDeps{
+		Resolver:           resolver,
+		Normalizer:         normalizer,
+		Rounding:           roundingPolicy,
+		HistoricalPriceUC:  historicalPriceUC,
+		TenantSymbolUC:     tenantSymbolUC,
+		ValuationJobUC:     valuationJobUC,
+		CustomAssetUC:      customAssetUC,
+		CoinMapReloader:    coinIdReloader,
+		CoinCatalogUC:      coinCatalogUC,
+		UnresolvedSymbolUC: unresolvedSymbolUC,
+		FX:                 fxProvider,
+	})
 
 	err = waitGroup.Wait()
 	if err != nil {
@@ -109,12 +204,26 @@ func runGrpcServer(
 	ctx context.Context,
 	waitGroup *errgroup.Group,
 	config *config.GRPC,
+	jobsConfig *config.Jobs,
 	log *logger.ZeroLogger,
-	resolver domain.CoinIdResolver,
-	historicalPriceUC domain.HistoricalPriceUseCase,
-	tenantSymbolUC domain.TenantSymbolUseCase,
+	deps grpcserver.PriceServerDeps,
 ) {
-	server := grpcserver.NewPriceServer(log, resolver, historicalPriceUC, tenantSymbolUC)
+	server := grpcserver.NewPriceServer(log, deps)
+
+	jobRunner := grpcserver.NewJobRunner(
+		log,
+		server,
+		deps.ValuationJobUC,
+		grpcserver.WithJobWorkers(jobsConfig.Workers),
+		grpcserver.WithJobChunkSize(jobsConfig.ChunkSize),
+		grpcserver.WithJobPollInterval(jobsConfig.PollInterval),
+		grpcserver.WithJobLease(jobsConfig.Lease),
+		grpcserver.WithJobMaxAttempts(jobsConfig.MaxAttempts),
+		grpcserver.WithJobRetryBackoff(jobsConfig.RetryBackoff),
+	)
+	waitGroup.Go(func() error {
+		return jobRunner.Run(ctx)
+	})
 
 	// Place for middleware injection
 	// grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
@@ -156,3 +265,218 @@ func runGrpcServer(
 		return nil
 	})
 }
+
+func runMetricsServer(ctx context.Context, waitGroup *errgroup.Group, config *config.Metrics, log *logger.ZeroLogger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	server := &http.Server{
+		Addr:              config.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	waitGroup.Go(func() error {
+		log.Info("start metrics server at %s", config.Addr)
+
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("metrics server failed to serve: %v", err)
+			return err
+		}
+
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info("graceful shutdown metrics server")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error("failed to shutdown metrics server: %v", err)
+			return err
+		}
+		log.Info("metrics server is stopped")
+
+		return nil
+	})
+}
+
+func runGapCleanup(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	log *logger.ZeroLogger,
+	historicalPriceUC domain.HistoricalPriceUseCase,
+	interval time.Duration,
+) {
+	if interval <= 0 {
+		return
+	}
+
+	waitGroup.Go(func() error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				n, err := historicalPriceUC.PurgeExpiredGaps(ctx)
+				if err != nil {
+					log.Error("purge expired price gaps: %v", err)
+					continue
+				}
+				if n > 0 {
+					log.Info("purged %d expired price gaps", n)
+				}
+			}
+		}
+	})
+}
+
+// runContractSync keeps the token contract cache fresh. At startup it only syncs a cache that is
+// empty or older than interval, so restarts do not refetch the coin list.
+func runContractSync(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	log *logger.ZeroLogger,
+	contracts domain.CoinContractUseCase,
+	interval time.Duration,
+) {
+	if interval <= 0 {
+		return
+	}
+
+	waitGroup.Go(func() error {
+		if n, err := contracts.SyncIfStale(ctx, interval); err != nil {
+			log.Error("sync coin contracts: %v", err)
+		} else if n > 0 {
+			log.Info("synced %d coin contracts", n)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				n, err := contracts.Sync(ctx)
+				if err != nil {
+					log.Error("sync coin contracts: %v", err)
+					continue
+				}
+				log.Info("synced %d coin contracts", n)
+			}
+		}
+	})
+}
+
+// runCatalogSync keeps the coin catalog fresh. At startup it only syncs a catalog that is
+// empty or older than interval, so restarts do not refetch the coin list.
+func runCatalogSync(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	log *logger.ZeroLogger,
+	catalog domain.CoinCatalogUseCase,
+	interval time.Duration,
+) {
+	if interval <= 0 {
+		return
+	}
+
+	logSync := func(res domain.CatalogSync) {
+		log.Info("coin catalog synced coins=%d added=%d removed=%d relisted=%d renamed=%d",
+			res.Coins, res.Added, res.Removed, res.Relisted, res.Renamed)
+	}
+
+	waitGroup.Go(func() error {
+		if res, ok, err := catalog.SyncIfStale(ctx, interval); err != nil {
+			log.Error("sync coin catalog: %v", err)
+		} else if ok {
+			logSync(res)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				res, err := catalog.Sync(ctx)
+				if err != nil {
+					log.Error("sync coin catalog: %v", err)
+					continue
+				}
+				logSync(res)
+			}
+		}
+	})
+}
+
+// runPairRefresh seeds trading pairs from exchange tickers at startup and then every interval.
+// Failures are logged by the parser and the previous pairs stay in use.
+func runPairRefresh(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	pairs *resolver.PairParser,
+	interval time.Duration,
+) {
+	waitGroup.Go(func() error {
+		_ = pairs.Refresh(ctx)
+		if interval <= 0 {
+			return nil
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				_ = pairs.Refresh(ctx)
+			}
+		}
+	})
+}
+
+// runCoinMapReload reloads the global symbol map when the file changes and on SIGHUP.
+func runCoinMapReload(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	log *logger.ZeroLogger,
+	reloader *inmemory.CoinIdReloader,
+	interval time.Duration,
+) {
+	if interval > 0 {
+		waitGroup.Go(func() error {
+			reloader.Watch(ctx, interval)
+			return nil
+		})
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	waitGroup.Go(func() error {
+		defer signal.Stop(hup)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-hup:
+				log.Info("SIGHUP received, reloading coin map")
+				_, _ = reloader.Reload(ctx)
+			}
+		}
+	})
+}
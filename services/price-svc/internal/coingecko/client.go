@@ -8,33 +8,47 @@ import (
 	"net/http"
 	"net/url"
 	"time"
-
-	"golang.org/x/time/rate"
 )
 
 type CGClient struct {
 	baseURL           *url.URL
 	apiKey            string
 	granularityPolicy GranularityPolicy
+	precision         PrecisionPolicy
 
 	httpClient *http.Client
-	limiter    *rate.Limiter
+	limiter    Limiter
 }
 
-func NewCGClient(cgConfig CGConfig) (*CGClient, error) {
+func NewCGClient(cgConfig CGConfig, opts ...Option) (*CGClient, error) {
+	if err := cgConfig.Validate(); err != nil {
+		return nil, err
+	}
+
 	u, err := url.Parse(cgConfig.BaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid baseURL: %w", err)
 	}
 
-	limiter := rate.NewLimiter(rate.Limit(cgConfig.RateLimitPerMin)/60, cgConfig.RateLimitPerMin)
-	return &CGClient{
+	precision, err := newPrecisionPolicy(cgConfig.Precision)
+	if err != nil {
+		return nil, fmt.Errorf("invalid precision: %w", err)
+	}
+
+	c := &CGClient{
 		baseURL:           u,
 		apiKey:            cgConfig.APIKey,
 		granularityPolicy: cgConfig.GranularityPolicy,
+		precision:         precision,
 		httpClient:        &http.Client{},
-		limiter:           limiter,
-	}, nil
+		limiter:           newLocalLimiter(cgConfig.RateLimitPerMin),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
 func (c *CGClient) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
@@ -10,6 +10,8 @@ type CoinListItem struct {
 	ID     string `json:"id"`
 	Symbol string `json:"symbol"`
 	Name   string `json:"name"`
+	// Platforms maps platform ID to contract address; only filled with includePlatform.
+	Platforms map[string]string `json:"platforms,omitempty"`
 }
 
 func (c *CGClient) CoinsList(ctx context.Context, includePlatform bool) ([]CoinListItem, error) {
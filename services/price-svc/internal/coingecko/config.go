@@ -1,6 +1,9 @@
 package coingecko
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type CGConfig struct {
 	APIKey string `env:"COINGECKO_API_KEY" env-required:"true"`
@@ -8,7 +11,23 @@ type CGConfig struct {
 	BaseURL           string            `yaml:"base_url"`
 	Currency          string            `yaml:"currency"`
 	RateLimitPerMin   int               `yaml:"rate_limit_per_min"`
+	SharedRateLimit   bool              `yaml:"shared_rate_limit"` // one Redis bucket for all replicas
 	GranularityPolicy GranularityPolicy `yaml:"granularity_policy"`
+	Precision         PrecisionPolicy   `yaml:"precision"`
+}
+
+// Validate rejects settings the client cannot run with.
+func (c CGConfig) Validate() error {
+	if c.RateLimitPerMin <= 0 {
+		return fmt.Errorf("rate_limit_per_min must be positive, got %d", c.RateLimitPerMin)
+	}
+	return nil
 }
 
 type GranularityPolicy map[string]time.Duration
+
+// PrecisionPolicy sets the decimal places requested for prices: "full" or 0..18.
+type PrecisionPolicy struct {
+	Default string            `yaml:"default" env-default:"full"`
+	Coins   map[string]string `yaml:"coins"` // coinID -> precision
+}
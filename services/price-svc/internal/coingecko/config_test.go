@@ -0,0 +1,19 @@
+package coingecko
+
+import "testing"
+
+func TestCGConfigValidateRateLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, perMin := range []int{0, -30} {
+		if err := (CGConfig{RateLimitPerMin: perMin}).Validate(); err == nil {
+			t.Fatalf("Validate() with rate_limit_per_min %d error = nil, want error", perMin)
+		}
+		if _, err := NewCGClient(CGConfig{RateLimitPerMin: perMin}); err == nil {
+			t.Fatalf("NewCGClient() with rate_limit_per_min %d error = nil, want error", perMin)
+		}
+	}
+	if err := (CGConfig{RateLimitPerMin: 30}).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,74 @@
+package coingecko
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/redis"
+	"golang.org/x/time/rate"
+)
+
+const SharedRateLimitKey = "ratelimit:coingecko"
+
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+func newLocalLimiter(ratePerMin int) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(ratePerMin)/60, ratePerMin)
+}
+
+// sharedLimiter is a token bucket kept in Redis, so all price-svc replicas share one CoinGecko quota.
+// When Redis is unreachable it degrades to the per-process limiter instead of failing requests;
+// the switch to and from the degraded state is logged once, not on every call.
+type sharedLimiter struct {
+	log      logger.Logger
+	bucket   redis.TokenBucket
+	key      string
+	rate     float64
+	burst    int
+	fallback *rate.Limiter
+	degraded atomic.Bool
+}
+
+func NewSharedLimiter(log logger.Logger, bucket redis.TokenBucket, key string, ratePerMin int) Limiter {
+	return &sharedLimiter{
+		log:      log,
+		bucket:   bucket,
+		key:      key,
+		rate:     float64(ratePerMin) / 60,
+		burst:    ratePerMin,
+		fallback: newLocalLimiter(ratePerMin),
+	}
+}
+
+func (l *sharedLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, err := l.bucket.TakeToken(ctx, l.key, l.rate, l.burst)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !l.degraded.Swap(true) {
+				l.log.Warn("coingecko limiter: shared bucket unavailable, using local limiter: %v", err)
+			}
+			return l.fallback.Wait(ctx)
+		}
+		if l.degraded.Swap(false) {
+			l.log.Info("coingecko limiter: shared bucket available again")
+		}
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
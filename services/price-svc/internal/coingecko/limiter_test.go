@@ -0,0 +1,104 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+)
+
+var errBucketDown = errors.New("bucket down")
+
+// fakeBucket returns the scripted waits and errors in order, then grants tokens.
+type fakeBucket struct {
+	waits []time.Duration
+	errs  []error
+	calls int
+}
+
+func (b *fakeBucket) TakeToken(_ context.Context, _ string, _ float64, _ int) (time.Duration, error) {
+	i := b.calls
+	b.calls++
+	if i < len(b.errs) && b.errs[i] != nil {
+		return 0, b.errs[i]
+	}
+	if i < len(b.waits) {
+		return b.waits[i], nil
+	}
+	return 0, nil
+}
+
+func TestSharedLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		waits     []time.Duration
+		errs      []error
+		wantCalls int
+	}{
+		{name: "token available", wantCalls: 1},
+		{name: "waits for refill", waits: []time.Duration{time.Millisecond, time.Millisecond}, wantCalls: 3},
+		{name: "falls back to local limiter", errs: []error{errBucketDown}, wantCalls: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			bucket := &fakeBucket{waits: tc.waits, errs: tc.errs}
+			l := NewSharedLimiter(logger.New("error"), bucket, SharedRateLimitKey, 60)
+
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatalf("Wait() error = %v, want nil", err)
+			}
+			if bucket.calls != tc.wantCalls {
+				t.Fatalf("TakeToken calls = %d, want %d", bucket.calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestSharedLimiterWaitCancelled(t *testing.T) {
+	t.Parallel()
+
+	bucket := &fakeBucket{waits: []time.Duration{time.Hour}}
+	l := NewSharedLimiter(logger.New("error"), bucket, SharedRateLimitKey, 60)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// countingLogger counts the warnings and infos it is given.
+type countingLogger struct {
+	logger.Logger
+	warns int
+	infos int
+}
+
+func (l *countingLogger) Warn(string, ...interface{}) { l.warns++ }
+func (l *countingLogger) Info(string, ...interface{}) { l.infos++ }
+
+func TestSharedLimiterLogsDegradedStateOnce(t *testing.T) {
+	t.Parallel()
+
+	// three calls while Redis is down, then it comes back and goes down again
+	bucket := &fakeBucket{errs: []error{errBucketDown, errBucketDown, errBucketDown, nil, nil, errBucketDown}}
+	log := &countingLogger{Logger: logger.New("error")}
+	l := NewSharedLimiter(log, bucket, SharedRateLimitKey, 600)
+
+	for i := 0; i < 6; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() #%d error = %v, want nil", i, err)
+		}
+	}
+	if log.warns != 2 || log.infos != 1 {
+		t.Fatalf("warns, infos = %d, %d, want 2, 1", log.warns, log.infos)
+	}
+}
@@ -0,0 +1,10 @@
+package coingecko
+
+type Option func(*CGClient)
+
+// WithLimiter replaces the per-process rate limiter built from RateLimitPerMin.
+func WithLimiter(limiter Limiter) Option {
+	return func(c *CGClient) {
+		c.limiter = limiter
+	}
+}
@@ -0,0 +1,49 @@
+package coingecko
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// PrecisionFull requests prices without rounding, the precision param is omitted then.
+const PrecisionFull = "full"
+
+const maxPrecision = 18
+
+func validatePrecision(p string) error {
+	if p == PrecisionFull {
+		return nil
+	}
+	n, err := strconv.Atoi(p)
+	if err != nil || n < 0 || n > maxPrecision {
+		return fmt.Errorf("precision must be %q or 0..%d, got %q", PrecisionFull, maxPrecision, p)
+	}
+	return nil
+}
+
+func newPrecisionPolicy(p PrecisionPolicy) (PrecisionPolicy, error) {
+	if p.Default == "" {
+		p.Default = PrecisionFull
+	}
+	if err := validatePrecision(p.Default); err != nil {
+		return PrecisionPolicy{}, fmt.Errorf("default: %w", err)
+	}
+	for coinID, v := range p.Coins {
+		if err := validatePrecision(v); err != nil {
+			return PrecisionPolicy{}, fmt.Errorf("coin %q: %w", coinID, err)
+		}
+	}
+	return p, nil
+}
+
+// PrecisionFor returns the precision param for a coin's prices, nil for full precision.
+func (c *CGClient) PrecisionFor(coinID string) *string {
+	p, ok := c.precision.Coins[coinID]
+	if !ok {
+		p = c.precision.Default
+	}
+	if p == PrecisionFull {
+		return nil
+	}
+	return &p
+}
@@ -0,0 +1,59 @@
+package coingecko
+
+import "testing"
+
+func TestNewPrecisionPolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		policy  PrecisionPolicy
+		wantErr bool
+	}{
+		{name: "empty default is full", policy: PrecisionPolicy{}},
+		{name: "numeric default", policy: PrecisionPolicy{Default: "8"}},
+		{name: "full per coin", policy: PrecisionPolicy{Default: "3", Coins: map[string]string{"shiba-inu": PrecisionFull}}},
+		{name: "above max", policy: PrecisionPolicy{Default: "19"}, wantErr: true},
+		{name: "negative", policy: PrecisionPolicy{Default: "-1"}, wantErr: true},
+		{name: "bad coin precision", policy: PrecisionPolicy{Coins: map[string]string{"pepe": "max"}}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		_, err := newPrecisionPolicy(tc.policy)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("%s: newPrecisionPolicy() error = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestPrecisionFor(t *testing.T) {
+	t.Parallel()
+
+	policy, err := newPrecisionPolicy(PrecisionPolicy{
+		Default: "3",
+		Coins:   map[string]string{"shiba-inu": PrecisionFull, "pepe": "18"},
+	})
+	if err != nil {
+		t.Fatalf("newPrecisionPolicy() error = %v", err)
+	}
+	c := &CGClient{precision: policy}
+
+	// sub-cent tokens must not be cut to zero by the default precision
+	if p := c.PrecisionFor("shiba-inu"); p != nil {
+		t.Fatalf("PrecisionFor(shiba-inu) = %q, want nil (full)", *p)
+	}
+	if p := c.PrecisionFor("pepe"); p == nil || *p != "18" {
+		t.Fatalf("PrecisionFor(pepe) = %v, want 18", p)
+	}
+	if p := c.PrecisionFor("bitcoin"); p == nil || *p != "3" {
+		t.Fatalf("PrecisionFor(bitcoin) = %v, want 3", p)
+	}
+
+	full, err := newPrecisionPolicy(PrecisionPolicy{})
+	if err != nil {
+		t.Fatalf("newPrecisionPolicy() error = %v", err)
+	}
+	if p := (&CGClient{precision: full}).PrecisionFor("bitcoin"); p != nil {
+		t.Fatalf("PrecisionFor(bitcoin) with no default = %q, want nil (full)", *p)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/pricing"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/resolver"
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
@@ -16,9 +18,12 @@ type (
 		Log      Log                `yaml:"log"`
 		PG       PG                 `yaml:"postgres"`
 		GRPC     GRPC               `yaml:"grpc"`
+		Metrics  Metrics            `yaml:"metrics"`
 		Redis    Redis              `yaml:"redis"`
 		CG       coingecko.CGConfig `yaml:"coingecko"`
 		Resolver Resolver           `yaml:"resolver"`
+		Pricing  pricing.Config     `yaml:"pricing"`
+		Jobs     Jobs               `yaml:"jobs"`
 	}
 
 	App struct {
@@ -35,6 +40,10 @@ type (
 		Addr string `yaml:"addr"`
 	}
 
+	Metrics struct {
+		Addr string `yaml:"addr" env-default:":9090"`
+	}
+
 	PG struct {
 		URL            string        `env:"DATABASE_URL" env-required:"true"`
 		PoolMax        int           `yaml:"pool_max"`
@@ -49,8 +58,41 @@ type (
 		Jitter   time.Duration `yaml:"jitter"`
 	}
 
+	Jobs struct {
+		Workers      int           `yaml:"workers" env-default:"2"`
+		ChunkSize    int           `yaml:"chunk_size" env-default:"500"`
+		PollInterval time.Duration `yaml:"poll_interval" env-default:"2s"`
+		Lease        time.Duration `yaml:"lease" env-default:"2m"`
+		// MaxAttempts is how many failed runs a job gets before it is marked failed.
+		MaxAttempts int `yaml:"max_attempts" env-default:"5"`
+		// RetryBackoff is the delay after the first failed run; it doubles with every further failure.
+		RetryBackoff time.Duration `yaml:"retry_backoff" env-default:"30s"`
+	}
+
 	Resolver struct {
 		Path string `yaml:"path"`
+		// ReloadInterval is how often the coin map file is checked for changes; 0 disables polling.
+		// SIGHUP and the ReloadCoinMap RPC reload it on demand.
+		ReloadInterval time.Duration `yaml:"reload_interval" env-default:"30s"`
+		// Sources holds per-source symbol normalization applied before resolution.
+		Sources []resolver.SourceNormalization `yaml:"sources"`
+		// Pairs holds per-source quote assets for splitting pair symbols such as "BTCUSDT".
+		Pairs []resolver.SourcePairs `yaml:"pairs"`
+		// PairsRefreshInterval is how often pairs are refreshed from exchange tickers; 0 refreshes only at startup.
+		PairsRefreshInterval time.Duration `yaml:"pairs_refresh_interval" env-default:"24h"`
+		// Chains maps chain names sent with contract addresses to provider platform IDs.
+		Chains map[string]string `yaml:"chains"`
+		// ContractsSyncInterval is how often token contracts are synced from the provider's coin list.
+		ContractsSyncInterval time.Duration `yaml:"contracts_sync_interval" env-default:"24h"`
+		// CatalogSyncInterval is how often the coin catalog is synced from the provider's coin list;
+		// 0 disables syncing.
+		CatalogSyncInterval time.Duration `yaml:"catalog_sync_interval" env-default:"24h"`
+		// Consensus lists the sources whose unknown symbols resolve once enough other tenants
+		// map them to the same coin; empty by default, sources opt in.
+		Consensus []resolver.SourceConsensus `yaml:"consensus"`
+		// CatalogFallback lists the sources whose unknown symbols resolve to the only active catalog
+		// coin with that symbol; empty by default, other sources get the coin as a suggestion.
+		CatalogFallback []string `yaml:"catalog_fallback"`
 	}
 )
 
@@ -67,6 +109,9 @@ func NewConfig() (*Config, error) {
 	if err := cleanenv.ReadEnv(&cfg); err != nil {
 		return nil, fmt.Errorf("read env: %w", err)
 	}
+	if err := cfg.CG.Validate(); err != nil {
+		return nil, fmt.Errorf("coingecko: %w", err)
+	}
 
 	return &cfg, nil
 }
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+)
+
+// Coin is a provider coin in the local catalog. Coins the provider stops listing stay in the
+// catalog with Delisted set, so historical mappings to them remain valid.
+type Coin struct {
+	ID        string
+	Symbol    string
+	Name      string
+	Platforms map[string]string // platform ID -> contract address
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Delisted  bool
+}
+
+type CoinChangeKind string
+
+const (
+	CoinAdded    CoinChangeKind = "added"
+	CoinRemoved  CoinChangeKind = "removed"
+	CoinRelisted CoinChangeKind = "relisted"
+	CoinRenamed  CoinChangeKind = "renamed"
+)
+
+// CoinChange is one entry of the catalog diff log written by a sync.
+type CoinChange struct {
+	CoinID    string
+	Kind      CoinChangeKind
+	OldSymbol string
+	OldName   string
+	NewSymbol string
+	NewName   string
+}
+
+// CatalogSync summarizes one catalog sync.
+type CatalogSync struct {
+	Coins    int
+	Added    int
+	Removed  int
+	Relisted int
+	Renamed  int
+}
+
+type CoinCatalogRepo interface {
+	List(ctx context.Context) ([]Coin, error)
+	Get(ctx context.Context, id string) (Coin, error)
+	// Search matches query (lower-cased, non-empty) against symbols, names and IDs.
+	Search(ctx context.Context, query string, limit int) ([]Coin, error)
+	GetByIDs(ctx context.Context, ids []string) ([]Coin, error)
+	// GetActiveBySymbols matches symbols case-insensitively and skips delisted coins.
+	GetActiveBySymbols(ctx context.Context, symbols []string) ([]Coin, error)
+	// LastSync is zero when the catalog was never synced.
+	LastSync(ctx context.Context) (time.Time, error)
+	// Sync stores coins as the provider's full list, flags the rest delisted and logs changes
+	// in one transaction.
+	Sync(ctx context.Context, coins []Coin, changes []CoinChange, syncedAt time.Time) error
+}
+
+type CoinCatalogUseCase interface {
+	// Search ranks exact symbol matches first, then symbol prefixes, name prefixes and substrings.
+	Search(ctx context.Context, query string, limit int) ([]Coin, error)
+	Get(ctx context.Context, id string) (Coin, error)
+	Sync(ctx context.Context) (CatalogSync, error)
+	// SyncIfStale syncs when the catalog is empty or older than maxAge; ok is false when skipped.
+	SyncIfStale(ctx context.Context, maxAge time.Duration) (sync CatalogSync, ok bool, err error)
+}
+
+// AmbiguousSymbolError is returned when a symbol matches several catalog coins.
+type AmbiguousSymbolError struct {
+	Symbol     string
+	Candidates []Coin
+}
+
+func (e *AmbiguousSymbolError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		ids[i] = c.ID
+	}
+	return fmt.Sprintf("%v: %s matches %s", apperr.ErrAmbiguousSymbol, e.Symbol, strings.Join(ids, ", "))
+}
+
+func (e *AmbiguousSymbolError) Unwrap() error {
+	return apperr.ErrAmbiguousSymbol
+}
+
+// CoinCandidate is a coin suggested for a symbol that did not resolve.
+type CoinCandidate struct {
+	CoinID string
+	Name   string
+	// Tenants is how many other tenants map the symbol to the coin; 0 for the catalog's coin.
+	Tenants int
+}
+
+// SuggestedCoinsError wraps an unknown symbol error with the coins other tenants map the symbol to,
+// most mapped first, then the only catalog coin with the symbol.
+type SuggestedCoinsError struct {
+	Err        error
+	Candidates []CoinCandidate
+}
+
+func (e *SuggestedCoinsError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		if c.Tenants > 0 {
+			ids[i] = fmt.Sprintf("%s (%d tenants)", c.CoinID, c.Tenants)
+		} else {
+			ids[i] = fmt.Sprintf("%s (catalog)", c.CoinID)
+		}
+	}
+	return fmt.Sprintf("%v; suggested coins: %s", e.Err, strings.Join(ids, ", "))
+}
+
+func (e *SuggestedCoinsError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// CoinContract is a token contract on one chain as listed by the provider.
+type CoinContract struct {
+	Platform        string // provider platform ID, e.g. ethereum
+	ContractAddress string
+	CoinID          string
+	SyncedAt        time.Time
+}
+
+// NormalizeContractAddress lower-cases hex (0x) addresses, which are case-insensitive;
+// other address formats, such as base58, are case-sensitive and only trimmed.
+func NormalizeContractAddress(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if len(addr) > 2 && (addr[:2] == "0x" || addr[:2] == "0X") {
+		return strings.ToLower(addr)
+	}
+	return addr
+}
+
+type CoinContractRepo interface {
+	GetByAddresses(ctx context.Context, addresses []string) ([]CoinContract, error)
+	// Replace stores contracts as the full provider list and removes the rest.
+	Replace(ctx context.Context, contracts []CoinContract, syncedAt time.Time) (removed int64, err error)
+	// LastSync is zero when contracts were never synced.
+	LastSync(ctx context.Context) (time.Time, error)
+}
+
+// CoinContractUseCase keeps the contract cache in line with the provider's coin list.
+type CoinContractUseCase interface {
+	Sync(ctx context.Context) (int, error)
+	// SyncIfStale syncs when the cache is empty or older than maxAge.
+	SyncIfStale(ctx context.Context, maxAge time.Duration) (int, error)
+}
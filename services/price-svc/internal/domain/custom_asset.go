@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ProviderManual marks prices supplied by a tenant for its custom assets.
+const ProviderManual = "manual"
+
+const customCoinIDPrefix = "custom:"
+
+// CustomAsset is a tenant-defined asset the provider does not list; it is priced from the
+// tenant's own price series and resolves ahead of the provider's coins for that tenant only.
+type CustomAsset struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	Symbol    string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CoinID is the ID the asset is priced under; it never collides with provider coin IDs.
+func (a CustomAsset) CoinID() string {
+	return CustomCoinID(a.ID)
+}
+
+func CustomCoinID(assetID uuid.UUID) string {
+	return customCoinIDPrefix + assetID.String()
+}
+
+// IsCustomCoinID reports whether coinID is in the namespace reserved for custom assets,
+// whether or not it names one.
+func IsCustomCoinID(coinID string) bool {
+	return strings.HasPrefix(coinID, customCoinIDPrefix)
+}
+
+// ParseCustomCoinID returns the custom asset ID behind a coin ID made by CustomCoinID.
+func ParseCustomCoinID(coinID string) (uuid.UUID, bool) {
+	raw, ok := strings.CutPrefix(coinID, customCoinIDPrefix)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// CustomPricePoint holds from Time until the asset's next point.
+type CustomPricePoint struct {
+	AssetID  uuid.UUID
+	Time     time.Time
+	Price    decimal.Decimal
+	Currency string // ISO-4217
+}
+
+// CustomPriceRow is an uploaded price point addressed by asset symbol.
+type CustomPriceRow struct {
+	Symbol   string
+	Time     time.Time
+	Price    decimal.Decimal
+	Currency string
+}
+
+type CustomPriceImport struct {
+	Rows   int
+	Assets int
+}
+
+type CustomAssetUseCase interface {
+	UpsertAsset(ctx context.Context, a CustomAsset) (CustomAsset, error)
+	ListAssets(ctx context.Context, tenantID uuid.UUID) ([]CustomAsset, error)
+	DeleteAsset(ctx context.Context, tenantID, assetID uuid.UUID) error
+
+	UpsertPrices(ctx context.Context, tenantID, assetID uuid.UUID, points []CustomPricePoint) error
+	// ListPrices returns the asset's points in [from, to).
+	ListPrices(ctx context.Context, tenantID, assetID uuid.UUID, from, to time.Time) ([]CustomPricePoint, error)
+	// ImportPricesCSV reads "symbol,time_utc,price,currency" rows, creating missing assets.
+	// Nothing is stored when any row is invalid.
+	ImportPricesCSV(ctx context.Context, tenantID uuid.UUID, r io.Reader) (CustomPriceImport, error)
+}
+
+type CustomAssetRepo interface {
+	Upsert(ctx context.Context, a CustomAsset) (CustomAsset, error)
+	Get(ctx context.Context, tenantID, assetID uuid.UUID) (CustomAsset, error)
+	List(ctx context.Context, tenantID uuid.UUID) ([]CustomAsset, error)
+	GetBySymbols(ctx context.Context, tenantID uuid.UUID, symbols []string) ([]CustomAsset, error)
+	Delete(ctx context.Context, tenantID, assetID uuid.UUID) error
+
+	UpsertPrices(ctx context.Context, points []CustomPricePoint) error
+	ListPrices(ctx context.Context, assetID uuid.UUID, from, to time.Time) ([]CustomPricePoint, error)
+	// GetPricesAt returns per (assetIDs[i], times[i]) the latest point at or before it, nil when there is none.
+	GetPricesAt(ctx context.Context, assetIDs []uuid.UUID, times []time.Time) ([]*CustomPricePoint, error)
+	// ImportPrices creates missing assets by symbol and upserts all rows in one transaction.
+	ImportPrices(ctx context.Context, tenantID uuid.UUID, rows []CustomPriceRow) error
+}
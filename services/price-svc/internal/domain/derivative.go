@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DerivativeRule values a wrapped or derivative token (WETH, stETH, exchange earn products)
+// through its underlying coin: price = underlying price * ratio.
+type DerivativeRule struct {
+	CoinID       string
+	UnderlyingID string
+	Ratio        decimal.Decimal // fixed ratio, used when Series is empty or starts later
+	Series       []RatioPoint    // step function sorted by From
+}
+
+type RatioPoint struct {
+	From  time.Time
+	Ratio decimal.Decimal
+}
+
+// RatioAt returns the ratio effective at t.
+func (r DerivativeRule) RatioAt(t time.Time) decimal.Decimal {
+	ratio := r.Ratio
+	for _, p := range r.Series {
+		if p.From.After(t) {
+			break
+		}
+		ratio = p.Ratio
+	}
+	return ratio
+}
+
+type DerivativeRegistry interface {
+	Lookup(coinID string) (DerivativeRule, bool)
+}
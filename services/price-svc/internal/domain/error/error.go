@@ -6,10 +6,13 @@ var (
 	ErrNotFound        = errors.New("not found")
 	ErrInvalidArgument = errors.New("invalid argument")
 	ErrConflict        = errors.New("conflict")
+	ErrLeaseLost       = errors.New("lease lost")
 
 	ErrPriceUnavailable    = errors.New("price unavailable")
 	ErrProviderUnavailable = errors.New("provider unavailable")
 	ErrProviderBadResponse = errors.New("provider bad response")
+	ErrNoProviderData      = errors.New("provider has no prices")
+	ErrBeforeListing       = errors.New("before coin's first available price")
 
 	ErrFXUnavailable   = errors.New("fx unavailable")
 	ErrUnsupportedFiat = errors.New("unsupported fiat")
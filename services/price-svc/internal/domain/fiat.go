@@ -0,0 +1,31 @@
+package domain
+
+import "strings"
+
+// iso4217 holds active ISO-4217 national currency codes.
+// Precious metals, funds and testing codes (XAU, XDR, XTS, ...) are left out on purpose.
+var iso4217 = toSet(`
+AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL
+BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP
+ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR
+IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL
+LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR
+NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD
+SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX
+USD UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL
+`)
+
+// IsFiat reports whether symbol is an ISO-4217 fiat currency code.
+func IsFiat(symbol string) bool {
+	_, ok := iso4217[strings.ToUpper(strings.TrimSpace(symbol))]
+	return ok
+}
+
+func toSet(codes string) map[string]struct{} {
+	fields := strings.Fields(codes)
+	set := make(map[string]struct{}, len(fields))
+	for _, c := range fields {
+		set[c] = struct{}{}
+	}
+	return set
+}
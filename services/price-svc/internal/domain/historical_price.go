@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
@@ -12,8 +13,12 @@ type HistoricalPrice struct {
 	Time               time.Time        `json:"bucket_start_utc"`
 	PriceUsd           *decimal.Decimal `json:"price_usd"`
 	GranularitySeconds *int             `json:"granularity_seconds"`
+	Provider           string           `json:"provider"`
+	FetchedAt          time.Time        `json:"fetched_at"`
 }
 
+const ProviderCoinGecko = "coingecko"
+
 type PriceKey struct {
 	CoinID         string
 	BucketStartUtc time.Time
@@ -22,23 +27,169 @@ type PriceKey struct {
 type Fiat = decimal.Decimal
 type Rate = decimal.Decimal
 
+// FXQuote is a rate with the day it was officially published for (earlier than the requested day on weekends/holidays).
+type FXQuote struct {
+	Rate          Rate
+	EffectiveDate time.Time
+}
+
+// PricingMethod tells how a valuation was obtained.
+type PricingMethod int
+
+const (
+	PricingMethodMarket       PricingMethod = iota // provider market price
+	PricingMethodPeg                               // pegged asset valued at its peg
+	PricingMethodMarketDepeg                       // pegged asset deviated beyond tolerance, market price used
+	PricingMethodFX                                // fiat leg converted with the official FX rate
+	PricingMethodDerivative                        // underlying coin price times the derivative ratio
+	PricingMethodManual                            // tenant-supplied price of a custom asset
+	PricingMethodPegUnchecked                      // pegged asset valued at its peg, no market price for the depeg check
+)
+
+type Valuation struct {
+	Fiat   Fiat
+	Method PricingMethod
+	// LowerPrecision is set when a neighbouring or coarser bucket was used instead of the exact one.
+	LowerPrecision bool
+	// Err is set when this leg has no price (e.g. before listing or a provider gap); Fiat is then zero.
+	Err        error
+	Provenance Provenance
+}
+
+// Provenance records which stored bucket and FX rate produced a valuation, for audits.
+type Provenance struct {
+	// Market bucket; zero/empty when no market price was used (peg).
+	BucketStartUtc     time.Time
+	GranularitySeconds int
+	Provider           string
+	PriceUsd           *decimal.Decimal // after interpolation/averaging and derivative ratio
+	FetchedAt          time.Time
+
+	// FXRate converts USD (or the peg currency) into the requested fiat.
+	FXRate        Rate
+	FXEffectiveAt time.Time
+}
+
+// PricePoint selects which point of the price series values a transaction.
+type PricePoint int
+
+const (
+	PricePointBucketOpen   PricePoint = iota // start of the bucket containing the tx
+	PricePointBucketClose                    // start of the following bucket
+	PricePointInterpolated                   // linear between bucket open and close by tx time
+	PricePointDailyAverage                   // mean of all buckets of the tx day (UTC)
+)
+
+// LookupPolicy controls which stored rows may answer a bucket lookup before the provider is called.
+type LookupPolicy struct {
+	// Nearest accepts the closest stored bucket at or before the wanted one,
+	// starting no more than Tolerance earlier.
+	Nearest   bool
+	Tolerance time.Duration
+	// AcceptCoarser keeps rows coarser than the desired granularity instead of refetching the day;
+	// a coarser bucket answers every wanted bucket it contains (the daily row for a 12:00 tx).
+	AcceptCoarser bool
+	// AsOf, when set, reads the price revisions known at that time and never calls the provider:
+	// it answers which price would have been used then. Zero reads the latest revisions.
+	AsOf time.Time
+}
+
+type ValuationOptions struct {
+	PricePoint PricePoint
+	Lookup     LookupPolicy
+	// SnapshotID, when set, answers from the values stored under this snapshot and stores new ones there.
+	SnapshotID string
+	// TenantID owns the snapshot and the custom assets priced; uuid.Nil without a tenant.
+	TenantID uuid.UUID
+}
+
 type HistoricalPriceUseCase interface {
-	GetHistoricalPrices(ctx context.Context, fiatCurrency string, priceKeys []PriceKey) ([]Fiat, error)
+	GetHistoricalPrices(ctx context.Context, fiatCurrency string, priceKeys []PriceKey, opts ValuationOptions) ([]Valuation, error)
+	// GetFiatRates values fiat-denominated legs; keys hold the ISO-4217 code as CoinID and the day as BucketStartUtc.
+	GetFiatRates(ctx context.Context, fiatCurrency string, legKeys []PriceKey, opts ValuationOptions) ([]Valuation, error)
+	// PinResolutions answers legs already valued under opts.SnapshotID with the coin they resolved
+	// to then and stores the others' resolutions there, so the snapshot keeps pricing the same coins
+	// after symbol mappings change. Without a snapshot ID it returns resolved unchanged.
+	PinResolutions(ctx context.Context, fiatCurrency, source string, assets []AssetRef, legs []AssetLeg, resolved []SymbolResolution, opts ValuationOptions) ([]SymbolResolution, error)
+	PurgeExpiredGaps(ctx context.Context) (int64, error)
 }
 
+// HistoricalPriceRepo keeps every stored price as a revision; reads take the latest revision
+// fetched at or before asOf, or the latest overall when asOf is zero.
 type HistoricalPriceRepo interface {
+	// Upsert and UpsertBatch append a revision when the bucket has none yet at the same or a finer
+	// granularity, or when the latest such revision has the same granularity and another price,
+	// so corrected re-fetches are kept. A coarser price never replaces a finer one.
 	Upsert(ctx context.Context, p HistoricalPrice) error
 	UpsertBatch(ctx context.Context, prices []HistoricalPrice) error
 
 	Get(ctx context.Context, coinID string, bucketStartUtc time.Time) (HistoricalPrice, error)
-	GetBatch(ctx context.Context, priceKeys []PriceKey) ([]HistoricalPrice, error)
+	GetBatch(ctx context.Context, priceKeys []PriceKey, asOf time.Time) ([]HistoricalPrice, error)
+	// GetNearestBatch returns per key the latest bucket at or before it within tolerance.
+	GetNearestBatch(ctx context.Context, priceKeys []PriceKey, tolerance time.Duration, asOf time.Time) ([]HistoricalPrice, error)
+	// GetContainingBatch returns per key the latest-starting bucket of any granularity containing it.
+	GetContainingBatch(ctx context.Context, priceKeys []PriceKey, asOf time.Time) ([]HistoricalPrice, error)
+	// GetDailyAverageBatch averages stored buckets per (coin, UTC day start); nil when the day has none.
+	GetDailyAverageBatch(ctx context.Context, dayKeys []PriceKey, asOf time.Time) ([]*decimal.Decimal, error)
 }
 
 type FXProvider interface {
 	Start(context.Context) error
-	GetUSDtoFiatRate(ctx context.Context, day time.Time, fiat string) (Fiat, error)
+	GetUSDtoFiatRate(ctx context.Context, day time.Time, fiat string) (FXQuote, error)
+	// GetRate returns how much quote one unit of base is worth on the given day.
+	GetRate(ctx context.Context, day time.Time, base, quote string) (FXQuote, error)
+	// SupportedFiats lists the currencies rates are published for, USD included, sorted.
+	SupportedFiats() []string
+}
+
+// AssetRef identifies an asset to resolve: by chain and contract address when the address is
+// set, otherwise by symbol.
+type AssetRef struct {
+	Symbol          string
+	Chain           string
+	ContractAddress string
+}
+
+// AssetLeg is one use of an asset; symbol mappings are windowed, so each leg resolves at its own time.
+type AssetLeg struct {
+	Asset int // index into the resolved assets
+	// At picks the symbol mapping valid at that time (the transaction time); zero means now.
+	At time.Time
+}
+
+// SymbolResolution is the outcome for one leg; Err is set when it could not be resolved.
+type SymbolResolution struct {
+	CoinID string
+	// CanonicalSymbol is the symbol after the source's normalization rules;
+	// for a trading pair it is the canonical base asset.
+	CanonicalSymbol string
+	// Quote is set when the symbol was a trading pair and its base asset was resolved.
+	Quote string
+	// Consensus is set when the coin was taken from other tenants' mappings of the symbol.
+	Consensus bool
+	Err       error
+}
+
+// SymbolNormalizer rewrites a symbol as reported by a source (exchange) into its canonical ticker.
+type SymbolNormalizer interface {
+	Normalize(source, symbol string) string
 }
 
 type CoinIdResolver interface {
-	Resolve(symbol string) (string, error)
+	// Resolve maps symbols to coin IDs in order: the tenant's custom assets, its symbol mappings
+	// for the source, then the global map. Without a tenant only the global map is used.
+	// Each level is tried with the symbol as sent first, then with its canonical form.
+	// A symbol nothing matches is tried as a trading pair and resolved by its base asset,
+	// then looked up in the coin catalog, which resolves it only when it is unique, and finally
+	// in other tenants' mappings, which resolve it only for sources opted into consensus and
+	// otherwise come back as suggestions.
+	// Assets with a contract address resolve by address only, never by symbol.
+	// Lookups run once per asset and mapping windows are applied per leg; the result holds one
+	// resolution per leg.
+	Resolve(ctx context.Context, tenantID uuid.UUID, source string, assets []AssetRef, legs []AssetLeg) ([]SymbolResolution, error)
+}
+
+// CoinMapReloader re-reads the global symbol map; on an invalid file the previous map is kept.
+type CoinMapReloader interface {
+	Reload(ctx context.Context) (int, error)
 }
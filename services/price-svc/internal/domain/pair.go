@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+)
+
+// PairSplit is one reading of a trading pair symbol such as "BTCUSDT" or "ETH/BTC".
+type PairSplit struct {
+	Base  string
+	Quote string
+	// Guessed is set when the split was inferred from a quote-asset suffix rather than a listed
+	// pair or an explicit separator; "WETH" would guess W/ETH.
+	Guessed bool
+}
+
+// PairParser proposes base/quote splits of a symbol reported by a source.
+type PairParser interface {
+	// Splits returns candidate splits, or none when the symbol does not look like a pair.
+	// Pairs the source is known to list and separated pairs win over guessed splits.
+	Splits(source, symbol string) []PairSplit
+}
+
+// AmbiguousPairError is returned when more than one split of a pair resolves to a coin,
+// or when the resolving splits were guessed and are only offered as candidates.
+type AmbiguousPairError struct {
+	Pair   string
+	Splits []PairSplit
+}
+
+func (e *AmbiguousPairError) Error() string {
+	splits := make([]string, len(e.Splits))
+	for i, s := range e.Splits {
+		splits[i] = s.Base + "/" + s.Quote
+	}
+	return fmt.Sprintf("%v: pair %s splits as %s", apperr.ErrAmbiguousSymbol, e.Pair, strings.Join(splits, " or "))
+}
+
+func (e *AmbiguousPairError) Unwrap() error {
+	return apperr.ErrAmbiguousSymbol
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+// PegRule describes an asset valued at a fixed 1:1 peg instead of its market price.
+type PegRule struct {
+	CoinID   string
+	Currency string // peg currency, ISO-4217 (e.g. "USD")
+
+	// Tolerance is the allowed relative deviation of the market price from the peg (0.01 = 1%).
+	Tolerance decimal.Decimal
+	// MarketFallback enables the depeg check: the market price is looked up and used
+	// instead of the peg when it deviates beyond Tolerance.
+	MarketFallback bool
+}
+
+type PegTable interface {
+	Lookup(coinID string) (PegRule, bool)
+}
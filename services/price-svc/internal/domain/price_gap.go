@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PriceGap marks a (coin, UTC day) the provider returned no prices for, so it is not refetched until ExpiresAt.
+type PriceGap struct {
+	CoinID    string    `json:"coin_id"`
+	Day       time.Time `json:"day_utc"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CoinListing holds the first moment the provider has a price for a coin, as probed at CheckedAt.
+type CoinListing struct {
+	CoinID       string    `json:"coin_id"`
+	FirstPriceAt time.Time `json:"first_price_at"`
+	CheckedAt    time.Time `json:"checked_at"`
+}
+
+type PriceGapRepo interface {
+	UpsertGap(ctx context.Context, g PriceGap) error
+	// GetActiveGaps returns the non-expired gaps among the given (coin, day start) keys.
+	GetActiveGaps(ctx context.Context, dayKeys []PriceKey) ([]PriceGap, error)
+	DeleteExpiredGaps(ctx context.Context) (int64, error)
+
+	UpsertListing(ctx context.Context, l CoinListing) error
+	GetListings(ctx context.Context, coinIDs []string) ([]CoinListing, error)
+}
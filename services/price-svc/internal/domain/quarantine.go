@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type QuarantineReason string
+
+const (
+	QuarantineReasonNonPositive QuarantineReason = "non_positive"
+	QuarantineReasonJump        QuarantineReason = "jump"
+)
+
+// QuarantinedPrice is a provider point that failed ingestion checks and is kept for review.
+type QuarantinedPrice struct {
+	CoinID             string           `json:"coin_id"`
+	BucketStartUtc     time.Time        `json:"bucket_start_utc"`
+	GranularitySeconds int              `json:"granularity_seconds"`
+	RawPrice           decimal.Decimal  `json:"raw_price"`
+	SubstitutedPrice   *decimal.Decimal `json:"substituted_price"` // nil when no neighbour could stand in and the bucket was dropped
+	NeighbourMedian    *decimal.Decimal `json:"neighbour_median"`
+	Reason             QuarantineReason `json:"reason"`
+}
+
+// PriceSanitizer validates a day of normalized buckets before they are stored.
+// It returns the buckets to store, with suspect points substituted or dropped, and the quarantined points.
+type PriceSanitizer interface {
+	Sanitize(buckets []HistoricalPrice) ([]HistoricalPrice, []QuarantinedPrice)
+}
+
+type QuarantineRepo interface {
+	InsertBatch(ctx context.Context, points []QuarantinedPrice) error
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+type RoundingMode int
+
+const (
+	RoundingHalfUp  RoundingMode = iota // half away from zero
+	RoundingBankers                     // half to even
+)
+
+// RoundingRule is how fiat values are rounded for output.
+type RoundingRule struct {
+	Decimals int32
+	Mode     RoundingMode
+}
+
+func (r RoundingRule) Apply(v decimal.Decimal) decimal.Decimal {
+	if r.Mode == RoundingBankers {
+		return v.RoundBank(r.Decimals)
+	}
+	return v.Round(r.Decimals)
+}
+
+type RoundingPolicy interface {
+	// For returns the rule for a fiat currency, falling back to the default rule.
+	For(fiat string) RoundingRule
+}
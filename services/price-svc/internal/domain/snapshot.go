@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SnapshotKind string
+
+const (
+	SnapshotKindCoin SnapshotKind = "coin"
+	SnapshotKindFiat SnapshotKind = "fiat"
+)
+
+// ValuationSnapshot pins the values of a report: every valuation made under it is stored once
+// and returned unchanged on later calls, whatever happens to stored prices, FX rates or symbol
+// mappings. Snapshot IDs are chosen by the caller and are unique per tenant.
+type ValuationSnapshot struct {
+	TenantID     uuid.UUID
+	ID           string
+	FiatCurrency string
+	PricePoint   PricePoint
+	Lookup       LookupPolicy
+	CreatedAt    time.Time
+}
+
+// Matches reports whether a call uses the settings the snapshot was taken with.
+func (s ValuationSnapshot) Matches(fiat string, opts ValuationOptions) bool {
+	return strings.EqualFold(s.FiatCurrency, fiat) &&
+		s.PricePoint == opts.PricePoint &&
+		s.Lookup.Nearest == opts.Lookup.Nearest &&
+		s.Lookup.Tolerance == opts.Lookup.Tolerance.Truncate(time.Second) &&
+		s.Lookup.AcceptCoarser == opts.Lookup.AcceptCoarser &&
+		s.Lookup.AsOf.Equal(opts.Lookup.AsOf.Truncate(time.Microsecond))
+}
+
+type SnapshotRepo interface {
+	// Create stores the snapshot header unless the tenant has one with the same ID.
+	Create(ctx context.Context, s ValuationSnapshot) error
+	Get(ctx context.Context, tenantID uuid.UUID, id string) (ValuationSnapshot, error)
+
+	// GetValuations returns stored valuations aligned with keys, nil where none is stored.
+	GetValuations(ctx context.Context, tenantID uuid.UUID, snapshotID string, kind SnapshotKind, keys []PriceKey) ([]*Valuation, error)
+	// SaveValuations stores valuations that are not stored yet; existing ones are never overwritten.
+	SaveValuations(ctx context.Context, tenantID uuid.UUID, snapshotID string, kind SnapshotKind, keys []PriceKey, vals []Valuation) error
+
+	// GetResolutions returns the stored resolution of each leg, nil where none is stored.
+	GetResolutions(ctx context.Context, tenantID uuid.UUID, snapshotID, source string, assets []AssetRef, legs []AssetLeg) ([]*SymbolResolution, error)
+	// SaveResolutions stores the resolutions of legs not stored yet, skipping failed ones.
+	SaveResolutions(ctx context.Context, tenantID uuid.UUID, snapshotID, source string, assets []AssetRef, legs []AssetLeg, resolved []SymbolResolution) error
+}
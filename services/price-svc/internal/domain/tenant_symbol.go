@@ -2,7 +2,11 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"time"
 
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
 	"github.com/google/uuid"
 )
 
@@ -11,6 +15,100 @@ type TenantSymbol struct {
 	Source   string    `json:"source"`
 	Symbol   string    `json:"symbol"`
 	CoinID   string    `json:"coin_id"`
+	// ValidFrom and ValidTo bound when the mapping applies, [from, to); zero means open.
+	ValidFrom time.Time `json:"valid_from,omitempty"`
+	ValidTo   time.Time `json:"valid_to,omitempty"`
+}
+
+// Mapping returns the symbol's coin ID with its validity window.
+func (s TenantSymbol) Mapping() SymbolMapping {
+	return SymbolMapping{CoinID: s.CoinID, ValidFrom: s.ValidFrom, ValidTo: s.ValidTo}
+}
+
+// SymbolMapping maps a symbol to a coin ID for [ValidFrom, ValidTo); zero bounds are open.
+// Tickers change meaning over time (MATIC became POL, LUNA went to the fork), so one symbol
+// can have several mappings with disjoint windows.
+type SymbolMapping struct {
+	CoinID    string
+	ValidFrom time.Time
+	ValidTo   time.Time
+}
+
+func (m SymbolMapping) ValidAt(t time.Time) bool {
+	return (m.ValidFrom.IsZero() || !t.Before(m.ValidFrom)) && (m.ValidTo.IsZero() || t.Before(m.ValidTo))
+}
+
+// Overlaps reports whether both windows share an instant.
+func (m SymbolMapping) Overlaps(o SymbolMapping) bool {
+	startsBeforeOtherEnds := m.ValidFrom.IsZero() || o.ValidTo.IsZero() || m.ValidFrom.Before(o.ValidTo)
+	otherStartsBeforeEnd := o.ValidFrom.IsZero() || m.ValidTo.IsZero() || o.ValidFrom.Before(m.ValidTo)
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
+
+// MappingAt returns the coin ID of the mapping valid at t.
+func MappingAt(mappings []SymbolMapping, t time.Time) (string, bool) {
+	for _, m := range mappings {
+		if m.ValidAt(t) {
+			return m.CoinID, true
+		}
+	}
+	return "", false
+}
+
+// SymbolConsensus counts the tenants that map a source's symbol to one coin.
+type SymbolConsensus struct {
+	Symbol  string
+	CoinID  string
+	Tenants int
+}
+
+// TenantSymbolFormat is the payload format of bulk imports and exports.
+type TenantSymbolFormat string
+
+const (
+	// TenantSymbolFormatCSV has the header "source,symbol,coin_id,valid_from,valid_to".
+	TenantSymbolFormatCSV TenantSymbolFormat = "csv"
+	// TenantSymbolFormatJSON is an array of objects with the CSV columns as keys.
+	TenantSymbolFormatJSON TenantSymbolFormat = "json"
+)
+
+type TenantSymbolIssueKind string
+
+const (
+	TenantSymbolIssueInvalid     TenantSymbolIssueKind = "invalid"      // malformed or incomplete row
+	TenantSymbolIssueUnknownCoin TenantSymbolIssueKind = "unknown_coin" // coin ID not in the coin catalog
+	TenantSymbolIssueConflict    TenantSymbolIssueKind = "conflict"     // window overlaps another mapping of the symbol
+)
+
+// TenantSymbolIssue is a row of a bulk import that cannot be applied; Row counts mappings from 1.
+type TenantSymbolIssue struct {
+	Row     int
+	Symbol  TenantSymbol // as far as the row could be read
+	Kind    TenantSymbolIssueKind
+	Message string
+}
+
+// TenantSymbolImport reports a bulk import. Applied is false for dry runs and whenever
+// there are issues; then nothing was stored.
+type TenantSymbolImport struct {
+	Rows    int
+	Issues  []TenantSymbolIssue
+	Applied bool
+}
+
+// ImportConflictError is returned by TenantSymbolRepo.Import when a mapping overlaps another
+// window of its symbol, e.g. one written concurrently after the import was checked.
+type ImportConflictError struct {
+	Row    int // 1-based index into the imported mappings
+	Symbol string
+}
+
+func (e *ImportConflictError) Error() string {
+	return fmt.Sprintf("%v: mapping %d (%s) overlaps another window", apperr.ErrConflict, e.Row, e.Symbol)
+}
+
+func (e *ImportConflictError) Unwrap() error {
+	return apperr.ErrConflict
 }
 
 type TenantSymbolUseCase interface {
@@ -19,12 +117,29 @@ type TenantSymbolUseCase interface {
 
 	GetList(ctx context.Context, tenantID uuid.UUID, source string, symbols []string) ([]TenantSymbol, error)
 	GetListBySource(ctx context.Context, tenantID uuid.UUID, source string) ([]TenantSymbol, error)
+
+	// Import reads mappings in the given format and checks them like Upsert, against the tenant's
+	// stored mappings and each other. Without issues and unless dryRun they are stored in one
+	// transaction; a mapping with the valid_from of a stored one replaces it.
+	Import(ctx context.Context, tenantID uuid.UUID, format TenantSymbolFormat, r io.Reader, dryRun bool) (TenantSymbolImport, error)
+	// Export writes the tenant's mappings, of one source or all when source is empty, in a format
+	// Import reads, and returns how many it wrote.
+	Export(ctx context.Context, tenantID uuid.UUID, source string, format TenantSymbolFormat, w io.Writer) (int, error)
 }
 
 type TenantSymbolRepo interface {
+	// Upsert and Import also clear the written symbols from the tenant's unresolved queue.
 	Upsert(ctx context.Context, s TenantSymbol) error
 	Delete(ctx context.Context, tenantID uuid.UUID, source, symbol string) error
 
 	GetList(ctx context.Context, tenantID uuid.UUID, source string, symbols []string) ([]TenantSymbol, error)
 	GetListBySource(ctx context.Context, tenantID uuid.UUID, source string) ([]TenantSymbol, error)
+	// CountMappings counts per symbol and coin how many tenants other than excludeTenantID map
+	// the source's symbol to the coin in a window valid now, one vote per tenant; most mapped
+	// coins come first. Custom asset coins are never counted.
+	CountMappings(ctx context.Context, excludeTenantID uuid.UUID, source string, symbols []string) ([]SymbolConsensus, error)
+	// ListByTenant returns all the tenant's mappings ordered by source, symbol and valid_from.
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]TenantSymbol, error)
+	// Import upserts all mappings in one transaction; overlapping windows fail it with *ImportConflictError.
+	Import(ctx context.Context, symbols []TenantSymbol) error
 }
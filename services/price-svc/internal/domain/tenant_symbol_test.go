@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// Polygon renamed MATIC to POL on 2024-09-04.
+var polSwitch = time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC)
+
+func TestMappingAtTickerMigration(t *testing.T) {
+	t.Parallel()
+
+	mappings := []SymbolMapping{
+		{CoinID: "matic-network", ValidTo: polSwitch},
+		{CoinID: "polygon-ecosystem-token", ValidFrom: polSwitch},
+	}
+
+	cases := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{name: "long before the switch", at: time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC), want: "matic-network"},
+		{name: "last instant before the switch", at: polSwitch.Add(-time.Nanosecond), want: "matic-network"},
+		{name: "valid_from is inclusive", at: polSwitch, want: "polygon-ecosystem-token"},
+		{name: "after the switch", at: polSwitch.AddDate(1, 0, 0), want: "polygon-ecosystem-token"},
+	}
+
+	for _, tc := range cases {
+		got, ok := MappingAt(mappings, tc.at)
+		if !ok || got != tc.want {
+			t.Fatalf("%s: MappingAt(%s) = %q, %v, want %q", tc.name, tc.at, got, ok, tc.want)
+		}
+	}
+}
+
+func TestMappingAtGap(t *testing.T) {
+	t.Parallel()
+
+	// a closed window leaves the times around it unmapped; valid_to is exclusive
+	from := time.Date(2022, 5, 28, 0, 0, 0, 0, time.UTC)
+	mappings := []SymbolMapping{{CoinID: "terra-luna-2", ValidFrom: from, ValidTo: polSwitch}}
+
+	for _, at := range []time.Time{from.Add(-time.Second), polSwitch} {
+		if got, ok := MappingAt(mappings, at); ok {
+			t.Fatalf("MappingAt(%s) = %q, want no mapping", at, got)
+		}
+	}
+	if _, ok := MappingAt(nil, from); ok {
+		t.Fatalf("MappingAt(nil) ok = true, want false")
+	}
+}
+
+func TestSymbolMappingOverlaps(t *testing.T) {
+	t.Parallel()
+
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		a, b SymbolMapping
+		want bool
+	}{
+		{name: "both open", want: true},
+		{name: "open mapping overlaps any window", b: SymbolMapping{ValidFrom: jan, ValidTo: feb}, want: true},
+		{name: "window ends where the next starts", a: SymbolMapping{ValidTo: feb}, b: SymbolMapping{ValidFrom: feb}, want: false},
+		{name: "open end after open start", a: SymbolMapping{ValidTo: mar}, b: SymbolMapping{ValidFrom: jan}, want: true},
+		{name: "partial overlap", a: SymbolMapping{ValidFrom: jan, ValidTo: mar}, b: SymbolMapping{ValidFrom: feb}, want: true},
+		{name: "disjoint windows", a: SymbolMapping{ValidFrom: jan, ValidTo: feb}, b: SymbolMapping{ValidFrom: mar}, want: false},
+	}
+
+	for _, tc := range cases {
+		// overlapping is symmetric
+		if got := tc.a.Overlaps(tc.b); got != tc.want {
+			t.Fatalf("%s: a.Overlaps(b) = %v, want %v", tc.name, got, tc.want)
+		}
+		if got := tc.b.Overlaps(tc.a); got != tc.want {
+			t.Fatalf("%s: b.Overlaps(a) = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UnresolvedReason string
+
+const (
+	UnresolvedUnknown   UnresolvedReason = "unknown"
+	UnresolvedAmbiguous UnresolvedReason = "ambiguous"
+)
+
+// UnresolvedSymbol is a symbol valuations of a tenant could not resolve for a source,
+// kept until support resolves it with a tenant mapping.
+type UnresolvedSymbol struct {
+	ID              int64
+	TenantID        uuid.UUID
+	Source          string
+	Symbol          string
+	CanonicalSymbol string
+	Reason          UnresolvedReason
+	Message         string
+	Occurrences     int64
+	FirstSeen       time.Time
+	LastSeen        time.Time
+}
+
+// UnresolvedSymbolFilter selects queue entries; a nil tenant or empty source matches all.
+type UnresolvedSymbolFilter struct {
+	TenantID uuid.UUID
+	Source   string
+	AfterID  int64
+	Limit    int
+}
+
+type UnresolvedSymbolUseCase interface {
+	// Record adds occurrences of failed symbols; TenantID and Source of the entries are ignored.
+	Record(ctx context.Context, tenantID uuid.UUID, source string, symbols []UnresolvedSymbol) error
+	List(ctx context.Context, filter UnresolvedSymbolFilter) ([]UnresolvedSymbol, error)
+	// Resolve writes the mapping to the tenant's symbols and clears the queued entry.
+	Resolve(ctx context.Context, s TenantSymbol) error
+}
+
+type UnresolvedSymbolRepo interface {
+	Record(ctx context.Context, tenantID uuid.UUID, source string, symbols []UnresolvedSymbol) error
+	List(ctx context.Context, filter UnresolvedSymbolFilter) ([]UnresolvedSymbol, error)
+	Resolve(ctx context.Context, s TenantSymbol) error
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// ValuationJob is an asynchronous valuation request. Request holds the serialized request settings,
+// items hold the serialized transactions and results; their encoding is up to the transport layer.
+type ValuationJob struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	Status     JobStatus  `json:"status"`
+	Request    []byte     `json:"request"`
+	Total      int        `json:"total"`
+	Processed  int        `json:"processed"`
+	Error      string     `json:"error"`
+	Attempts   int        `json:"attempts"`
+	LeaseToken uuid.UUID  `json:"lease_token"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+type ValuationJobItem struct {
+	Seq    int    `json:"seq"`
+	Tx     []byte `json:"tx"`
+	Result []byte `json:"result"`
+}
+
+type ValuationJobUseCase interface {
+	Submit(ctx context.Context, tenantID string, request []byte, txs [][]byte) (ValuationJob, error)
+	// Get and ListResults only see jobs submitted by tenantID; another tenant's job is not found.
+	Get(ctx context.Context, tenantID string, id uuid.UUID) (ValuationJob, error)
+	// ListResults returns priced items with Seq > afterSeq in order.
+	ListResults(ctx context.Context, tenantID string, id uuid.UUID, afterSeq, limit int) ([]ValuationJobItem, error)
+
+	// Claim takes the oldest queued job, or a running one whose lease expired; nil when there is none.
+	// The job carries a fresh LeaseToken that the writes below must present.
+	Claim(ctx context.Context, lease time.Duration) (*ValuationJob, error)
+	NextItems(ctx context.Context, id uuid.UUID, limit int) ([]ValuationJobItem, error)
+	// SaveResults, Finish, Release and Retry fail with apperr.ErrLeaseLost and change nothing
+	// when the job was claimed by another worker since.
+	SaveResults(ctx context.Context, id, leaseToken uuid.UUID, items []ValuationJobItem, lease time.Duration) error
+	Finish(ctx context.Context, id, leaseToken uuid.UUID, jobErr error) error
+	// Release puts a running job back to the queue, e.g. on shutdown.
+	Release(ctx context.Context, id, leaseToken uuid.UUID) error
+	// Retry puts a running job back to the queue after a failed attempt; it is not claimed again before delay passes.
+	Retry(ctx context.Context, id, leaseToken uuid.UUID, delay time.Duration) error
+}
+
+type ValuationJobRepo interface {
+	Create(ctx context.Context, job ValuationJob, txs [][]byte) error
+	Get(ctx context.Context, tenantID string, id uuid.UUID) (ValuationJob, error)
+	ListResults(ctx context.Context, id uuid.UUID, afterSeq, limit int) ([]ValuationJobItem, error)
+
+	Claim(ctx context.Context, lease time.Duration) (*ValuationJob, error)
+	ListPending(ctx context.Context, id uuid.UUID, limit int) ([]ValuationJobItem, error)
+	SaveResults(ctx context.Context, id, leaseToken uuid.UUID, items []ValuationJobItem, lease time.Duration) error
+	Finish(ctx context.Context, id, leaseToken uuid.UUID, status JobStatus, errMsg string) error
+	Release(ctx context.Context, id, leaseToken uuid.UUID) error
+	Retry(ctx context.Context, id, leaseToken uuid.UUID, delay time.Duration) error
+}
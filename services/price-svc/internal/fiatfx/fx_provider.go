@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/shopspring/decimal"
 )
 
 type FXProvider struct {
@@ -50,14 +54,14 @@ func (r *FXProvider) runSource(ctx context.Context, src FXSource) {
 	}
 }
 
-func (r *FXProvider) GetUSDtoFiatRate(ctx context.Context, day time.Time, currency string) (domain.Fiat, error) {
+func (r *FXProvider) GetUSDtoFiatRate(ctx context.Context, day time.Time, currency string) (domain.FXQuote, error) {
 	source, ok := r.registry.GetSource(currency)
 	if !ok {
-		return domain.Fiat{}, fmt.Errorf("GetUSDtoFiatRate: no source for currency %s", currency)
+		return domain.FXQuote{}, fmt.Errorf("GetUSDtoFiatRate: no source for currency %s: %w", currency, apperr.ErrUnsupportedFiat)
 	}
 
-	if rate, ok := source.Get(day); ok {
-		return rate, nil
+	if q, ok := source.Get(day); ok {
+		return domain.FXQuote{Rate: q.Rate, EffectiveDate: q.Date}, nil
 	}
 
 	// Need to implement certain day update logic
@@ -66,5 +70,46 @@ func (r *FXProvider) GetUSDtoFiatRate(ctx context.Context, day time.Time, curren
 	// 	return domain.Fiat{}, fmt.Errorf("GetUSDtoFiatRate: source update failed: %w", err)
 	// }
 
-	return domain.Fiat{}, fmt.Errorf("GetUSDtoFiatRate: no rate for currency %s at day %s", currency, day.Format("2006-01-02"))
+	return domain.FXQuote{}, fmt.Errorf("GetUSDtoFiatRate: no rate for currency %s at day %s", currency, day.Format("2006-01-02"))
+}
+
+func (r *FXProvider) SupportedFiats() []string {
+	out := append([]string{USD}, r.registry.Currencies()...)
+	sort.Strings(out)
+	return out
+}
+
+// GetRate converts through USD: base->quote = (USD->quote) / (USD->base).
+// The effective date is the older of the two legs' publication days.
+func (r *FXProvider) GetRate(ctx context.Context, day time.Time, base, quote string) (domain.FXQuote, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if base == quote {
+		return domain.FXQuote{Rate: decimal.NewFromInt(1), EffectiveDate: day}, nil
+	}
+
+	usdTo := func(currency string) (domain.FXQuote, error) {
+		if currency == USD {
+			return domain.FXQuote{Rate: decimal.NewFromInt(1), EffectiveDate: day}, nil
+		}
+		return r.GetUSDtoFiatRate(ctx, day, currency)
+	}
+
+	toQuote, err := usdTo(quote)
+	if err != nil {
+		return domain.FXQuote{}, err
+	}
+	toBase, err := usdTo(base)
+	if err != nil {
+		return domain.FXQuote{}, err
+	}
+	if toBase.Rate.IsZero() {
+		return domain.FXQuote{}, fmt.Errorf("GetRate: zero rate for currency %s at day %s: %w", base, day.Format("2006-01-02"), apperr.ErrFXUnavailable)
+	}
+
+	effective := toQuote.EffectiveDate
+	if toBase.EffectiveDate.Before(effective) {
+		effective = toBase.EffectiveDate
+	}
+
+	return domain.FXQuote{Rate: toQuote.Rate.Div(toBase.Rate), EffectiveDate: effective}, nil
 }
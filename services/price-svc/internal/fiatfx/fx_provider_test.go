@@ -0,0 +1,72 @@
+package fiatfx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/shopspring/decimal"
+)
+
+// fixedSource publishes one rate for every day.
+type fixedSource struct {
+	currency Currency
+	rate     string
+}
+
+func (s fixedSource) Currency() Currency { return s.currency }
+func (s fixedSource) Get(day time.Time) (Quote, bool) {
+	return Quote{Rate: decimal.RequireFromString(s.rate), Date: day}, true
+}
+func (s fixedSource) Schedule() Schedule           { return Schedule{Loc: time.UTC} }
+func (s fixedSource) Update(context.Context) error { return nil }
+
+func newTestProvider() *FXProvider {
+	registry := NewFXRegistry()
+	registry.Register(fixedSource{currency: RUB, rate: "90"})
+	registry.Register(fixedSource{currency: KZT, rate: "450"})
+	return NewFXProvider(registry).(*FXProvider)
+}
+
+func TestSupportedFiats(t *testing.T) {
+	t.Parallel()
+
+	if got, want := newTestProvider().SupportedFiats(), []string{"KZT", "RUB", "USD"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("SupportedFiats() = %v, want %v", got, want)
+	}
+}
+
+func TestGetRate(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProvider()
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		base, quote string
+		want        string
+	}{
+		{base: "usd", quote: "RUB", want: "90"},
+		{base: "KZT", quote: "RUB", want: "0.2"},
+		{base: "EUR", quote: "eur", want: "1"},
+	}
+	for _, tc := range cases {
+		got, err := p.GetRate(context.Background(), day, tc.base, tc.quote)
+		if err != nil {
+			t.Fatalf("GetRate(%s, %s) error = %v", tc.base, tc.quote, err)
+		}
+		if !got.Rate.Equal(decimal.RequireFromString(tc.want)) {
+			t.Fatalf("GetRate(%s, %s) = %s, want %s", tc.base, tc.quote, got.Rate, tc.want)
+		}
+	}
+
+	// a currency without a source is unsupported, not merely missing a rate
+	for _, pair := range [][2]string{{"USD", "EUR"}, {"EUR", "RUB"}} {
+		if _, err := p.GetRate(context.Background(), day, pair[0], pair[1]); !errors.Is(err, apperr.ErrUnsupportedFiat) {
+			t.Fatalf("GetRate(%s, %s) error = %v, want %v", pair[0], pair[1], err, apperr.ErrUnsupportedFiat)
+		}
+	}
+}
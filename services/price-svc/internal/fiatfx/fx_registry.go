@@ -10,6 +10,13 @@ import (
 type Currency = string
 type Rate = decimal.Decimal
 
+// Quote is a stored daily rate with the publication day it came from;
+// weekends and holidays carry the last published rate forward, so Date may precede the key.
+type Quote struct {
+	Rate Rate
+	Date time.Time
+}
+
 const (
 	USD Currency = "USD"
 	RUB Currency = "RUB"
@@ -24,7 +31,7 @@ type Schedule struct {
 
 type FXSource interface {
 	Currency() Currency
-	Get(key time.Time) (Rate, bool)
+	Get(key time.Time) (Quote, bool)
 	Schedule() Schedule
 	Update(ctx context.Context) error
 }
@@ -28,7 +28,7 @@ type nbrkItem struct {
 
 type KZTSource struct {
 	httpClient *http.Client
-	store      *inmemory.Store[string, Rate]
+	store      *inmemory.Store[string, Quote]
 	schedule   Schedule
 
 	mu       sync.Mutex
@@ -40,7 +40,7 @@ func NewKZTSource(httpClient *http.Client) FXSource {
 
 	return &KZTSource{
 		httpClient: httpClient,
-		store:      inmemory.NewStore[string, Rate](),
+		store:      inmemory.NewStore[string, Quote](),
 		schedule: Schedule{
 			Loc:  loc,
 			Hour: 20,
@@ -52,7 +52,7 @@ func NewKZTSource(httpClient *http.Client) FXSource {
 func (s *KZTSource) Currency() Currency { return KZT }
 func (s *KZTSource) Schedule() Schedule { return s.schedule }
 
-func (s *KZTSource) Get(key time.Time) (Rate, bool) {
+func (s *KZTSource) Get(key time.Time) (Quote, bool) {
 	return s.store.Get(dateKeyISO(key))
 }
 
@@ -80,7 +80,7 @@ func (s *KZTSource) Update(ctx context.Context) error {
 		return nil
 	}
 
-	var carry Rate
+	var carry Quote
 	haveCarry := false
 	if !lastSaved.IsZero() {
 		if r, ok := s.store.Get(dateKeyISO(dateOnly(lastSaved, loc))); ok {
@@ -89,7 +89,7 @@ func (s *KZTSource) Update(ctx context.Context) error {
 		}
 	}
 
-	patch := make(map[string]Rate)
+	patch := make(map[string]Quote)
 	newLastDate := time.Time{}
 
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
@@ -99,9 +99,9 @@ func (s *KZTSource) Update(ctx context.Context) error {
 		if err == nil {
 			usdRate, ok := parseNBRKUSD(doc)
 			if ok {
-				carry = usdRate
+				carry = Quote{Rate: usdRate, Date: d}
 				haveCarry = true
-				patch[dateKeyISO(d)] = usdRate
+				patch[dateKeyISO(d)] = carry
 				newLastDate = d
 				continue
 			}
@@ -45,7 +45,7 @@ type dynamicRecord struct {
 // RUBSource provides USD/RUB official rate from CBR.
 // Storage model:
 //   - store key is an ISO day string "YYYY-MM-DD" (see dateKeyISO).
-//   - store value is Rate (decimal).
+//   - store value is Quote (decimal rate and the CBR day it was published for).
 //
 // Concurrency:
 //   - store is copy-on-write, readers are lock-free (atomic.Value inside).
@@ -57,7 +57,7 @@ type dynamicRecord struct {
 //     do not move the window forward and do not lose data).
 type RUBSource struct {
 	httpClient *http.Client
-	store      *inmemory.Store[string, Rate]
+	store      *inmemory.Store[string, Quote]
 	schedule   Schedule
 
 	mu       sync.Mutex
@@ -71,7 +71,7 @@ func NewRUBSource(httpClient *http.Client) FXSource {
 
 	return &RUBSource{
 		httpClient: httpClient,
-		store:      inmemory.NewStore[string, Rate](),
+		store:      inmemory.NewStore[string, Quote](),
 		schedule: Schedule{
 			Loc:  loc,
 			Hour: 20,
@@ -84,7 +84,7 @@ func (s *RUBSource) Currency() Currency { return RUB }
 func (s *RUBSource) Schedule() Schedule { return s.schedule }
 
 // Get returns rate by ISO day key ("YYYY-MM-DD").
-func (s *RUBSource) Get(key time.Time) (Rate, bool) { return s.store.Get(dateKeyISO(key)) }
+func (s *RUBSource) Get(key time.Time) (Quote, bool) { return s.store.Get(dateKeyISO(key)) }
 
 // Update fetches and persists missing days from CBR.
 //
@@ -161,7 +161,7 @@ func (s *RUBSource) Update(ctx context.Context) error {
 
 	// carry is the last known rate from previous successful day, used to fill gaps.
 	// We try to load carry from store at lastSaved day (ISO key).
-	var carry Rate
+	var carry Quote
 	haveCarry := false
 	if !lastSaved.IsZero() {
 		if r, ok := s.store.Get(dateKeyISO(dateOnly(lastSaved, loc))); ok {
@@ -170,15 +170,15 @@ func (s *RUBSource) Update(ctx context.Context) error {
 		}
 	}
 
-	patch := make(map[string]Rate)
+	patch := make(map[string]Quote)
 	newLastDate := time.Time{} // will be the last processed day in [from..to] we actually wrote
 
 	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
 		if r, ok := raw[d]; ok {
 			// Working-day (real) point from CBR.
-			carry = r
+			carry = Quote{Rate: r, Date: d}
 			haveCarry = true
-			patch[dateKeyISO(d)] = r
+			patch[dateKeyISO(d)] = carry
 			newLastDate = d
 			continue
 		}
@@ -9,6 +9,7 @@ package pricev1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,6 +23,70 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PricingMethod int32
+
+const (
+	PricingMethod_PRICING_METHOD_UNSPECIFIED   PricingMethod = 0
+	PricingMethod_PRICING_METHOD_MARKET        PricingMethod = 1 // provider market price
+	PricingMethod_PRICING_METHOD_PEG           PricingMethod = 2 // pegged asset valued at its peg
+	PricingMethod_PRICING_METHOD_MARKET_DEPEG  PricingMethod = 3 // pegged asset off-peg beyond tolerance, market price used
+	PricingMethod_PRICING_METHOD_FX            PricingMethod = 4 // fiat leg converted with the official FX rate
+	PricingMethod_PRICING_METHOD_DERIVATIVE    PricingMethod = 5 // underlying coin price times the derivative ratio
+	PricingMethod_PRICING_METHOD_MANUAL        PricingMethod = 6 // tenant-supplied price of a custom asset
+	PricingMethod_PRICING_METHOD_PEG_UNCHECKED PricingMethod = 7 // pegged asset valued at its peg, no market price for the depeg check
+)
+
+// Enum value maps for PricingMethod.
+var (
+	PricingMethod_name = map[int32]string{
+		0: "PRICING_METHOD_UNSPECIFIED",
+		1: "PRICING_METHOD_MARKET",
+		2: "PRICING_METHOD_PEG",
+		3: "PRICING_METHOD_MARKET_DEPEG",
+		4: "PRICING_METHOD_FX",
+		5: "PRICING_METHOD_DERIVATIVE",
+		6: "PRICING_METHOD_MANUAL",
+		7: "PRICING_METHOD_PEG_UNCHECKED",
+	}
+	PricingMethod_value = map[string]int32{
+		"PRICING_METHOD_UNSPECIFIED":   0,
+		"PRICING_METHOD_MARKET":        1,
+		"PRICING_METHOD_PEG":           2,
+		"PRICING_METHOD_MARKET_DEPEG":  3,
+		"PRICING_METHOD_FX":            4,
+		"PRICING_METHOD_DERIVATIVE":    5,
+		"PRICING_METHOD_MANUAL":        6,
+		"PRICING_METHOD_PEG_UNCHECKED": 7,
+	}
+)
+
+func (x PricingMethod) Enum() *PricingMethod {
+	p := new(PricingMethod)
+	*p = x
+	return p
+}
+
+func (x PricingMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PricingMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_v1_price_proto_enumTypes[0].Descriptor()
+}
+
+func (PricingMethod) Type() protoreflect.EnumType {
+	return &file_price_v1_price_proto_enumTypes[0]
+}
+
+func (x PricingMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PricingMethod.Descriptor instead.
+func (PricingMethod) EnumDescriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{0}
+}
+
 type AssetErrorCode int32
 
 const (
@@ -30,6 +95,7 @@ const (
 	AssetErrorCode_ASSET_AMBIGUOUS              AssetErrorCode = 2
 	AssetErrorCode_RATE_NOT_FOUND               AssetErrorCode = 3
 	AssetErrorCode_PROVIDER_ERROR               AssetErrorCode = 4
+	AssetErrorCode_INVALID_AMOUNT               AssetErrorCode = 5 // the leg is priced, but its amount is missing or not a decimal, so it has no total
 )
 
 // Enum value maps for AssetErrorCode.
@@ -40,6 +106,7 @@ var (
 		2: "ASSET_AMBIGUOUS",
 		3: "RATE_NOT_FOUND",
 		4: "PROVIDER_ERROR",
+		5: "INVALID_AMOUNT",
 	}
 	AssetErrorCode_value = map[string]int32{
 		"ASSET_ERROR_CODE_UNSPECIFIED": 0,
@@ -47,6 +114,7 @@ var (
 		"ASSET_AMBIGUOUS":              2,
 		"RATE_NOT_FOUND":               3,
 		"PROVIDER_ERROR":               4,
+		"INVALID_AMOUNT":               5,
 	}
 )
 
@@ -61,11 +129,11 @@ func (x AssetErrorCode) String() string {
 }
 
 func (AssetErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_price_v1_price_proto_enumTypes[0].Descriptor()
+	return file_price_v1_price_proto_enumTypes[1].Descriptor()
 }
 
 func (AssetErrorCode) Type() protoreflect.EnumType {
-	return &file_price_v1_price_proto_enumTypes[0]
+	return &file_price_v1_price_proto_enumTypes[1]
 }
 
 func (x AssetErrorCode) Number() protoreflect.EnumNumber {
@@ -74,15 +142,334 @@ func (x AssetErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AssetErrorCode.Descriptor instead.
 func (AssetErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{0}
+	return file_price_v1_price_proto_rawDescGZIP(), []int{1}
+}
+
+// Why RATE_NOT_FOUND was returned for a priced leg.
+type RateNotFoundReason int32
+
+const (
+	RateNotFoundReason_RATE_NOT_FOUND_REASON_UNSPECIFIED RateNotFoundReason = 0
+	// The transaction predates the first price the provider has for the coin.
+	RateNotFoundReason_RATE_NOT_FOUND_REASON_BEFORE_LISTING RateNotFoundReason = 1
+	// The provider returned no prices for the day.
+	RateNotFoundReason_RATE_NOT_FOUND_REASON_NO_PROVIDER_DATA RateNotFoundReason = 2
+)
+
+// Enum value maps for RateNotFoundReason.
+var (
+	RateNotFoundReason_name = map[int32]string{
+		0: "RATE_NOT_FOUND_REASON_UNSPECIFIED",
+		1: "RATE_NOT_FOUND_REASON_BEFORE_LISTING",
+		2: "RATE_NOT_FOUND_REASON_NO_PROVIDER_DATA",
+	}
+	RateNotFoundReason_value = map[string]int32{
+		"RATE_NOT_FOUND_REASON_UNSPECIFIED":      0,
+		"RATE_NOT_FOUND_REASON_BEFORE_LISTING":   1,
+		"RATE_NOT_FOUND_REASON_NO_PROVIDER_DATA": 2,
+	}
+)
+
+func (x RateNotFoundReason) Enum() *RateNotFoundReason {
+	p := new(RateNotFoundReason)
+	*p = x
+	return p
+}
+
+func (x RateNotFoundReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RateNotFoundReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_v1_price_proto_enumTypes[2].Descriptor()
+}
+
+func (RateNotFoundReason) Type() protoreflect.EnumType {
+	return &file_price_v1_price_proto_enumTypes[2]
+}
+
+func (x RateNotFoundReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RateNotFoundReason.Descriptor instead.
+func (RateNotFoundReason) EnumDescriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{2}
+}
+
+type PricePoint int32
+
+const (
+	PricePoint_PRICE_POINT_UNSPECIFIED   PricePoint = 0 // same as PRICE_POINT_BUCKET_OPEN
+	PricePoint_PRICE_POINT_BUCKET_OPEN   PricePoint = 1 // start of the bucket containing the tx
+	PricePoint_PRICE_POINT_BUCKET_CLOSE  PricePoint = 2 // start of the following bucket
+	PricePoint_PRICE_POINT_INTERPOLATED  PricePoint = 3 // linear between bucket open and close by tx time
+	PricePoint_PRICE_POINT_DAILY_AVERAGE PricePoint = 4 // mean of the tx day's buckets (UTC)
+)
+
+// Enum value maps for PricePoint.
+var (
+	PricePoint_name = map[int32]string{
+		0: "PRICE_POINT_UNSPECIFIED",
+		1: "PRICE_POINT_BUCKET_OPEN",
+		2: "PRICE_POINT_BUCKET_CLOSE",
+		3: "PRICE_POINT_INTERPOLATED",
+		4: "PRICE_POINT_DAILY_AVERAGE",
+	}
+	PricePoint_value = map[string]int32{
+		"PRICE_POINT_UNSPECIFIED":   0,
+		"PRICE_POINT_BUCKET_OPEN":   1,
+		"PRICE_POINT_BUCKET_CLOSE":  2,
+		"PRICE_POINT_INTERPOLATED":  3,
+		"PRICE_POINT_DAILY_AVERAGE": 4,
+	}
+)
+
+func (x PricePoint) Enum() *PricePoint {
+	p := new(PricePoint)
+	*p = x
+	return p
+}
+
+func (x PricePoint) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PricePoint) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_v1_price_proto_enumTypes[3].Descriptor()
+}
+
+func (PricePoint) Type() protoreflect.EnumType {
+	return &file_price_v1_price_proto_enumTypes[3]
+}
+
+func (x PricePoint) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PricePoint.Descriptor instead.
+func (PricePoint) EnumDescriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{3}
+}
+
+type TenantSymbolFormat int32
+
+const (
+	TenantSymbolFormat_TENANT_SYMBOL_FORMAT_UNSPECIFIED TenantSymbolFormat = 0 // same as TENANT_SYMBOL_FORMAT_CSV
+	// Header "source,symbol,coin_id,valid_from,valid_to"; bounds are RFC 3339 or YYYY-MM-DD, empty when open.
+	TenantSymbolFormat_TENANT_SYMBOL_FORMAT_CSV TenantSymbolFormat = 1
+	// Array of objects with the CSV columns as keys; valid_from and valid_to may be omitted.
+	TenantSymbolFormat_TENANT_SYMBOL_FORMAT_JSON TenantSymbolFormat = 2
+)
+
+// Enum value maps for TenantSymbolFormat.
+var (
+	TenantSymbolFormat_name = map[int32]string{
+		0: "TENANT_SYMBOL_FORMAT_UNSPECIFIED",
+		1: "TENANT_SYMBOL_FORMAT_CSV",
+		2: "TENANT_SYMBOL_FORMAT_JSON",
+	}
+	TenantSymbolFormat_value = map[string]int32{
+		"TENANT_SYMBOL_FORMAT_UNSPECIFIED": 0,
+		"TENANT_SYMBOL_FORMAT_CSV":         1,
+		"TENANT_SYMBOL_FORMAT_JSON":        2,
+	}
+)
+
+func (x TenantSymbolFormat) Enum() *TenantSymbolFormat {
+	p := new(TenantSymbolFormat)
+	*p = x
+	return p
+}
+
+func (x TenantSymbolFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TenantSymbolFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_v1_price_proto_enumTypes[4].Descriptor()
+}
+
+func (TenantSymbolFormat) Type() protoreflect.EnumType {
+	return &file_price_v1_price_proto_enumTypes[4]
+}
+
+func (x TenantSymbolFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TenantSymbolFormat.Descriptor instead.
+func (TenantSymbolFormat) EnumDescriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{4}
+}
+
+type TenantSymbolIssueCode int32
+
+const (
+	TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_CODE_UNSPECIFIED TenantSymbolIssueCode = 0
+	TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_INVALID          TenantSymbolIssueCode = 1 // malformed or incomplete row
+	TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_UNKNOWN_COIN     TenantSymbolIssueCode = 2 // coin ID not in the local coin catalog
+	TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_CONFLICT         TenantSymbolIssueCode = 3 // window overlaps a stored mapping or another row of the symbol
+)
+
+// Enum value maps for TenantSymbolIssueCode.
+var (
+	TenantSymbolIssueCode_name = map[int32]string{
+		0: "TENANT_SYMBOL_ISSUE_CODE_UNSPECIFIED",
+		1: "TENANT_SYMBOL_ISSUE_INVALID",
+		2: "TENANT_SYMBOL_ISSUE_UNKNOWN_COIN",
+		3: "TENANT_SYMBOL_ISSUE_CONFLICT",
+	}
+	TenantSymbolIssueCode_value = map[string]int32{
+		"TENANT_SYMBOL_ISSUE_CODE_UNSPECIFIED": 0,
+		"TENANT_SYMBOL_ISSUE_INVALID":          1,
+		"TENANT_SYMBOL_ISSUE_UNKNOWN_COIN":     2,
+		"TENANT_SYMBOL_ISSUE_CONFLICT":         3,
+	}
+)
+
+func (x TenantSymbolIssueCode) Enum() *TenantSymbolIssueCode {
+	p := new(TenantSymbolIssueCode)
+	*p = x
+	return p
+}
+
+func (x TenantSymbolIssueCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TenantSymbolIssueCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_v1_price_proto_enumTypes[5].Descriptor()
+}
+
+func (TenantSymbolIssueCode) Type() protoreflect.EnumType {
+	return &file_price_v1_price_proto_enumTypes[5]
+}
+
+func (x TenantSymbolIssueCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TenantSymbolIssueCode.Descriptor instead.
+func (TenantSymbolIssueCode) EnumDescriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{5}
+}
+
+type ValuationJobStatus int32
+
+const (
+	ValuationJobStatus_VALUATION_JOB_STATUS_UNSPECIFIED ValuationJobStatus = 0
+	ValuationJobStatus_VALUATION_JOB_STATUS_QUEUED      ValuationJobStatus = 1
+	ValuationJobStatus_VALUATION_JOB_STATUS_RUNNING     ValuationJobStatus = 2
+	ValuationJobStatus_VALUATION_JOB_STATUS_SUCCEEDED   ValuationJobStatus = 3
+	ValuationJobStatus_VALUATION_JOB_STATUS_FAILED      ValuationJobStatus = 4
+)
+
+// Enum value maps for ValuationJobStatus.
+var (
+	ValuationJobStatus_name = map[int32]string{
+		0: "VALUATION_JOB_STATUS_UNSPECIFIED",
+		1: "VALUATION_JOB_STATUS_QUEUED",
+		2: "VALUATION_JOB_STATUS_RUNNING",
+		3: "VALUATION_JOB_STATUS_SUCCEEDED",
+		4: "VALUATION_JOB_STATUS_FAILED",
+	}
+	ValuationJobStatus_value = map[string]int32{
+		"VALUATION_JOB_STATUS_UNSPECIFIED": 0,
+		"VALUATION_JOB_STATUS_QUEUED":      1,
+		"VALUATION_JOB_STATUS_RUNNING":     2,
+		"VALUATION_JOB_STATUS_SUCCEEDED":   3,
+		"VALUATION_JOB_STATUS_FAILED":      4,
+	}
+)
+
+func (x ValuationJobStatus) Enum() *ValuationJobStatus {
+	p := new(ValuationJobStatus)
+	*p = x
+	return p
+}
+
+func (x ValuationJobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValuationJobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_v1_price_proto_enumTypes[6].Descriptor()
+}
+
+func (ValuationJobStatus) Type() protoreflect.EnumType {
+	return &file_price_v1_price_proto_enumTypes[6]
+}
+
+func (x ValuationJobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValuationJobStatus.Descriptor instead.
+func (ValuationJobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{6}
+}
+
+type UnresolvedReason int32
+
+const (
+	UnresolvedReason_UNRESOLVED_REASON_UNSPECIFIED UnresolvedReason = 0
+	UnresolvedReason_UNRESOLVED_REASON_UNKNOWN     UnresolvedReason = 1 // no mapping or catalog coin matched
+	UnresolvedReason_UNRESOLVED_REASON_AMBIGUOUS   UnresolvedReason = 2 // several coins or pair splits matched
+)
+
+// Enum value maps for UnresolvedReason.
+var (
+	UnresolvedReason_name = map[int32]string{
+		0: "UNRESOLVED_REASON_UNSPECIFIED",
+		1: "UNRESOLVED_REASON_UNKNOWN",
+		2: "UNRESOLVED_REASON_AMBIGUOUS",
+	}
+	UnresolvedReason_value = map[string]int32{
+		"UNRESOLVED_REASON_UNSPECIFIED": 0,
+		"UNRESOLVED_REASON_UNKNOWN":     1,
+		"UNRESOLVED_REASON_AMBIGUOUS":   2,
+	}
+)
+
+func (x UnresolvedReason) Enum() *UnresolvedReason {
+	p := new(UnresolvedReason)
+	*p = x
+	return p
+}
+
+func (x UnresolvedReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UnresolvedReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_v1_price_proto_enumTypes[7].Descriptor()
+}
+
+func (UnresolvedReason) Type() protoreflect.EnumType {
+	return &file_price_v1_price_proto_enumTypes[7]
+}
+
+func (x UnresolvedReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UnresolvedReason.Descriptor instead.
+func (UnresolvedReason) EnumDescriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{7}
 }
 
 type MoneyLeg struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"` // decimal as string
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Amount string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"` // decimal as string
+	// On-chain assets: when contract_address is set the leg resolves by address, never by symbol.
+	// chain is the provider platform ID or an alias such as "eth" or "bsc"; it may be empty
+	// when the address is listed on one chain only.
+	Chain           string `protobuf:"bytes,3,opt,name=chain,proto3" json:"chain,omitempty"`
+	ContractAddress string `protobuf:"bytes,4,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *MoneyLeg) Reset() {
@@ -129,11 +516,36 @@ func (x *MoneyLeg) GetAmount() string {
 	return ""
 }
 
+func (x *MoneyLeg) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *MoneyLeg) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
 type FiatLeg struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Fiat          string                 `protobuf:"bytes,2,opt,name=fiat,proto3" json:"fiat,omitempty"` // decimal as string
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Fiat            string                 `protobuf:"bytes,2,opt,name=fiat,proto3" json:"fiat,omitempty"` // fiat price of one unit as decimal string, rounded by the fiat's rounding rule
+	Method          PricingMethod          `protobuf:"varint,3,opt,name=method,proto3,enum=price.v1.PricingMethod" json:"method,omitempty"`
+	LowerPrecision  bool                   `protobuf:"varint,4,opt,name=lower_precision,json=lowerPrecision,proto3" json:"lower_precision,omitempty"` // a neighbouring or coarser bucket was used
+	FiatUnrounded   string                 `protobuf:"bytes,5,opt,name=fiat_unrounded,json=fiatUnrounded,proto3" json:"fiat_unrounded,omitempty"`     // fiat price of one unit as decimal string, full precision
+	Provenance      *Provenance            `protobuf:"bytes,6,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	CanonicalSymbol string                 `protobuf:"bytes,7,opt,name=canonical_symbol,json=canonicalSymbol,proto3" json:"canonical_symbol,omitempty"` // symbol after the source's normalization rules; the base asset of a pair
+	QuoteSymbol     string                 `protobuf:"bytes,8,opt,name=quote_symbol,json=quoteSymbol,proto3" json:"quote_symbol,omitempty"`             // set when the symbol was a trading pair valued by its base asset
+	Consensus       bool                   `protobuf:"varint,9,opt,name=consensus,proto3" json:"consensus,omitempty"`                                   // the coin was taken from other tenants' mappings of the symbol
+	// Leg total (amount x unit price) as decimal string, rounded by the fiat's rounding rule.
+	// Empty when the leg's amount is missing or invalid; the tx then carries an INVALID_AMOUNT error.
+	Total          string `protobuf:"bytes,10,opt,name=total,proto3" json:"total,omitempty"`
+	TotalUnrounded string `protobuf:"bytes,11,opt,name=total_unrounded,json=totalUnrounded,proto3" json:"total_unrounded,omitempty"` // leg total as decimal string, full precision
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *FiatLeg) Reset() {
@@ -173,32 +585,2599 @@ func (x *FiatLeg) GetFiat() string {
 	return ""
 }
 
-type TxToValuate struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	TimeUtc       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time_utc,json=timeUtc,proto3" json:"time_utc,omitempty"`
-	InMoney       *MoneyLeg              `protobuf:"bytes,3,opt,name=in_money,json=inMoney,proto3,oneof" json:"in_money,omitempty"`
-	OutMoney      *MoneyLeg              `protobuf:"bytes,4,opt,name=out_money,json=outMoney,proto3,oneof" json:"out_money,omitempty"`
-	FeeMoney      *MoneyLeg              `protobuf:"bytes,5,opt,name=fee_money,json=feeMoney,proto3,oneof" json:"fee_money,omitempty"`
+func (x *FiatLeg) GetMethod() PricingMethod {
+	if x != nil {
+		return x.Method
+	}
+	return PricingMethod_PRICING_METHOD_UNSPECIFIED
+}
+
+func (x *FiatLeg) GetLowerPrecision() bool {
+	if x != nil {
+		return x.LowerPrecision
+	}
+	return false
+}
+
+func (x *FiatLeg) GetFiatUnrounded() string {
+	if x != nil {
+		return x.FiatUnrounded
+	}
+	return ""
+}
+
+func (x *FiatLeg) GetProvenance() *Provenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+func (x *FiatLeg) GetCanonicalSymbol() string {
+	if x != nil {
+		return x.CanonicalSymbol
+	}
+	return ""
+}
+
+func (x *FiatLeg) GetQuoteSymbol() string {
+	if x != nil {
+		return x.QuoteSymbol
+	}
+	return ""
+}
+
+func (x *FiatLeg) GetConsensus() bool {
+	if x != nil {
+		return x.Consensus
+	}
+	return false
+}
+
+func (x *FiatLeg) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *FiatLeg) GetTotalUnrounded() string {
+	if x != nil {
+		return x.TotalUnrounded
+	}
+	return ""
+}
+
+// Provenance tells which stored price and FX rate produced a leg, for audits.
+// Market fields are empty for pegged and fiat legs.
+type Provenance struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	BucketStartUtc     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=bucket_start_utc,json=bucketStartUtc,proto3" json:"bucket_start_utc,omitempty"`
+	GranularitySeconds int32                  `protobuf:"varint,2,opt,name=granularity_seconds,json=granularitySeconds,proto3" json:"granularity_seconds,omitempty"`
+	Provider           string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	PriceUsd           string                 `protobuf:"bytes,4,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"` // decimal as string, after interpolation/averaging and derivative ratio
+	FetchedAt          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	FxRate             string                 `protobuf:"bytes,6,opt,name=fx_rate,json=fxRate,proto3" json:"fx_rate,omitempty"`                              // decimal as string, USD (or peg/leg currency) -> requested fiat
+	FxEffectiveDate    string                 `protobuf:"bytes,7,opt,name=fx_effective_date,json=fxEffectiveDate,proto3" json:"fx_effective_date,omitempty"` // YYYY-MM-DD the FX rate was published for
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	mi := &file_price_v1_price_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Provenance) GetBucketStartUtc() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BucketStartUtc
+	}
+	return nil
+}
+
+func (x *Provenance) GetGranularitySeconds() int32 {
+	if x != nil {
+		return x.GranularitySeconds
+	}
+	return 0
+}
+
+func (x *Provenance) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Provenance) GetPriceUsd() string {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return ""
+}
+
+func (x *Provenance) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+func (x *Provenance) GetFxRate() string {
+	if x != nil {
+		return x.FxRate
+	}
+	return ""
+}
+
+func (x *Provenance) GetFxEffectiveDate() string {
+	if x != nil {
+		return x.FxEffectiveDate
+	}
+	return ""
+}
+
+type TxToValuate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	TimeUtc       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time_utc,json=timeUtc,proto3" json:"time_utc,omitempty"`
+	InMoney       *MoneyLeg              `protobuf:"bytes,3,opt,name=in_money,json=inMoney,proto3,oneof" json:"in_money,omitempty"`
+	OutMoney      *MoneyLeg              `protobuf:"bytes,4,opt,name=out_money,json=outMoney,proto3,oneof" json:"out_money,omitempty"`
+	FeeMoney      *MoneyLeg              `protobuf:"bytes,5,opt,name=fee_money,json=feeMoney,proto3,oneof" json:"fee_money,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxToValuate) Reset() {
+	*x = TxToValuate{}
+	mi := &file_price_v1_price_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxToValuate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxToValuate) ProtoMessage() {}
+
+func (x *TxToValuate) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxToValuate.ProtoReflect.Descriptor instead.
+func (*TxToValuate) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TxToValuate) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *TxToValuate) GetTimeUtc() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeUtc
+	}
+	return nil
+}
+
+func (x *TxToValuate) GetInMoney() *MoneyLeg {
+	if x != nil {
+		return x.InMoney
+	}
+	return nil
+}
+
+func (x *TxToValuate) GetOutMoney() *MoneyLeg {
+	if x != nil {
+		return x.OutMoney
+	}
+	return nil
+}
+
+func (x *TxToValuate) GetFeeMoney() *MoneyLeg {
+	if x != nil {
+		return x.FeeMoney
+	}
+	return nil
+}
+
+type CoinCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CoinId        string                 `protobuf:"bytes,1,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TenantCount   int32                  `protobuf:"varint,3,opt,name=tenant_count,json=tenantCount,proto3" json:"tenant_count,omitempty"` // ASSET_UNKNOWN suggestions: other tenants mapping the symbol to the coin, 0 for the catalog's only coin with the symbol
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinCandidate) Reset() {
+	*x = CoinCandidate{}
+	mi := &file_price_v1_price_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinCandidate) ProtoMessage() {}
+
+func (x *CoinCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinCandidate.ProtoReflect.Descriptor instead.
+func (*CoinCandidate) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CoinCandidate) GetCoinId() string {
+	if x != nil {
+		return x.CoinId
+	}
+	return ""
+}
+
+func (x *CoinCandidate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CoinCandidate) GetTenantCount() int32 {
+	if x != nil {
+		return x.TenantCount
+	}
+	return 0
+}
+
+type AssetError struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Code            AssetErrorCode         `protobuf:"varint,2,opt,name=code,proto3,enum=price.v1.AssetErrorCode" json:"code,omitempty"`
+	Message         string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Candidates      []*CoinCandidate       `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Reason          RateNotFoundReason     `protobuf:"varint,5,opt,name=reason,proto3,enum=price.v1.RateNotFoundReason" json:"reason,omitempty"`
+	CanonicalSymbol string                 `protobuf:"bytes,6,opt,name=canonical_symbol,json=canonicalSymbol,proto3" json:"canonical_symbol,omitempty"` // symbol after the source's normalization rules
+	// ASSET_AMBIGUOUS for a pair: every split whose base resolves. Splits guessed from a quote
+	// suffix are always returned here for confirmation, even when only one resolves.
+	PairSplits    []*PairSplit `protobuf:"bytes,7,rep,name=pair_splits,json=pairSplits,proto3" json:"pair_splits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetError) Reset() {
+	*x = AssetError{}
+	mi := &file_price_v1_price_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetError) ProtoMessage() {}
+
+func (x *AssetError) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetError.ProtoReflect.Descriptor instead.
+func (*AssetError) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AssetError) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AssetError) GetCode() AssetErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return AssetErrorCode_ASSET_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *AssetError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AssetError) GetCandidates() []*CoinCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *AssetError) GetReason() RateNotFoundReason {
+	if x != nil {
+		return x.Reason
+	}
+	return RateNotFoundReason_RATE_NOT_FOUND_REASON_UNSPECIFIED
+}
+
+func (x *AssetError) GetCanonicalSymbol() string {
+	if x != nil {
+		return x.CanonicalSymbol
+	}
+	return ""
+}
+
+func (x *AssetError) GetPairSplits() []*PairSplit {
+	if x != nil {
+		return x.PairSplits
+	}
+	return nil
+}
+
+// One reading of a trading pair symbol, e.g. BTC/USDT for "BTCUSDT".
+type PairSplit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Quote         string                 `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	Guessed       bool                   `protobuf:"varint,3,opt,name=guessed,proto3" json:"guessed,omitempty"` // inferred from a quote suffix, not a listed pair or an explicit separator
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairSplit) Reset() {
+	*x = PairSplit{}
+	mi := &file_price_v1_price_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairSplit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairSplit) ProtoMessage() {}
+
+func (x *PairSplit) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairSplit.ProtoReflect.Descriptor instead.
+func (*PairSplit) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PairSplit) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *PairSplit) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *PairSplit) GetGuessed() bool {
+	if x != nil {
+		return x.Guessed
+	}
+	return false
+}
+
+type ValuatedTx struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	InFiat        *FiatLeg               `protobuf:"bytes,2,opt,name=in_fiat,json=inFiat,proto3,oneof" json:"in_fiat,omitempty"`
+	OutFiat       *FiatLeg               `protobuf:"bytes,3,opt,name=out_fiat,json=outFiat,proto3,oneof" json:"out_fiat,omitempty"`
+	FeeFiat       *FiatLeg               `protobuf:"bytes,4,opt,name=fee_fiat,json=feeFiat,proto3,oneof" json:"fee_fiat,omitempty"`
+	Errors        []*AssetError          `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValuatedTx) Reset() {
+	*x = ValuatedTx{}
+	mi := &file_price_v1_price_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValuatedTx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuatedTx) ProtoMessage() {}
+
+func (x *ValuatedTx) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuatedTx.ProtoReflect.Descriptor instead.
+func (*ValuatedTx) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ValuatedTx) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *ValuatedTx) GetInFiat() *FiatLeg {
+	if x != nil {
+		return x.InFiat
+	}
+	return nil
+}
+
+func (x *ValuatedTx) GetOutFiat() *FiatLeg {
+	if x != nil {
+		return x.OutFiat
+	}
+	return nil
+}
+
+func (x *ValuatedTx) GetFeeFiat() *FiatLeg {
+	if x != nil {
+		return x.FeeFiat
+	}
+	return nil
+}
+
+func (x *ValuatedTx) GetErrors() []*AssetError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type LookupPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Accept the nearest stored bucket at or before the wanted one instead of refetching.
+	Nearest bool `protobuf:"varint,1,opt,name=nearest,proto3" json:"nearest,omitempty"`
+	// How much earlier than the wanted bucket the nearest one may start.
+	Tolerance *durationpb.Duration `protobuf:"bytes,2,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	// Accept rows coarser than the desired granularity (e.g. the daily row) instead of refetching;
+	// a coarser bucket answers every time it contains, whatever the tolerance.
+	AcceptCoarser bool `protobuf:"varint,3,opt,name=accept_coarser,json=acceptCoarser,proto3" json:"accept_coarser,omitempty"`
+	// Answer with the prices stored at this time, without calling the provider:
+	// "which price would we have used on that date". Unset reads the latest prices.
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupPolicy) Reset() {
+	*x = LookupPolicy{}
+	mi := &file_price_v1_price_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupPolicy) ProtoMessage() {}
+
+func (x *LookupPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupPolicy.ProtoReflect.Descriptor instead.
+func (*LookupPolicy) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LookupPolicy) GetNearest() bool {
+	if x != nil {
+		return x.Nearest
+	}
+	return false
+}
+
+func (x *LookupPolicy) GetTolerance() *durationpb.Duration {
+	if x != nil {
+		return x.Tolerance
+	}
+	return nil
+}
+
+func (x *LookupPolicy) GetAcceptCoarser() bool {
+	if x != nil {
+		return x.AcceptCoarser
+	}
+	return false
+}
+
+func (x *LookupPolicy) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type ValuateTransactionsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Source   string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// ISO-4217 code; only currencies with an FX source (USD, RUB, KZT) are accepted,
+	// others fail with INVALID_ARGUMENT listing the supported ones.
+	FiatCurrency string         `protobuf:"bytes,3,opt,name=fiat_currency,json=fiatCurrency,proto3" json:"fiat_currency,omitempty"`
+	Transactions []*TxToValuate `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	PricePoint   PricePoint     `protobuf:"varint,5,opt,name=price_point,json=pricePoint,proto3,enum=price.v1.PricePoint" json:"price_point,omitempty"`
+	Lookup       *LookupPolicy  `protobuf:"bytes,6,opt,name=lookup,proto3" json:"lookup,omitempty"`
+	// Optional. Values and the coin each leg resolved to are stored under this ID on first use and
+	// returned unchanged afterwards, so a filed report can be reproduced even after symbol mappings
+	// change. IDs are scoped to the tenant. Reusing an ID with other fiat or price settings fails.
+	SnapshotId    string `protobuf:"bytes,7,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValuateTransactionsRequest) Reset() {
+	*x = ValuateTransactionsRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValuateTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuateTransactionsRequest) ProtoMessage() {}
+
+func (x *ValuateTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuateTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ValuateTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValuateTransactionsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ValuateTransactionsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ValuateTransactionsRequest) GetFiatCurrency() string {
+	if x != nil {
+		return x.FiatCurrency
+	}
+	return ""
+}
+
+func (x *ValuateTransactionsRequest) GetTransactions() []*TxToValuate {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ValuateTransactionsRequest) GetPricePoint() PricePoint {
+	if x != nil {
+		return x.PricePoint
+	}
+	return PricePoint_PRICE_POINT_UNSPECIFIED
+}
+
+func (x *ValuateTransactionsRequest) GetLookup() *LookupPolicy {
+	if x != nil {
+		return x.Lookup
+	}
+	return nil
+}
+
+func (x *ValuateTransactionsRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type ValuateTransactionsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Transactions []*ValuatedTx          `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Streaming only: the chunk failed as a whole, e.g. it changed the stream settings or a
+	// transaction has no time_utc; transactions is then empty.
+	ChunkError    *ChunkError `protobuf:"bytes,2,opt,name=chunk_error,json=chunkError,proto3" json:"chunk_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValuateTransactionsResponse) Reset() {
+	*x = ValuateTransactionsResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValuateTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuateTransactionsResponse) ProtoMessage() {}
+
+func (x *ValuateTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuateTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ValuateTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValuateTransactionsResponse) GetTransactions() []*ValuatedTx {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ValuateTransactionsResponse) GetChunkError() *ChunkError {
+	if x != nil {
+		return x.ChunkError
+	}
+	return nil
+}
+
+type ChunkError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // gRPC status code the unary RPC would have failed with
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChunkError) Reset() {
+	*x = ChunkError{}
+	mi := &file_price_v1_price_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkError) ProtoMessage() {}
+
+func (x *ChunkError) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkError.ProtoReflect.Descriptor instead.
+func (*ChunkError) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChunkError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ChunkError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UpsertTenantSymbolRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Source   string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Symbol   string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	CoinId   string                 `protobuf:"bytes,4,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
+	// Optional validity window [valid_from, valid_to) for tickers that changed meaning over time;
+	// unset bounds are open. Legs resolve with the mapping valid at their time_utc.
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertTenantSymbolRequest) Reset() {
+	*x = UpsertTenantSymbolRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertTenantSymbolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertTenantSymbolRequest) ProtoMessage() {}
+
+func (x *UpsertTenantSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertTenantSymbolRequest.ProtoReflect.Descriptor instead.
+func (*UpsertTenantSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpsertTenantSymbolRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UpsertTenantSymbolRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *UpsertTenantSymbolRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *UpsertTenantSymbolRequest) GetCoinId() string {
+	if x != nil {
+		return x.CoinId
+	}
+	return ""
+}
+
+func (x *UpsertTenantSymbolRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *UpsertTenantSymbolRequest) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+type UpsertTenantSymbolResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertTenantSymbolResponse) Reset() {
+	*x = UpsertTenantSymbolResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertTenantSymbolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertTenantSymbolResponse) ProtoMessage() {}
+
+func (x *UpsertTenantSymbolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertTenantSymbolResponse.ProtoReflect.Descriptor instead.
+func (*UpsertTenantSymbolResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{13}
+}
+
+type TenantSymbolIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // mapping position in the payload, from 1, header excluded
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	CoinId        string                 `protobuf:"bytes,4,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
+	Code          TenantSymbolIssueCode  `protobuf:"varint,5,opt,name=code,proto3,enum=price.v1.TenantSymbolIssueCode" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantSymbolIssue) Reset() {
+	*x = TenantSymbolIssue{}
+	mi := &file_price_v1_price_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantSymbolIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantSymbolIssue) ProtoMessage() {}
+
+func (x *TenantSymbolIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantSymbolIssue.ProtoReflect.Descriptor instead.
+func (*TenantSymbolIssue) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TenantSymbolIssue) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *TenantSymbolIssue) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TenantSymbolIssue) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TenantSymbolIssue) GetCoinId() string {
+	if x != nil {
+		return x.CoinId
+	}
+	return ""
+}
+
+func (x *TenantSymbolIssue) GetCode() TenantSymbolIssueCode {
+	if x != nil {
+		return x.Code
+	}
+	return TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_CODE_UNSPECIFIED
+}
+
+func (x *TenantSymbolIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportTenantSymbolsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Format        TenantSymbolFormat     `protobuf:"varint,2,opt,name=format,proto3,enum=price.v1.TenantSymbolFormat" json:"format,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // only check the payload and report issues
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTenantSymbolsRequest) Reset() {
+	*x = ImportTenantSymbolsRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTenantSymbolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTenantSymbolsRequest) ProtoMessage() {}
+
+func (x *ImportTenantSymbolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTenantSymbolsRequest.ProtoReflect.Descriptor instead.
+func (*ImportTenantSymbolsRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportTenantSymbolsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ImportTenantSymbolsRequest) GetFormat() TenantSymbolFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TenantSymbolFormat_TENANT_SYMBOL_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportTenantSymbolsRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportTenantSymbolsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportTenantSymbolsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          int32                  `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Issues        []*TenantSymbolIssue   `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"`    // sorted by row, at most 1000
+	Applied       bool                   `protobuf:"varint,3,opt,name=applied,proto3" json:"applied,omitempty"` // false for dry runs and whenever there are issues
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTenantSymbolsResponse) Reset() {
+	*x = ImportTenantSymbolsResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTenantSymbolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTenantSymbolsResponse) ProtoMessage() {}
+
+func (x *ImportTenantSymbolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTenantSymbolsResponse.ProtoReflect.Descriptor instead.
+func (*ImportTenantSymbolsResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImportTenantSymbolsResponse) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ImportTenantSymbolsResponse) GetIssues() []*TenantSymbolIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *ImportTenantSymbolsResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type ExportTenantSymbolsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"` // empty exports every source
+	Format        TenantSymbolFormat     `protobuf:"varint,3,opt,name=format,proto3,enum=price.v1.TenantSymbolFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTenantSymbolsRequest) Reset() {
+	*x = ExportTenantSymbolsRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTenantSymbolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTenantSymbolsRequest) ProtoMessage() {}
+
+func (x *ExportTenantSymbolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTenantSymbolsRequest.ProtoReflect.Descriptor instead.
+func (*ExportTenantSymbolsRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExportTenantSymbolsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ExportTenantSymbolsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ExportTenantSymbolsRequest) GetFormat() TenantSymbolFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TenantSymbolFormat_TENANT_SYMBOL_FORMAT_UNSPECIFIED
+}
+
+type ExportTenantSymbolsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Rows          int32                  `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTenantSymbolsResponse) Reset() {
+	*x = ExportTenantSymbolsResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTenantSymbolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTenantSymbolsResponse) ProtoMessage() {}
+
+func (x *ExportTenantSymbolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTenantSymbolsResponse.ProtoReflect.Descriptor instead.
+func (*ExportTenantSymbolsResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportTenantSymbolsResponse) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ExportTenantSymbolsResponse) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+type ValuationJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        ValuationJobStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=price.v1.ValuationJobStatus" json:"status,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Processed     int32                  `protobuf:"varint,4,opt,name=processed,proto3" json:"processed,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"` // set when status is FAILED
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValuationJob) Reset() {
+	*x = ValuationJob{}
+	mi := &file_price_v1_price_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValuationJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuationJob) ProtoMessage() {}
+
+func (x *ValuationJob) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuationJob.ProtoReflect.Descriptor instead.
+func (*ValuationJob) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ValuationJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ValuationJob) GetStatus() ValuationJobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ValuationJobStatus_VALUATION_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *ValuationJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ValuationJob) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *ValuationJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValuationJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ValuationJob) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ValuationJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ValuationJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type SubmitValuationJobRequest struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Request       *ValuateTransactionsRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitValuationJobRequest) Reset() {
+	*x = SubmitValuationJobRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitValuationJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitValuationJobRequest) ProtoMessage() {}
+
+func (x *SubmitValuationJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitValuationJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitValuationJobRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubmitValuationJobRequest) GetRequest() *ValuateTransactionsRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type SubmitValuationJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *ValuationJob          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitValuationJobResponse) Reset() {
+	*x = SubmitValuationJobResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitValuationJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitValuationJobResponse) ProtoMessage() {}
+
+func (x *SubmitValuationJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitValuationJobResponse.ProtoReflect.Descriptor instead.
+func (*SubmitValuationJobResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubmitValuationJobResponse) GetJob() *ValuationJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type GetValuationJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // the tenant the job was submitted for; empty for jobs without a tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValuationJobRequest) Reset() {
+	*x = GetValuationJobRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValuationJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValuationJobRequest) ProtoMessage() {}
+
+func (x *GetValuationJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValuationJobRequest.ProtoReflect.Descriptor instead.
+func (*GetValuationJobRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetValuationJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetValuationJobRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type GetValuationJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *ValuationJob          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValuationJobResponse) Reset() {
+	*x = GetValuationJobResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValuationJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValuationJobResponse) ProtoMessage() {}
+
+func (x *GetValuationJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValuationJobResponse.ProtoReflect.Descriptor instead.
+func (*GetValuationJobResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetValuationJobResponse) GetJob() *ValuationJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type ListValuationJobResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 500, max 5000
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	TenantId      string                 `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // the tenant the job was submitted for; empty for jobs without a tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListValuationJobResultsRequest) Reset() {
+	*x = ListValuationJobResultsRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListValuationJobResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListValuationJobResultsRequest) ProtoMessage() {}
+
+func (x *ListValuationJobResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListValuationJobResultsRequest.ProtoReflect.Descriptor instead.
+func (*ListValuationJobResultsRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListValuationJobResultsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ListValuationJobResultsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListValuationJobResultsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListValuationJobResultsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ListValuationJobResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*ValuatedTx          `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty when no more results are available yet
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListValuationJobResultsResponse) Reset() {
+	*x = ListValuationJobResultsResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListValuationJobResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListValuationJobResultsResponse) ProtoMessage() {}
+
+func (x *ListValuationJobResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListValuationJobResultsResponse.ProtoReflect.Descriptor instead.
+func (*ListValuationJobResultsResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListValuationJobResultsResponse) GetTransactions() []*ValuatedTx {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListValuationJobResultsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// CustomAsset is a tenant-defined asset priced from the tenant's own price series.
+type CustomAsset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomAsset) Reset() {
+	*x = CustomAsset{}
+	mi := &file_price_v1_price_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomAsset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomAsset) ProtoMessage() {}
+
+func (x *CustomAsset) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomAsset.ProtoReflect.Descriptor instead.
+func (*CustomAsset) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CustomAsset) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *CustomAsset) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CustomAsset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomAsset) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CustomAsset) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// A custom asset price holds from time_utc until the asset's next point.
+type CustomPricePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeUtc       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time_utc,json=timeUtc,proto3" json:"time_utc,omitempty"`
+	Price         string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`       // decimal as string
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"` // ISO-4217 code the price is quoted in
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomPricePoint) Reset() {
+	*x = CustomPricePoint{}
+	mi := &file_price_v1_price_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomPricePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomPricePoint) ProtoMessage() {}
+
+func (x *CustomPricePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomPricePoint.ProtoReflect.Descriptor instead.
+func (*CustomPricePoint) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CustomPricePoint) GetTimeUtc() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimeUtc
+	}
+	return nil
+}
+
+func (x *CustomPricePoint) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *CustomPricePoint) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type UpsertCustomAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // defaults to the symbol
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertCustomAssetRequest) Reset() {
+	*x = UpsertCustomAssetRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertCustomAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertCustomAssetRequest) ProtoMessage() {}
+
+func (x *UpsertCustomAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertCustomAssetRequest.ProtoReflect.Descriptor instead.
+func (*UpsertCustomAssetRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpsertCustomAssetRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UpsertCustomAssetRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *UpsertCustomAssetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpsertCustomAssetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *CustomAsset           `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertCustomAssetResponse) Reset() {
+	*x = UpsertCustomAssetResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertCustomAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertCustomAssetResponse) ProtoMessage() {}
+
+func (x *UpsertCustomAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertCustomAssetResponse.ProtoReflect.Descriptor instead.
+func (*UpsertCustomAssetResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpsertCustomAssetResponse) GetAsset() *CustomAsset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type ListCustomAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomAssetsRequest) Reset() {
+	*x = ListCustomAssetsRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomAssetsRequest) ProtoMessage() {}
+
+func (x *ListCustomAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListCustomAssetsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ListCustomAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*CustomAsset         `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomAssetsResponse) Reset() {
+	*x = ListCustomAssetsResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomAssetsResponse) ProtoMessage() {}
+
+func (x *ListCustomAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListCustomAssetsResponse) GetAssets() []*CustomAsset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+type DeleteCustomAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AssetId       string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomAssetRequest) Reset() {
+	*x = DeleteCustomAssetRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomAssetRequest) ProtoMessage() {}
+
+func (x *DeleteCustomAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomAssetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomAssetRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteCustomAssetRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DeleteCustomAssetRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+type DeleteCustomAssetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomAssetResponse) Reset() {
+	*x = DeleteCustomAssetResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomAssetResponse) ProtoMessage() {}
+
+func (x *DeleteCustomAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomAssetResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCustomAssetResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{33}
+}
+
+type UpsertCustomAssetPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AssetId       string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Prices        []*CustomPricePoint    `protobuf:"bytes,3,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertCustomAssetPricesRequest) Reset() {
+	*x = UpsertCustomAssetPricesRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertCustomAssetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertCustomAssetPricesRequest) ProtoMessage() {}
+
+func (x *UpsertCustomAssetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertCustomAssetPricesRequest.ProtoReflect.Descriptor instead.
+func (*UpsertCustomAssetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpsertCustomAssetPricesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UpsertCustomAssetPricesRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *UpsertCustomAssetPricesRequest) GetPrices() []*CustomPricePoint {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type UpsertCustomAssetPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Upserted      int32                  `protobuf:"varint,1,opt,name=upserted,proto3" json:"upserted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertCustomAssetPricesResponse) Reset() {
+	*x = UpsertCustomAssetPricesResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertCustomAssetPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertCustomAssetPricesResponse) ProtoMessage() {}
+
+func (x *UpsertCustomAssetPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertCustomAssetPricesResponse.ProtoReflect.Descriptor instead.
+func (*UpsertCustomAssetPricesResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpsertCustomAssetPricesResponse) GetUpserted() int32 {
+	if x != nil {
+		return x.Upserted
+	}
+	return 0
+}
+
+type ListCustomAssetPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AssetId       string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"` // unset: from the first point
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`     // unset: up to the last point
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomAssetPricesRequest) Reset() {
+	*x = ListCustomAssetPricesRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomAssetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomAssetPricesRequest) ProtoMessage() {}
+
+func (x *ListCustomAssetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomAssetPricesRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomAssetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListCustomAssetPricesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListCustomAssetPricesRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *ListCustomAssetPricesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListCustomAssetPricesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type ListCustomAssetPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*CustomPricePoint    `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomAssetPricesResponse) Reset() {
+	*x = ListCustomAssetPricesResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomAssetPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomAssetPricesResponse) ProtoMessage() {}
+
+func (x *ListCustomAssetPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomAssetPricesResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomAssetPricesResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListCustomAssetPricesResponse) GetPrices() []*CustomPricePoint {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type UploadCustomAssetPricesCsvRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Csv           []byte                 `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadCustomAssetPricesCsvRequest) Reset() {
+	*x = UploadCustomAssetPricesCsvRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadCustomAssetPricesCsvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadCustomAssetPricesCsvRequest) ProtoMessage() {}
+
+func (x *UploadCustomAssetPricesCsvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadCustomAssetPricesCsvRequest.ProtoReflect.Descriptor instead.
+func (*UploadCustomAssetPricesCsvRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UploadCustomAssetPricesCsvRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UploadCustomAssetPricesCsvRequest) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+type UploadCustomAssetPricesCsvResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          int32                  `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Assets        int32                  `protobuf:"varint,2,opt,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadCustomAssetPricesCsvResponse) Reset() {
+	*x = UploadCustomAssetPricesCsvResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadCustomAssetPricesCsvResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadCustomAssetPricesCsvResponse) ProtoMessage() {}
+
+func (x *UploadCustomAssetPricesCsvResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadCustomAssetPricesCsvResponse.ProtoReflect.Descriptor instead.
+func (*UploadCustomAssetPricesCsvResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UploadCustomAssetPricesCsvResponse) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *UploadCustomAssetPricesCsvResponse) GetAssets() int32 {
+	if x != nil {
+		return x.Assets
+	}
+	return 0
+}
+
+type ReloadCoinMapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadCoinMapRequest) Reset() {
+	*x = ReloadCoinMapRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadCoinMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadCoinMapRequest) ProtoMessage() {}
+
+func (x *ReloadCoinMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadCoinMapRequest.ProtoReflect.Descriptor instead.
+func (*ReloadCoinMapRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{40}
+}
+
+type ReloadCoinMapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coins         int32                  `protobuf:"varint,1,opt,name=coins,proto3" json:"coins,omitempty"` // symbols in the loaded map
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadCoinMapResponse) Reset() {
+	*x = ReloadCoinMapResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadCoinMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadCoinMapResponse) ProtoMessage() {}
+
+func (x *ReloadCoinMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadCoinMapResponse.ProtoReflect.Descriptor instead.
+func (*ReloadCoinMapResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReloadCoinMapResponse) GetCoins() int32 {
+	if x != nil {
+		return x.Coins
+	}
+	return 0
+}
+
+// Coin is a provider coin from the local catalog.
+type Coin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CoinId        string                 `protobuf:"bytes,1,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Platforms     map[string]string      `protobuf:"bytes,4,rep,name=platforms,proto3" json:"platforms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // platform ID -> contract address
+	FirstSeen     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Delisted      bool                   `protobuf:"varint,7,opt,name=delisted,proto3" json:"delisted,omitempty"` // no longer listed by the provider
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coin) Reset() {
+	*x = Coin{}
+	mi := &file_price_v1_price_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coin) ProtoMessage() {}
+
+func (x *Coin) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coin.ProtoReflect.Descriptor instead.
+func (*Coin) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Coin) GetCoinId() string {
+	if x != nil {
+		return x.CoinId
+	}
+	return ""
+}
+
+func (x *Coin) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Coin) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Coin) GetPlatforms() map[string]string {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *Coin) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *Coin) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *Coin) GetDelisted() bool {
+	if x != nil {
+		return x.Delisted
+	}
+	return false
+}
+
+type SearchCoinsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // default 20, max 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxToValuate) Reset() {
-	*x = TxToValuate{}
-	mi := &file_price_v1_price_proto_msgTypes[2]
+func (x *SearchCoinsRequest) Reset() {
+	*x = SearchCoinsRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxToValuate) String() string {
+func (x *SearchCoinsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxToValuate) ProtoMessage() {}
+func (*SearchCoinsRequest) ProtoMessage() {}
 
-func (x *TxToValuate) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[2]
+func (x *SearchCoinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -209,69 +3188,91 @@ func (x *TxToValuate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxToValuate.ProtoReflect.Descriptor instead.
-func (*TxToValuate) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use SearchCoinsRequest.ProtoReflect.Descriptor instead.
+func (*SearchCoinsRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *TxToValuate) GetTxId() string {
+func (x *SearchCoinsRequest) GetQuery() string {
 	if x != nil {
-		return x.TxId
+		return x.Query
 	}
 	return ""
 }
 
-func (x *TxToValuate) GetTimeUtc() *timestamppb.Timestamp {
+func (x *SearchCoinsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.TimeUtc
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *TxToValuate) GetInMoney() *MoneyLeg {
-	if x != nil {
-		return x.InMoney
-	}
-	return nil
+type SearchCoinsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coins         []*Coin                `protobuf:"bytes,1,rep,name=coins,proto3" json:"coins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxToValuate) GetOutMoney() *MoneyLeg {
+func (x *SearchCoinsResponse) Reset() {
+	*x = SearchCoinsResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCoinsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCoinsResponse) ProtoMessage() {}
+
+func (x *SearchCoinsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[44]
 	if x != nil {
-		return x.OutMoney
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TxToValuate) GetFeeMoney() *MoneyLeg {
+// Deprecated: Use SearchCoinsResponse.ProtoReflect.Descriptor instead.
+func (*SearchCoinsResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SearchCoinsResponse) GetCoins() []*Coin {
 	if x != nil {
-		return x.FeeMoney
+		return x.Coins
 	}
 	return nil
 }
 
-type CoinCandidate struct {
+type GetCoinRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CoinId        string                 `protobuf:"bytes,1,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoinCandidate) Reset() {
-	*x = CoinCandidate{}
-	mi := &file_price_v1_price_proto_msgTypes[3]
+func (x *GetCoinRequest) Reset() {
+	*x = GetCoinRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoinCandidate) String() string {
+func (x *GetCoinRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoinCandidate) ProtoMessage() {}
+func (*GetCoinRequest) ProtoMessage() {}
 
-func (x *CoinCandidate) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[3]
+func (x *GetCoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,50 +3283,40 @@ func (x *CoinCandidate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoinCandidate.ProtoReflect.Descriptor instead.
-func (*CoinCandidate) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetCoinRequest.ProtoReflect.Descriptor instead.
+func (*GetCoinRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *CoinCandidate) GetCoinId() string {
+func (x *GetCoinRequest) GetCoinId() string {
 	if x != nil {
 		return x.CoinId
 	}
 	return ""
 }
 
-func (x *CoinCandidate) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-type AssetError struct {
+type GetCoinResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Code          AssetErrorCode         `protobuf:"varint,2,opt,name=code,proto3,enum=price.v1.AssetErrorCode" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Candidates    []*CoinCandidate       `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Coin          *Coin                  `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssetError) Reset() {
-	*x = AssetError{}
-	mi := &file_price_v1_price_proto_msgTypes[4]
+func (x *GetCoinResponse) Reset() {
+	*x = GetCoinResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssetError) String() string {
+func (x *GetCoinResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssetError) ProtoMessage() {}
+func (*GetCoinResponse) ProtoMessage() {}
 
-func (x *AssetError) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[4]
+func (x *GetCoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -336,65 +3327,48 @@ func (x *AssetError) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssetError.ProtoReflect.Descriptor instead.
-func (*AssetError) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *AssetError) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
-}
-
-func (x *AssetError) GetCode() AssetErrorCode {
-	if x != nil {
-		return x.Code
-	}
-	return AssetErrorCode_ASSET_ERROR_CODE_UNSPECIFIED
-}
-
-func (x *AssetError) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use GetCoinResponse.ProtoReflect.Descriptor instead.
+func (*GetCoinResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *AssetError) GetCandidates() []*CoinCandidate {
+func (x *GetCoinResponse) GetCoin() *Coin {
 	if x != nil {
-		return x.Candidates
+		return x.Coin
 	}
 	return nil
 }
 
-type ValuatedTx struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	InFiat        *FiatLeg               `protobuf:"bytes,2,opt,name=in_fiat,json=inFiat,proto3,oneof" json:"in_fiat,omitempty"`
-	OutFiat       *FiatLeg               `protobuf:"bytes,3,opt,name=out_fiat,json=outFiat,proto3,oneof" json:"out_fiat,omitempty"`
-	FeeFiat       *FiatLeg               `protobuf:"bytes,4,opt,name=fee_fiat,json=feeFiat,proto3,oneof" json:"fee_fiat,omitempty"`
-	Errors        []*AssetError          `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type UnresolvedSymbol struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TenantId        string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Source          string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Symbol          string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	CanonicalSymbol string                 `protobuf:"bytes,4,opt,name=canonical_symbol,json=canonicalSymbol,proto3" json:"canonical_symbol,omitempty"`
+	Reason          UnresolvedReason       `protobuf:"varint,5,opt,name=reason,proto3,enum=price.v1.UnresolvedReason" json:"reason,omitempty"`
+	Message         string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`          // last resolution error
+	Occurrences     int64                  `protobuf:"varint,7,opt,name=occurrences,proto3" json:"occurrences,omitempty"` // legs that failed on this symbol
+	FirstSeen       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ValuatedTx) Reset() {
-	*x = ValuatedTx{}
-	mi := &file_price_v1_price_proto_msgTypes[5]
+func (x *UnresolvedSymbol) Reset() {
+	*x = UnresolvedSymbol{}
+	mi := &file_price_v1_price_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValuatedTx) String() string {
+func (x *UnresolvedSymbol) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValuatedTx) ProtoMessage() {}
+func (*UnresolvedSymbol) ProtoMessage() {}
 
-func (x *ValuatedTx) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[5]
+func (x *UnresolvedSymbol) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,71 +3379,99 @@ func (x *ValuatedTx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValuatedTx.ProtoReflect.Descriptor instead.
-func (*ValuatedTx) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UnresolvedSymbol.ProtoReflect.Descriptor instead.
+func (*UnresolvedSymbol) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *ValuatedTx) GetTxId() string {
+func (x *UnresolvedSymbol) GetTenantId() string {
 	if x != nil {
-		return x.TxId
+		return x.TenantId
 	}
 	return ""
 }
 
-func (x *ValuatedTx) GetInFiat() *FiatLeg {
+func (x *UnresolvedSymbol) GetSource() string {
 	if x != nil {
-		return x.InFiat
+		return x.Source
 	}
-	return nil
+	return ""
 }
 
-func (x *ValuatedTx) GetOutFiat() *FiatLeg {
+func (x *UnresolvedSymbol) GetSymbol() string {
 	if x != nil {
-		return x.OutFiat
+		return x.Symbol
 	}
-	return nil
+	return ""
 }
 
-func (x *ValuatedTx) GetFeeFiat() *FiatLeg {
+func (x *UnresolvedSymbol) GetCanonicalSymbol() string {
 	if x != nil {
-		return x.FeeFiat
+		return x.CanonicalSymbol
+	}
+	return ""
+}
+
+func (x *UnresolvedSymbol) GetReason() UnresolvedReason {
+	if x != nil {
+		return x.Reason
+	}
+	return UnresolvedReason_UNRESOLVED_REASON_UNSPECIFIED
+}
+
+func (x *UnresolvedSymbol) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UnresolvedSymbol) GetOccurrences() int64 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+func (x *UnresolvedSymbol) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
 	}
 	return nil
 }
 
-func (x *ValuatedTx) GetErrors() []*AssetError {
+func (x *UnresolvedSymbol) GetLastSeen() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Errors
+		return x.LastSeen
 	}
 	return nil
 }
 
-type ValuateTransactionsRequest struct {
+type ListUnresolvedSymbolsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
-	FiatCurrency  string                 `protobuf:"bytes,3,opt,name=fiat_currency,json=fiatCurrency,proto3" json:"fiat_currency,omitempty"`
-	Transactions  []*TxToValuate         `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`  // empty: all tenants
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                      // empty: all sources
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 100, max 1000
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValuateTransactionsRequest) Reset() {
-	*x = ValuateTransactionsRequest{}
-	mi := &file_price_v1_price_proto_msgTypes[6]
+func (x *ListUnresolvedSymbolsRequest) Reset() {
+	*x = ListUnresolvedSymbolsRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValuateTransactionsRequest) String() string {
+func (x *ListUnresolvedSymbolsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValuateTransactionsRequest) ProtoMessage() {}
+func (*ListUnresolvedSymbolsRequest) ProtoMessage() {}
 
-func (x *ValuateTransactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[6]
+func (x *ListUnresolvedSymbolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -480,61 +3482,62 @@ func (x *ValuateTransactionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValuateTransactionsRequest.ProtoReflect.Descriptor instead.
-func (*ValuateTransactionsRequest) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListUnresolvedSymbolsRequest.ProtoReflect.Descriptor instead.
+func (*ListUnresolvedSymbolsRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ValuateTransactionsRequest) GetTenantId() string {
+func (x *ListUnresolvedSymbolsRequest) GetTenantId() string {
 	if x != nil {
 		return x.TenantId
 	}
 	return ""
 }
 
-func (x *ValuateTransactionsRequest) GetSource() string {
+func (x *ListUnresolvedSymbolsRequest) GetSource() string {
 	if x != nil {
 		return x.Source
 	}
 	return ""
 }
 
-func (x *ValuateTransactionsRequest) GetFiatCurrency() string {
+func (x *ListUnresolvedSymbolsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.FiatCurrency
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *ValuateTransactionsRequest) GetTransactions() []*TxToValuate {
+func (x *ListUnresolvedSymbolsRequest) GetPageToken() string {
 	if x != nil {
-		return x.Transactions
+		return x.PageToken
 	}
-	return nil
+	return ""
 }
 
-type ValuateTransactionsResponse struct {
+type ListUnresolvedSymbolsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transactions  []*ValuatedTx          `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Symbols       []*UnresolvedSymbol    `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValuateTransactionsResponse) Reset() {
-	*x = ValuateTransactionsResponse{}
-	mi := &file_price_v1_price_proto_msgTypes[7]
+func (x *ListUnresolvedSymbolsResponse) Reset() {
+	*x = ListUnresolvedSymbolsResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValuateTransactionsResponse) String() string {
+func (x *ListUnresolvedSymbolsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValuateTransactionsResponse) ProtoMessage() {}
+func (*ListUnresolvedSymbolsResponse) ProtoMessage() {}
 
-func (x *ValuateTransactionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[7]
+func (x *ListUnresolvedSymbolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,43 +3548,52 @@ func (x *ValuateTransactionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValuateTransactionsResponse.ProtoReflect.Descriptor instead.
-func (*ValuateTransactionsResponse) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListUnresolvedSymbolsResponse.ProtoReflect.Descriptor instead.
+func (*ListUnresolvedSymbolsResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *ValuateTransactionsResponse) GetTransactions() []*ValuatedTx {
+func (x *ListUnresolvedSymbolsResponse) GetSymbols() []*UnresolvedSymbol {
 	if x != nil {
-		return x.Transactions
+		return x.Symbols
 	}
 	return nil
 }
 
-type UpsertTenantSymbolRequest struct {
+func (x *ListUnresolvedSymbolsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ResolveUnresolvedSymbolRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
 	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
 	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
 	CoinId        string                 `protobuf:"bytes,4,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpsertTenantSymbolRequest) Reset() {
-	*x = UpsertTenantSymbolRequest{}
-	mi := &file_price_v1_price_proto_msgTypes[8]
+func (x *ResolveUnresolvedSymbolRequest) Reset() {
+	*x = ResolveUnresolvedSymbolRequest{}
+	mi := &file_price_v1_price_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpsertTenantSymbolRequest) String() string {
+func (x *ResolveUnresolvedSymbolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpsertTenantSymbolRequest) ProtoMessage() {}
+func (*ResolveUnresolvedSymbolRequest) ProtoMessage() {}
 
-func (x *UpsertTenantSymbolRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[8]
+func (x *ResolveUnresolvedSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,60 +3604,74 @@ func (x *UpsertTenantSymbolRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpsertTenantSymbolRequest.ProtoReflect.Descriptor instead.
-func (*UpsertTenantSymbolRequest) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ResolveUnresolvedSymbolRequest.ProtoReflect.Descriptor instead.
+func (*ResolveUnresolvedSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *UpsertTenantSymbolRequest) GetTenantId() string {
+func (x *ResolveUnresolvedSymbolRequest) GetTenantId() string {
 	if x != nil {
 		return x.TenantId
 	}
 	return ""
 }
 
-func (x *UpsertTenantSymbolRequest) GetSource() string {
+func (x *ResolveUnresolvedSymbolRequest) GetSource() string {
 	if x != nil {
 		return x.Source
 	}
 	return ""
 }
 
-func (x *UpsertTenantSymbolRequest) GetSymbol() string {
+func (x *ResolveUnresolvedSymbolRequest) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-func (x *UpsertTenantSymbolRequest) GetCoinId() string {
+func (x *ResolveUnresolvedSymbolRequest) GetCoinId() string {
 	if x != nil {
 		return x.CoinId
 	}
 	return ""
 }
 
-type UpsertTenantSymbolResponse struct {
+func (x *ResolveUnresolvedSymbolRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *ResolveUnresolvedSymbolRequest) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+type ResolveUnresolvedSymbolResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpsertTenantSymbolResponse) Reset() {
-	*x = UpsertTenantSymbolResponse{}
-	mi := &file_price_v1_price_proto_msgTypes[9]
+func (x *ResolveUnresolvedSymbolResponse) Reset() {
+	*x = ResolveUnresolvedSymbolResponse{}
+	mi := &file_price_v1_price_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpsertTenantSymbolResponse) String() string {
+func (x *ResolveUnresolvedSymbolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpsertTenantSymbolResponse) ProtoMessage() {}
+func (*ResolveUnresolvedSymbolResponse) ProtoMessage() {}
 
-func (x *UpsertTenantSymbolResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_price_v1_price_proto_msgTypes[9]
+func (x *ResolveUnresolvedSymbolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_v1_price_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,21 +3682,45 @@ func (x *UpsertTenantSymbolResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpsertTenantSymbolResponse.ProtoReflect.Descriptor instead.
-func (*UpsertTenantSymbolResponse) Descriptor() ([]byte, []int) {
-	return file_price_v1_price_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ResolveUnresolvedSymbolResponse.ProtoReflect.Descriptor instead.
+func (*ResolveUnresolvedSymbolResponse) Descriptor() ([]byte, []int) {
+	return file_price_v1_price_proto_rawDescGZIP(), []int{51}
 }
 
 var File_price_v1_price_proto protoreflect.FileDescriptor
 
 const file_price_v1_price_proto_rawDesc = "" +
 	"\n" +
-	"\x14price/v1/price.proto\x12\bprice.v1\x1a\x1fgoogle/protobuf/timestamp.proto\":\n" +
+	"\x14price/v1/price.proto\x12\bprice.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"{\n" +
 	"\bMoneyLeg\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\tR\x06amount\"\x1d\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12\x14\n" +
+	"\x05chain\x18\x03 \x01(\tR\x05chain\x12)\n" +
+	"\x10contract_address\x18\x04 \x01(\tR\x0fcontractAddress\"\xff\x02\n" +
 	"\aFiatLeg\x12\x12\n" +
-	"\x04fiat\x18\x02 \x01(\tR\x04fiat\"\xa2\x02\n" +
+	"\x04fiat\x18\x02 \x01(\tR\x04fiat\x12/\n" +
+	"\x06method\x18\x03 \x01(\x0e2\x17.price.v1.PricingMethodR\x06method\x12'\n" +
+	"\x0flower_precision\x18\x04 \x01(\bR\x0elowerPrecision\x12%\n" +
+	"\x0efiat_unrounded\x18\x05 \x01(\tR\rfiatUnrounded\x124\n" +
+	"\n" +
+	"provenance\x18\x06 \x01(\v2\x14.price.v1.ProvenanceR\n" +
+	"provenance\x12)\n" +
+	"\x10canonical_symbol\x18\a \x01(\tR\x0fcanonicalSymbol\x12!\n" +
+	"\fquote_symbol\x18\b \x01(\tR\vquoteSymbol\x12\x1c\n" +
+	"\tconsensus\x18\t \x01(\bR\tconsensus\x12\x14\n" +
+	"\x05total\x18\n" +
+	" \x01(\tR\x05total\x12'\n" +
+	"\x0ftotal_unrounded\x18\v \x01(\tR\x0etotalUnrounded\"\xbc\x02\n" +
+	"\n" +
+	"Provenance\x12D\n" +
+	"\x10bucket_start_utc\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x0ebucketStartUtc\x12/\n" +
+	"\x13granularity_seconds\x18\x02 \x01(\x05R\x12granularitySeconds\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x1b\n" +
+	"\tprice_usd\x18\x04 \x01(\tR\bpriceUsd\x129\n" +
+	"\n" +
+	"fetched_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\x12\x17\n" +
+	"\afx_rate\x18\x06 \x01(\tR\x06fxRate\x12*\n" +
+	"\x11fx_effective_date\x18\a \x01(\tR\x0ffxEffectiveDate\"\xa2\x02\n" +
 	"\vTxToValuate\x12\x13\n" +
 	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x125\n" +
 	"\btime_utc\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\atimeUtc\x122\n" +
@@ -681,10 +3731,11 @@ const file_price_v1_price_proto_rawDesc = "" +
 	"\n" +
 	"_out_moneyB\f\n" +
 	"\n" +
-	"_fee_money\"<\n" +
+	"_fee_money\"_\n" +
 	"\rCoinCandidate\x12\x17\n" +
 	"\acoin_id\x18\x01 \x01(\tR\x06coinId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xa5\x01\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\ftenant_count\x18\x03 \x01(\x05R\vtenantCount\"\xbc\x02\n" +
 	"\n" +
 	"AssetError\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12,\n" +
@@ -692,7 +3743,15 @@ const file_price_v1_price_proto_rawDesc = "" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x127\n" +
 	"\n" +
 	"candidates\x18\x04 \x03(\v2\x17.price.v1.CoinCandidateR\n" +
-	"candidates\"\x8c\x02\n" +
+	"candidates\x124\n" +
+	"\x06reason\x18\x05 \x01(\x0e2\x1c.price.v1.RateNotFoundReasonR\x06reason\x12)\n" +
+	"\x10canonical_symbol\x18\x06 \x01(\tR\x0fcanonicalSymbol\x124\n" +
+	"\vpair_splits\x18\a \x03(\v2\x13.price.v1.PairSplitR\n" +
+	"pairSplits\"O\n" +
+	"\tPairSplit\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x14\n" +
+	"\x05quote\x18\x02 \x01(\tR\x05quote\x12\x18\n" +
+	"\aguessed\x18\x03 \x01(\bR\aguessed\"\x8c\x02\n" +
 	"\n" +
 	"ValuatedTx\x12\x13\n" +
 	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12/\n" +
@@ -703,29 +3762,258 @@ const file_price_v1_price_proto_rawDesc = "" +
 	"\n" +
 	"\b_in_fiatB\v\n" +
 	"\t_out_fiatB\v\n" +
-	"\t_fee_fiat\"\xb1\x01\n" +
+	"\t_fee_fiat\"\xb9\x01\n" +
+	"\fLookupPolicy\x12\x18\n" +
+	"\anearest\x18\x01 \x01(\bR\anearest\x127\n" +
+	"\ttolerance\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\ttolerance\x12%\n" +
+	"\x0eaccept_coarser\x18\x03 \x01(\bR\racceptCoarser\x12/\n" +
+	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\xb9\x02\n" +
 	"\x1aValuateTransactionsRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12#\n" +
 	"\rfiat_currency\x18\x03 \x01(\tR\ffiatCurrency\x129\n" +
-	"\ftransactions\x18\x04 \x03(\v2\x15.price.v1.TxToValuateR\ftransactions\"W\n" +
+	"\ftransactions\x18\x04 \x03(\v2\x15.price.v1.TxToValuateR\ftransactions\x125\n" +
+	"\vprice_point\x18\x05 \x01(\x0e2\x14.price.v1.PricePointR\n" +
+	"pricePoint\x12.\n" +
+	"\x06lookup\x18\x06 \x01(\v2\x16.price.v1.LookupPolicyR\x06lookup\x12\x1f\n" +
+	"\vsnapshot_id\x18\a \x01(\tR\n" +
+	"snapshotId\"\x8e\x01\n" +
 	"\x1bValuateTransactionsResponse\x128\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x14.price.v1.ValuatedTxR\ftransactions\"\x81\x01\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x14.price.v1.ValuatedTxR\ftransactions\x125\n" +
+	"\vchunk_error\x18\x02 \x01(\v2\x14.price.v1.ChunkErrorR\n" +
+	"chunkError\":\n" +
+	"\n" +
+	"ChunkError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xf3\x01\n" +
 	"\x19UpsertTenantSymbolRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
 	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x17\n" +
-	"\acoin_id\x18\x04 \x01(\tR\x06coinId\"\x1c\n" +
-	"\x1aUpsertTenantSymbolResponse*\x82\x01\n" +
+	"\acoin_id\x18\x04 \x01(\tR\x06coinId\x129\n" +
+	"\n" +
+	"valid_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\avalidTo\"\x1c\n" +
+	"\x1aUpsertTenantSymbolResponse\"\xbd\x01\n" +
+	"\x11TenantSymbolIssue\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x17\n" +
+	"\acoin_id\x18\x04 \x01(\tR\x06coinId\x123\n" +
+	"\x04code\x18\x05 \x01(\x0e2\x1f.price.v1.TenantSymbolIssueCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\"\xa2\x01\n" +
+	"\x1aImportTenantSymbolsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x124\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1c.price.v1.TenantSymbolFormatR\x06format\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\x80\x01\n" +
+	"\x1bImportTenantSymbolsResponse\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x05R\x04rows\x123\n" +
+	"\x06issues\x18\x02 \x03(\v2\x1b.price.v1.TenantSymbolIssueR\x06issues\x12\x18\n" +
+	"\aapplied\x18\x03 \x01(\bR\aapplied\"\x87\x01\n" +
+	"\x1aExportTenantSymbolsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x124\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x1c.price.v1.TenantSymbolFormatR\x06format\"K\n" +
+	"\x1bExportTenantSymbolsResponse\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\x12\x12\n" +
+	"\x04rows\x18\x02 \x01(\x05R\x04rows\"\x93\x03\n" +
+	"\fValuationJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x124\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1c.price.v1.ValuationJobStatusR\x06status\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x04 \x01(\x05R\tprocessed\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"[\n" +
+	"\x19SubmitValuationJobRequest\x12>\n" +
+	"\arequest\x18\x01 \x01(\v2$.price.v1.ValuateTransactionsRequestR\arequest\"F\n" +
+	"\x1aSubmitValuationJobResponse\x12(\n" +
+	"\x03job\x18\x01 \x01(\v2\x16.price.v1.ValuationJobR\x03job\"L\n" +
+	"\x16GetValuationJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\"C\n" +
+	"\x17GetValuationJobResponse\x12(\n" +
+	"\x03job\x18\x01 \x01(\v2\x16.price.v1.ValuationJobR\x03job\"\x90\x01\n" +
+	"\x1eListValuationJobResultsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\tR\btenantId\"\x83\x01\n" +
+	"\x1fListValuationJobResultsResponse\x128\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x14.price.v1.ValuatedTxR\ftransactions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xca\x01\n" +
+	"\vCustomAsset\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"{\n" +
+	"\x10CustomPricePoint\x125\n" +
+	"\btime_utc\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\atimeUtc\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"c\n" +
+	"\x18UpsertCustomAssetRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"H\n" +
+	"\x19UpsertCustomAssetResponse\x12+\n" +
+	"\x05asset\x18\x01 \x01(\v2\x15.price.v1.CustomAssetR\x05asset\"6\n" +
+	"\x17ListCustomAssetsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"I\n" +
+	"\x18ListCustomAssetsResponse\x12-\n" +
+	"\x06assets\x18\x01 \x03(\v2\x15.price.v1.CustomAssetR\x06assets\"R\n" +
+	"\x18DeleteCustomAssetRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\"\x1b\n" +
+	"\x19DeleteCustomAssetResponse\"\x8c\x01\n" +
+	"\x1eUpsertCustomAssetPricesRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x122\n" +
+	"\x06prices\x18\x03 \x03(\v2\x1a.price.v1.CustomPricePointR\x06prices\"=\n" +
+	"\x1fUpsertCustomAssetPricesResponse\x12\x1a\n" +
+	"\bupserted\x18\x01 \x01(\x05R\bupserted\"\xb2\x01\n" +
+	"\x1cListCustomAssetPricesRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"S\n" +
+	"\x1dListCustomAssetPricesResponse\x122\n" +
+	"\x06prices\x18\x01 \x03(\v2\x1a.price.v1.CustomPricePointR\x06prices\"R\n" +
+	"!UploadCustomAssetPricesCsvRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\fR\x03csv\"P\n" +
+	"\"UploadCustomAssetPricesCsvResponse\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x05R\x04rows\x12\x16\n" +
+	"\x06assets\x18\x02 \x01(\x05R\x06assets\"\x16\n" +
+	"\x14ReloadCoinMapRequest\"-\n" +
+	"\x15ReloadCoinMapResponse\x12\x14\n" +
+	"\x05coins\x18\x01 \x01(\x05R\x05coins\"\xd6\x02\n" +
+	"\x04Coin\x12\x17\n" +
+	"\acoin_id\x18\x01 \x01(\tR\x06coinId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12;\n" +
+	"\tplatforms\x18\x04 \x03(\v2\x1d.price.v1.Coin.PlatformsEntryR\tplatforms\x129\n" +
+	"\n" +
+	"first_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x1a\n" +
+	"\bdelisted\x18\a \x01(\bR\bdelisted\x1a<\n" +
+	"\x0ePlatformsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
+	"\x12SearchCoinsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\";\n" +
+	"\x13SearchCoinsResponse\x12$\n" +
+	"\x05coins\x18\x01 \x03(\v2\x0e.price.v1.CoinR\x05coins\")\n" +
+	"\x0eGetCoinRequest\x12\x17\n" +
+	"\acoin_id\x18\x01 \x01(\tR\x06coinId\"5\n" +
+	"\x0fGetCoinResponse\x12\"\n" +
+	"\x04coin\x18\x01 \x01(\v2\x0e.price.v1.CoinR\x04coin\"\xee\x02\n" +
+	"\x10UnresolvedSymbol\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12)\n" +
+	"\x10canonical_symbol\x18\x04 \x01(\tR\x0fcanonicalSymbol\x122\n" +
+	"\x06reason\x18\x05 \x01(\x0e2\x1a.price.v1.UnresolvedReasonR\x06reason\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12 \n" +
+	"\voccurrences\x18\a \x01(\x03R\voccurrences\x129\n" +
+	"\n" +
+	"first_seen\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\x8f\x01\n" +
+	"\x1cListUnresolvedSymbolsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"}\n" +
+	"\x1dListUnresolvedSymbolsResponse\x124\n" +
+	"\asymbols\x18\x01 \x03(\v2\x1a.price.v1.UnresolvedSymbolR\asymbols\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf8\x01\n" +
+	"\x1eResolveUnresolvedSymbolRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x17\n" +
+	"\acoin_id\x18\x04 \x01(\tR\x06coinId\x129\n" +
+	"\n" +
+	"valid_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\avalidTo\"!\n" +
+	"\x1fResolveUnresolvedSymbolResponse*\xf6\x01\n" +
+	"\rPricingMethod\x12\x1e\n" +
+	"\x1aPRICING_METHOD_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15PRICING_METHOD_MARKET\x10\x01\x12\x16\n" +
+	"\x12PRICING_METHOD_PEG\x10\x02\x12\x1f\n" +
+	"\x1bPRICING_METHOD_MARKET_DEPEG\x10\x03\x12\x15\n" +
+	"\x11PRICING_METHOD_FX\x10\x04\x12\x1d\n" +
+	"\x19PRICING_METHOD_DERIVATIVE\x10\x05\x12\x19\n" +
+	"\x15PRICING_METHOD_MANUAL\x10\x06\x12 \n" +
+	"\x1cPRICING_METHOD_PEG_UNCHECKED\x10\a*\x96\x01\n" +
 	"\x0eAssetErrorCode\x12 \n" +
 	"\x1cASSET_ERROR_CODE_UNSPECIFIED\x10\x00\x12\x11\n" +
 	"\rASSET_UNKNOWN\x10\x01\x12\x13\n" +
 	"\x0fASSET_AMBIGUOUS\x10\x02\x12\x12\n" +
 	"\x0eRATE_NOT_FOUND\x10\x03\x12\x12\n" +
-	"\x0ePROVIDER_ERROR\x10\x042\xd1\x01\n" +
+	"\x0ePROVIDER_ERROR\x10\x04\x12\x12\n" +
+	"\x0eINVALID_AMOUNT\x10\x05*\x91\x01\n" +
+	"\x12RateNotFoundReason\x12%\n" +
+	"!RATE_NOT_FOUND_REASON_UNSPECIFIED\x10\x00\x12(\n" +
+	"$RATE_NOT_FOUND_REASON_BEFORE_LISTING\x10\x01\x12*\n" +
+	"&RATE_NOT_FOUND_REASON_NO_PROVIDER_DATA\x10\x02*\xa1\x01\n" +
+	"\n" +
+	"PricePoint\x12\x1b\n" +
+	"\x17PRICE_POINT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17PRICE_POINT_BUCKET_OPEN\x10\x01\x12\x1c\n" +
+	"\x18PRICE_POINT_BUCKET_CLOSE\x10\x02\x12\x1c\n" +
+	"\x18PRICE_POINT_INTERPOLATED\x10\x03\x12\x1d\n" +
+	"\x19PRICE_POINT_DAILY_AVERAGE\x10\x04*w\n" +
+	"\x12TenantSymbolFormat\x12$\n" +
+	" TENANT_SYMBOL_FORMAT_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18TENANT_SYMBOL_FORMAT_CSV\x10\x01\x12\x1d\n" +
+	"\x19TENANT_SYMBOL_FORMAT_JSON\x10\x02*\xaa\x01\n" +
+	"\x15TenantSymbolIssueCode\x12(\n" +
+	"$TENANT_SYMBOL_ISSUE_CODE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bTENANT_SYMBOL_ISSUE_INVALID\x10\x01\x12$\n" +
+	" TENANT_SYMBOL_ISSUE_UNKNOWN_COIN\x10\x02\x12 \n" +
+	"\x1cTENANT_SYMBOL_ISSUE_CONFLICT\x10\x03*\xc2\x01\n" +
+	"\x12ValuationJobStatus\x12$\n" +
+	" VALUATION_JOB_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bVALUATION_JOB_STATUS_QUEUED\x10\x01\x12 \n" +
+	"\x1cVALUATION_JOB_STATUS_RUNNING\x10\x02\x12\"\n" +
+	"\x1eVALUATION_JOB_STATUS_SUCCEEDED\x10\x03\x12\x1f\n" +
+	"\x1bVALUATION_JOB_STATUS_FAILED\x10\x04*u\n" +
+	"\x10UnresolvedReason\x12!\n" +
+	"\x1dUNRESOLVED_REASON_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19UNRESOLVED_REASON_UNKNOWN\x10\x01\x12\x1f\n" +
+	"\x1bUNRESOLVED_REASON_AMBIGUOUS\x10\x022\xd2\x0e\n" +
 	"\x05Price\x12g\n" +
-	"\x18ValuateTransactionsBatch\x12$.price.v1.ValuateTransactionsRequest\x1a%.price.v1.ValuateTransactionsResponse\x12_\n" +
-	"\x12UpsertTenantSymbol\x12#.price.v1.UpsertTenantSymbolRequest\x1a$.price.v1.UpsertTenantSymbolResponseBVZTgithub.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1;pricev1b\x06proto3"
+	"\x18ValuateTransactionsBatch\x12$.price.v1.ValuateTransactionsRequest\x1a%.price.v1.ValuateTransactionsResponse\x12l\n" +
+	"\x19ValuateTransactionsStream\x12$.price.v1.ValuateTransactionsRequest\x1a%.price.v1.ValuateTransactionsResponse(\x010\x01\x12_\n" +
+	"\x12SubmitValuationJob\x12#.price.v1.SubmitValuationJobRequest\x1a$.price.v1.SubmitValuationJobResponse\x12V\n" +
+	"\x0fGetValuationJob\x12 .price.v1.GetValuationJobRequest\x1a!.price.v1.GetValuationJobResponse\x12n\n" +
+	"\x17ListValuationJobResults\x12(.price.v1.ListValuationJobResultsRequest\x1a).price.v1.ListValuationJobResultsResponse\x12_\n" +
+	"\x12UpsertTenantSymbol\x12#.price.v1.UpsertTenantSymbolRequest\x1a$.price.v1.UpsertTenantSymbolResponse\x12b\n" +
+	"\x13ImportTenantSymbols\x12$.price.v1.ImportTenantSymbolsRequest\x1a%.price.v1.ImportTenantSymbolsResponse\x12b\n" +
+	"\x13ExportTenantSymbols\x12$.price.v1.ExportTenantSymbolsRequest\x1a%.price.v1.ExportTenantSymbolsResponse\x12\\\n" +
+	"\x11UpsertCustomAsset\x12\".price.v1.UpsertCustomAssetRequest\x1a#.price.v1.UpsertCustomAssetResponse\x12Y\n" +
+	"\x10ListCustomAssets\x12!.price.v1.ListCustomAssetsRequest\x1a\".price.v1.ListCustomAssetsResponse\x12\\\n" +
+	"\x11DeleteCustomAsset\x12\".price.v1.DeleteCustomAssetRequest\x1a#.price.v1.DeleteCustomAssetResponse\x12n\n" +
+	"\x17UpsertCustomAssetPrices\x12(.price.v1.UpsertCustomAssetPricesRequest\x1a).price.v1.UpsertCustomAssetPricesResponse\x12h\n" +
+	"\x15ListCustomAssetPrices\x12&.price.v1.ListCustomAssetPricesRequest\x1a'.price.v1.ListCustomAssetPricesResponse\x12w\n" +
+	"\x1aUploadCustomAssetPricesCsv\x12+.price.v1.UploadCustomAssetPricesCsvRequest\x1a,.price.v1.UploadCustomAssetPricesCsvResponse\x12P\n" +
+	"\rReloadCoinMap\x12\x1e.price.v1.ReloadCoinMapRequest\x1a\x1f.price.v1.ReloadCoinMapResponse\x12J\n" +
+	"\vSearchCoins\x12\x1c.price.v1.SearchCoinsRequest\x1a\x1d.price.v1.SearchCoinsResponse\x12>\n" +
+	"\aGetCoin\x12\x18.price.v1.GetCoinRequest\x1a\x19.price.v1.GetCoinResponse\x12h\n" +
+	"\x15ListUnresolvedSymbols\x12&.price.v1.ListUnresolvedSymbolsRequest\x1a'.price.v1.ListUnresolvedSymbolsResponse\x12n\n" +
+	"\x17ResolveUnresolvedSymbol\x12(.price.v1.ResolveUnresolvedSymbolRequest\x1a).price.v1.ResolveUnresolvedSymbolResponseBVZTgithub.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1;pricev1b\x06proto3"
 
 var (
 	file_price_v1_price_proto_rawDescOnce sync.Once
@@ -739,44 +4027,175 @@ func file_price_v1_price_proto_rawDescGZIP() []byte {
 	return file_price_v1_price_proto_rawDescData
 }
 
-var file_price_v1_price_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_price_v1_price_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_price_v1_price_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_price_v1_price_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_price_v1_price_proto_goTypes = []any{
-	(AssetErrorCode)(0),                 // 0: price.v1.AssetErrorCode
-	(*MoneyLeg)(nil),                    // 1: price.v1.MoneyLeg
-	(*FiatLeg)(nil),                     // 2: price.v1.FiatLeg
-	(*TxToValuate)(nil),                 // 3: price.v1.TxToValuate
-	(*CoinCandidate)(nil),               // 4: price.v1.CoinCandidate
-	(*AssetError)(nil),                  // 5: price.v1.AssetError
-	(*ValuatedTx)(nil),                  // 6: price.v1.ValuatedTx
-	(*ValuateTransactionsRequest)(nil),  // 7: price.v1.ValuateTransactionsRequest
-	(*ValuateTransactionsResponse)(nil), // 8: price.v1.ValuateTransactionsResponse
-	(*UpsertTenantSymbolRequest)(nil),   // 9: price.v1.UpsertTenantSymbolRequest
-	(*UpsertTenantSymbolResponse)(nil),  // 10: price.v1.UpsertTenantSymbolResponse
-	(*timestamppb.Timestamp)(nil),       // 11: google.protobuf.Timestamp
+	(PricingMethod)(0),                         // 0: price.v1.PricingMethod
+	(AssetErrorCode)(0),                        // 1: price.v1.AssetErrorCode
+	(RateNotFoundReason)(0),                    // 2: price.v1.RateNotFoundReason
+	(PricePoint)(0),                            // 3: price.v1.PricePoint
+	(TenantSymbolFormat)(0),                    // 4: price.v1.TenantSymbolFormat
+	(TenantSymbolIssueCode)(0),                 // 5: price.v1.TenantSymbolIssueCode
+	(ValuationJobStatus)(0),                    // 6: price.v1.ValuationJobStatus
+	(UnresolvedReason)(0),                      // 7: price.v1.UnresolvedReason
+	(*MoneyLeg)(nil),                           // 8: price.v1.MoneyLeg
+	(*FiatLeg)(nil),                            // 9: price.v1.FiatLeg
+	(*Provenance)(nil),                         // 10: price.v1.Provenance
+	(*TxToValuate)(nil),                        // 11: price.v1.TxToValuate
+	(*CoinCandidate)(nil),                      // 12: price.v1.CoinCandidate
+	(*AssetError)(nil),                         // 13: price.v1.AssetError
+	(*PairSplit)(nil),                          // 14: price.v1.PairSplit
+	(*ValuatedTx)(nil),                         // 15: price.v1.ValuatedTx
+	(*LookupPolicy)(nil),                       // 16: price.v1.LookupPolicy
+	(*ValuateTransactionsRequest)(nil),         // 17: price.v1.ValuateTransactionsRequest
+	(*ValuateTransactionsResponse)(nil),        // 18: price.v1.ValuateTransactionsResponse
+	(*ChunkError)(nil),                         // 19: price.v1.ChunkError
+	(*UpsertTenantSymbolRequest)(nil),          // 20: price.v1.UpsertTenantSymbolRequest
+	(*UpsertTenantSymbolResponse)(nil),         // 21: price.v1.UpsertTenantSymbolResponse
+	(*TenantSymbolIssue)(nil),                  // 22: price.v1.TenantSymbolIssue
+	(*ImportTenantSymbolsRequest)(nil),         // 23: price.v1.ImportTenantSymbolsRequest
+	(*ImportTenantSymbolsResponse)(nil),        // 24: price.v1.ImportTenantSymbolsResponse
+	(*ExportTenantSymbolsRequest)(nil),         // 25: price.v1.ExportTenantSymbolsRequest
+	(*ExportTenantSymbolsResponse)(nil),        // 26: price.v1.ExportTenantSymbolsResponse
+	(*ValuationJob)(nil),                       // 27: price.v1.ValuationJob
+	(*SubmitValuationJobRequest)(nil),          // 28: price.v1.SubmitValuationJobRequest
+	(*SubmitValuationJobResponse)(nil),         // 29: price.v1.SubmitValuationJobResponse
+	(*GetValuationJobRequest)(nil),             // 30: price.v1.GetValuationJobRequest
+	(*GetValuationJobResponse)(nil),            // 31: price.v1.GetValuationJobResponse
+	(*ListValuationJobResultsRequest)(nil),     // 32: price.v1.ListValuationJobResultsRequest
+	(*ListValuationJobResultsResponse)(nil),    // 33: price.v1.ListValuationJobResultsResponse
+	(*CustomAsset)(nil),                        // 34: price.v1.CustomAsset
+	(*CustomPricePoint)(nil),                   // 35: price.v1.CustomPricePoint
+	(*UpsertCustomAssetRequest)(nil),           // 36: price.v1.UpsertCustomAssetRequest
+	(*UpsertCustomAssetResponse)(nil),          // 37: price.v1.UpsertCustomAssetResponse
+	(*ListCustomAssetsRequest)(nil),            // 38: price.v1.ListCustomAssetsRequest
+	(*ListCustomAssetsResponse)(nil),           // 39: price.v1.ListCustomAssetsResponse
+	(*DeleteCustomAssetRequest)(nil),           // 40: price.v1.DeleteCustomAssetRequest
+	(*DeleteCustomAssetResponse)(nil),          // 41: price.v1.DeleteCustomAssetResponse
+	(*UpsertCustomAssetPricesRequest)(nil),     // 42: price.v1.UpsertCustomAssetPricesRequest
+	(*UpsertCustomAssetPricesResponse)(nil),    // 43: price.v1.UpsertCustomAssetPricesResponse
+	(*ListCustomAssetPricesRequest)(nil),       // 44: price.v1.ListCustomAssetPricesRequest
+	(*ListCustomAssetPricesResponse)(nil),      // 45: price.v1.ListCustomAssetPricesResponse
+	(*UploadCustomAssetPricesCsvRequest)(nil),  // 46: price.v1.UploadCustomAssetPricesCsvRequest
+	(*UploadCustomAssetPricesCsvResponse)(nil), // 47: price.v1.UploadCustomAssetPricesCsvResponse
+	(*ReloadCoinMapRequest)(nil),               // 48: price.v1.ReloadCoinMapRequest
+	(*ReloadCoinMapResponse)(nil),              // 49: price.v1.ReloadCoinMapResponse
+	(*Coin)(nil),                               // 50: price.v1.Coin
+	(*SearchCoinsRequest)(nil),                 // 51: price.v1.SearchCoinsRequest
+	(*SearchCoinsResponse)(nil),                // 52: price.v1.SearchCoinsResponse
+	(*GetCoinRequest)(nil),                     // 53: price.v1.GetCoinRequest
+	(*GetCoinResponse)(nil),                    // 54: price.v1.GetCoinResponse
+	(*UnresolvedSymbol)(nil),                   // 55: price.v1.UnresolvedSymbol
+	(*ListUnresolvedSymbolsRequest)(nil),       // 56: price.v1.ListUnresolvedSymbolsRequest
+	(*ListUnresolvedSymbolsResponse)(nil),      // 57: price.v1.ListUnresolvedSymbolsResponse
+	(*ResolveUnresolvedSymbolRequest)(nil),     // 58: price.v1.ResolveUnresolvedSymbolRequest
+	(*ResolveUnresolvedSymbolResponse)(nil),    // 59: price.v1.ResolveUnresolvedSymbolResponse
+	nil,                                        // 60: price.v1.Coin.PlatformsEntry
+	(*timestamppb.Timestamp)(nil),              // 61: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                // 62: google.protobuf.Duration
 }
 var file_price_v1_price_proto_depIdxs = []int32{
-	11, // 0: price.v1.TxToValuate.time_utc:type_name -> google.protobuf.Timestamp
-	1,  // 1: price.v1.TxToValuate.in_money:type_name -> price.v1.MoneyLeg
-	1,  // 2: price.v1.TxToValuate.out_money:type_name -> price.v1.MoneyLeg
-	1,  // 3: price.v1.TxToValuate.fee_money:type_name -> price.v1.MoneyLeg
-	0,  // 4: price.v1.AssetError.code:type_name -> price.v1.AssetErrorCode
-	4,  // 5: price.v1.AssetError.candidates:type_name -> price.v1.CoinCandidate
-	2,  // 6: price.v1.ValuatedTx.in_fiat:type_name -> price.v1.FiatLeg
-	2,  // 7: price.v1.ValuatedTx.out_fiat:type_name -> price.v1.FiatLeg
-	2,  // 8: price.v1.ValuatedTx.fee_fiat:type_name -> price.v1.FiatLeg
-	5,  // 9: price.v1.ValuatedTx.errors:type_name -> price.v1.AssetError
-	3,  // 10: price.v1.ValuateTransactionsRequest.transactions:type_name -> price.v1.TxToValuate
-	6,  // 11: price.v1.ValuateTransactionsResponse.transactions:type_name -> price.v1.ValuatedTx
-	7,  // 12: price.v1.Price.ValuateTransactionsBatch:input_type -> price.v1.ValuateTransactionsRequest
-	9,  // 13: price.v1.Price.UpsertTenantSymbol:input_type -> price.v1.UpsertTenantSymbolRequest
-	8,  // 14: price.v1.Price.ValuateTransactionsBatch:output_type -> price.v1.ValuateTransactionsResponse
-	10, // 15: price.v1.Price.UpsertTenantSymbol:output_type -> price.v1.UpsertTenantSymbolResponse
-	14, // [14:16] is the sub-list for method output_type
-	12, // [12:14] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	0,  // 0: price.v1.FiatLeg.method:type_name -> price.v1.PricingMethod
+	10, // 1: price.v1.FiatLeg.provenance:type_name -> price.v1.Provenance
+	61, // 2: price.v1.Provenance.bucket_start_utc:type_name -> google.protobuf.Timestamp
+	61, // 3: price.v1.Provenance.fetched_at:type_name -> google.protobuf.Timestamp
+	61, // 4: price.v1.TxToValuate.time_utc:type_name -> google.protobuf.Timestamp
+	8,  // 5: price.v1.TxToValuate.in_money:type_name -> price.v1.MoneyLeg
+	8,  // 6: price.v1.TxToValuate.out_money:type_name -> price.v1.MoneyLeg
+	8,  // 7: price.v1.TxToValuate.fee_money:type_name -> price.v1.MoneyLeg
+	1,  // 8: price.v1.AssetError.code:type_name -> price.v1.AssetErrorCode
+	12, // 9: price.v1.AssetError.candidates:type_name -> price.v1.CoinCandidate
+	2,  // 10: price.v1.AssetError.reason:type_name -> price.v1.RateNotFoundReason
+	14, // 11: price.v1.AssetError.pair_splits:type_name -> price.v1.PairSplit
+	9,  // 12: price.v1.ValuatedTx.in_fiat:type_name -> price.v1.FiatLeg
+	9,  // 13: price.v1.ValuatedTx.out_fiat:type_name -> price.v1.FiatLeg
+	9,  // 14: price.v1.ValuatedTx.fee_fiat:type_name -> price.v1.FiatLeg
+	13, // 15: price.v1.ValuatedTx.errors:type_name -> price.v1.AssetError
+	62, // 16: price.v1.LookupPolicy.tolerance:type_name -> google.protobuf.Duration
+	61, // 17: price.v1.LookupPolicy.as_of:type_name -> google.protobuf.Timestamp
+	11, // 18: price.v1.ValuateTransactionsRequest.transactions:type_name -> price.v1.TxToValuate
+	3,  // 19: price.v1.ValuateTransactionsRequest.price_point:type_name -> price.v1.PricePoint
+	16, // 20: price.v1.ValuateTransactionsRequest.lookup:type_name -> price.v1.LookupPolicy
+	15, // 21: price.v1.ValuateTransactionsResponse.transactions:type_name -> price.v1.ValuatedTx
+	19, // 22: price.v1.ValuateTransactionsResponse.chunk_error:type_name -> price.v1.ChunkError
+	61, // 23: price.v1.UpsertTenantSymbolRequest.valid_from:type_name -> google.protobuf.Timestamp
+	61, // 24: price.v1.UpsertTenantSymbolRequest.valid_to:type_name -> google.protobuf.Timestamp
+	5,  // 25: price.v1.TenantSymbolIssue.code:type_name -> price.v1.TenantSymbolIssueCode
+	4,  // 26: price.v1.ImportTenantSymbolsRequest.format:type_name -> price.v1.TenantSymbolFormat
+	22, // 27: price.v1.ImportTenantSymbolsResponse.issues:type_name -> price.v1.TenantSymbolIssue
+	4,  // 28: price.v1.ExportTenantSymbolsRequest.format:type_name -> price.v1.TenantSymbolFormat
+	6,  // 29: price.v1.ValuationJob.status:type_name -> price.v1.ValuationJobStatus
+	61, // 30: price.v1.ValuationJob.created_at:type_name -> google.protobuf.Timestamp
+	61, // 31: price.v1.ValuationJob.updated_at:type_name -> google.protobuf.Timestamp
+	61, // 32: price.v1.ValuationJob.started_at:type_name -> google.protobuf.Timestamp
+	61, // 33: price.v1.ValuationJob.finished_at:type_name -> google.protobuf.Timestamp
+	17, // 34: price.v1.SubmitValuationJobRequest.request:type_name -> price.v1.ValuateTransactionsRequest
+	27, // 35: price.v1.SubmitValuationJobResponse.job:type_name -> price.v1.ValuationJob
+	27, // 36: price.v1.GetValuationJobResponse.job:type_name -> price.v1.ValuationJob
+	15, // 37: price.v1.ListValuationJobResultsResponse.transactions:type_name -> price.v1.ValuatedTx
+	61, // 38: price.v1.CustomAsset.created_at:type_name -> google.protobuf.Timestamp
+	61, // 39: price.v1.CustomAsset.updated_at:type_name -> google.protobuf.Timestamp
+	61, // 40: price.v1.CustomPricePoint.time_utc:type_name -> google.protobuf.Timestamp
+	34, // 41: price.v1.UpsertCustomAssetResponse.asset:type_name -> price.v1.CustomAsset
+	34, // 42: price.v1.ListCustomAssetsResponse.assets:type_name -> price.v1.CustomAsset
+	35, // 43: price.v1.UpsertCustomAssetPricesRequest.prices:type_name -> price.v1.CustomPricePoint
+	61, // 44: price.v1.ListCustomAssetPricesRequest.from:type_name -> google.protobuf.Timestamp
+	61, // 45: price.v1.ListCustomAssetPricesRequest.to:type_name -> google.protobuf.Timestamp
+	35, // 46: price.v1.ListCustomAssetPricesResponse.prices:type_name -> price.v1.CustomPricePoint
+	60, // 47: price.v1.Coin.platforms:type_name -> price.v1.Coin.PlatformsEntry
+	61, // 48: price.v1.Coin.first_seen:type_name -> google.protobuf.Timestamp
+	61, // 49: price.v1.Coin.last_seen:type_name -> google.protobuf.Timestamp
+	50, // 50: price.v1.SearchCoinsResponse.coins:type_name -> price.v1.Coin
+	50, // 51: price.v1.GetCoinResponse.coin:type_name -> price.v1.Coin
+	7,  // 52: price.v1.UnresolvedSymbol.reason:type_name -> price.v1.UnresolvedReason
+	61, // 53: price.v1.UnresolvedSymbol.first_seen:type_name -> google.protobuf.Timestamp
+	61, // 54: price.v1.UnresolvedSymbol.last_seen:type_name -> google.protobuf.Timestamp
+	55, // 55: price.v1.ListUnresolvedSymbolsResponse.symbols:type_name -> price.v1.UnresolvedSymbol
+	61, // 56: price.v1.ResolveUnresolvedSymbolRequest.valid_from:type_name -> google.protobuf.Timestamp
+	61, // 57: price.v1.ResolveUnresolvedSymbolRequest.valid_to:type_name -> google.protobuf.Timestamp
+	17, // 58: price.v1.Price.ValuateTransactionsBatch:input_type -> price.v1.ValuateTransactionsRequest
+	17, // 59: price.v1.Price.ValuateTransactionsStream:input_type -> price.v1.ValuateTransactionsRequest
+	28, // 60: price.v1.Price.SubmitValuationJob:input_type -> price.v1.SubmitValuationJobRequest
+	30, // 61: price.v1.Price.GetValuationJob:input_type -> price.v1.GetValuationJobRequest
+	32, // 62: price.v1.Price.ListValuationJobResults:input_type -> price.v1.ListValuationJobResultsRequest
+	20, // 63: price.v1.Price.UpsertTenantSymbol:input_type -> price.v1.UpsertTenantSymbolRequest
+	23, // 64: price.v1.Price.ImportTenantSymbols:input_type -> price.v1.ImportTenantSymbolsRequest
+	25, // 65: price.v1.Price.ExportTenantSymbols:input_type -> price.v1.ExportTenantSymbolsRequest
+	36, // 66: price.v1.Price.UpsertCustomAsset:input_type -> price.v1.UpsertCustomAssetRequest
+	38, // 67: price.v1.Price.ListCustomAssets:input_type -> price.v1.ListCustomAssetsRequest
+	40, // 68: price.v1.Price.DeleteCustomAsset:input_type -> price.v1.DeleteCustomAssetRequest
+	42, // 69: price.v1.Price.UpsertCustomAssetPrices:input_type -> price.v1.UpsertCustomAssetPricesRequest
+	44, // 70: price.v1.Price.ListCustomAssetPrices:input_type -> price.v1.ListCustomAssetPricesRequest
+	46, // 71: price.v1.Price.UploadCustomAssetPricesCsv:input_type -> price.v1.UploadCustomAssetPricesCsvRequest
+	48, // 72: price.v1.Price.ReloadCoinMap:input_type -> price.v1.ReloadCoinMapRequest
+	51, // 73: price.v1.Price.SearchCoins:input_type -> price.v1.SearchCoinsRequest
+	53, // 74: price.v1.Price.GetCoin:input_type -> price.v1.GetCoinRequest
+	56, // 75: price.v1.Price.ListUnresolvedSymbols:input_type -> price.v1.ListUnresolvedSymbolsRequest
+	58, // 76: price.v1.Price.ResolveUnresolvedSymbol:input_type -> price.v1.ResolveUnresolvedSymbolRequest
+	18, // 77: price.v1.Price.ValuateTransactionsBatch:output_type -> price.v1.ValuateTransactionsResponse
+	18, // 78: price.v1.Price.ValuateTransactionsStream:output_type -> price.v1.ValuateTransactionsResponse
+	29, // 79: price.v1.Price.SubmitValuationJob:output_type -> price.v1.SubmitValuationJobResponse
+	31, // 80: price.v1.Price.GetValuationJob:output_type -> price.v1.GetValuationJobResponse
+	33, // 81: price.v1.Price.ListValuationJobResults:output_type -> price.v1.ListValuationJobResultsResponse
+	21, // 82: price.v1.Price.UpsertTenantSymbol:output_type -> price.v1.UpsertTenantSymbolResponse
+	24, // 83: price.v1.Price.ImportTenantSymbols:output_type -> price.v1.ImportTenantSymbolsResponse
+	26, // 84: price.v1.Price.ExportTenantSymbols:output_type -> price.v1.ExportTenantSymbolsResponse
+	37, // 85: price.v1.Price.UpsertCustomAsset:output_type -> price.v1.UpsertCustomAssetResponse
+	39, // 86: price.v1.Price.ListCustomAssets:output_type -> price.v1.ListCustomAssetsResponse
+	41, // 87: price.v1.Price.DeleteCustomAsset:output_type -> price.v1.DeleteCustomAssetResponse
+	43, // 88: price.v1.Price.UpsertCustomAssetPrices:output_type -> price.v1.UpsertCustomAssetPricesResponse
+	45, // 89: price.v1.Price.ListCustomAssetPrices:output_type -> price.v1.ListCustomAssetPricesResponse
+	47, // 90: price.v1.Price.UploadCustomAssetPricesCsv:output_type -> price.v1.UploadCustomAssetPricesCsvResponse
+	49, // 91: price.v1.Price.ReloadCoinMap:output_type -> price.v1.ReloadCoinMapResponse
+	52, // 92: price.v1.Price.SearchCoins:output_type -> price.v1.SearchCoinsResponse
+	54, // 93: price.v1.Price.GetCoin:output_type -> price.v1.GetCoinResponse
+	57, // 94: price.v1.Price.ListUnresolvedSymbols:output_type -> price.v1.ListUnresolvedSymbolsResponse
+	59, // 95: price.v1.Price.ResolveUnresolvedSymbol:output_type -> price.v1.ResolveUnresolvedSymbolResponse
+	77, // [77:96] is the sub-list for method output_type
+	58, // [58:77] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
 }
 
 func init() { file_price_v1_price_proto_init() }
@@ -784,15 +4203,15 @@ func file_price_v1_price_proto_init() {
 	if File_price_v1_price_proto != nil {
 		return
 	}
-	file_price_v1_price_proto_msgTypes[2].OneofWrappers = []any{}
-	file_price_v1_price_proto_msgTypes[5].OneofWrappers = []any{}
+	file_price_v1_price_proto_msgTypes[3].OneofWrappers = []any{}
+	file_price_v1_price_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_price_v1_price_proto_rawDesc), len(file_price_v1_price_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      8,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Price_ValuateTransactionsBatch_FullMethodName = "/price.v1.Price/ValuateTransactionsBatch"
-	Price_UpsertTenantSymbol_FullMethodName       = "/price.v1.Price/UpsertTenantSymbol"
+	Price_ValuateTransactionsBatch_FullMethodName   = "/price.v1.Price/ValuateTransactionsBatch"
+	Price_ValuateTransactionsStream_FullMethodName  = "/price.v1.Price/ValuateTransactionsStream"
+	Price_SubmitValuationJob_FullMethodName         = "/price.v1.Price/SubmitValuationJob"
+	Price_GetValuationJob_FullMethodName            = "/price.v1.Price/GetValuationJob"
+	Price_ListValuationJobResults_FullMethodName    = "/price.v1.Price/ListValuationJobResults"
+	Price_UpsertTenantSymbol_FullMethodName         = "/price.v1.Price/UpsertTenantSymbol"
+	Price_ImportTenantSymbols_FullMethodName        = "/price.v1.Price/ImportTenantSymbols"
+	Price_ExportTenantSymbols_FullMethodName        = "/price.v1.Price/ExportTenantSymbols"
+	Price_UpsertCustomAsset_FullMethodName          = "/price.v1.Price/UpsertCustomAsset"
+	Price_ListCustomAssets_FullMethodName           = "/price.v1.Price/ListCustomAssets"
+	Price_DeleteCustomAsset_FullMethodName          = "/price.v1.Price/DeleteCustomAsset"
+	Price_UpsertCustomAssetPrices_FullMethodName    = "/price.v1.Price/UpsertCustomAssetPrices"
+	Price_ListCustomAssetPrices_FullMethodName      = "/price.v1.Price/ListCustomAssetPrices"
+	Price_UploadCustomAssetPricesCsv_FullMethodName = "/price.v1.Price/UploadCustomAssetPricesCsv"
+	Price_ReloadCoinMap_FullMethodName              = "/price.v1.Price/ReloadCoinMap"
+	Price_SearchCoins_FullMethodName                = "/price.v1.Price/SearchCoins"
+	Price_GetCoin_FullMethodName                    = "/price.v1.Price/GetCoin"
+	Price_ListUnresolvedSymbols_FullMethodName      = "/price.v1.Price/ListUnresolvedSymbols"
+	Price_ResolveUnresolvedSymbol_FullMethodName    = "/price.v1.Price/ResolveUnresolvedSymbol"
 )
 
 // PriceClient is the client API for Price service.
@@ -29,8 +46,57 @@ const (
 type PriceClient interface {
 	// Valuates batch of transactions and returns calculated prices.
 	ValuateTransactionsBatch(ctx context.Context, in *ValuateTransactionsRequest, opts ...grpc.CallOption) (*ValuateTransactionsResponse, error)
-	// Creates or updates tenant symbol.
+	// Valuates a stream of transaction chunks, answering each chunk as soon as it is priced,
+	// one response per chunk in the order they were sent.
+	// Settings (tenant, source, fiat, price point, lookup, snapshot) are taken from the first chunk;
+	// later chunks may leave them empty but must not change them. A chunk that fails as a whole
+	// is answered with chunk_error and the stream goes on.
+	ValuateTransactionsStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValuateTransactionsRequest, ValuateTransactionsResponse], error)
+	// Queues an asynchronous valuation job; poll GetValuationJob for progress.
+	SubmitValuationJob(ctx context.Context, in *SubmitValuationJobRequest, opts ...grpc.CallOption) (*SubmitValuationJobResponse, error)
+	// Returns status and progress of a valuation job.
+	GetValuationJob(ctx context.Context, in *GetValuationJobRequest, opts ...grpc.CallOption) (*GetValuationJobResponse, error)
+	// Pages through the priced transactions of a valuation job, in submission order.
+	ListValuationJobResults(ctx context.Context, in *ListValuationJobResultsRequest, opts ...grpc.CallOption) (*ListValuationJobResultsResponse, error)
+	// Creates or updates tenant symbol. The coin ID must be in the local coin catalog while
+	// it is synced (unchecked when syncing is disabled or has not run yet);
+	// a window overlapping another mapping of the symbol fails with ALREADY_EXISTS.
 	UpsertTenantSymbol(ctx context.Context, in *UpsertTenantSymbolRequest, opts ...grpc.CallOption) (*UpsertTenantSymbolResponse, error)
+	// Imports tenant symbol mappings from a CSV or JSON payload, checked like UpsertTenantSymbol
+	// against the stored mappings and each other. Rows that cannot be applied come back as issues;
+	// then nothing is stored. Otherwise, unless dry_run is set, all rows are stored in one transaction.
+	ImportTenantSymbols(ctx context.Context, in *ImportTenantSymbolsRequest, opts ...grpc.CallOption) (*ImportTenantSymbolsResponse, error)
+	// Exports the tenant's symbol mappings in a payload ImportTenantSymbols reads.
+	ExportTenantSymbols(ctx context.Context, in *ExportTenantSymbolsRequest, opts ...grpc.CallOption) (*ExportTenantSymbolsResponse, error)
+	// Creates a tenant's custom asset, or renames it when the symbol exists.
+	// Custom assets resolve ahead of provider coins for that tenant.
+	UpsertCustomAsset(ctx context.Context, in *UpsertCustomAssetRequest, opts ...grpc.CallOption) (*UpsertCustomAssetResponse, error)
+	// Lists a tenant's custom assets.
+	ListCustomAssets(ctx context.Context, in *ListCustomAssetsRequest, opts ...grpc.CallOption) (*ListCustomAssetsResponse, error)
+	// Deletes a custom asset together with its prices.
+	DeleteCustomAsset(ctx context.Context, in *DeleteCustomAssetRequest, opts ...grpc.CallOption) (*DeleteCustomAssetResponse, error)
+	// Creates or replaces price points of a custom asset.
+	UpsertCustomAssetPrices(ctx context.Context, in *UpsertCustomAssetPricesRequest, opts ...grpc.CallOption) (*UpsertCustomAssetPricesResponse, error)
+	// Lists price points of a custom asset in [from, to).
+	ListCustomAssetPrices(ctx context.Context, in *ListCustomAssetPricesRequest, opts ...grpc.CallOption) (*ListCustomAssetPricesResponse, error)
+	// Uploads price points as CSV with header "symbol,time_utc,price,currency",
+	// creating missing assets. Nothing is stored when any row is invalid.
+	UploadCustomAssetPricesCsv(ctx context.Context, in *UploadCustomAssetPricesCsvRequest, opts ...grpc.CallOption) (*UploadCustomAssetPricesCsvResponse, error)
+	// Admin: re-reads the global symbol map file. An invalid file is rejected
+	// with FAILED_PRECONDITION and the previous map stays in use.
+	ReloadCoinMap(ctx context.Context, in *ReloadCoinMapRequest, opts ...grpc.CallOption) (*ReloadCoinMapResponse, error)
+	// Searches the local coin catalog by symbol, name or coin ID. Exact symbol matches come
+	// first, then symbol prefixes, name prefixes and other substring matches.
+	SearchCoins(ctx context.Context, in *SearchCoinsRequest, opts ...grpc.CallOption) (*SearchCoinsResponse, error)
+	// Returns one coin of the local coin catalog.
+	GetCoin(ctx context.Context, in *GetCoinRequest, opts ...grpc.CallOption) (*GetCoinResponse, error)
+	// Lists symbols valuations could not resolve, per tenant and source, oldest first.
+	// Only requests with a tenant and source are recorded; a symbol leaves the list once the
+	// tenant maps it through UpsertTenantSymbol, ImportTenantSymbols or ResolveUnresolvedSymbol.
+	ListUnresolvedSymbols(ctx context.Context, in *ListUnresolvedSymbolsRequest, opts ...grpc.CallOption) (*ListUnresolvedSymbolsResponse, error)
+	// Maps a queued symbol to a coin in the tenant's symbols and clears the queue entry.
+	// Validated like UpsertTenantSymbol; NOT_FOUND when the symbol is not queued.
+	ResolveUnresolvedSymbol(ctx context.Context, in *ResolveUnresolvedSymbolRequest, opts ...grpc.CallOption) (*ResolveUnresolvedSymbolResponse, error)
 }
 
 type priceClient struct {
@@ -51,6 +117,49 @@ func (c *priceClient) ValuateTransactionsBatch(ctx context.Context, in *ValuateT
 	return out, nil
 }
 
+func (c *priceClient) ValuateTransactionsStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValuateTransactionsRequest, ValuateTransactionsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Price_ServiceDesc.Streams[0], Price_ValuateTransactionsStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ValuateTransactionsRequest, ValuateTransactionsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Price_ValuateTransactionsStreamClient = grpc.BidiStreamingClient[ValuateTransactionsRequest, ValuateTransactionsResponse]
+
+func (c *priceClient) SubmitValuationJob(ctx context.Context, in *SubmitValuationJobRequest, opts ...grpc.CallOption) (*SubmitValuationJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitValuationJobResponse)
+	err := c.cc.Invoke(ctx, Price_SubmitValuationJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) GetValuationJob(ctx context.Context, in *GetValuationJobRequest, opts ...grpc.CallOption) (*GetValuationJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetValuationJobResponse)
+	err := c.cc.Invoke(ctx, Price_GetValuationJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) ListValuationJobResults(ctx context.Context, in *ListValuationJobResultsRequest, opts ...grpc.CallOption) (*ListValuationJobResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListValuationJobResultsResponse)
+	err := c.cc.Invoke(ctx, Price_ListValuationJobResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *priceClient) UpsertTenantSymbol(ctx context.Context, in *UpsertTenantSymbolRequest, opts ...grpc.CallOption) (*UpsertTenantSymbolResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpsertTenantSymbolResponse)
@@ -61,14 +170,193 @@ func (c *priceClient) UpsertTenantSymbol(ctx context.Context, in *UpsertTenantSy
 	return out, nil
 }
 
+func (c *priceClient) ImportTenantSymbols(ctx context.Context, in *ImportTenantSymbolsRequest, opts ...grpc.CallOption) (*ImportTenantSymbolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportTenantSymbolsResponse)
+	err := c.cc.Invoke(ctx, Price_ImportTenantSymbols_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) ExportTenantSymbols(ctx context.Context, in *ExportTenantSymbolsRequest, opts ...grpc.CallOption) (*ExportTenantSymbolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportTenantSymbolsResponse)
+	err := c.cc.Invoke(ctx, Price_ExportTenantSymbols_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) UpsertCustomAsset(ctx context.Context, in *UpsertCustomAssetRequest, opts ...grpc.CallOption) (*UpsertCustomAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertCustomAssetResponse)
+	err := c.cc.Invoke(ctx, Price_UpsertCustomAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) ListCustomAssets(ctx context.Context, in *ListCustomAssetsRequest, opts ...grpc.CallOption) (*ListCustomAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCustomAssetsResponse)
+	err := c.cc.Invoke(ctx, Price_ListCustomAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) DeleteCustomAsset(ctx context.Context, in *DeleteCustomAssetRequest, opts ...grpc.CallOption) (*DeleteCustomAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCustomAssetResponse)
+	err := c.cc.Invoke(ctx, Price_DeleteCustomAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) UpsertCustomAssetPrices(ctx context.Context, in *UpsertCustomAssetPricesRequest, opts ...grpc.CallOption) (*UpsertCustomAssetPricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertCustomAssetPricesResponse)
+	err := c.cc.Invoke(ctx, Price_UpsertCustomAssetPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) ListCustomAssetPrices(ctx context.Context, in *ListCustomAssetPricesRequest, opts ...grpc.CallOption) (*ListCustomAssetPricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCustomAssetPricesResponse)
+	err := c.cc.Invoke(ctx, Price_ListCustomAssetPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) UploadCustomAssetPricesCsv(ctx context.Context, in *UploadCustomAssetPricesCsvRequest, opts ...grpc.CallOption) (*UploadCustomAssetPricesCsvResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadCustomAssetPricesCsvResponse)
+	err := c.cc.Invoke(ctx, Price_UploadCustomAssetPricesCsv_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) ReloadCoinMap(ctx context.Context, in *ReloadCoinMapRequest, opts ...grpc.CallOption) (*ReloadCoinMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadCoinMapResponse)
+	err := c.cc.Invoke(ctx, Price_ReloadCoinMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) SearchCoins(ctx context.Context, in *SearchCoinsRequest, opts ...grpc.CallOption) (*SearchCoinsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCoinsResponse)
+	err := c.cc.Invoke(ctx, Price_SearchCoins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) GetCoin(ctx context.Context, in *GetCoinRequest, opts ...grpc.CallOption) (*GetCoinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCoinResponse)
+	err := c.cc.Invoke(ctx, Price_GetCoin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) ListUnresolvedSymbols(ctx context.Context, in *ListUnresolvedSymbolsRequest, opts ...grpc.CallOption) (*ListUnresolvedSymbolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUnresolvedSymbolsResponse)
+	err := c.cc.Invoke(ctx, Price_ListUnresolvedSymbols_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceClient) ResolveUnresolvedSymbol(ctx context.Context, in *ResolveUnresolvedSymbolRequest, opts ...grpc.CallOption) (*ResolveUnresolvedSymbolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveUnresolvedSymbolResponse)
+	err := c.cc.Invoke(ctx, Price_ResolveUnresolvedSymbol_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PriceServer is the server API for Price service.
 // All implementations must embed UnimplementedPriceServer
 // for forward compatibility.
 type PriceServer interface {
 	// Valuates batch of transactions and returns calculated prices.
 	ValuateTransactionsBatch(context.Context, *ValuateTransactionsRequest) (*ValuateTransactionsResponse, error)
-	// Creates or updates tenant symbol.
+	// Valuates a stream of transaction chunks, answering each chunk as soon as it is priced,
+	// one response per chunk in the order they were sent.
+	// Settings (tenant, source, fiat, price point, lookup, snapshot) are taken from the first chunk;
+	// later chunks may leave them empty but must not change them. A chunk that fails as a whole
+	// is answered with chunk_error and the stream goes on.
+	ValuateTransactionsStream(grpc.BidiStreamingServer[ValuateTransactionsRequest, ValuateTransactionsResponse]) error
+	// Queues an asynchronous valuation job; poll GetValuationJob for progress.
+	SubmitValuationJob(context.Context, *SubmitValuationJobRequest) (*SubmitValuationJobResponse, error)
+	// Returns status and progress of a valuation job.
+	GetValuationJob(context.Context, *GetValuationJobRequest) (*GetValuationJobResponse, error)
+	// Pages through the priced transactions of a valuation job, in submission order.
+	ListValuationJobResults(context.Context, *ListValuationJobResultsRequest) (*ListValuationJobResultsResponse, error)
+	// Creates or updates tenant symbol. The coin ID must be in the local coin catalog while
+	// it is synced (unchecked when syncing is disabled or has not run yet);
+	// a window overlapping another mapping of the symbol fails with ALREADY_EXISTS.
 	UpsertTenantSymbol(context.Context, *UpsertTenantSymbolRequest) (*UpsertTenantSymbolResponse, error)
+	// Imports tenant symbol mappings from a CSV or JSON payload, checked like UpsertTenantSymbol
+	// against the stored mappings and each other. Rows that cannot be applied come back as issues;
+	// then nothing is stored. Otherwise, unless dry_run is set, all rows are stored in one transaction.
+	ImportTenantSymbols(context.Context, *ImportTenantSymbolsRequest) (*ImportTenantSymbolsResponse, error)
+	// Exports the tenant's symbol mappings in a payload ImportTenantSymbols reads.
+	ExportTenantSymbols(context.Context, *ExportTenantSymbolsRequest) (*ExportTenantSymbolsResponse, error)
+	// Creates a tenant's custom asset, or renames it when the symbol exists.
+	// Custom assets resolve ahead of provider coins for that tenant.
+	UpsertCustomAsset(context.Context, *UpsertCustomAssetRequest) (*UpsertCustomAssetResponse, error)
+	// Lists a tenant's custom assets.
+	ListCustomAssets(context.Context, *ListCustomAssetsRequest) (*ListCustomAssetsResponse, error)
+	// Deletes a custom asset together with its prices.
+	DeleteCustomAsset(context.Context, *DeleteCustomAssetRequest) (*DeleteCustomAssetResponse, error)
+	// Creates or replaces price points of a custom asset.
+	UpsertCustomAssetPrices(context.Context, *UpsertCustomAssetPricesRequest) (*UpsertCustomAssetPricesResponse, error)
+	// Lists price points of a custom asset in [from, to).
+	ListCustomAssetPrices(context.Context, *ListCustomAssetPricesRequest) (*ListCustomAssetPricesResponse, error)
+	// Uploads price points as CSV with header "symbol,time_utc,price,currency",
+	// creating missing assets. Nothing is stored when any row is invalid.
+	UploadCustomAssetPricesCsv(context.Context, *UploadCustomAssetPricesCsvRequest) (*UploadCustomAssetPricesCsvResponse, error)
+	// Admin: re-reads the global symbol map file. An invalid file is rejected
+	// with FAILED_PRECONDITION and the previous map stays in use.
+	ReloadCoinMap(context.Context, *ReloadCoinMapRequest) (*ReloadCoinMapResponse, error)
+	// Searches the local coin catalog by symbol, name or coin ID. Exact symbol matches come
+	// first, then symbol prefixes, name prefixes and other substring matches.
+	SearchCoins(context.Context, *SearchCoinsRequest) (*SearchCoinsResponse, error)
+	// Returns one coin of the local coin catalog.
+	GetCoin(context.Context, *GetCoinRequest) (*GetCoinResponse, error)
+	// Lists symbols valuations could not resolve, per tenant and source, oldest first.
+	// Only requests with a tenant and source are recorded; a symbol leaves the list once the
+	// tenant maps it through UpsertTenantSymbol, ImportTenantSymbols or ResolveUnresolvedSymbol.
+	ListUnresolvedSymbols(context.Context, *ListUnresolvedSymbolsRequest) (*ListUnresolvedSymbolsResponse, error)
+	// Maps a queued symbol to a coin in the tenant's symbols and clears the queue entry.
+	// Validated like UpsertTenantSymbol; NOT_FOUND when the symbol is not queued.
+	ResolveUnresolvedSymbol(context.Context, *ResolveUnresolvedSymbolRequest) (*ResolveUnresolvedSymbolResponse, error)
 	mustEmbedUnimplementedPriceServer()
 }
 
@@ -82,9 +370,60 @@ type UnimplementedPriceServer struct{}
 func (UnimplementedPriceServer) ValuateTransactionsBatch(context.Context, *ValuateTransactionsRequest) (*ValuateTransactionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ValuateTransactionsBatch not implemented")
 }
+func (UnimplementedPriceServer) ValuateTransactionsStream(grpc.BidiStreamingServer[ValuateTransactionsRequest, ValuateTransactionsResponse]) error {
+	return status.Error(codes.Unimplemented, "method ValuateTransactionsStream not implemented")
+}
+func (UnimplementedPriceServer) SubmitValuationJob(context.Context, *SubmitValuationJobRequest) (*SubmitValuationJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitValuationJob not implemented")
+}
+func (UnimplementedPriceServer) GetValuationJob(context.Context, *GetValuationJobRequest) (*GetValuationJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetValuationJob not implemented")
+}
+func (UnimplementedPriceServer) ListValuationJobResults(context.Context, *ListValuationJobResultsRequest) (*ListValuationJobResultsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListValuationJobResults not implemented")
+}
 func (UnimplementedPriceServer) UpsertTenantSymbol(context.Context, *UpsertTenantSymbolRequest) (*UpsertTenantSymbolResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpsertTenantSymbol not implemented")
 }
+func (UnimplementedPriceServer) ImportTenantSymbols(context.Context, *ImportTenantSymbolsRequest) (*ImportTenantSymbolsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportTenantSymbols not implemented")
+}
+func (UnimplementedPriceServer) ExportTenantSymbols(context.Context, *ExportTenantSymbolsRequest) (*ExportTenantSymbolsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportTenantSymbols not implemented")
+}
+func (UnimplementedPriceServer) UpsertCustomAsset(context.Context, *UpsertCustomAssetRequest) (*UpsertCustomAssetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertCustomAsset not implemented")
+}
+func (UnimplementedPriceServer) ListCustomAssets(context.Context, *ListCustomAssetsRequest) (*ListCustomAssetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCustomAssets not implemented")
+}
+func (UnimplementedPriceServer) DeleteCustomAsset(context.Context, *DeleteCustomAssetRequest) (*DeleteCustomAssetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCustomAsset not implemented")
+}
+func (UnimplementedPriceServer) UpsertCustomAssetPrices(context.Context, *UpsertCustomAssetPricesRequest) (*UpsertCustomAssetPricesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertCustomAssetPrices not implemented")
+}
+func (UnimplementedPriceServer) ListCustomAssetPrices(context.Context, *ListCustomAssetPricesRequest) (*ListCustomAssetPricesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCustomAssetPrices not implemented")
+}
+func (UnimplementedPriceServer) UploadCustomAssetPricesCsv(context.Context, *UploadCustomAssetPricesCsvRequest) (*UploadCustomAssetPricesCsvResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UploadCustomAssetPricesCsv not implemented")
+}
+func (UnimplementedPriceServer) ReloadCoinMap(context.Context, *ReloadCoinMapRequest) (*ReloadCoinMapResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReloadCoinMap not implemented")
+}
+func (UnimplementedPriceServer) SearchCoins(context.Context, *SearchCoinsRequest) (*SearchCoinsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchCoins not implemented")
+}
+func (UnimplementedPriceServer) GetCoin(context.Context, *GetCoinRequest) (*GetCoinResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCoin not implemented")
+}
+func (UnimplementedPriceServer) ListUnresolvedSymbols(context.Context, *ListUnresolvedSymbolsRequest) (*ListUnresolvedSymbolsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUnresolvedSymbols not implemented")
+}
+func (UnimplementedPriceServer) ResolveUnresolvedSymbol(context.Context, *ResolveUnresolvedSymbolRequest) (*ResolveUnresolvedSymbolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveUnresolvedSymbol not implemented")
+}
 func (UnimplementedPriceServer) mustEmbedUnimplementedPriceServer() {}
 func (UnimplementedPriceServer) testEmbeddedByValue()               {}
 
@@ -124,6 +463,67 @@ func _Price_ValuateTransactionsBatch_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Price_ValuateTransactionsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PriceServer).ValuateTransactionsStream(&grpc.GenericServerStream[ValuateTransactionsRequest, ValuateTransactionsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Price_ValuateTransactionsStreamServer = grpc.BidiStreamingServer[ValuateTransactionsRequest, ValuateTransactionsResponse]
+
+func _Price_SubmitValuationJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitValuationJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).SubmitValuationJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_SubmitValuationJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).SubmitValuationJob(ctx, req.(*SubmitValuationJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_GetValuationJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValuationJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).GetValuationJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_GetValuationJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).GetValuationJob(ctx, req.(*GetValuationJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_ListValuationJobResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListValuationJobResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ListValuationJobResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ListValuationJobResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ListValuationJobResults(ctx, req.(*ListValuationJobResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Price_UpsertTenantSymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpsertTenantSymbolRequest)
 	if err := dec(in); err != nil {
@@ -142,6 +542,240 @@ func _Price_UpsertTenantSymbol_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Price_ImportTenantSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTenantSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ImportTenantSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ImportTenantSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ImportTenantSymbols(ctx, req.(*ImportTenantSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_ExportTenantSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportTenantSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ExportTenantSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ExportTenantSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ExportTenantSymbols(ctx, req.(*ExportTenantSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_UpsertCustomAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertCustomAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).UpsertCustomAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_UpsertCustomAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).UpsertCustomAsset(ctx, req.(*UpsertCustomAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_ListCustomAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ListCustomAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ListCustomAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ListCustomAssets(ctx, req.(*ListCustomAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_DeleteCustomAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).DeleteCustomAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_DeleteCustomAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).DeleteCustomAsset(ctx, req.(*DeleteCustomAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_UpsertCustomAssetPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertCustomAssetPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).UpsertCustomAssetPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_UpsertCustomAssetPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).UpsertCustomAssetPrices(ctx, req.(*UpsertCustomAssetPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_ListCustomAssetPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomAssetPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ListCustomAssetPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ListCustomAssetPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ListCustomAssetPrices(ctx, req.(*ListCustomAssetPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_UploadCustomAssetPricesCsv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadCustomAssetPricesCsvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).UploadCustomAssetPricesCsv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_UploadCustomAssetPricesCsv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).UploadCustomAssetPricesCsv(ctx, req.(*UploadCustomAssetPricesCsvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_ReloadCoinMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadCoinMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ReloadCoinMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ReloadCoinMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ReloadCoinMap(ctx, req.(*ReloadCoinMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_SearchCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCoinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).SearchCoins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_SearchCoins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).SearchCoins(ctx, req.(*SearchCoinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_GetCoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).GetCoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_GetCoin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).GetCoin(ctx, req.(*GetCoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_ListUnresolvedSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnresolvedSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ListUnresolvedSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ListUnresolvedSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ListUnresolvedSymbols(ctx, req.(*ListUnresolvedSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Price_ResolveUnresolvedSymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveUnresolvedSymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceServer).ResolveUnresolvedSymbol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Price_ResolveUnresolvedSymbol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceServer).ResolveUnresolvedSymbol(ctx, req.(*ResolveUnresolvedSymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Price_ServiceDesc is the grpc.ServiceDesc for Price service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,11 +787,82 @@ var Price_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValuateTransactionsBatch",
 			Handler:    _Price_ValuateTransactionsBatch_Handler,
 		},
+		{
+			MethodName: "SubmitValuationJob",
+			Handler:    _Price_SubmitValuationJob_Handler,
+		},
+		{
+			MethodName: "GetValuationJob",
+			Handler:    _Price_GetValuationJob_Handler,
+		},
+		{
+			MethodName: "ListValuationJobResults",
+			Handler:    _Price_ListValuationJobResults_Handler,
+		},
 		{
 			MethodName: "UpsertTenantSymbol",
 			Handler:    _Price_UpsertTenantSymbol_Handler,
 		},
+		{
+			MethodName: "ImportTenantSymbols",
+			Handler:    _Price_ImportTenantSymbols_Handler,
+		},
+		{
+			MethodName: "ExportTenantSymbols",
+			Handler:    _Price_ExportTenantSymbols_Handler,
+		},
+		{
+			MethodName: "UpsertCustomAsset",
+			Handler:    _Price_UpsertCustomAsset_Handler,
+		},
+		{
+			MethodName: "ListCustomAssets",
+			Handler:    _Price_ListCustomAssets_Handler,
+		},
+		{
+			MethodName: "DeleteCustomAsset",
+			Handler:    _Price_DeleteCustomAsset_Handler,
+		},
+		{
+			MethodName: "UpsertCustomAssetPrices",
+			Handler:    _Price_UpsertCustomAssetPrices_Handler,
+		},
+		{
+			MethodName: "ListCustomAssetPrices",
+			Handler:    _Price_ListCustomAssetPrices_Handler,
+		},
+		{
+			MethodName: "UploadCustomAssetPricesCsv",
+			Handler:    _Price_UploadCustomAssetPricesCsv_Handler,
+		},
+		{
+			MethodName: "ReloadCoinMap",
+			Handler:    _Price_ReloadCoinMap_Handler,
+		},
+		{
+			MethodName: "SearchCoins",
+			Handler:    _Price_SearchCoins_Handler,
+		},
+		{
+			MethodName: "GetCoin",
+			Handler:    _Price_GetCoin_Handler,
+		},
+		{
+			MethodName: "ListUnresolvedSymbols",
+			Handler:    _Price_ListUnresolvedSymbols_Handler,
+		},
+		{
+			MethodName: "ResolveUnresolvedSymbol",
+			Handler:    _Price_ResolveUnresolvedSymbol_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ValuateTransactionsStream",
+			Handler:       _Price_ValuateTransactionsStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "price/v1/price.proto",
 }
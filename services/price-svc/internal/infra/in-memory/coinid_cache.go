@@ -3,25 +3,44 @@ package inmemory
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/in-memory"
 	"gopkg.in/yaml.v3"
 )
 
 type Symbol = string
-type CoinID = string
 
-type CoinIdCache = inmemory.Store[Symbol, CoinID]
+// CoinIdCache holds the global symbol map; a symbol has one mapping per validity window.
+type CoinIdCache = inmemory.Store[Symbol, []domain.SymbolMapping]
 
 type coinIdFile struct {
 	Coins []struct {
 		Symbol string `yaml:"symbol"`
 		CoinID string `yaml:"coin_id"`
+		// ValidFrom and ValidTo are optional RFC3339 times or YYYY-MM-DD dates (UTC midnight).
+		ValidFrom string `yaml:"valid_from"`
+		ValidTo   string `yaml:"valid_to"`
 	} `yaml:"coins"`
 }
 
 func NewCoinIdCache(path string) (*CoinIdCache, error) {
+	m, err := loadCoinIds(path)
+	if err != nil {
+		return nil, err
+	}
+
+	store := inmemory.NewStore[Symbol, []domain.SymbolMapping]()
+	store.ReplaceAll(m)
+
+	return store, nil
+}
+
+// loadCoinIds reads and validates the coin map file; any invalid entry rejects the whole file.
+func loadCoinIds(path string) (map[Symbol][]domain.SymbolMapping, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -31,8 +50,11 @@ func NewCoinIdCache(path string) (*CoinIdCache, error) {
 	if err := yaml.Unmarshal(b, &f); err != nil {
 		return nil, err
 	}
+	if len(f.Coins) == 0 {
+		return nil, fmt.Errorf("coinid: %s has no coins", path)
+	}
 
-	m := make(map[Symbol]CoinID, len(f.Coins))
+	m := make(map[Symbol][]domain.SymbolMapping, len(f.Coins))
 	for i, c := range f.Coins {
 		sym := strings.TrimSpace(c.Symbol)
 		id := strings.TrimSpace(c.CoinID)
@@ -40,14 +62,40 @@ func NewCoinIdCache(path string) (*CoinIdCache, error) {
 		if sym == "" || id == "" {
 			return nil, fmt.Errorf("coinid: invalid entry at idx=%d (symbol=%q, coin_id=%q)", i, c.Symbol, c.CoinID)
 		}
-		if _, exists := m[sym]; exists {
-			return nil, fmt.Errorf("coinid: duplicate symbol %q", sym)
+
+		mapping := domain.SymbolMapping{CoinID: id}
+		if mapping.ValidFrom, err = parseValidity(c.ValidFrom); err != nil {
+			return nil, fmt.Errorf("coinid: invalid valid_from at idx=%d: %w", i, err)
+		}
+		if mapping.ValidTo, err = parseValidity(c.ValidTo); err != nil {
+			return nil, fmt.Errorf("coinid: invalid valid_to at idx=%d: %w", i, err)
+		}
+		if !mapping.ValidFrom.IsZero() && !mapping.ValidTo.IsZero() && !mapping.ValidFrom.Before(mapping.ValidTo) {
+			return nil, fmt.Errorf("coinid: empty validity window at idx=%d (symbol=%q)", i, sym)
+		}
+
+		for _, other := range m[sym] {
+			if other.Overlaps(mapping) {
+				return nil, fmt.Errorf("coinid: overlapping mappings for symbol %q", sym)
+			}
 		}
-		m[sym] = id
+		m[sym] = append(m[sym], mapping)
 	}
 
-	store := inmemory.NewStore[Symbol, CoinID]()
-	store.ReplaceAll(m)
+	for _, mappings := range m {
+		sort.Slice(mappings, func(i, j int) bool { return mappings[i].ValidFrom.Before(mappings[j].ValidFrom) })
+	}
 
-	return store, nil
+	return m, nil
+}
+
+func parseValidity(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(time.DateOnly, s)
 }
@@ -0,0 +1,91 @@
+package inmemory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+)
+
+func writeCoinIds(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "assets.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestLoadCoinIdsValidityWindows(t *testing.T) {
+	t.Parallel()
+
+	// windows are listed out of order and use both accepted formats
+	path := writeCoinIds(t, `
+coins:
+  - symbol: MATIC
+    coin_id: polygon-ecosystem-token
+    valid_from: 2024-09-04T00:00:00Z
+  - symbol: MATIC
+    coin_id: matic-network
+    valid_to: 2024-09-04
+  - symbol: BTC
+    coin_id: bitcoin
+`)
+
+	m, err := loadCoinIds(path)
+	if err != nil {
+		t.Fatalf("loadCoinIds() error = %v", err)
+	}
+
+	matic := m["MATIC"]
+	if len(matic) != 2 || matic[0].CoinID != "matic-network" {
+		t.Fatalf("MATIC mappings = %+v, want matic-network first", matic)
+	}
+
+	switchAt := time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC)
+	if got, _ := domain.MappingAt(matic, switchAt.Add(-time.Minute)); got != "matic-network" {
+		t.Fatalf("MATIC before the switch = %q, want matic-network", got)
+	}
+	if got, _ := domain.MappingAt(matic, switchAt); got != "polygon-ecosystem-token" {
+		t.Fatalf("MATIC at the switch = %q, want polygon-ecosystem-token", got)
+	}
+	if got, _ := domain.MappingAt(m["BTC"], time.Time{}); got != "bitcoin" {
+		t.Fatalf("BTC = %q, want bitcoin", got)
+	}
+}
+
+func TestLoadCoinIdsRejectsBadWindows(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		content string
+	}{
+		{name: "overlapping windows", content: `
+coins:
+  - {symbol: LUNA, coin_id: terra-luna, valid_to: 2022-06-01}
+  - {symbol: LUNA, coin_id: terra-luna-2, valid_from: 2022-05-28}
+`},
+		{name: "two open mappings", content: `
+coins:
+  - {symbol: LUNA, coin_id: terra-luna}
+  - {symbol: LUNA, coin_id: terra-luna-2}
+`},
+		{name: "empty window", content: `
+coins:
+  - {symbol: LUNA, coin_id: terra-luna, valid_from: 2022-05-28, valid_to: 2022-05-28}
+`},
+		{name: "bad date", content: `
+coins:
+  - {symbol: LUNA, coin_id: terra-luna, valid_to: 28.05.2022}
+`},
+	}
+
+	for _, tc := range cases {
+		if _, err := loadCoinIds(writeCoinIds(t, tc.content)); err == nil {
+			t.Fatalf("%s: loadCoinIds() error = nil, want error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package inmemory
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+)
+
+// CoinIdReloader swaps a freshly read coin map file into the cache. A file that fails
+// validation is rejected and the previous map keeps serving.
+type CoinIdReloader struct {
+	log   logger.Logger
+	path  string
+	cache *CoinIdCache
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+func NewCoinIdReloader(log logger.Logger, path string, cache *CoinIdCache) *CoinIdReloader {
+	r := &CoinIdReloader{log: log, path: path, cache: cache}
+	if fi, err := os.Stat(path); err == nil {
+		r.modTime, r.size = fi.ModTime(), fi.Size()
+	}
+	return r
+}
+
+// Reload reads the file now and returns how many coins it maps.
+func (r *CoinIdReloader) Reload(_ context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reload()
+}
+
+func (r *CoinIdReloader) reload() (int, error) {
+	fi, statErr := os.Stat(r.path)
+
+	m, err := loadCoinIds(r.path)
+	if err != nil {
+		r.log.Error("coin map reload failed, keeping previous map path=%s: %v", r.path, err)
+		// remember the broken version so polling does not retry it until the file changes again
+		if statErr == nil {
+			r.modTime, r.size = fi.ModTime(), fi.Size()
+		}
+		return 0, err
+	}
+
+	r.cache.ReplaceAll(m)
+	if statErr == nil {
+		r.modTime, r.size = fi.ModTime(), fi.Size()
+	}
+
+	r.log.Info("coin map reloaded path=%s coins=%d", r.path, len(m))
+	return len(m), nil
+}
+
+// Watch polls the file and reloads it whenever it changes, until ctx is done.
+func (r *CoinIdReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
+
+func (r *CoinIdReloader) reloadIfChanged() {
+	fi, err := os.Stat(r.path)
+	if err != nil {
+		r.log.Warn("coin map stat failed path=%s: %v", r.path, err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if fi.ModTime().Equal(r.modTime) && fi.Size() == r.size {
+		return
+	}
+	_, _ = r.reload()
+}
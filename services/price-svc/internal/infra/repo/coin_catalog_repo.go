@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type coinCatalogRepository struct {
+	store db.Store
+}
+
+func NewCoinCatalogRepo(store db.Store) domain.CoinCatalogRepo {
+	return &coinCatalogRepository{store: store}
+}
+
+func (r *coinCatalogRepository) List(ctx context.Context) ([]domain.Coin, error) {
+	rows, err := r.store.ListCoins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("List: query failed: %w", err)
+	}
+	return mapCoinsDBToDomain(rows)
+}
+
+func (r *coinCatalogRepository) Get(ctx context.Context, id string) (domain.Coin, error) {
+	row, err := r.store.GetCoin(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Coin{}, fmt.Errorf("Get: coin %s: %w", id, apperr.ErrNotFound)
+		}
+		return domain.Coin{}, fmt.Errorf("Get: query failed: %w", err)
+	}
+
+	coins, err := mapCoinsDBToDomain([]db.Coin{row})
+	if err != nil {
+		return domain.Coin{}, fmt.Errorf("Get: %w", err)
+	}
+	return coins[0], nil
+}
+
+func (r *coinCatalogRepository) Search(ctx context.Context, query string, limit int) ([]domain.Coin, error) {
+	escaped := likeEscaper.Replace(query)
+
+	rows, err := r.store.SearchCoins(ctx, db.SearchCoinsParams{
+		Query:      query,
+		Prefix:     escaped + "%",
+		Contains:   "%" + escaped + "%",
+		MaxResults: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Search: query failed: %w", err)
+	}
+	return mapCoinsDBToDomain(rows)
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *coinCatalogRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Coin, error) {
+	if len(ids) == 0 {
+		return []domain.Coin{}, nil
+	}
+
+	rows, err := r.store.GetCoinsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("GetByIDs: query failed: %w", err)
+	}
+	return mapCoinsDBToDomain(rows)
+}
+
+func (r *coinCatalogRepository) GetActiveBySymbols(ctx context.Context, symbols []string) ([]domain.Coin, error) {
+	if len(symbols) == 0 {
+		return []domain.Coin{}, nil
+	}
+
+	lower := make([]string, len(symbols))
+	for i, s := range symbols {
+		lower[i] = strings.ToLower(s)
+	}
+
+	rows, err := r.store.GetActiveCoinsBySymbols(ctx, lower)
+	if err != nil {
+		return nil, fmt.Errorf("GetActiveBySymbols: query failed: %w", err)
+	}
+	return mapCoinsDBToDomain(rows)
+}
+
+func (r *coinCatalogRepository) LastSync(ctx context.Context) (time.Time, error) {
+	ts, err := r.store.GetCoinCatalogLastSync(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("LastSync: query failed: %w", err)
+	}
+	if !ts.Valid {
+		return time.Time{}, nil
+	}
+	return ts.Time, nil
+}
+
+func (r *coinCatalogRepository) Sync(
+	ctx context.Context,
+	coins []domain.Coin,
+	changes []domain.CoinChange,
+	syncedAt time.Time,
+) error {
+	if len(coins) == 0 {
+		return fmt.Errorf("Sync: refusing to sync an empty coin list")
+	}
+
+	arg := db.SyncCoinCatalogTxParams{
+		SyncedAt: pgtype.Timestamptz{Time: syncedAt.UTC(), Valid: true},
+		Coins: db.UpsertCoinsParams{
+			Ids:       make([]string, len(coins)),
+			Symbols:   make([]string, len(coins)),
+			Names:     make([]string, len(coins)),
+			Platforms: make([][]byte, len(coins)),
+		},
+	}
+
+	for i, c := range coins {
+		platforms := c.Platforms
+		if platforms == nil {
+			platforms = map[string]string{}
+		}
+		b, err := json.Marshal(platforms)
+		if err != nil {
+			return fmt.Errorf("Sync: marshal platforms of %s: %w", c.ID, err)
+		}
+
+		arg.Coins.Ids[i] = c.ID
+		arg.Coins.Symbols[i] = c.Symbol
+		arg.Coins.Names[i] = c.Name
+		arg.Coins.Platforms[i] = b
+	}
+
+	for _, ch := range changes {
+		arg.Changes.CoinIds = append(arg.Changes.CoinIds, ch.CoinID)
+		arg.Changes.Changes = append(arg.Changes.Changes, string(ch.Kind))
+		arg.Changes.OldSymbols = append(arg.Changes.OldSymbols, ch.OldSymbol)
+		arg.Changes.OldNames = append(arg.Changes.OldNames, ch.OldName)
+		arg.Changes.NewSymbols = append(arg.Changes.NewSymbols, ch.NewSymbol)
+		arg.Changes.NewNames = append(arg.Changes.NewNames, ch.NewName)
+	}
+
+	if _, err := r.store.SyncCoinCatalogTx(ctx, arg); err != nil {
+		return fmt.Errorf("Sync: tx failed: %w", err)
+	}
+	return nil
+}
+
+func mapCoinsDBToDomain(rows []db.Coin) ([]domain.Coin, error) {
+	out := make([]domain.Coin, 0, len(rows))
+	for _, row := range rows {
+		c := domain.Coin{
+			ID:        row.ID,
+			Symbol:    row.Symbol,
+			Name:      row.Name,
+			FirstSeen: row.FirstSeen.Time,
+			LastSeen:  row.LastSeen.Time,
+			Delisted:  row.Delisted,
+		}
+		if len(row.Platforms) > 0 {
+			if err := json.Unmarshal(row.Platforms, &c.Platforms); err != nil {
+				return nil, fmt.Errorf("coin %s: invalid platforms: %w", row.ID, err)
+			}
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
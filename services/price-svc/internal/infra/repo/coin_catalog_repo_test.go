@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/postgres"
+	"github.com/google/uuid"
+)
+
+// TestSearchCoins runs against the migrated database in DATABASE_URL.
+func TestSearchCoins(t *testing.T) {
+	url := os.Getenv("DATABASE_URL")
+	if url == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pg, err := postgres.New(ctx, url)
+	if err != nil {
+		t.Fatalf("postgres.New() error = %v", err)
+	}
+	defer pg.Close()
+
+	// a token no real coin contains, so other rows never match
+	tok := "q" + strings.ReplaceAll(uuid.NewString(), "-", "")[:10]
+	defer func() {
+		if _, err := pg.Pool.Exec(ctx, "DELETE FROM coins WHERE id LIKE '%' || $1 || '%'", tok); err != nil {
+			t.Errorf("cleanup: %v", err)
+		}
+	}()
+
+	coins := []struct {
+		id, symbol, name string
+		delisted         bool
+	}{
+		{id: "coin-" + tok + "-id", symbol: "yy", name: "Other"},
+		{id: "coin-" + tok + "-name", symbol: "zz", name: strings.ToUpper(tok) + " Network"},
+		{id: tok + "-symbol-prefix", symbol: tok + "x", name: "Prefix"},
+		{id: tok + "-delisted", symbol: tok, name: "Gone", delisted: true},
+		{id: tok + "-exact", symbol: strings.ToUpper(tok), name: "Exact"},
+	}
+	for _, c := range coins {
+		if _, err := pg.Pool.Exec(ctx, "INSERT INTO coins (id, symbol, name, delisted) VALUES ($1, $2, $3, $4)", c.id, c.symbol, c.name, c.delisted); err != nil {
+			t.Fatalf("insert %s: %v", c.id, err)
+		}
+	}
+
+	repo := NewCoinCatalogRepo(db.NewStore(pg))
+	search := func(query string, limit int) []string {
+		t.Helper()
+		found, err := repo.Search(ctx, query, limit)
+		if err != nil {
+			t.Fatalf("Search(%q) error = %v", query, err)
+		}
+		ids := make([]string, len(found))
+		for i, c := range found {
+			ids[i] = c.ID
+		}
+		return ids
+	}
+
+	want := []string{tok + "-exact", tok + "-delisted", tok + "-symbol-prefix", "coin-" + tok + "-name", "coin-" + tok + "-id"}
+	if got := search(tok, 10); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Search(%q) = %v, want %v", tok, got, want)
+	}
+	if got := search(tok, 2); strings.Join(got, ",") != strings.Join(want[:2], ",") {
+		t.Fatalf("Search(%q, 2) = %v, want %v", tok, got, want[:2])
+	}
+	// wildcards in the query match literally
+	if got := search(tok[:4]+"%"+tok[5:], 10); len(got) != 0 {
+		t.Fatalf("Search() with a wildcard = %v, want nothing", got)
+	}
+}
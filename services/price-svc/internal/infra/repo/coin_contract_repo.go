@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type coinContractRepository struct {
+	store db.Store
+}
+
+func NewCoinContractRepo(store db.Store) domain.CoinContractRepo {
+	return &coinContractRepository{store: store}
+}
+
+func (r *coinContractRepository) GetByAddresses(ctx context.Context, addresses []string) ([]domain.CoinContract, error) {
+	if len(addresses) == 0 {
+		return []domain.CoinContract{}, nil
+	}
+
+	rows, err := r.store.GetCoinContractsByAddresses(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("GetByAddresses: query failed: %w", err)
+	}
+
+	out := make([]domain.CoinContract, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.CoinContract{
+			Platform:        row.Platform,
+			ContractAddress: row.ContractAddress,
+			CoinID:          row.CoinID,
+			SyncedAt:        row.SyncedAt.Time,
+		})
+	}
+	return out, nil
+}
+
+func (r *coinContractRepository) Replace(ctx context.Context, contracts []domain.CoinContract, syncedAt time.Time) (int64, error) {
+	if len(contracts) == 0 {
+		return 0, fmt.Errorf("Replace: refusing to replace contracts with an empty list")
+	}
+
+	arg := db.ReplaceCoinContractsTxParams{
+		SyncedAt:          pgtype.Timestamptz{Time: syncedAt.UTC(), Valid: true},
+		Platforms:         make([]string, len(contracts)),
+		ContractAddresses: make([]string, len(contracts)),
+		CoinIds:           make([]string, len(contracts)),
+	}
+	for i, c := range contracts {
+		arg.Platforms[i] = c.Platform
+		arg.ContractAddresses[i] = c.ContractAddress
+		arg.CoinIds[i] = c.CoinID
+	}
+
+	removed, err := r.store.ReplaceCoinContractsTx(ctx, arg)
+	if err != nil {
+		return 0, fmt.Errorf("Replace: tx failed: %w", err)
+	}
+	return removed, nil
+}
+
+func (r *coinContractRepository) LastSync(ctx context.Context) (time.Time, error) {
+	ts, err := r.store.GetCoinContractsLastSync(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("LastSync: query failed: %w", err)
+	}
+	if !ts.Valid {
+		return time.Time{}, nil
+	}
+	return ts.Time, nil
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type customAssetRepository struct {
+	store db.Store
+}
+
+func NewCustomAssetRepo(store db.Store) domain.CustomAssetRepo {
+	return &customAssetRepository{store: store}
+}
+
+func (r *customAssetRepository) Upsert(ctx context.Context, a domain.CustomAsset) (domain.CustomAsset, error) {
+	if a.TenantID == uuid.Nil {
+		return domain.CustomAsset{}, fmt.Errorf("Upsert: tenantID is nil")
+	}
+	if a.Symbol == "" {
+		return domain.CustomAsset{}, fmt.Errorf("Upsert: symbol is empty")
+	}
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+
+	row, err := r.store.UpsertCustomAsset(ctx, db.UpsertCustomAssetParams{
+		ID:       a.ID,
+		TenantID: a.TenantID,
+		Symbol:   a.Symbol,
+		Name:     a.Name,
+	})
+	if err != nil {
+		return domain.CustomAsset{}, fmt.Errorf("Upsert: query failed: %w", err)
+	}
+
+	return mapCustomAssetDBToDomain(row), nil
+}
+
+func (r *customAssetRepository) Get(ctx context.Context, tenantID, assetID uuid.UUID) (domain.CustomAsset, error) {
+	row, err := r.store.GetCustomAsset(ctx, db.GetCustomAssetParams{TenantID: tenantID, ID: assetID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CustomAsset{}, fmt.Errorf("Get: custom asset %s: %w", assetID, apperr.ErrNotFound)
+		}
+		return domain.CustomAsset{}, fmt.Errorf("Get: query failed: %w", err)
+	}
+
+	return mapCustomAssetDBToDomain(row), nil
+}
+
+func (r *customAssetRepository) List(ctx context.Context, tenantID uuid.UUID) ([]domain.CustomAsset, error) {
+	rows, err := r.store.ListCustomAssets(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("List: query failed: %w", err)
+	}
+
+	out := make([]domain.CustomAsset, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapCustomAssetDBToDomain(row))
+	}
+	return out, nil
+}
+
+func (r *customAssetRepository) GetBySymbols(ctx context.Context, tenantID uuid.UUID, symbols []string) ([]domain.CustomAsset, error) {
+	if tenantID == uuid.Nil || len(symbols) == 0 {
+		return []domain.CustomAsset{}, nil
+	}
+
+	rows, err := r.store.GetCustomAssetsBySymbols(ctx, db.GetCustomAssetsBySymbolsParams{
+		TenantID: tenantID,
+		Column2:  symbols,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetBySymbols: query failed: %w", err)
+	}
+
+	out := make([]domain.CustomAsset, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapCustomAssetDBToDomain(row))
+	}
+	return out, nil
+}
+
+func (r *customAssetRepository) Delete(ctx context.Context, tenantID, assetID uuid.UUID) error {
+	rowsAffected, err := r.store.DeleteCustomAsset(ctx, db.DeleteCustomAssetParams{TenantID: tenantID, ID: assetID})
+	if err != nil {
+		return fmt.Errorf("Delete: query failed: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("Delete: custom asset %s: %w", assetID, apperr.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *customAssetRepository) UpsertPrices(ctx context.Context, points []domain.CustomPricePoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	arg := db.UpsertCustomAssetPricesParams{
+		AssetIds:   make([]uuid.UUID, len(points)),
+		AtUtc:      make([]pgtype.Timestamptz, len(points)),
+		Prices:     make([]pgtype.Numeric, len(points)),
+		Currencies: make([]string, len(points)),
+	}
+	for i, p := range points {
+		price, err := decimalToNumeric(&p.Price)
+		if err != nil {
+			return fmt.Errorf("UpsertPrices: price: %w", err)
+		}
+		arg.AssetIds[i] = p.AssetID
+		arg.AtUtc[i] = pgtype.Timestamptz{Time: p.Time, Valid: true}
+		arg.Prices[i] = price
+		arg.Currencies[i] = p.Currency
+	}
+
+	if err := r.store.UpsertCustomAssetPrices(ctx, arg); err != nil {
+		return fmt.Errorf("UpsertPrices: query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *customAssetRepository) ListPrices(ctx context.Context, assetID uuid.UUID, from, to time.Time) ([]domain.CustomPricePoint, error) {
+	rows, err := r.store.ListCustomAssetPrices(ctx, db.ListCustomAssetPricesParams{
+		AssetID: assetID,
+		FromUtc: pgtype.Timestamptz{Time: from, Valid: true},
+		ToUtc:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListPrices: query failed: %w", err)
+	}
+
+	out := make([]domain.CustomPricePoint, 0, len(rows))
+	for _, row := range rows {
+		p, err := mapCustomAssetPriceDBToDomain(row.AssetID, row.AtUtc, row.Price, row.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("ListPrices: %w", err)
+		}
+		out = append(out, *p)
+	}
+	return out, nil
+}
+
+func (r *customAssetRepository) GetPricesAt(ctx context.Context, assetIDs []uuid.UUID, times []time.Time) ([]*domain.CustomPricePoint, error) {
+	if len(assetIDs) != len(times) {
+		return nil, fmt.Errorf("GetPricesAt: got %d times for %d assets", len(times), len(assetIDs))
+	}
+	if len(assetIDs) == 0 {
+		return []*domain.CustomPricePoint{}, nil
+	}
+
+	rows, err := r.store.GetCustomAssetPricesAt(ctx, db.GetCustomAssetPricesAtParams{
+		Column1: assetIDs,
+		Column2: toTimestamptzSlice(times),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetPricesAt: query failed: %w", err)
+	}
+
+	out := make([]*domain.CustomPricePoint, 0, len(rows))
+	for _, row := range rows {
+		if !row.Price.Valid {
+			out = append(out, nil)
+			continue
+		}
+		p, err := mapCustomAssetPriceDBToDomain(row.AssetID, row.AtUtc, row.Price, row.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("GetPricesAt: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (r *customAssetRepository) ImportPrices(ctx context.Context, tenantID uuid.UUID, rows []domain.CustomPriceRow) error {
+	if tenantID == uuid.Nil {
+		return fmt.Errorf("ImportPrices: tenantID is nil")
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	arg := db.ImportCustomAssetPricesTxParams{
+		TenantID:   tenantID,
+		Symbols:    make([]string, len(rows)),
+		AtUtc:      make([]pgtype.Timestamptz, len(rows)),
+		Prices:     make([]pgtype.Numeric, len(rows)),
+		Currencies: make([]string, len(rows)),
+	}
+	for i, row := range rows {
+		price, err := decimalToNumeric(&row.Price)
+		if err != nil {
+			return fmt.Errorf("ImportPrices: price: %w", err)
+		}
+		arg.Symbols[i] = row.Symbol
+		arg.AtUtc[i] = pgtype.Timestamptz{Time: row.Time, Valid: true}
+		arg.Prices[i] = price
+		arg.Currencies[i] = row.Currency
+	}
+
+	if err := r.store.ImportCustomAssetPricesTx(ctx, arg); err != nil {
+		return fmt.Errorf("ImportPrices: tx failed: %w", err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 )
 
 type historicalPriceRepository struct {
@@ -20,7 +21,7 @@ func NewHistoricalPriceRepo(store db.Store) domain.HistoricalPriceRepo {
 	return &historicalPriceRepository{store: store}
 }
 
-func (r *historicalPriceRepository) GetBatch(ctx context.Context, priceKeys []domain.PriceKey) ([]domain.HistoricalPrice, error) {
+func (r *historicalPriceRepository) GetBatch(ctx context.Context, priceKeys []domain.PriceKey, asOf time.Time) ([]domain.HistoricalPrice, error) {
 	if len(priceKeys) == 0 {
 		return []domain.HistoricalPrice{}, nil
 	}
@@ -35,6 +36,7 @@ func (r *historicalPriceRepository) GetBatch(ctx context.Context, priceKeys []do
 	rows, err := r.store.GetHistoricalPricesBatch(ctx, db.GetHistoricalPricesBatchParams{
 		Column1: coinIDs,
 		Column2: toTimestamptzSlice(bucketStarts),
+		Column3: nullableTimestamptz(asOf),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("GetBatch: query failed: %w", err)
@@ -52,6 +54,95 @@ func (r *historicalPriceRepository) GetBatch(ctx context.Context, priceKeys []do
 	return out, nil
 }
 
+func (r *historicalPriceRepository) GetNearestBatch(ctx context.Context, priceKeys []domain.PriceKey, tolerance time.Duration, asOf time.Time) ([]domain.HistoricalPrice, error) {
+	if len(priceKeys) == 0 {
+		return []domain.HistoricalPrice{}, nil
+	}
+
+	coinIDs := make([]string, 0, len(priceKeys))
+	bucketStarts := make([]time.Time, 0, len(priceKeys))
+	for _, k := range priceKeys {
+		coinIDs = append(coinIDs, k.CoinID)
+		bucketStarts = append(bucketStarts, k.BucketStartUtc)
+	}
+
+	rows, err := r.store.GetNearestHistoricalPricesBatch(ctx, db.GetNearestHistoricalPricesBatchParams{
+		Column1: coinIDs,
+		Column2: toTimestamptzSlice(bucketStarts),
+		Column3: int32(tolerance / time.Second),
+		Column4: nullableTimestamptz(asOf),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetNearestBatch: query failed: %w", err)
+	}
+
+	out := make([]domain.HistoricalPrice, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapNearestHistoricalPriceRowDBToDomain(row))
+	}
+
+	return out, nil
+}
+
+func (r *historicalPriceRepository) GetContainingBatch(ctx context.Context, priceKeys []domain.PriceKey, asOf time.Time) ([]domain.HistoricalPrice, error) {
+	if len(priceKeys) == 0 {
+		return []domain.HistoricalPrice{}, nil
+	}
+
+	coinIDs := make([]string, 0, len(priceKeys))
+	bucketStarts := make([]time.Time, 0, len(priceKeys))
+	for _, k := range priceKeys {
+		coinIDs = append(coinIDs, k.CoinID)
+		bucketStarts = append(bucketStarts, k.BucketStartUtc)
+	}
+
+	rows, err := r.store.GetContainingHistoricalPricesBatch(ctx, db.GetContainingHistoricalPricesBatchParams{
+		Column1: coinIDs,
+		Column2: toTimestamptzSlice(bucketStarts),
+		Column3: nullableTimestamptz(asOf),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetContainingBatch: query failed: %w", err)
+	}
+
+	out := make([]domain.HistoricalPrice, 0, len(rows))
+	for _, row := range rows {
+		// same columns as the nearest lookup
+		out = append(out, mapNearestHistoricalPriceRowDBToDomain(db.GetNearestHistoricalPricesBatchRow(row)))
+	}
+
+	return out, nil
+}
+
+func (r *historicalPriceRepository) GetDailyAverageBatch(ctx context.Context, dayKeys []domain.PriceKey, asOf time.Time) ([]*decimal.Decimal, error) {
+	if len(dayKeys) == 0 {
+		return []*decimal.Decimal{}, nil
+	}
+
+	coinIDs := make([]string, 0, len(dayKeys))
+	dayStarts := make([]time.Time, 0, len(dayKeys))
+	for _, k := range dayKeys {
+		coinIDs = append(coinIDs, k.CoinID)
+		dayStarts = append(dayStarts, k.BucketStartUtc)
+	}
+
+	rows, err := r.store.GetDailyAveragePricesBatch(ctx, db.GetDailyAveragePricesBatchParams{
+		Column1: coinIDs,
+		Column2: toTimestamptzSlice(dayStarts),
+		Column3: nullableTimestamptz(asOf),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetDailyAverageBatch: query failed: %w", err)
+	}
+
+	out := make([]*decimal.Decimal, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, numericToDecimal(row.PriceUsd))
+	}
+
+	return out, nil
+}
+
 func (r *historicalPriceRepository) Get(ctx context.Context, coinID string, bucketStartUTC time.Time) (domain.HistoricalPrice, error) {
 	if coinID == "" {
 		return domain.HistoricalPrice{}, fmt.Errorf("Get: coinID is empty")
@@ -86,11 +177,12 @@ func (r *historicalPriceRepository) Upsert(ctx context.Context, p domain.Histori
 		return fmt.Errorf("Upsert: invalid PriceUSD: %w", err)
 	}
 
-	if err := r.store.UpsertHistoricalPrice(ctx, db.UpsertHistoricalPriceParams{
+	if err := r.store.InsertHistoricalPriceRevision(ctx, db.InsertHistoricalPriceRevisionParams{
 		CoinID:             p.CoinID,
 		BucketStartUtc:     pgtype.Timestamptz{Time: p.Time, Valid: true},
 		PriceUsd:           priceNumeric,
 		GranularitySeconds: int32(*p.GranularitySeconds),
+		Provider:           providerOrDefault(p.Provider),
 	}); err != nil {
 		return fmt.Errorf("Upsert: query failed: %w", err)
 	}
@@ -110,6 +202,7 @@ func (r *historicalPriceRepository) UpsertBatch(
 	bucketStarts := make([]pgtype.Timestamptz, 0, len(prices))
 	priceNums := make([]pgtype.Numeric, 0, len(prices))
 	grans := make([]int32, 0, len(prices))
+	providers := make([]string, 0, len(prices))
 
 	for _, p := range prices {
 		if p.CoinID == "" || p.PriceUsd == nil || p.GranularitySeconds == nil {
@@ -125,15 +218,17 @@ func (r *historicalPriceRepository) UpsertBatch(
 		bucketStarts = append(bucketStarts, pgtype.Timestamptz{Time: p.Time, Valid: true})
 		priceNums = append(priceNums, num)
 		grans = append(grans, int32(*p.GranularitySeconds))
+		providers = append(providers, providerOrDefault(p.Provider))
 	}
 
-	if err := r.store.UpsertHistoricalPricesBatch(
+	if err := r.store.InsertHistoricalPriceRevisionsBatch(
 		ctx,
-		db.UpsertHistoricalPricesBatchParams{
+		db.InsertHistoricalPriceRevisionsBatchParams{
 			Column1: coinIDs,
 			Column2: bucketStarts,
 			Column3: priceNums,
 			Column4: grans,
+			Column5: providers,
 		},
 	); err != nil {
 		return fmt.Errorf("UpsertBatch: query failed: %w", err)
@@ -141,3 +236,10 @@ func (r *historicalPriceRepository) UpsertBatch(
 
 	return nil
 }
+
+func providerOrDefault(provider string) string {
+	if provider == "" {
+		return domain.ProviderCoinGecko
+	}
+	return provider
+}
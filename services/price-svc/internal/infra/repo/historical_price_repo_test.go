@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TestPriceRevisions runs against the migrated database in DATABASE_URL.
+func TestPriceRevisions(t *testing.T) {
+	url := os.Getenv("DATABASE_URL")
+	if url == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pg, err := postgres.New(ctx, url)
+	if err != nil {
+		t.Fatalf("postgres.New() error = %v", err)
+	}
+	defer pg.Close()
+
+	coinID := "test-" + uuid.NewString()
+	defer func() {
+		if _, err := pg.Pool.Exec(ctx, "DELETE FROM historical_price_revisions WHERE coin_id = $1", coinID); err != nil {
+			t.Errorf("cleanup: %v", err)
+		}
+	}()
+
+	repo := NewHistoricalPriceRepo(db.NewStore(pg))
+	bucket := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	key := []domain.PriceKey{{CoinID: coinID, BucketStartUtc: bucket}}
+
+	revisions := func() int {
+		t.Helper()
+		var n int
+		if err := pg.Pool.QueryRow(ctx, "SELECT count(*) FROM historical_price_revisions WHERE coin_id = $1", coinID).Scan(&n); err != nil {
+			t.Fatalf("count revisions: %v", err)
+		}
+		return n
+	}
+	price := func(v string, granularity time.Duration) domain.HistoricalPrice {
+		d := decimal.RequireFromString(v)
+		g := int(granularity.Seconds())
+		return domain.HistoricalPrice{CoinID: coinID, Time: bucket, PriceUsd: &d, GranularitySeconds: &g, Provider: domain.ProviderCoinGecko}
+	}
+	readAt := func(asOf time.Time) *decimal.Decimal {
+		t.Helper()
+		rows, err := repo.GetBatch(ctx, key, asOf)
+		if err != nil {
+			t.Fatalf("GetBatch() error = %v", err)
+		}
+		return rows[0].PriceUsd
+	}
+
+	steps := []struct {
+		name      string
+		write     domain.HistoricalPrice
+		revisions int
+	}{
+		{name: "first price", write: price("100", time.Hour), revisions: 1},
+		{name: "unchanged re-fetch", write: price("100.0", time.Hour), revisions: 1},
+		{name: "coarser price", write: price("150", 24*time.Hour), revisions: 1},
+		{name: "corrected re-fetch", write: price("101", time.Hour), revisions: 2},
+		{name: "finer price", write: price("99", 5*time.Minute), revisions: 3},
+		{name: "hourly price under a finer one", write: price("102", time.Hour), revisions: 3},
+	}
+
+	var fetched []time.Time
+	for _, s := range steps {
+		if err := repo.UpsertBatch(ctx, []domain.HistoricalPrice{s.write}); err != nil {
+			t.Fatalf("%s: UpsertBatch() error = %v", s.name, err)
+		}
+		if n := revisions(); n != s.revisions {
+			t.Fatalf("%s: revisions = %d, want %d", s.name, n, s.revisions)
+		}
+		latest, err := repo.Get(ctx, coinID, bucket)
+		if err != nil {
+			t.Fatalf("%s: Get() error = %v", s.name, err)
+		}
+		if len(fetched) < s.revisions {
+			fetched = append(fetched, latest.FetchedAt)
+		}
+		// fetched_at is the transaction time, so revisions need distinct timestamps
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// the single-row upsert follows the same rules
+	if err := repo.Upsert(ctx, price("99", 5*time.Minute)); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if n := revisions(); n != 3 {
+		t.Fatalf("revisions after unchanged Upsert = %d, want 3", n)
+	}
+
+	asOf := []struct {
+		at   time.Time
+		want string // empty when nothing was stored yet
+	}{
+		{at: fetched[0].Add(-time.Millisecond)},
+		{at: fetched[0], want: "100"},
+		{at: fetched[1], want: "101"},
+		{at: fetched[2], want: "99"},
+		{want: "99"}, // zero reads the latest
+	}
+	for _, tc := range asOf {
+		got := readAt(tc.at)
+		if tc.want == "" {
+			if got != nil {
+				t.Fatalf("price as of %s = %s, want none", tc.at, got)
+			}
+			continue
+		}
+		if got == nil || !got.Equal(decimal.RequireFromString(tc.want)) {
+			t.Fatalf("price as of %s = %v, want %s", tc.at, got, tc.want)
+		}
+	}
+}
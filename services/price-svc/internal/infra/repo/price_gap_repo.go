@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type priceGapRepository struct {
+	store db.Store
+}
+
+func NewPriceGapRepo(store db.Store) domain.PriceGapRepo {
+	return &priceGapRepository{store: store}
+}
+
+func (r *priceGapRepository) UpsertGap(ctx context.Context, g domain.PriceGap) error {
+	if g.CoinID == "" {
+		return fmt.Errorf("UpsertGap: coinID is empty")
+	}
+
+	if err := r.store.UpsertPriceGap(ctx, db.UpsertPriceGapParams{
+		CoinID:    g.CoinID,
+		DayUtc:    pgtype.Timestamptz{Time: g.Day, Valid: true},
+		Reason:    g.Reason,
+		ExpiresAt: pgtype.Timestamptz{Time: g.ExpiresAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("UpsertGap: query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *priceGapRepository) GetActiveGaps(ctx context.Context, dayKeys []domain.PriceKey) ([]domain.PriceGap, error) {
+	if len(dayKeys) == 0 {
+		return []domain.PriceGap{}, nil
+	}
+
+	coinIDs := make([]string, 0, len(dayKeys))
+	days := make([]time.Time, 0, len(dayKeys))
+	for _, k := range dayKeys {
+		coinIDs = append(coinIDs, k.CoinID)
+		days = append(days, k.BucketStartUtc)
+	}
+
+	rows, err := r.store.GetActivePriceGaps(ctx, db.GetActivePriceGapsParams{
+		Column1: coinIDs,
+		Column2: toTimestamptzSlice(days),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetActiveGaps: query failed: %w", err)
+	}
+
+	out := make([]domain.PriceGap, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapPriceGapDBToDomain(row))
+	}
+	return out, nil
+}
+
+func (r *priceGapRepository) DeleteExpiredGaps(ctx context.Context) (int64, error) {
+	n, err := r.store.DeleteExpiredPriceGaps(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteExpiredGaps: query failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *priceGapRepository) UpsertListing(ctx context.Context, l domain.CoinListing) error {
+	if l.CoinID == "" {
+		return fmt.Errorf("UpsertListing: coinID is empty")
+	}
+
+	if err := r.store.UpsertCoinListing(ctx, db.UpsertCoinListingParams{
+		CoinID:       l.CoinID,
+		FirstPriceAt: pgtype.Timestamptz{Time: l.FirstPriceAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("UpsertListing: query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *priceGapRepository) GetListings(ctx context.Context, coinIDs []string) ([]domain.CoinListing, error) {
+	if len(coinIDs) == 0 {
+		return []domain.CoinListing{}, nil
+	}
+
+	rows, err := r.store.GetCoinListings(ctx, coinIDs)
+	if err != nil {
+		return nil, fmt.Errorf("GetListings: query failed: %w", err)
+	}
+
+	out := make([]domain.CoinListing, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.CoinListing{
+			CoinID:       row.CoinID,
+			FirstPriceAt: row.FirstPriceAt.Time,
+			CheckedAt:    row.CheckedAt.Time,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type quarantineRepository struct {
+	store db.Store
+}
+
+func NewQuarantineRepo(store db.Store) domain.QuarantineRepo {
+	return &quarantineRepository{store: store}
+}
+
+func (r *quarantineRepository) InsertBatch(ctx context.Context, points []domain.QuarantinedPrice) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	coinIDs := make([]string, 0, len(points))
+	bucketStarts := make([]pgtype.Timestamptz, 0, len(points))
+	grans := make([]int32, 0, len(points))
+	raws := make([]pgtype.Numeric, 0, len(points))
+	subs := make([]pgtype.Numeric, 0, len(points))
+	medians := make([]pgtype.Numeric, 0, len(points))
+	reasons := make([]string, 0, len(points))
+
+	for _, p := range points {
+		if p.CoinID == "" {
+			return fmt.Errorf("InsertBatch: invalid QuarantinedPrice %+v", p)
+		}
+
+		raw, err := decimalToNumeric(&p.RawPrice)
+		if err != nil {
+			return fmt.Errorf("InsertBatch: raw_price: %w", err)
+		}
+		sub, err := nullableDecimalToNumeric(p.SubstitutedPrice)
+		if err != nil {
+			return fmt.Errorf("InsertBatch: substituted_price: %w", err)
+		}
+		median, err := nullableDecimalToNumeric(p.NeighbourMedian)
+		if err != nil {
+			return fmt.Errorf("InsertBatch: neighbour_median: %w", err)
+		}
+
+		coinIDs = append(coinIDs, p.CoinID)
+		bucketStarts = append(bucketStarts, pgtype.Timestamptz{Time: p.BucketStartUtc, Valid: true})
+		grans = append(grans, int32(p.GranularitySeconds))
+		raws = append(raws, raw)
+		subs = append(subs, sub)
+		medians = append(medians, median)
+		reasons = append(reasons, string(p.Reason))
+	}
+
+	if err := r.store.InsertQuarantinedPricesBatch(ctx, db.InsertQuarantinedPricesBatchParams{
+		Column1: coinIDs,
+		Column2: bucketStarts,
+		Column3: grans,
+		Column4: raws,
+		Column5: subs,
+		Column6: medians,
+		Column7: reasons,
+	}); err != nil {
+		return fmt.Errorf("InsertBatch: query failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type snapshotRepository struct {
+	store db.Store
+}
+
+func NewSnapshotRepo(store db.Store) domain.SnapshotRepo {
+	return &snapshotRepository{store: store}
+}
+
+func (r *snapshotRepository) Create(ctx context.Context, s domain.ValuationSnapshot) error {
+	if s.ID == "" {
+		return fmt.Errorf("Create: snapshot id is empty")
+	}
+
+	if err := r.store.CreateValuationSnapshot(ctx, db.CreateValuationSnapshotParams{
+		TenantID:               s.TenantID,
+		ID:                     s.ID,
+		FiatCurrency:           s.FiatCurrency,
+		PricePoint:             int32(s.PricePoint),
+		LookupNearest:          s.Lookup.Nearest,
+		LookupToleranceSeconds: int32(s.Lookup.Tolerance / time.Second),
+		LookupAcceptCoarser:    s.Lookup.AcceptCoarser,
+		LookupAsOf:             nullableTimestamptz(s.Lookup.AsOf),
+	}); err != nil {
+		return fmt.Errorf("Create: query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *snapshotRepository) Get(ctx context.Context, tenantID uuid.UUID, id string) (domain.ValuationSnapshot, error) {
+	row, err := r.store.GetValuationSnapshot(ctx, db.GetValuationSnapshotParams{TenantID: tenantID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ValuationSnapshot{}, fmt.Errorf("Get: snapshot %s: %w", id, apperr.ErrNotFound)
+		}
+		return domain.ValuationSnapshot{}, fmt.Errorf("Get: query failed: %w", err)
+	}
+
+	return mapValuationSnapshotDBToDomain(row), nil
+}
+
+func (r *snapshotRepository) GetValuations(ctx context.Context, tenantID uuid.UUID, snapshotID string, kind domain.SnapshotKind, keys []domain.PriceKey) ([]*domain.Valuation, error) {
+	out := make([]*domain.Valuation, len(keys))
+	if len(keys) == 0 {
+		return out, nil
+	}
+
+	assets := make([]string, len(keys))
+	times := make([]time.Time, len(keys))
+	for i, k := range keys {
+		assets[i] = k.CoinID
+		times[i] = k.BucketStartUtc
+	}
+
+	rows, err := r.store.GetSnapshotValuations(ctx, db.GetSnapshotValuationsParams{
+		TenantID:   tenantID,
+		SnapshotID: snapshotID,
+		Kind:       string(kind),
+		Assets:     assets,
+		AtUtc:      toTimestamptzSlice(times),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetValuations: query failed: %w", err)
+	}
+
+	type key struct {
+		asset string
+		at    int64
+	}
+	stored := make(map[key]*domain.Valuation, len(rows))
+	for _, row := range rows {
+		v, err := mapSnapshotValuationDBToDomain(row)
+		if err != nil {
+			return nil, fmt.Errorf("GetValuations: %w", err)
+		}
+		stored[key{row.Asset, row.AtUtc.Time.UnixMicro()}] = &v
+	}
+
+	// the same key may appear several times in a batch, each gets the stored value
+	for i, k := range keys {
+		out[i] = stored[key{k.CoinID, k.BucketStartUtc.UnixMicro()}]
+	}
+
+	return out, nil
+}
+
+func (r *snapshotRepository) SaveValuations(ctx context.Context, tenantID uuid.UUID, snapshotID string, kind domain.SnapshotKind, keys []domain.PriceKey, vals []domain.Valuation) error {
+	if len(keys) != len(vals) {
+		return fmt.Errorf("SaveValuations: got %d valuations for %d keys", len(vals), len(keys))
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	arg := db.InsertSnapshotValuationsParams{
+		TenantID:       tenantID,
+		SnapshotID:     snapshotID,
+		Kind:           string(kind),
+		Assets:         make([]string, len(keys)),
+		AtUtc:          make([]pgtype.Timestamptz, len(keys)),
+		FiatValues:     make([]pgtype.Numeric, len(keys)),
+		Methods:        make([]int32, len(keys)),
+		LowerPrecision: make([]bool, len(keys)),
+		BucketStarts:   make([]pgtype.Timestamptz, len(keys)),
+		Granularities:  make([]int32, len(keys)),
+		Providers:      make([]string, len(keys)),
+		PricesUsd:      make([]pgtype.Numeric, len(keys)),
+		FetchedAt:      make([]pgtype.Timestamptz, len(keys)),
+		FxRates:        make([]pgtype.Numeric, len(keys)),
+		FxEffectiveAt:  make([]pgtype.Timestamptz, len(keys)),
+	}
+
+	for i, k := range keys {
+		v := vals[i]
+		p := v.Provenance
+
+		fiat, err := decimalToNumeric(&v.Fiat)
+		if err != nil {
+			return fmt.Errorf("SaveValuations: fiat value: %w", err)
+		}
+		priceUsd, err := nullableDecimalToNumeric(p.PriceUsd)
+		if err != nil {
+			return fmt.Errorf("SaveValuations: price usd: %w", err)
+		}
+		fxRate, err := decimalToNumeric(&p.FXRate)
+		if err != nil {
+			return fmt.Errorf("SaveValuations: fx rate: %w", err)
+		}
+
+		arg.Assets[i] = k.CoinID
+		arg.AtUtc[i] = pgtype.Timestamptz{Time: k.BucketStartUtc, Valid: true}
+		arg.FiatValues[i] = fiat
+		arg.Methods[i] = int32(v.Method)
+		arg.LowerPrecision[i] = v.LowerPrecision
+		arg.BucketStarts[i] = nullableTimestamptz(p.BucketStartUtc)
+		arg.Granularities[i] = int32(p.GranularitySeconds)
+		arg.Providers[i] = p.Provider
+		arg.PricesUsd[i] = priceUsd
+		arg.FetchedAt[i] = nullableTimestamptz(p.FetchedAt)
+		arg.FxRates[i] = fxRate
+		arg.FxEffectiveAt[i] = nullableTimestamptz(p.FXEffectiveAt)
+	}
+
+	if err := r.store.InsertSnapshotValuations(ctx, arg); err != nil {
+		return fmt.Errorf("SaveValuations: query failed: %w", err)
+	}
+
+	return nil
+}
+
+type snapshotLegKey struct {
+	asset domain.AssetRef
+	at    int64
+}
+
+func (r *snapshotRepository) GetResolutions(ctx context.Context, tenantID uuid.UUID, snapshotID, source string, assets []domain.AssetRef, legs []domain.AssetLeg) ([]*domain.SymbolResolution, error) {
+	out := make([]*domain.SymbolResolution, len(legs))
+	if len(legs) == 0 {
+		return out, nil
+	}
+
+	arg := db.GetSnapshotResolutionsParams{
+		TenantID:          tenantID,
+		SnapshotID:        snapshotID,
+		Source:            source,
+		Symbols:           make([]string, len(legs)),
+		Chains:            make([]string, len(legs)),
+		ContractAddresses: make([]string, len(legs)),
+		AtUtc:             make([]pgtype.Timestamptz, len(legs)),
+	}
+	for i, l := range legs {
+		a := assets[l.Asset]
+		arg.Symbols[i] = a.Symbol
+		arg.Chains[i] = a.Chain
+		arg.ContractAddresses[i] = a.ContractAddress
+		arg.AtUtc[i] = pgtype.Timestamptz{Time: l.At, Valid: true}
+	}
+
+	rows, err := r.store.GetSnapshotResolutions(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("GetResolutions: query failed: %w", err)
+	}
+
+	stored := make(map[snapshotLegKey]*domain.SymbolResolution, len(rows))
+	for _, row := range rows {
+		asset := domain.AssetRef{Symbol: row.Symbol, Chain: row.Chain, ContractAddress: row.ContractAddress}
+		stored[snapshotLegKey{asset, row.AtUtc.Time.UnixMicro()}] = &domain.SymbolResolution{
+			CoinID:          row.CoinID,
+			CanonicalSymbol: row.CanonicalSymbol,
+			Quote:           row.Quote,
+			Consensus:       row.Consensus,
+		}
+	}
+
+	for i, l := range legs {
+		out[i] = stored[snapshotLegKey{assets[l.Asset], l.At.UnixMicro()}]
+	}
+
+	return out, nil
+}
+
+func (r *snapshotRepository) SaveResolutions(ctx context.Context, tenantID uuid.UUID, snapshotID, source string, assets []domain.AssetRef, legs []domain.AssetLeg, resolved []domain.SymbolResolution) error {
+	if len(legs) != len(resolved) {
+		return fmt.Errorf("SaveResolutions: got %d resolutions for %d legs", len(resolved), len(legs))
+	}
+
+	arg := db.InsertSnapshotResolutionsParams{
+		TenantID:   tenantID,
+		SnapshotID: snapshotID,
+		Source:     source,
+	}
+	for i, l := range legs {
+		res := resolved[i]
+		if res.Err != nil {
+			continue
+		}
+		a := assets[l.Asset]
+		arg.Symbols = append(arg.Symbols, a.Symbol)
+		arg.Chains = append(arg.Chains, a.Chain)
+		arg.ContractAddresses = append(arg.ContractAddresses, a.ContractAddress)
+		arg.AtUtc = append(arg.AtUtc, pgtype.Timestamptz{Time: l.At, Valid: true})
+		arg.CoinIds = append(arg.CoinIds, res.CoinID)
+		arg.CanonicalSymbols = append(arg.CanonicalSymbols, res.CanonicalSymbol)
+		arg.Quotes = append(arg.Quotes, res.Quote)
+		arg.Consensus = append(arg.Consensus, res.Consensus)
+	}
+	if len(arg.Symbols) == 0 {
+		return nil
+	}
+
+	if err := r.store.InsertSnapshotResolutions(ctx, arg); err != nil {
+		return fmt.Errorf("SaveResolutions: query failed: %w", err)
+	}
+
+	return nil
+}
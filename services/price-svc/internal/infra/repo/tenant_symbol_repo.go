@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type tenantSymbolRepository struct {
@@ -31,13 +34,18 @@ func (r *tenantSymbolRepository) Upsert(ctx context.Context, s domain.TenantSymb
 		return fmt.Errorf("Upsert: coinID is empty")
 	}
 
-	if err := r.store.UpsertTenantSymbol(ctx, db.UpsertTenantSymbolParams{
-		TenantID: s.TenantID,
-		Source:   s.Source,
-		Symbol:   s.Symbol,
-		CoinID:   s.CoinID,
+	if err := r.store.UpsertTenantSymbolTx(ctx, db.UpsertTenantSymbolParams{
+		TenantID:  s.TenantID,
+		Source:    s.Source,
+		Symbol:    s.Symbol,
+		CoinID:    s.CoinID,
+		ValidFrom: validityBoundToPg(s.ValidFrom, pgtype.NegativeInfinity),
+		ValidTo:   validityBoundToPg(s.ValidTo, pgtype.Infinity),
 	}); err != nil {
-		return fmt.Errorf("Upsert: query failed: %w", err)
+		if isExclusionViolation(err) {
+			return fmt.Errorf("Upsert: symbol %s overlaps another window: %w", s.Symbol, apperr.ErrConflict)
+		}
+		return fmt.Errorf("Upsert: tx failed: %w", err)
 	}
 
 	return nil
@@ -128,3 +136,83 @@ func (r *tenantSymbolRepository) GetListBySource(
 	}
 	return out, nil
 }
+
+func (r *tenantSymbolRepository) CountMappings(
+	ctx context.Context,
+	excludeTenantID uuid.UUID,
+	source string,
+	symbols []string,
+) ([]domain.SymbolConsensus, error) {
+	if source == "" {
+		return nil, fmt.Errorf("CountMappings: source is empty")
+	}
+	if len(symbols) == 0 {
+		return []domain.SymbolConsensus{}, nil
+	}
+
+	rows, err := r.store.CountTenantSymbolMappings(ctx, db.CountTenantSymbolMappingsParams{
+		Source:          source,
+		Symbols:         symbols,
+		ExcludeTenantID: excludeTenantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CountMappings: query failed: %w", err)
+	}
+
+	out := make([]domain.SymbolConsensus, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.SymbolConsensus{
+			Symbol:  row.Symbol,
+			CoinID:  row.CoinID,
+			Tenants: int(row.Tenants),
+		})
+	}
+	return out, nil
+}
+
+func (r *tenantSymbolRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantSymbol, error) {
+	if tenantID == uuid.Nil {
+		return nil, fmt.Errorf("ListByTenant: tenantID is nil")
+	}
+
+	rows, err := r.store.ListTenantSymbols(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("ListByTenant: query failed: %w", err)
+	}
+
+	out := make([]domain.TenantSymbol, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapTenantSymbolDBToDomain(row))
+	}
+	return out, nil
+}
+
+func (r *tenantSymbolRepository) Import(ctx context.Context, symbols []domain.TenantSymbol) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	args := make([]db.UpsertTenantSymbolParams, len(symbols))
+	for i, s := range symbols {
+		if s.TenantID == uuid.Nil {
+			return fmt.Errorf("Import: mapping %d: tenantID is nil", i+1)
+		}
+		args[i] = db.UpsertTenantSymbolParams{
+			TenantID:  s.TenantID,
+			Source:    s.Source,
+			Symbol:    s.Symbol,
+			CoinID:    s.CoinID,
+			ValidFrom: validityBoundToPg(s.ValidFrom, pgtype.NegativeInfinity),
+			ValidTo:   validityBoundToPg(s.ValidTo, pgtype.Infinity),
+		}
+	}
+
+	if err := r.store.ImportTenantSymbolsTx(ctx, args); err != nil {
+		var rowErr *db.ImportRowError
+		if isExclusionViolation(err) && errors.As(err, &rowErr) {
+			return fmt.Errorf("Import: %w", &domain.ImportConflictError{Row: rowErr.Row, Symbol: rowErr.Symbol})
+		}
+		return fmt.Errorf("Import: tx failed: %w", err)
+	}
+	return nil
+}
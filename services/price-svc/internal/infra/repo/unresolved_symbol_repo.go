@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type unresolvedSymbolRepository struct {
+	store db.Store
+}
+
+func NewUnresolvedSymbolRepo(store db.Store) domain.UnresolvedSymbolRepo {
+	return &unresolvedSymbolRepository{store: store}
+}
+
+func (r *unresolvedSymbolRepository) Record(ctx context.Context, tenantID uuid.UUID, source string, symbols []domain.UnresolvedSymbol) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	arg := db.RecordUnresolvedSymbolsParams{
+		TenantID:         tenantID,
+		Source:           source,
+		Symbols:          make([]string, len(symbols)),
+		CanonicalSymbols: make([]string, len(symbols)),
+		Reasons:          make([]string, len(symbols)),
+		Messages:         make([]string, len(symbols)),
+		Occurrences:      make([]int64, len(symbols)),
+	}
+	for i, s := range symbols {
+		arg.Symbols[i] = s.Symbol
+		arg.CanonicalSymbols[i] = s.CanonicalSymbol
+		arg.Reasons[i] = string(s.Reason)
+		arg.Messages[i] = s.Message
+		arg.Occurrences[i] = s.Occurrences
+	}
+
+	if err := r.store.RecordUnresolvedSymbols(ctx, arg); err != nil {
+		return fmt.Errorf("Record: query failed: %w", err)
+	}
+	return nil
+}
+
+func (r *unresolvedSymbolRepository) List(ctx context.Context, filter domain.UnresolvedSymbolFilter) ([]domain.UnresolvedSymbol, error) {
+	arg := db.ListUnresolvedSymbolsParams{
+		Source:     filter.Source,
+		AfterID:    filter.AfterID,
+		MaxResults: int32(filter.Limit),
+	}
+	if filter.TenantID != uuid.Nil {
+		arg.TenantID = &filter.TenantID
+	}
+
+	rows, err := r.store.ListUnresolvedSymbols(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("List: query failed: %w", err)
+	}
+
+	out := make([]domain.UnresolvedSymbol, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.UnresolvedSymbol{
+			ID:              row.ID,
+			TenantID:        row.TenantID,
+			Source:          row.Source,
+			Symbol:          row.Symbol,
+			CanonicalSymbol: row.CanonicalSymbol,
+			Reason:          domain.UnresolvedReason(row.Reason),
+			Message:         row.Message,
+			Occurrences:     row.Occurrences,
+			FirstSeen:       row.FirstSeen.Time,
+			LastSeen:        row.LastSeen.Time,
+		})
+	}
+	return out, nil
+}
+
+func (r *unresolvedSymbolRepository) Resolve(ctx context.Context, s domain.TenantSymbol) error {
+	err := r.store.ResolveUnresolvedSymbolTx(ctx, db.UpsertTenantSymbolParams{
+		TenantID:  s.TenantID,
+		Source:    s.Source,
+		Symbol:    s.Symbol,
+		CoinID:    s.CoinID,
+		ValidFrom: validityBoundToPg(s.ValidFrom, pgtype.NegativeInfinity),
+		ValidTo:   validityBoundToPg(s.ValidTo, pgtype.Infinity),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("Resolve: unresolved symbol %s/%s: %w", s.Source, s.Symbol, apperr.ErrNotFound)
+		}
+		if isExclusionViolation(err) {
+			return fmt.Errorf("Resolve: symbol %s overlaps another window: %w", s.Symbol, apperr.ErrConflict)
+		}
+		return fmt.Errorf("Resolve: tx failed: %w", err)
+	}
+	return nil
+}
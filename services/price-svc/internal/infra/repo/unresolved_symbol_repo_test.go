@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/postgres"
+	"github.com/google/uuid"
+)
+
+// TestUnresolvedQueue runs against the migrated database in DATABASE_URL.
+func TestUnresolvedQueue(t *testing.T) {
+	url := os.Getenv("DATABASE_URL")
+	if url == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	pg, err := postgres.New(ctx, url)
+	if err != nil {
+		t.Fatalf("postgres.New() error = %v", err)
+	}
+	defer pg.Close()
+
+	tenant := uuid.New()
+	defer func() {
+		for _, table := range []string{"unresolved_symbols", "tenant_symbols"} {
+			if _, err := pg.Pool.Exec(ctx, "DELETE FROM "+table+" WHERE tenant_id = $1", tenant); err != nil {
+				t.Errorf("cleanup %s: %v", table, err)
+			}
+		}
+	}()
+
+	store := db.NewStore(pg)
+	queue := NewUnresolvedSymbolRepo(store)
+	list := func() []domain.UnresolvedSymbol {
+		t.Helper()
+		got, err := queue.List(ctx, domain.UnresolvedSymbolFilter{TenantID: tenant, Source: "binance", Limit: 10})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		return got
+	}
+
+	record := func(reason domain.UnresolvedReason, occurrences int64) {
+		t.Helper()
+		symbols := []domain.UnresolvedSymbol{
+			{Symbol: "NEW", CanonicalSymbol: "NEW", Reason: reason, Message: string(reason), Occurrences: occurrences},
+			{Symbol: "OTHER", CanonicalSymbol: "OTHER", Reason: domain.UnresolvedUnknown, Occurrences: 1},
+		}
+		if err := queue.Record(ctx, tenant, "binance", symbols); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	record(domain.UnresolvedUnknown, 2)
+	record(domain.UnresolvedAmbiguous, 3)
+
+	got := list()
+	if len(got) != 2 || got[0].Symbol != "NEW" || got[0].Occurrences != 5 || got[0].Reason != domain.UnresolvedAmbiguous {
+		t.Fatalf("queue = %+v, want NEW first with 5 occurrences and the latest reason", got)
+	}
+	if got[0].LastSeen.Before(got[0].FirstSeen) {
+		t.Fatalf("last_seen %s before first_seen %s", got[0].LastSeen, got[0].FirstSeen)
+	}
+
+	// another source's queue is separate
+	if err := queue.Resolve(ctx, domain.TenantSymbol{TenantID: tenant, Source: "kraken", Symbol: "NEW", CoinID: "new-coin"}); !errors.Is(err, apperr.ErrNotFound) {
+		t.Fatalf("Resolve() on another source error = %v, want ErrNotFound", err)
+	}
+
+	mapping := domain.TenantSymbol{TenantID: tenant, Source: "binance", Symbol: "NEW", CoinID: "new-coin"}
+	if err := queue.Resolve(ctx, mapping); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := list(); len(got) != 1 || got[0].Symbol != "OTHER" {
+		t.Fatalf("queue after Resolve() = %+v, want only OTHER", got)
+	}
+	mapped, err := NewTenantSymbolRepo(store).GetList(ctx, tenant, "binance", []string{"NEW"})
+	if err != nil {
+		t.Fatalf("GetList() error = %v", err)
+	}
+	if len(mapped) != 1 || mapped[0].CoinID != "new-coin" {
+		t.Fatalf("mappings = %+v, want NEW mapped to new-coin", mapped)
+	}
+
+	// resolving again finds nothing queued and writes nothing
+	if err := queue.Resolve(ctx, domain.TenantSymbol{TenantID: tenant, Source: "binance", Symbol: "NEW", CoinID: "other-coin"}); !errors.Is(err, apperr.ErrNotFound) {
+		t.Fatalf("second Resolve() error = %v, want ErrNotFound", err)
+	}
+}
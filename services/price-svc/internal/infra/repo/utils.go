@@ -1,11 +1,15 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
 	sqlc "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
@@ -13,9 +17,10 @@ import (
 func mapHistoricalPriceRowDBToDomain(h sqlc.GetHistoricalPricesBatchRow) (domain.HistoricalPrice, error) {
 	price := numericToDecimal(h.PriceUsd)
 
+	// granularity is 0 when the bucket has no revision (as of the requested time)
 	var gsPtr *int
-	if h.GranularitySeconds != nil {
-		gs := int(*h.GranularitySeconds)
+	if price != nil {
+		gs := int(h.GranularitySeconds)
 		gsPtr = &gs
 	}
 
@@ -24,9 +29,31 @@ func mapHistoricalPriceRowDBToDomain(h sqlc.GetHistoricalPricesBatchRow) (domain
 		Time:               h.BucketStartUtc.Time, // guaranteed to be valid
 		PriceUsd:           price,
 		GranularitySeconds: gsPtr,
+		Provider:           h.Provider,
+		FetchedAt:          h.FetchedAt.Time,
 	}, nil
 }
 
+func mapNearestHistoricalPriceRowDBToDomain(h sqlc.GetNearestHistoricalPricesBatchRow) domain.HistoricalPrice {
+	price := numericToDecimal(h.PriceUsd)
+
+	// granularity is 0 when no bucket was found within tolerance
+	var gsPtr *int
+	if price != nil {
+		gs := int(h.GranularitySeconds)
+		gsPtr = &gs
+	}
+
+	return domain.HistoricalPrice{
+		CoinID:             h.CoinID,
+		Time:               h.BucketStartUtc.Time, // found bucket, or the requested one when missing
+		PriceUsd:           price,
+		GranularitySeconds: gsPtr,
+		Provider:           h.Provider,
+		FetchedAt:          h.FetchedAt.Time,
+	}
+}
+
 func mapHistoricalPriceDBToDomain(h sqlc.HistoricalPrice) (domain.HistoricalPrice, error) {
 	price := numericToDecimal(h.PriceUsd)
 
@@ -37,16 +64,126 @@ func mapHistoricalPriceDBToDomain(h sqlc.HistoricalPrice) (domain.HistoricalPric
 		Time:               h.BucketStartUtc.Time, // guaranteed to be valid
 		PriceUsd:           price,
 		GranularitySeconds: &granularitySeconds,
+		Provider:           h.Provider,
+		FetchedAt:          h.FetchedAt.Time,
+	}, nil
+}
+
+func mapPriceGapDBToDomain(g sqlc.PriceGap) domain.PriceGap {
+	return domain.PriceGap{
+		CoinID:    g.CoinID,
+		Day:       g.DayUtc.Time,
+		Reason:    g.Reason,
+		ExpiresAt: g.ExpiresAt.Time,
+	}
+}
+
+func mapValuationJobDBToDomain(j sqlc.ValuationJob) domain.ValuationJob {
+	return domain.ValuationJob{
+		ID:         j.ID,
+		TenantID:   j.TenantID,
+		Status:     domain.JobStatus(j.Status),
+		Request:    j.Request,
+		Total:      int(j.Total),
+		Processed:  int(j.Processed),
+		Error:      deref(j.Error),
+		Attempts:   int(j.Attempts),
+		LeaseToken: deref(j.LeaseToken),
+		CreatedAt:  j.CreatedAt.Time,
+		UpdatedAt:  j.UpdatedAt.Time,
+		StartedAt:  timestamptzToPtr(j.StartedAt),
+		FinishedAt: timestamptzToPtr(j.FinishedAt),
+	}
+}
+
+func mapValuationSnapshotDBToDomain(s sqlc.ValuationSnapshot) domain.ValuationSnapshot {
+	return domain.ValuationSnapshot{
+		TenantID:     s.TenantID,
+		ID:           s.ID,
+		FiatCurrency: s.FiatCurrency,
+		PricePoint:   domain.PricePoint(s.PricePoint),
+		Lookup: domain.LookupPolicy{
+			Nearest:       s.LookupNearest,
+			Tolerance:     time.Duration(s.LookupToleranceSeconds) * time.Second,
+			AcceptCoarser: s.LookupAcceptCoarser,
+			AsOf:          s.LookupAsOf.Time,
+		},
+		CreatedAt: s.CreatedAt.Time,
+	}
+}
+
+func mapSnapshotValuationDBToDomain(v sqlc.SnapshotValuation) (domain.Valuation, error) {
+	fiat := numericToDecimal(v.FiatValue)
+	if fiat == nil {
+		return domain.Valuation{}, fmt.Errorf("snapshot valuation asset=%s has no fiat value", v.Asset)
+	}
+
+	return domain.Valuation{
+		Fiat:           *fiat,
+		Method:         domain.PricingMethod(v.Method),
+		LowerPrecision: v.LowerPrecision,
+		Provenance: domain.Provenance{
+			BucketStartUtc:     v.BucketStartUtc.Time,
+			GranularitySeconds: int(deref(v.GranularitySeconds)),
+			Provider:           v.Provider,
+			PriceUsd:           numericToDecimal(v.PriceUsd),
+			FetchedAt:          v.FetchedAt.Time,
+			FXRate:             deref(numericToDecimal(v.FxRate)),
+			FXEffectiveAt:      v.FxEffectiveAt.Time,
+		},
+	}, nil
+}
+
+func mapCustomAssetDBToDomain(a sqlc.CustomAsset) domain.CustomAsset {
+	return domain.CustomAsset{
+		ID:        a.ID,
+		TenantID:  a.TenantID,
+		Symbol:    a.Symbol,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt.Time,
+		UpdatedAt: a.UpdatedAt.Time,
+	}
+}
+
+func mapCustomAssetPriceDBToDomain(assetID uuid.UUID, at pgtype.Timestamptz, price pgtype.Numeric, currency string) (*domain.CustomPricePoint, error) {
+	p := numericToDecimal(price)
+	if p == nil {
+		return nil, fmt.Errorf("custom asset %s price at %s is NULL", assetID, at.Time.Format(time.RFC3339))
+	}
+
+	return &domain.CustomPricePoint{
+		AssetID:  assetID,
+		Time:     at.Time,
+		Price:    *p,
+		Currency: currency,
 	}, nil
 }
 
 func mapTenantSymbolDBToDomain(s sqlc.TenantSymbol) domain.TenantSymbol {
 	return domain.TenantSymbol{
-		TenantID: s.TenantID,
-		Source:   s.Source,
-		Symbol:   s.Symbol,
-		CoinID:   s.CoinID,
+		TenantID:  s.TenantID,
+		Source:    s.Source,
+		Symbol:    s.Symbol,
+		CoinID:    s.CoinID,
+		ValidFrom: validityBound(s.ValidFrom),
+		ValidTo:   validityBound(s.ValidTo),
+	}
+}
+
+// validityBound maps an infinite validity bound to the zero time used for open bounds.
+func validityBound(ts pgtype.Timestamptz) time.Time {
+	if !ts.Valid || ts.InfinityModifier != pgtype.Finite {
+		return time.Time{}
 	}
+	return ts.Time.UTC()
+}
+
+// validityBoundToPg maps an open (zero) bound to the given infinity.
+func validityBoundToPg(t time.Time, open pgtype.InfinityModifier) pgtype.Timestamptz {
+	if t.IsZero() {
+		return pgtype.Timestamptz{InfinityModifier: open, Valid: true}
+	}
+	return pgtype.Timestamptz{Time: t.UTC(), Valid: true}
 }
 
 func numericToDecimal(n pgtype.Numeric) *decimal.Decimal {
@@ -72,6 +209,29 @@ func decimalToNumeric(d *decimal.Decimal) (pgtype.Numeric, error) {
 	return n, nil
 }
 
+// nullableDecimalToNumeric maps nil to SQL NULL.
+func nullableDecimalToNumeric(d *decimal.Decimal) (pgtype.Numeric, error) {
+	if d == nil {
+		return pgtype.Numeric{}, nil
+	}
+	return decimalToNumeric(d)
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func timestamptzToPtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
 func toTimestamptzSlice(times []time.Time) []pgtype.Timestamptz {
 	res := make([]pgtype.Timestamptz, len(times))
 	for i, t := range times {
@@ -82,3 +242,19 @@ func toTimestamptzSlice(times []time.Time) []pgtype.Timestamptz {
 	}
 	return res
 }
+
+// nullableTimestamptz maps the zero time to SQL NULL.
+func nullableTimestamptz(t time.Time) pgtype.Timestamptz {
+	if t.IsZero() {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+// exclusionViolation is the SQLSTATE of an EXCLUDE constraint violation, e.g. overlapping tenant symbol windows.
+const exclusionViolation = "23P01"
+
+func isExclusionViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == exclusionViolation
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NightRunner/CryptoTax-Go/services/price-svc/db/sqlc"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type valuationJobRepository struct {
+	store db.Store
+}
+
+func NewValuationJobRepo(store db.Store) domain.ValuationJobRepo {
+	return &valuationJobRepository{store: store}
+}
+
+func (r *valuationJobRepository) Create(ctx context.Context, job domain.ValuationJob, txs [][]byte) error {
+	if job.ID == uuid.Nil {
+		return fmt.Errorf("Create: job ID is nil")
+	}
+
+	seq := make([]int32, len(txs))
+	for i := range txs {
+		seq[i] = int32(i)
+	}
+
+	if err := r.store.CreateValuationJobTx(ctx, db.CreateValuationJobTxParams{
+		Job: db.CreateValuationJobParams{
+			ID:       job.ID,
+			TenantID: job.TenantID,
+			Request:  job.Request,
+			Total:    int32(len(txs)),
+		},
+		Seq: seq,
+		Txs: txs,
+	}); err != nil {
+		return fmt.Errorf("Create: tx failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *valuationJobRepository) Get(ctx context.Context, tenantID string, id uuid.UUID) (domain.ValuationJob, error) {
+	row, err := r.store.GetValuationJob(ctx, db.GetValuationJobParams{ID: id, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ValuationJob{}, fmt.Errorf("Get: job %s: %w", id, apperr.ErrNotFound)
+		}
+		return domain.ValuationJob{}, fmt.Errorf("Get: query failed: %w", err)
+	}
+
+	return mapValuationJobDBToDomain(row), nil
+}
+
+func (r *valuationJobRepository) ListResults(ctx context.Context, id uuid.UUID, afterSeq, limit int) ([]domain.ValuationJobItem, error) {
+	rows, err := r.store.ListValuationJobResults(ctx, db.ListValuationJobResultsParams{
+		JobID: id,
+		Seq:   int32(afterSeq),
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListResults: query failed: %w", err)
+	}
+
+	out := make([]domain.ValuationJobItem, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.ValuationJobItem{Seq: int(row.Seq), Result: row.Result})
+	}
+	return out, nil
+}
+
+func (r *valuationJobRepository) Claim(ctx context.Context, lease time.Duration) (*domain.ValuationJob, error) {
+	row, err := r.store.ClaimValuationJob(ctx, db.ClaimValuationJobParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		LeaseToken:   uuid.New(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Claim: query failed: %w", err)
+	}
+
+	job := mapValuationJobDBToDomain(row)
+	return &job, nil
+}
+
+func (r *valuationJobRepository) ListPending(ctx context.Context, id uuid.UUID, limit int) ([]domain.ValuationJobItem, error) {
+	rows, err := r.store.ListPendingValuationJobItems(ctx, db.ListPendingValuationJobItemsParams{
+		JobID: id,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListPending: query failed: %w", err)
+	}
+
+	out := make([]domain.ValuationJobItem, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.ValuationJobItem{Seq: int(row.Seq), Tx: row.Tx})
+	}
+	return out, nil
+}
+
+func (r *valuationJobRepository) SaveResults(ctx context.Context, id, leaseToken uuid.UUID, items []domain.ValuationJobItem, lease time.Duration) error {
+	seq := make([]int32, 0, len(items))
+	results := make([][]byte, 0, len(items))
+	for _, it := range items {
+		if it.Result == nil {
+			return fmt.Errorf("SaveResults: item seq=%d has no result", it.Seq)
+		}
+		seq = append(seq, int32(it.Seq))
+		results = append(results, it.Result)
+	}
+
+	if err := r.store.SaveValuationJobChunkTx(ctx, db.SaveValuationJobChunkTxParams{
+		JobID:        id,
+		Seq:          seq,
+		Results:      results,
+		LeaseSeconds: int32(lease.Seconds()),
+		LeaseToken:   leaseToken,
+	}); err != nil {
+		if errors.Is(err, db.ErrJobLeaseLost) {
+			return fmt.Errorf("SaveResults: job %s: %w", id, apperr.ErrLeaseLost)
+		}
+		return fmt.Errorf("SaveResults: tx failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *valuationJobRepository) Finish(ctx context.Context, id, leaseToken uuid.UUID, status domain.JobStatus, errMsg string) error {
+	var errPtr *string
+	if errMsg != "" {
+		errPtr = &errMsg
+	}
+
+	n, err := r.store.FinishValuationJob(ctx, db.FinishValuationJobParams{
+		ID:         id,
+		Status:     string(status),
+		Error:      errPtr,
+		LeaseToken: leaseToken,
+	})
+	if err != nil {
+		return fmt.Errorf("Finish: query failed: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("Finish: job %s: %w", id, apperr.ErrLeaseLost)
+	}
+
+	return nil
+}
+
+func (r *valuationJobRepository) Release(ctx context.Context, id, leaseToken uuid.UUID) error {
+	n, err := r.store.ReleaseValuationJob(ctx, db.ReleaseValuationJobParams{
+		ID:         id,
+		LeaseToken: leaseToken,
+	})
+	if err != nil {
+		return fmt.Errorf("Release: query failed: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("Release: job %s: %w", id, apperr.ErrLeaseLost)
+	}
+	return nil
+}
+
+func (r *valuationJobRepository) Retry(ctx context.Context, id, leaseToken uuid.UUID, delay time.Duration) error {
+	n, err := r.store.RetryValuationJob(ctx, db.RetryValuationJobParams{
+		ID:           id,
+		LeaseToken:   leaseToken,
+		DelaySeconds: int32(delay.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("Retry: query failed: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("Retry: job %s: %w", id, apperr.ErrLeaseLost)
+	}
+	return nil
+}
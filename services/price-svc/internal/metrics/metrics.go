@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "price_svc"
+
+var (
+	// PricesQuarantined counts provider points rejected at ingestion, by reason.
+	PricesQuarantined = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "prices_quarantined_total",
+		Help:      "Provider price points quarantined by ingestion sanity checks.",
+	}, []string{"reason"})
+
+	// PricesSubstituted counts quarantined points replaced by a neighbouring value.
+	PricesSubstituted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "prices_substituted_total",
+		Help:      "Quarantined price points replaced by a neighbouring value.",
+	})
+
+	// PricesDropped counts quarantined points stored without any price.
+	PricesDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "prices_dropped_total",
+		Help:      "Quarantined price points with no neighbour to substitute.",
+	})
+)
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,60 @@
+package pricing
+
+import "time"
+
+type Config struct {
+	PeggedAssets []PeggedAsset     `yaml:"pegged_assets"`
+	Derivatives  []DerivativeAsset `yaml:"derivatives"`
+	// Timeout bounds one pricing batch (a unary request or one streamed batch), provider fetches included.
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// NegativeCacheTTL is how long an empty provider day is served as "no data" before it is refetched,
+	// and how long a probed listing date is trusted before it is probed again.
+	NegativeCacheTTL time.Duration `yaml:"negative_cache_ttl" env-default:"24h"`
+	// GapCleanupInterval is how often expired gaps are purged; 0 disables purging.
+	GapCleanupInterval time.Duration  `yaml:"gap_cleanup_interval" env-default:"1h"`
+	Sanity             SanityConfig   `yaml:"sanity"`
+	Rounding           RoundingConfig `yaml:"rounding"`
+}
+
+// SanityConfig bounds provider points accepted at ingestion.
+type SanityConfig struct {
+	// MaxJumpMultiple is how many times a point may differ from its neighbouring median.
+	MaxJumpMultiple float64 `yaml:"max_jump_multiple" env-default:"10"`
+	// NeighbourWindow is how many points on each side form the median.
+	NeighbourWindow int `yaml:"neighbour_window" env-default:"6"`
+}
+
+type PeggedAsset struct {
+	CoinID         string  `yaml:"coin_id"`
+	Peg            string  `yaml:"peg"`
+	Tolerance      float64 `yaml:"tolerance"`
+	MarketFallback bool    `yaml:"market_fallback"`
+}
+
+type DerivativeAsset struct {
+	CoinID     string       `yaml:"coin_id"`
+	Underlying string       `yaml:"underlying"`
+	Ratio      float64      `yaml:"ratio"`
+	Series     []RatioPoint `yaml:"series"`
+}
+
+type RatioPoint struct {
+	From  time.Time `yaml:"from"`
+	Ratio float64   `yaml:"ratio"`
+}
+
+// RoundingConfig sets how fiat values are rounded in responses, per currency.
+type RoundingConfig struct {
+	Default RoundingRule   `yaml:"default"`
+	Fiats   []FiatRounding `yaml:"fiats"`
+}
+
+type RoundingRule struct {
+	Decimals int    `yaml:"decimals" env-default:"8"`
+	Mode     string `yaml:"mode" env-default:"half_up"` // half_up | bankers
+}
+
+type FiatRounding struct {
+	Currency     string `yaml:"currency"`
+	RoundingRule `yaml:",inline"`
+}
@@ -0,0 +1,68 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type derivativeRegistry struct {
+	rules map[string]domain.DerivativeRule
+}
+
+func NewDerivativeRegistry(assets []DerivativeAsset) (domain.DerivativeRegistry, error) {
+	rules := make(map[string]domain.DerivativeRule, len(assets))
+	for i, a := range assets {
+		coinID := strings.TrimSpace(a.CoinID)
+		underlying := strings.TrimSpace(a.Underlying)
+
+		if coinID == "" || underlying == "" || coinID == underlying {
+			return nil, fmt.Errorf("pricing: invalid derivative at idx=%d (coin_id=%q, underlying=%q)", i, a.CoinID, a.Underlying)
+		}
+		if a.Ratio < 0 || (a.Ratio == 0 && len(a.Series) == 0) {
+			return nil, fmt.Errorf("pricing: derivative %q needs a positive ratio or a ratio series", coinID)
+		}
+		if _, exists := rules[coinID]; exists {
+			return nil, fmt.Errorf("pricing: duplicate derivative %q", coinID)
+		}
+
+		series := make([]domain.RatioPoint, 0, len(a.Series))
+		for _, p := range a.Series {
+			if p.From.IsZero() || p.Ratio <= 0 {
+				return nil, fmt.Errorf("pricing: derivative %q has invalid series point (from=%s, ratio=%v)", coinID, p.From, p.Ratio)
+			}
+			series = append(series, domain.RatioPoint{From: p.From.UTC(), Ratio: decimal.NewFromFloat(p.Ratio)})
+		}
+		sort.Slice(series, func(i, j int) bool { return series[i].From.Before(series[j].From) })
+
+		ratio := decimal.NewFromFloat(a.Ratio)
+		if a.Ratio == 0 {
+			// series only: before its first point the earliest known ratio is the best estimate
+			ratio = series[0].Ratio
+		}
+
+		rules[coinID] = domain.DerivativeRule{
+			CoinID:       coinID,
+			UnderlyingID: underlying,
+			Ratio:        ratio,
+			Series:       series,
+		}
+	}
+
+	// chains would need recursive lookups, keep underlyings plain coins
+	for _, r := range rules {
+		if _, nested := rules[r.UnderlyingID]; nested {
+			return nil, fmt.Errorf("pricing: derivative %q has derivative underlying %q", r.CoinID, r.UnderlyingID)
+		}
+	}
+
+	return &derivativeRegistry{rules: rules}, nil
+}
+
+func (r *derivativeRegistry) Lookup(coinID string) (domain.DerivativeRule, bool) {
+	rule, ok := r.rules[coinID]
+	return rule, ok
+}
@@ -0,0 +1,81 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestNewDerivativeRegistryRejectsBadConfig(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name   string
+		assets []DerivativeAsset
+	}{
+		{name: "no underlying", assets: []DerivativeAsset{{CoinID: "weth", Ratio: 1}}},
+		{name: "own underlying", assets: []DerivativeAsset{{CoinID: "weth", Underlying: "weth", Ratio: 1}}},
+		{name: "no ratio and no series", assets: []DerivativeAsset{{CoinID: "weth", Underlying: "ethereum"}}},
+		{name: "negative ratio", assets: []DerivativeAsset{{CoinID: "weth", Underlying: "ethereum", Ratio: -1}}},
+		{name: "series point without a start", assets: []DerivativeAsset{{CoinID: "wsteth", Underlying: "ethereum", Series: []RatioPoint{{Ratio: 1.1}}}}},
+		{name: "series point without a ratio", assets: []DerivativeAsset{{CoinID: "wsteth", Underlying: "ethereum", Series: []RatioPoint{{From: from}}}}},
+		{name: "duplicate coin", assets: []DerivativeAsset{
+			{CoinID: "weth", Underlying: "ethereum", Ratio: 1},
+			{CoinID: "weth", Underlying: "ethereum", Ratio: 1},
+		}},
+		{name: "derivative of a derivative", assets: []DerivativeAsset{
+			{CoinID: "wsteth", Underlying: "steth", Ratio: 1.1},
+			{CoinID: "steth", Underlying: "ethereum", Ratio: 1},
+		}},
+	}
+
+	for _, tc := range cases {
+		if _, err := NewDerivativeRegistry(tc.assets); err == nil {
+			t.Fatalf("%s: NewDerivativeRegistry() error = nil, want error", tc.name)
+		}
+	}
+}
+
+func TestDerivativeRatioSeries(t *testing.T) {
+	t.Parallel()
+
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jun := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// the series is configured out of order and with a fixed ratio for times before it
+	registry, err := NewDerivativeRegistry([]DerivativeAsset{
+		{CoinID: "wsteth", Underlying: "ethereum", Ratio: 1.1, Series: []RatioPoint{{From: jun, Ratio: 1.2}, {From: jan, Ratio: 1.15}}},
+		{CoinID: "reth", Underlying: "ethereum", Series: []RatioPoint{{From: jan, Ratio: 1.05}}},
+	})
+	if err != nil {
+		t.Fatalf("NewDerivativeRegistry() error = %v", err)
+	}
+
+	cases := []struct {
+		coinID string
+		at     time.Time
+		want   string
+	}{
+		{coinID: "wsteth", at: jan.Add(-time.Second), want: "1.1"},
+		{coinID: "wsteth", at: jan, want: "1.15"},
+		{coinID: "wsteth", at: jun.Add(-time.Second), want: "1.15"},
+		{coinID: "wsteth", at: jun.AddDate(1, 0, 0), want: "1.2"},
+		// a series-only rule uses its earliest ratio before the series starts
+		{coinID: "reth", at: jan.AddDate(-1, 0, 0), want: "1.05"},
+	}
+
+	for _, tc := range cases {
+		rule, ok := registry.Lookup(tc.coinID)
+		if !ok {
+			t.Fatalf("Lookup(%q) ok = false", tc.coinID)
+		}
+		if rule.UnderlyingID != "ethereum" {
+			t.Fatalf("Lookup(%q).UnderlyingID = %q, want ethereum", tc.coinID, rule.UnderlyingID)
+		}
+		if got := rule.RatioAt(tc.at); !got.Equal(decimal.RequireFromString(tc.want)) {
+			t.Fatalf("%s RatioAt(%s) = %s, want %s", tc.coinID, tc.at, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package pricing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type pegTable struct {
+	rules map[string]domain.PegRule
+}
+
+func NewPegTable(assets []PeggedAsset) (domain.PegTable, error) {
+	rules := make(map[string]domain.PegRule, len(assets))
+	for i, a := range assets {
+		coinID := strings.TrimSpace(a.CoinID)
+		peg := strings.ToUpper(strings.TrimSpace(a.Peg))
+
+		if coinID == "" || peg == "" {
+			return nil, fmt.Errorf("pricing: invalid pegged asset at idx=%d (coin_id=%q, peg=%q)", i, a.CoinID, a.Peg)
+		}
+		if a.Tolerance < 0 {
+			return nil, fmt.Errorf("pricing: negative tolerance for pegged asset %q", coinID)
+		}
+		if _, exists := rules[coinID]; exists {
+			return nil, fmt.Errorf("pricing: duplicate pegged asset %q", coinID)
+		}
+
+		rules[coinID] = domain.PegRule{
+			CoinID:         coinID,
+			Currency:       peg,
+			Tolerance:      decimal.NewFromFloat(a.Tolerance),
+			MarketFallback: a.MarketFallback,
+		}
+	}
+
+	return &pegTable{rules: rules}, nil
+}
+
+func (t *pegTable) Lookup(coinID string) (domain.PegRule, bool) {
+	r, ok := t.rules[coinID]
+	return r, ok
+}
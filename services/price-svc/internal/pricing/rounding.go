@@ -0,0 +1,66 @@
+package pricing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+)
+
+const maxRoundingDecimals = 18
+
+type roundingPolicy struct {
+	def   domain.RoundingRule
+	rules map[string]domain.RoundingRule
+}
+
+func NewRoundingPolicy(cfg RoundingConfig) (domain.RoundingPolicy, error) {
+	def, err := toRoundingRule(cfg.Default)
+	if err != nil {
+		return nil, fmt.Errorf("pricing: default rounding: %w", err)
+	}
+
+	rules := make(map[string]domain.RoundingRule, len(cfg.Fiats))
+	for i, f := range cfg.Fiats {
+		currency := strings.ToUpper(strings.TrimSpace(f.Currency))
+		if currency == "" {
+			return nil, fmt.Errorf("pricing: invalid fiat rounding at idx=%d: empty currency", i)
+		}
+		if _, exists := rules[currency]; exists {
+			return nil, fmt.Errorf("pricing: duplicate fiat rounding %q", currency)
+		}
+
+		r, err := toRoundingRule(f.RoundingRule)
+		if err != nil {
+			return nil, fmt.Errorf("pricing: fiat rounding %q: %w", currency, err)
+		}
+		rules[currency] = r
+	}
+
+	return &roundingPolicy{def: def, rules: rules}, nil
+}
+
+func (p *roundingPolicy) For(fiat string) domain.RoundingRule {
+	if r, ok := p.rules[strings.ToUpper(strings.TrimSpace(fiat))]; ok {
+		return r
+	}
+	return p.def
+}
+
+func toRoundingRule(r RoundingRule) (domain.RoundingRule, error) {
+	if r.Decimals < 0 || r.Decimals > maxRoundingDecimals {
+		return domain.RoundingRule{}, fmt.Errorf("decimals must be 0..%d, got %d", maxRoundingDecimals, r.Decimals)
+	}
+
+	var mode domain.RoundingMode
+	switch strings.ToLower(strings.TrimSpace(r.Mode)) {
+	case "", "half_up":
+		mode = domain.RoundingHalfUp
+	case "bankers", "half_even":
+		mode = domain.RoundingBankers
+	default:
+		return domain.RoundingRule{}, fmt.Errorf("unknown mode %q (half_up, bankers)", r.Mode)
+	}
+
+	return domain.RoundingRule{Decimals: int32(r.Decimals), Mode: mode}, nil
+}
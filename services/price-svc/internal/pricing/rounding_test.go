@@ -0,0 +1,68 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestNewRoundingPolicyRejectsBadConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		cfg  RoundingConfig
+	}{
+		{name: "unknown mode", cfg: RoundingConfig{Default: RoundingRule{Decimals: 2, Mode: "ceil"}}},
+		{name: "negative decimals", cfg: RoundingConfig{Default: RoundingRule{Decimals: -1}}},
+		{name: "too many decimals", cfg: RoundingConfig{Default: RoundingRule{Decimals: 19}}},
+		{name: "empty currency", cfg: RoundingConfig{Fiats: []FiatRounding{{Currency: " "}}}},
+		{name: "currency listed twice in different case", cfg: RoundingConfig{Fiats: []FiatRounding{{Currency: "rub"}, {Currency: "RUB"}}}},
+		{name: "bad fiat mode", cfg: RoundingConfig{Fiats: []FiatRounding{{Currency: "KZT", RoundingRule: RoundingRule{Mode: "down"}}}}},
+	}
+
+	for _, tc := range cases {
+		if _, err := NewRoundingPolicy(tc.cfg); err == nil {
+			t.Fatalf("%s: NewRoundingPolicy() error = nil, want error", tc.name)
+		}
+	}
+}
+
+func TestRoundingPolicyPerFiat(t *testing.T) {
+	t.Parallel()
+
+	// RUB rounds half up, KZT rounds half to even, others keep 8 decimals
+	policy, err := NewRoundingPolicy(RoundingConfig{
+		Default: RoundingRule{Decimals: 8},
+		Fiats: []FiatRounding{
+			{Currency: "RUB", RoundingRule: RoundingRule{Decimals: 2, Mode: "half_up"}},
+			{Currency: "kzt", RoundingRule: RoundingRule{Decimals: 2, Mode: "bankers"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRoundingPolicy() error = %v", err)
+	}
+
+	cases := []struct {
+		fiat  string
+		value string
+		want  string
+	}{
+		{fiat: "RUB", value: "10.125", want: "10.13"},
+		{fiat: "RUB", value: "10.135", want: "10.14"},
+		{fiat: "RUB", value: "-10.125", want: "-10.13"},
+		{fiat: "KZT", value: "10.125", want: "10.12"},
+		{fiat: "KZT", value: "10.135", want: "10.14"},
+		{fiat: " kzt ", value: "10.125", want: "10.12"},
+		// a sub-cent valuation keeps its digits under the default rule
+		{fiat: "USD", value: "0.0000123456789", want: "0.00001235"},
+		{fiat: "", value: "1.5", want: "1.5"},
+	}
+
+	for _, tc := range cases {
+		got := policy.For(tc.fiat).Apply(decimal.RequireFromString(tc.value))
+		if got.String() != tc.want {
+			t.Fatalf("For(%q).Apply(%s) = %s, want %s", tc.fiat, tc.value, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type sanitizer struct {
+	maxJump decimal.Decimal
+	window  int
+}
+
+func NewPriceSanitizer(cfg SanityConfig) (domain.PriceSanitizer, error) {
+	if cfg.MaxJumpMultiple <= 1 {
+		return nil, fmt.Errorf("pricing: max_jump_multiple must be greater than 1, got %v", cfg.MaxJumpMultiple)
+	}
+	if cfg.NeighbourWindow < 1 {
+		return nil, fmt.Errorf("pricing: neighbour_window must be positive, got %d", cfg.NeighbourWindow)
+	}
+
+	return &sanitizer{
+		maxJump: decimal.NewFromFloat(cfg.MaxJumpMultiple),
+		window:  cfg.NeighbourWindow,
+	}, nil
+}
+
+// Sanitize flags non-positive points and points deviating from the median of up to window
+// positive neighbours on each side by more than maxJump (either direction).
+// A flagged point takes the value of the nearest unflagged neighbour, the previous one first.
+func (s *sanitizer) Sanitize(buckets []domain.HistoricalPrice) ([]domain.HistoricalPrice, []domain.QuarantinedPrice) {
+	flagged := make([]bool, len(buckets))
+	reasons := make([]domain.QuarantineReason, len(buckets))
+	medians := make([]*decimal.Decimal, len(buckets))
+
+	for i, b := range buckets {
+		if b.PriceUsd == nil {
+			continue
+		}
+		price := *b.PriceUsd
+		medians[i] = s.neighbourMedian(buckets, i)
+
+		switch {
+		case !price.IsPositive():
+			flagged[i], reasons[i] = true, domain.QuarantineReasonNonPositive
+		case medians[i] != nil && s.isJump(price, *medians[i]):
+			flagged[i], reasons[i] = true, domain.QuarantineReasonJump
+		}
+	}
+
+	out := make([]domain.HistoricalPrice, 0, len(buckets))
+	var quarantined []domain.QuarantinedPrice
+
+	for i, b := range buckets {
+		if !flagged[i] {
+			out = append(out, b)
+			continue
+		}
+
+		q := domain.QuarantinedPrice{
+			CoinID:          b.CoinID,
+			BucketStartUtc:  b.Time,
+			RawPrice:        *b.PriceUsd,
+			NeighbourMedian: medians[i],
+			Reason:          reasons[i],
+		}
+		if b.GranularitySeconds != nil {
+			q.GranularitySeconds = *b.GranularitySeconds
+		}
+
+		if sub := nearestClean(buckets, flagged, i); sub != nil {
+			q.SubstitutedPrice = sub
+			b.PriceUsd = sub
+			out = append(out, b)
+		}
+		quarantined = append(quarantined, q)
+	}
+
+	return out, quarantined
+}
+
+func (s *sanitizer) neighbourMedian(buckets []domain.HistoricalPrice, i int) *decimal.Decimal {
+	vals := make([]decimal.Decimal, 0, 2*s.window)
+	for j := max(0, i-s.window); j <= min(len(buckets)-1, i+s.window); j++ {
+		if j == i || buckets[j].PriceUsd == nil || !buckets[j].PriceUsd.IsPositive() {
+			continue
+		}
+		vals = append(vals, *buckets[j].PriceUsd)
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+
+	sort.Slice(vals, func(a, b int) bool { return vals[a].LessThan(vals[b]) })
+	mid := len(vals) / 2
+	m := vals[mid]
+	if len(vals)%2 == 0 {
+		m = vals[mid-1].Add(vals[mid]).Div(decimal.NewFromInt(2))
+	}
+	return &m
+}
+
+func (s *sanitizer) isJump(price, median decimal.Decimal) bool {
+	if !median.IsPositive() {
+		return false
+	}
+	return price.GreaterThan(median.Mul(s.maxJump)) || median.GreaterThan(price.Mul(s.maxJump))
+}
+
+func nearestClean(buckets []domain.HistoricalPrice, flagged []bool, i int) *decimal.Decimal {
+	for d := 1; d < len(buckets); d++ {
+		for _, j := range []int{i - d, i + d} {
+			if j >= 0 && j < len(buckets) && !flagged[j] && buckets[j].PriceUsd != nil {
+				v := *buckets[j].PriceUsd
+				return &v
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+var sanityStart = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// fiveMinuteBuckets builds one bucket per price, 5 minutes apart; an empty price is a missing one.
+func fiveMinuteBuckets(prices ...string) []domain.HistoricalPrice {
+	granularity := 300
+	out := make([]domain.HistoricalPrice, len(prices))
+	for i, p := range prices {
+		out[i] = domain.HistoricalPrice{
+			CoinID:             "pepe",
+			Time:               sanityStart.Add(time.Duration(i) * 5 * time.Minute),
+			GranularitySeconds: &granularity,
+		}
+		if p != "" {
+			v := decimal.RequireFromString(p)
+			out[i].PriceUsd = &v
+		}
+	}
+	return out
+}
+
+func storedPrices(buckets []domain.HistoricalPrice) []string {
+	out := make([]string, len(buckets))
+	for i, b := range buckets {
+		if b.PriceUsd != nil {
+			out[i] = b.PriceUsd.String()
+		}
+	}
+	return out
+}
+
+func newTestSanitizer(t *testing.T) domain.PriceSanitizer {
+	t.Helper()
+	s, err := NewPriceSanitizer(SanityConfig{MaxJumpMultiple: 10, NeighbourWindow: 2})
+	if err != nil {
+		t.Fatalf("NewPriceSanitizer() error = %v", err)
+	}
+	return s
+}
+
+func TestNewPriceSanitizerRejectsBadConfig(t *testing.T) {
+	t.Parallel()
+
+	// a multiple of 1 or less would flag every point that is not exactly the median
+	for _, cfg := range []SanityConfig{
+		{MaxJumpMultiple: 1, NeighbourWindow: 6},
+		{MaxJumpMultiple: 0.5, NeighbourWindow: 6},
+		{MaxJumpMultiple: 10, NeighbourWindow: 0},
+	} {
+		if _, err := NewPriceSanitizer(cfg); err == nil {
+			t.Fatalf("NewPriceSanitizer(%+v) error = nil, want error", cfg)
+		}
+	}
+}
+
+func TestSanitizeSubstitutesSpikeOfLowCapCoin(t *testing.T) {
+	t.Parallel()
+
+	// one 5-minute point off by 100x, as seen for low-cap coins
+	buckets := fiveMinuteBuckets("0.00001200", "0.00001210", "0.00001190", "0.00120000", "0.00001220", "0.00001200", "0.00001210")
+
+	out, quarantined := newTestSanitizer(t).Sanitize(buckets)
+
+	want := []string{"0.000012", "0.0000121", "0.0000119", "0.0000119", "0.0000122", "0.000012", "0.0000121"}
+	if got := storedPrices(out); !equalStrings(got, want) {
+		t.Fatalf("stored prices = %v, want %v", got, want)
+	}
+	if len(quarantined) != 1 {
+		t.Fatalf("quarantined %d points, want 1", len(quarantined))
+	}
+
+	q := quarantined[0]
+	if q.Reason != domain.QuarantineReasonJump {
+		t.Fatalf("Reason = %q, want %q", q.Reason, domain.QuarantineReasonJump)
+	}
+	if !q.BucketStartUtc.Equal(sanityStart.Add(15 * time.Minute)) {
+		t.Fatalf("BucketStartUtc = %s, want the 4th bucket", q.BucketStartUtc)
+	}
+	if q.RawPrice.String() != "0.0012" || q.GranularitySeconds != 300 {
+		t.Fatalf("RawPrice = %s, GranularitySeconds = %d, want 0.0012 and 300", q.RawPrice, q.GranularitySeconds)
+	}
+	if q.NeighbourMedian == nil || q.NeighbourMedian.String() != "0.00001205" {
+		t.Fatalf("NeighbourMedian = %v, want 0.00001205", q.NeighbourMedian)
+	}
+	if q.SubstitutedPrice == nil || q.SubstitutedPrice.String() != "0.0000119" {
+		t.Fatalf("SubstitutedPrice = %v, want 0.0000119", q.SubstitutedPrice)
+	}
+}
+
+func TestSanitizeFlags(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		window int // neighbours on each side, 2 when zero
+		prices []string
+		want   []string
+		// reasons are expected in bucket order
+		reasons []domain.QuarantineReason
+	}{
+		{
+			name:    "drop by 100x is a jump too",
+			prices:  []string{"1.00", "1.01", "0.99", "0.01", "1.02", "1.00"},
+			want:    []string{"1", "1.01", "0.99", "0.99", "1.02", "1"},
+			reasons: []domain.QuarantineReason{domain.QuarantineReasonJump},
+		},
+		{
+			name:   "move within the multiple is kept",
+			prices: []string{"1", "9", "1"},
+			want:   []string{"1", "9", "1"},
+		},
+		{
+			name:    "zero first point takes the next one",
+			prices:  []string{"0", "1.00", "1.01"},
+			want:    []string{"1", "1", "1.01"},
+			reasons: []domain.QuarantineReason{domain.QuarantineReasonNonPositive},
+		},
+		{
+			name:    "negative price",
+			prices:  []string{"1.00", "-1.00", "1.01"},
+			want:    []string{"1", "1", "1.01"},
+			reasons: []domain.QuarantineReason{domain.QuarantineReasonNonPositive},
+		},
+		{
+			// the second spike cannot take its flagged predecessor and takes the next point;
+			// a window of 2 would let the spikes pull their neighbours' median up
+			name:    "two spikes in a row",
+			window:  3,
+			prices:  []string{"1", "1", "1", "1", "100", "100", "1.01", "1.01", "1.01", "1.01"},
+			want:    []string{"1", "1", "1", "1", "1", "1.01", "1.01", "1.01", "1.01", "1.01"},
+			reasons: []domain.QuarantineReason{domain.QuarantineReasonJump, domain.QuarantineReasonJump},
+		},
+		{
+			name:   "missing prices pass through and are not neighbours",
+			prices: []string{"1", "", "1.01"},
+			want:   []string{"1", "", "1.01"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			window := tc.window
+			if window == 0 {
+				window = 2
+			}
+			s, err := NewPriceSanitizer(SanityConfig{MaxJumpMultiple: 10, NeighbourWindow: window})
+			if err != nil {
+				t.Fatalf("NewPriceSanitizer() error = %v", err)
+			}
+
+			out, quarantined := s.Sanitize(fiveMinuteBuckets(tc.prices...))
+
+			if got := storedPrices(out); !equalStrings(got, tc.want) {
+				t.Fatalf("stored prices = %v, want %v", got, tc.want)
+			}
+			if len(quarantined) != len(tc.reasons) {
+				t.Fatalf("quarantined %d points, want %d", len(quarantined), len(tc.reasons))
+			}
+			for i, q := range quarantined {
+				if q.Reason != tc.reasons[i] {
+					t.Fatalf("quarantined[%d].Reason = %q, want %q", i, q.Reason, tc.reasons[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeDropsBucketWithoutCleanNeighbour(t *testing.T) {
+	t.Parallel()
+
+	out, quarantined := newTestSanitizer(t).Sanitize(fiveMinuteBuckets("0"))
+
+	if len(out) != 0 {
+		t.Fatalf("stored %d buckets, want 0", len(out))
+	}
+	if len(quarantined) != 1 || quarantined[0].SubstitutedPrice != nil {
+		t.Fatalf("quarantined = %+v, want one point without a substitute", quarantined)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
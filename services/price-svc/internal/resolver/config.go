@@ -0,0 +1,35 @@
+package resolver
+
+// SourceNormalization rewrites the symbols one exchange source reports into canonical tickers.
+// Steps run in order: trim, case folding, prefix/suffix stripping, aliases.
+type SourceNormalization struct {
+	Source string `yaml:"source"`
+	// Case is upper (default), lower or keep.
+	Case string `yaml:"case"`
+	// StripPrefixes and StripSuffixes are removed once each, longest match first,
+	// e.g. perp suffixes "-PERP" or ".P". A symbol is never stripped to nothing.
+	StripPrefixes []string `yaml:"strip_prefixes"`
+	StripSuffixes []string `yaml:"strip_suffixes"`
+	// Aliases map exchange tickers to canonical ones after folding and stripping, e.g. XXBT: BTC.
+	Aliases map[string]string `yaml:"aliases"`
+}
+
+// SourcePairs tells how to split trading pairs ("BTCUSDT", "ETH/BTC") reported by one source.
+type SourcePairs struct {
+	Source string `yaml:"source"`
+	// Exchange is the CoinGecko exchange ID whose tickers seed the source's quote assets and known pairs.
+	Exchange string `yaml:"exchange"`
+	// Quotes are quote assets known without asking the provider.
+	Quotes []string `yaml:"quotes"`
+}
+
+// SourceConsensus opts a source into resolving symbols a tenant has not mapped by how the
+// other tenants map them. Suggestions are returned for every source; this only auto-applies them.
+type SourceConsensus struct {
+	Source string `yaml:"source"`
+	// MinTenants is how many other tenants must map the symbol to the winning coin.
+	MinTenants int `yaml:"min_tenants"`
+	// MinShare is the winning coin's least share of all other tenants' mappings of the symbol,
+	// above 0.5 and at most 1.
+	MinShare float64 `yaml:"min_share"`
+}
@@ -0,0 +1,147 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+// maxSuggestions bounds the coins suggested for one unknown symbol.
+const maxSuggestions = 5
+
+type consensusRule struct {
+	minTenants int
+	minShare   float64
+}
+
+// newConsensusRules validates the per-source thresholds for auto-applying other tenants' mappings.
+func newConsensusRules(cfg []SourceConsensus) (map[string]consensusRule, error) {
+	rules := make(map[string]consensusRule, len(cfg))
+	for i, c := range cfg {
+		source := strings.TrimSpace(c.Source)
+		if source == "" {
+			return nil, fmt.Errorf("resolver: consensus at idx=%d has no source", i)
+		}
+		if _, exists := rules[source]; exists {
+			return nil, fmt.Errorf("resolver: duplicate consensus for source %q", source)
+		}
+		if c.MinTenants < 1 {
+			return nil, fmt.Errorf("resolver: consensus min_tenants for source %q must be at least 1", source)
+		}
+		// a majority keeps two coins from both reaching the threshold
+		if c.MinShare <= 0.5 || c.MinShare > 1 {
+			return nil, fmt.Errorf("resolver: consensus min_share for source %q must be above 0.5 and at most 1", source)
+		}
+		rules[source] = consensusRule{minTenants: c.MinTenants, minShare: c.MinShare}
+	}
+	return rules, nil
+}
+
+// resolveFromTenants handles symbols still unknown after the catalog: when the source opts in and
+// enough other tenants agree on one coin the symbol resolves to it, otherwise the coins other
+// tenants map it to are attached to the error as suggestions.
+func (r *CoinIdResolver) resolveFromTenants(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	source string,
+	lookups []symbolLookup,
+	idx []int,
+	out []domain.SymbolResolution,
+) error {
+	if tenantID == uuid.Nil || source == "" {
+		return nil
+	}
+
+	var unknown []int
+	var symbols []string
+	for _, i := range idx {
+		if out[i].Err == nil || !errors.Is(out[i].Err, apperr.ErrUnknownSymbol) {
+			continue
+		}
+		unknown = append(unknown, i)
+		symbols = append(symbols, lookups[i].candidates...)
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	counts, err := r.tenantSymbolRepo.CountMappings(ctx, tenantID, source, symbols)
+	if err != nil {
+		return fmt.Errorf("tenantSymbolRepo.CountMappings: %w", err)
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	bySymbol := make(map[string][]domain.SymbolConsensus, len(counts))
+	coinIDs := make([]string, 0, len(counts))
+	for _, c := range counts {
+		bySymbol[c.Symbol] = append(bySymbol[c.Symbol], c)
+		coinIDs = append(coinIDs, c.CoinID)
+	}
+
+	coins, err := r.catalogRepo.GetByIDs(ctx, coinIDs)
+	if err != nil {
+		return fmt.Errorf("catalogRepo.GetByIDs: %w", err)
+	}
+	names := make(map[string]string, len(coins))
+	for _, c := range coins {
+		names[c.ID] = c.Name
+	}
+
+	rule, autoApply := r.consensus[source]
+	for _, i := range unknown {
+		// the symbol as sent wins over its canonical form, as with the tenant's own mappings
+		var votes []domain.SymbolConsensus
+		for _, s := range lookups[i].candidates {
+			if votes = bySymbol[s]; len(votes) > 0 {
+				break
+			}
+		}
+		if len(votes) == 0 {
+			continue
+		}
+
+		if autoApply && rule.reached(votes) {
+			out[i].CoinID = votes[0].CoinID
+			out[i].Consensus = true
+			out[i].Err = nil
+			continue
+		}
+
+		// other tenants' coins come ahead of a catalog suggestion
+		e := &domain.SuggestedCoinsError{Err: out[i].Err}
+		var catalog *domain.SuggestedCoinsError
+		if errors.As(out[i].Err, &catalog) {
+			e.Err = catalog.Err
+		}
+		for _, v := range votes[:min(len(votes), maxSuggestions)] {
+			e.Candidates = append(e.Candidates, domain.CoinCandidate{CoinID: v.CoinID, Name: names[v.CoinID], Tenants: v.Tenants})
+		}
+		if catalog != nil {
+			for _, c := range catalog.Candidates {
+				if !slices.ContainsFunc(e.Candidates, func(v domain.CoinCandidate) bool { return v.CoinID == c.CoinID }) {
+					e.Candidates = append(e.Candidates, c)
+				}
+			}
+		}
+		out[i].Err = e
+	}
+
+	return nil
+}
+
+// reached reports whether the most mapped coin meets the rule; votes are sorted most mapped first.
+func (c consensusRule) reached(votes []domain.SymbolConsensus) bool {
+	total := 0
+	for _, v := range votes {
+		total += v.Tenants
+	}
+	top := votes[0].Tenants
+	return top >= c.minTenants && float64(top) >= c.minShare*float64(total)
+}
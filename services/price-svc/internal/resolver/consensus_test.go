@@ -0,0 +1,82 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+)
+
+// votes builds the consensus counts of one symbol, most mapped coin first.
+func votes(tenants ...int) []domain.SymbolConsensus {
+	out := make([]domain.SymbolConsensus, len(tenants))
+	for i, n := range tenants {
+		out[i] = domain.SymbolConsensus{Symbol: "PEPE2", CoinID: string(rune('a' + i)), Tenants: n}
+	}
+	return out
+}
+
+func TestConsensusRuleReached(t *testing.T) {
+	t.Parallel()
+
+	rule := consensusRule{minTenants: 3, minShare: 0.75}
+
+	cases := []struct {
+		name  string
+		votes []domain.SymbolConsensus
+		want  bool
+	}{
+		{name: "enough tenants agree", votes: votes(3), want: true},
+		{name: "too few tenants", votes: votes(2), want: false},
+		{name: "share exactly at the threshold", votes: votes(3, 1), want: true},
+		{name: "share below the threshold", votes: votes(3, 2), want: false},
+		{name: "dissent spread over several coins", votes: votes(300, 40, 30, 20), want: true},
+		{name: "dissent adds up", votes: votes(300, 40, 30, 20, 20), want: false},
+	}
+
+	for _, tc := range cases {
+		if got := rule.reached(tc.votes); got != tc.want {
+			t.Fatalf("%s: reached() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestConsensusRuleTieNeverReached(t *testing.T) {
+	t.Parallel()
+
+	// min_share is above 0.5, so two coins mapped equally often never auto-apply
+	rule := consensusRule{minTenants: 1, minShare: 0.51}
+	if rule.reached(votes(50, 50)) {
+		t.Fatalf("reached() = true for a tie, want false")
+	}
+}
+
+func TestNewConsensusRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := newConsensusRules([]SourceConsensus{{Source: " binance ", MinTenants: 3, MinShare: 1}})
+	if err != nil {
+		t.Fatalf("newConsensusRules() error = %v", err)
+	}
+	if r, ok := rules["binance"]; !ok || r.minTenants != 3 || r.minShare != 1 {
+		t.Fatalf("rules[binance] = %+v, %v, want min_tenants 3 and min_share 1", r, ok)
+	}
+
+	bad := []struct {
+		name string
+		cfg  []SourceConsensus
+	}{
+		{name: "no source", cfg: []SourceConsensus{{MinTenants: 3, MinShare: 0.8}}},
+		{name: "duplicate source", cfg: []SourceConsensus{
+			{Source: "binance", MinTenants: 3, MinShare: 0.8},
+			{Source: "binance", MinTenants: 5, MinShare: 0.9},
+		}},
+		{name: "no tenants", cfg: []SourceConsensus{{Source: "binance", MinShare: 0.8}}},
+		{name: "share of one half", cfg: []SourceConsensus{{Source: "binance", MinTenants: 3, MinShare: 0.5}}},
+		{name: "share above one", cfg: []SourceConsensus{{Source: "binance", MinTenants: 3, MinShare: 1.1}}},
+	}
+	for _, tc := range bad {
+		if _, err := newConsensusRules(tc.cfg); err == nil {
+			t.Fatalf("%s: newConsensusRules() error = nil, want error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+)
+
+// resolveContracts resolves the assets at idx by chain and contract address. Without a chain
+// an address that several coins list on different chains is ambiguous.
+func (r *CoinIdResolver) resolveContracts(ctx context.Context, assets []domain.AssetRef, idx []int, out []domain.SymbolResolution) error {
+	if len(idx) == 0 {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(idx))
+	for _, i := range idx {
+		addresses = append(addresses, domain.NormalizeContractAddress(assets[i].ContractAddress))
+	}
+
+	contracts, err := r.contractRepo.GetByAddresses(ctx, addresses)
+	if err != nil {
+		return fmt.Errorf("contractRepo.GetByAddresses: %w", err)
+	}
+	byAddress := make(map[string][]domain.CoinContract, len(contracts))
+	for _, c := range contracts {
+		byAddress[c.ContractAddress] = append(byAddress[c.ContractAddress], c)
+	}
+
+	for j, i := range idx {
+		addr := addresses[j]
+		platform := r.platform(assets[i].Chain)
+
+		coinIDs := make(map[string]struct{})
+		for _, c := range byAddress[addr] {
+			if platform == "" || c.Platform == platform {
+				coinIDs[c.CoinID] = struct{}{}
+			}
+		}
+
+		switch len(coinIDs) {
+		case 0:
+			if platform == "" {
+				out[i].Err = fmt.Errorf("%w: contract %s", apperr.ErrUnknownSymbol, addr)
+			} else {
+				out[i].Err = fmt.Errorf("%w: contract %s on %s", apperr.ErrUnknownSymbol, addr, platform)
+			}
+		case 1:
+			for coinID := range coinIDs {
+				out[i].CoinID = coinID
+			}
+		default:
+			ids := make([]string, 0, len(coinIDs))
+			for coinID := range coinIDs {
+				ids = append(ids, coinID)
+			}
+			sort.Strings(ids)
+			out[i].Err = fmt.Errorf("%w: contract %s is listed for %s; set the chain", apperr.ErrAmbiguousSymbol, addr, strings.Join(ids, ", "))
+		}
+	}
+
+	return nil
+}
+
+// platform maps a client chain name to the provider platform ID; unknown names pass through.
+func (r *CoinIdResolver) platform(chain string) string {
+	chain = strings.ToLower(strings.TrimSpace(chain))
+	if p, ok := r.chains[chain]; ok {
+		return p
+	}
+	return chain
+}
@@ -0,0 +1,94 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+// fakeContracts looks contracts up by normalised address like the real store.
+type fakeContracts struct {
+	domain.CoinContractRepo
+	contracts []domain.CoinContract
+}
+
+func (f fakeContracts) GetByAddresses(_ context.Context, addresses []string) ([]domain.CoinContract, error) {
+	var out []domain.CoinContract
+	for _, c := range f.contracts {
+		if slices.Contains(addresses, c.ContractAddress) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func TestResolveContracts(t *testing.T) {
+	t.Parallel()
+
+	const (
+		usdc   = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+		bridge = "0x1111111111111111111111111111111111111111"
+		mint   = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	)
+	contracts := fakeContracts{contracts: []domain.CoinContract{
+		{Platform: "ethereum", ContractAddress: usdc, CoinID: "usd-coin"},
+		{Platform: "ethereum", ContractAddress: bridge, CoinID: "token-a"},
+		{Platform: "binance-smart-chain", ContractAddress: bridge, CoinID: "token-b"},
+		{Platform: "solana", ContractAddress: mint, CoinID: "usd-coin"},
+	}}
+	normalizer, err := NewSymbolNormalizer(nil)
+	if err != nil {
+		t.Fatalf("NewSymbolNormalizer() error = %v", err)
+	}
+	r, err := NewCoinIdResolver(Deps{
+		ContractRepo: contracts,
+		Normalizer:   normalizer,
+	}, map[string]string{"ETH": "ethereum", "bsc": "binance-smart-chain"}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewCoinIdResolver() error = %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		asset domain.AssetRef
+		want  string
+		err   error
+	}{
+		{name: "address only", asset: domain.AssetRef{ContractAddress: usdc}, want: "usd-coin"},
+		{name: "mixed-case EVM address", asset: domain.AssetRef{ContractAddress: " 0xA0b86991C6218b36c1d19D4a2e9Eb0cE3606eB48 "}, want: "usd-coin"},
+		{name: "contract wins over symbol", asset: domain.AssetRef{Symbol: "FAKE", ContractAddress: usdc}, want: "usd-coin"},
+		{name: "chain alias", asset: domain.AssetRef{ContractAddress: bridge, Chain: " eth "}, want: "token-a"},
+		{name: "provider platform ID", asset: domain.AssetRef{ContractAddress: bridge, Chain: "binance-smart-chain"}, want: "token-b"},
+		{name: "case-sensitive address", asset: domain.AssetRef{ContractAddress: mint, Chain: "solana"}, want: "usd-coin"},
+		{name: "address on several chains", asset: domain.AssetRef{ContractAddress: bridge}, err: apperr.ErrAmbiguousSymbol},
+		{name: "not on the chain", asset: domain.AssetRef{ContractAddress: usdc, Chain: "bsc"}, err: apperr.ErrUnknownSymbol},
+		{name: "unknown address", asset: domain.AssetRef{ContractAddress: "0xdead"}, err: apperr.ErrUnknownSymbol},
+		{name: "base58 address folded", asset: domain.AssetRef{ContractAddress: "epjfwdd5aufqssqem2qn1xzybapc8g4weggkzwytdt1v"}, err: apperr.ErrUnknownSymbol},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			legs := []domain.AssetLeg{{Asset: 0, At: time.Now().UTC()}}
+			out, err := r.Resolve(context.Background(), uuid.Nil, "binance", []domain.AssetRef{tc.asset}, legs)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if tc.err != nil {
+				if !errors.Is(out[0].Err, tc.err) || out[0].CoinID != "" {
+					t.Fatalf("Resolve() = %+v, want %v", out[0], tc.err)
+				}
+				return
+			}
+			if out[0].Err != nil || out[0].CoinID != tc.want {
+				t.Fatalf("Resolve() = %+v, want %s", out[0], tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+)
+
+const (
+	caseUpper = "upper"
+	caseLower = "lower"
+	caseKeep  = "keep"
+)
+
+type sourceRules struct {
+	fold          func(string) string
+	stripPrefixes []string
+	stripSuffixes []string
+	aliases       map[string]string
+}
+
+type symbolNormalizer struct {
+	sources  map[string]sourceRules
+	fallback sourceRules
+}
+
+// NewSymbolNormalizer builds per-source rules; sources without rules are trimmed and upper-cased.
+func NewSymbolNormalizer(cfg []SourceNormalization) (domain.SymbolNormalizer, error) {
+	n := &symbolNormalizer{
+		sources:  make(map[string]sourceRules, len(cfg)),
+		fallback: sourceRules{fold: strings.ToUpper},
+	}
+
+	for i, c := range cfg {
+		source := strings.TrimSpace(c.Source)
+		if source == "" {
+			return nil, fmt.Errorf("resolver: normalization at idx=%d has no source", i)
+		}
+		if _, exists := n.sources[source]; exists {
+			return nil, fmt.Errorf("resolver: duplicate normalization for source %q", source)
+		}
+
+		var rules sourceRules
+		switch strings.ToLower(strings.TrimSpace(c.Case)) {
+		case "", caseUpper:
+			rules.fold = strings.ToUpper
+		case caseLower:
+			rules.fold = strings.ToLower
+		case caseKeep:
+			rules.fold = func(s string) string { return s }
+		default:
+			return nil, fmt.Errorf("resolver: unknown case %q for source %q (want upper, lower or keep)", c.Case, source)
+		}
+
+		// affixes and alias keys are compared after folding
+		rules.stripPrefixes = foldAffixes(c.StripPrefixes, rules.fold)
+		rules.stripSuffixes = foldAffixes(c.StripSuffixes, rules.fold)
+
+		rules.aliases = make(map[string]string, len(c.Aliases))
+		for from, to := range c.Aliases {
+			from, to = rules.fold(strings.TrimSpace(from)), strings.TrimSpace(to)
+			if from == "" || to == "" {
+				return nil, fmt.Errorf("resolver: invalid alias %q -> %q for source %q", from, to, source)
+			}
+			rules.aliases[from] = to
+		}
+
+		n.sources[source] = rules
+	}
+
+	return n, nil
+}
+
+func (n *symbolNormalizer) Normalize(source, symbol string) string {
+	rules, ok := n.sources[source]
+	if !ok {
+		rules = n.fallback
+	}
+
+	s := rules.fold(strings.TrimSpace(symbol))
+	for _, p := range rules.stripPrefixes {
+		if rest, ok := strings.CutPrefix(s, p); ok && rest != "" {
+			s = rest
+			break
+		}
+	}
+	for _, sfx := range rules.stripSuffixes {
+		if rest, ok := strings.CutSuffix(s, sfx); ok && rest != "" {
+			s = rest
+			break
+		}
+	}
+	if alias, ok := rules.aliases[s]; ok {
+		s = alias
+	}
+
+	return s
+}
+
+// foldAffixes folds and sorts affixes longest first, so "-PERP" wins over "PERP".
+func foldAffixes(affixes []string, fold func(string) string) []string {
+	out := make([]string, 0, len(affixes))
+	for _, a := range affixes {
+		if a = fold(strings.TrimSpace(a)); a != "" {
+			out = append(out, a)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return len(out[i]) > len(out[j]) })
+	return out
+}
@@ -0,0 +1,100 @@
+package resolver
+
+import "testing"
+
+// shippedNormalization follows the sources in config.yaml; bybit lists its suffixes shortest
+// first to check they are tried longest first.
+var shippedNormalization = []SourceNormalization{
+	{Source: "binance", Case: "upper"},
+	{Source: "kraken", Aliases: map[string]string{"XXBT": "BTC", "XBT": "BTC", "XETH": "ETH", "ZEUR": "EUR"}},
+	{Source: "bybit", StripSuffixes: []string{"PERP", ".P", "-PERP"}},
+}
+
+func TestSymbolNormalizerShippedSources(t *testing.T) {
+	t.Parallel()
+
+	n, err := NewSymbolNormalizer(shippedNormalization)
+	if err != nil {
+		t.Fatalf("NewSymbolNormalizer() error = %v", err)
+	}
+
+	cases := []struct {
+		source string
+		symbol string
+		want   string
+	}{
+		{source: "binance", symbol: "btc", want: "BTC"},
+		{source: "binance", symbol: " Eth ", want: "ETH"},
+		{source: "kraken", symbol: "XXBT", want: "BTC"},
+		{source: "kraken", symbol: "xeth", want: "ETH"},
+		{source: "kraken", symbol: "ZEUR", want: "EUR"},
+		{source: "kraken", symbol: "DOT", want: "DOT"},
+		// "-PERP" wins over "PERP", so no dash is left behind
+		{source: "bybit", symbol: "BTC-PERP", want: "BTC"},
+		{source: "bybit", symbol: "ETHPERP", want: "ETH"},
+		{source: "bybit", symbol: "BTC.P", want: "BTC"},
+		// a suffix is stripped once and never down to nothing
+		{source: "bybit", symbol: "PERP", want: "PERP"},
+		{source: "bybit", symbol: "SOL.P.P", want: "SOL.P"},
+		// aliases are per source
+		{source: "binance", symbol: "XXBT", want: "XXBT"},
+		{source: "unknown", symbol: " sol ", want: "SOL"},
+	}
+
+	for _, tc := range cases {
+		if got := n.Normalize(tc.source, tc.symbol); got != tc.want {
+			t.Fatalf("Normalize(%q, %q) = %q, want %q", tc.source, tc.symbol, got, tc.want)
+		}
+	}
+}
+
+func TestSymbolNormalizerCaseModes(t *testing.T) {
+	t.Parallel()
+
+	n, err := NewSymbolNormalizer([]SourceNormalization{
+		{Source: "onchain", Case: "keep", StripPrefixes: []string{"w"}},
+		{Source: "lowerdex", Case: "lower", StripSuffixes: []string{".E"}, Aliases: map[string]string{"XBT": "BTC"}},
+	})
+	if err != nil {
+		t.Fatalf("NewSymbolNormalizer() error = %v", err)
+	}
+
+	cases := []struct {
+		source string
+		symbol string
+		want   string
+	}{
+		{source: "onchain", symbol: "stETH", want: "stETH"},
+		{source: "onchain", symbol: "wstETH", want: "stETH"},
+		{source: "onchain", symbol: "WETH", want: "WETH"},
+		// affixes and alias keys are folded like the symbol, alias targets are kept as configured
+		{source: "lowerdex", symbol: "USDC.e", want: "usdc"},
+		{source: "lowerdex", symbol: "XBT", want: "BTC"},
+	}
+
+	for _, tc := range cases {
+		if got := n.Normalize(tc.source, tc.symbol); got != tc.want {
+			t.Fatalf("Normalize(%q, %q) = %q, want %q", tc.source, tc.symbol, got, tc.want)
+		}
+	}
+}
+
+func TestNewSymbolNormalizerRejectsBadConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		cfg  []SourceNormalization
+	}{
+		{name: "no source", cfg: []SourceNormalization{{Source: " "}}},
+		{name: "duplicate source", cfg: []SourceNormalization{{Source: "kraken"}, {Source: "kraken"}}},
+		{name: "unknown case", cfg: []SourceNormalization{{Source: "kraken", Case: "title"}}},
+		{name: "empty alias target", cfg: []SourceNormalization{{Source: "kraken", Aliases: map[string]string{"XXBT": " "}}}},
+	}
+
+	for _, tc := range cases {
+		if _, err := NewSymbolNormalizer(tc.cfg); err == nil {
+			t.Fatalf("%s: NewSymbolNormalizer() error = nil, want error", tc.name)
+		}
+	}
+}
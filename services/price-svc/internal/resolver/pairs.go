@@ -0,0 +1,182 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+)
+
+// maxTickerPages bounds one exchange refresh; CoinGecko pages hold 100 tickers.
+const maxTickerPages = 50
+
+// pairSeparators split pairs such as "ETH/BTC" or "ETH-BTC" without consulting quote assets.
+const pairSeparators = "/-_:"
+
+type exchangeTickersClient interface {
+	ExchangesTickers(ctx context.Context, exchangeID string, p coingecko.ExchangeTickersParams) (*coingecko.ExchangeTickersResponse, error)
+}
+
+type pairTable struct {
+	quotes []string                      // longest first
+	known  map[string][]domain.PairSplit // concatenated base+quote -> listed pairs
+}
+
+// PairParser splits trading pairs using each source's quote assets, seeded from the
+// configured list and refreshed from the exchange's CoinGecko tickers.
+type PairParser struct {
+	log    logger.Logger
+	client exchangeTickersClient
+	cfg    map[string]SourcePairs
+
+	mu     sync.RWMutex
+	tables map[string]pairTable
+}
+
+func NewPairParser(log logger.Logger, client exchangeTickersClient, cfg []SourcePairs) (*PairParser, error) {
+	p := &PairParser{
+		log:    log,
+		client: client,
+		cfg:    make(map[string]SourcePairs, len(cfg)),
+		tables: make(map[string]pairTable, len(cfg)),
+	}
+
+	for i, c := range cfg {
+		c.Source = strings.TrimSpace(c.Source)
+		c.Exchange = strings.TrimSpace(c.Exchange)
+		if c.Source == "" {
+			return nil, fmt.Errorf("resolver: pairs at idx=%d have no source", i)
+		}
+		if _, exists := p.cfg[c.Source]; exists {
+			return nil, fmt.Errorf("resolver: duplicate pairs for source %q", c.Source)
+		}
+		for _, q := range c.Quotes {
+			if strings.TrimSpace(q) == "" {
+				return nil, fmt.Errorf("resolver: empty quote asset for source %q", c.Source)
+			}
+		}
+
+		p.cfg[c.Source] = c
+		p.tables[c.Source] = newPairTable(c.Quotes, nil)
+	}
+
+	return p, nil
+}
+
+// Refresh reloads quote assets and listed pairs of every source with an exchange.
+// A source whose tickers cannot be fetched keeps its previous table.
+func (p *PairParser) Refresh(ctx context.Context) error {
+	var errs []error
+	for source, c := range p.cfg {
+		if c.Exchange == "" {
+			continue
+		}
+
+		tickers, err := p.fetchTickers(ctx, c.Exchange)
+		if err != nil {
+			p.log.Error("pair refresh failed, keeping previous pairs source=%s exchange=%s: %v", source, c.Exchange, err)
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+			continue
+		}
+
+		table := newPairTable(c.Quotes, tickers)
+
+		p.mu.Lock()
+		p.tables[source] = table
+		p.mu.Unlock()
+
+		p.log.Info("pairs refreshed source=%s exchange=%s quotes=%d tickers=%d", source, c.Exchange, len(table.quotes), len(tickers))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *PairParser) fetchTickers(ctx context.Context, exchangeID string) ([]coingecko.ExchangeTicker, error) {
+	var out []coingecko.ExchangeTicker
+	for page := 1; page <= maxTickerPages; page++ {
+		resp, err := p.client.ExchangesTickers(ctx, exchangeID, coingecko.ExchangeTickersParams{Page: &page})
+		if err != nil {
+			return nil, fmt.Errorf("ExchangesTickers page=%d: %w", page, err)
+		}
+		if len(resp.Tickers) == 0 {
+			break
+		}
+		out = append(out, resp.Tickers...)
+	}
+	return out, nil
+}
+
+func (p *PairParser) Splits(source, symbol string) []domain.PairSplit {
+	s := strings.ToUpper(strings.TrimSpace(symbol))
+
+	p.mu.RLock()
+	table := p.tables[source]
+	p.mu.RUnlock()
+
+	if known, ok := table.known[s]; ok {
+		return known
+	}
+
+	if i := strings.IndexAny(s, pairSeparators); i > 0 && i < len(s)-1 {
+		return []domain.PairSplit{{Base: s[:i], Quote: s[i+1:]}}
+	}
+
+	// a quote suffix alone does not make a pair ("WETH" is not W/ETH), so these are only candidates
+	var out []domain.PairSplit
+	for _, q := range table.quotes {
+		if base, ok := strings.CutSuffix(s, q); ok && base != "" {
+			out = append(out, domain.PairSplit{Base: base, Quote: q, Guessed: true})
+		}
+	}
+	return out
+}
+
+func newPairTable(quotes []string, tickers []coingecko.ExchangeTicker) pairTable {
+	t := pairTable{known: make(map[string][]domain.PairSplit, len(tickers))}
+
+	seen := make(map[string]struct{})
+	addQuote := func(q string) {
+		if _, ok := seen[q]; ok || q == "" {
+			return
+		}
+		seen[q] = struct{}{}
+		t.quotes = append(t.quotes, q)
+	}
+
+	for _, q := range quotes {
+		addQuote(strings.ToUpper(strings.TrimSpace(q)))
+	}
+
+	for _, tk := range tickers {
+		base := strings.ToUpper(strings.TrimSpace(tk.Base))
+		quote := strings.ToUpper(strings.TrimSpace(tk.Target))
+		if base == "" || quote == "" {
+			continue
+		}
+		addQuote(quote)
+
+		split := domain.PairSplit{Base: base, Quote: quote}
+		pair := base + quote
+		if !containsSplit(t.known[pair], split) {
+			t.known[pair] = append(t.known[pair], split)
+		}
+	}
+
+	sort.SliceStable(t.quotes, func(i, j int) bool { return len(t.quotes[i]) > len(t.quotes[j]) })
+	return t
+}
+
+func containsSplit(splits []domain.PairSplit, s domain.PairSplit) bool {
+	for _, x := range splits {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
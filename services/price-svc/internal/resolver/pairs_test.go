@@ -0,0 +1,158 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+)
+
+// fakeTickers serves its tickers as a single page, or fails with err.
+type fakeTickers struct {
+	tickers []coingecko.ExchangeTicker
+	err     error
+}
+
+func (f *fakeTickers) ExchangesTickers(_ context.Context, _ string, p coingecko.ExchangeTickersParams) (*coingecko.ExchangeTickersResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if p.Page != nil && *p.Page > 1 {
+		return &coingecko.ExchangeTickersResponse{}, nil
+	}
+	return &coingecko.ExchangeTickersResponse{Tickers: f.tickers}, nil
+}
+
+func TestNewPairParserRejectsBadConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		cfg  []SourcePairs
+	}{
+		{name: "no source", cfg: []SourcePairs{{Source: " "}}},
+		{name: "duplicate source", cfg: []SourcePairs{{Source: "binance"}, {Source: "binance"}}},
+		{name: "empty quote", cfg: []SourcePairs{{Source: "binance", Quotes: []string{"USDT", ""}}}},
+	}
+
+	for _, tc := range cases {
+		if _, err := NewPairParser(logger.New("error"), &fakeTickers{}, tc.cfg); err == nil {
+			t.Fatalf("%s: NewPairParser() error = nil, want error", tc.name)
+		}
+	}
+}
+
+func TestPairParserSplits(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeTickers{tickers: []coingecko.ExchangeTicker{
+		{Base: "BTC", Target: "USDT"},
+		{Base: "eth", Target: "btc"},
+		{Base: "WBTC", Target: "BTC"},
+	}}
+	p, err := NewPairParser(logger.New("error"), client, []SourcePairs{
+		{Source: "binance", Exchange: "binance", Quotes: []string{"USDT", "ETH"}},
+		{Source: "kraken", Quotes: []string{"EUR", "ZEUR"}},
+	})
+	if err != nil {
+		t.Fatalf("NewPairParser() error = %v", err)
+	}
+	if err := p.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		source string
+		symbol string
+		want   []domain.PairSplit
+	}{
+		{
+			name:   "listed pair",
+			source: "binance", symbol: "btcusdt",
+			want: []domain.PairSplit{{Base: "BTC", Quote: "USDT"}},
+		},
+		{
+			name:   "listed pair quoted in an asset only the tickers know",
+			source: "binance", symbol: "ETHBTC",
+			want: []domain.PairSplit{{Base: "ETH", Quote: "BTC"}},
+		},
+		{
+			name:   "separator",
+			source: "binance", symbol: "ETH/BTC",
+			want: []domain.PairSplit{{Base: "ETH", Quote: "BTC"}},
+		},
+		{
+			name:   "separator on a source without pairs config",
+			source: "coinbase", symbol: "sol-usd",
+			want: []domain.PairSplit{{Base: "SOL", Quote: "USD"}},
+		},
+		{
+			name:   "unlisted pair ending in a quote asset",
+			source: "binance", symbol: "SOLUSDT",
+			want: []domain.PairSplit{{Base: "SOL", Quote: "USDT", Guessed: true}},
+		},
+		{
+			name:   "token ending in a quote asset",
+			source: "binance", symbol: "WETH",
+			want: []domain.PairSplit{{Base: "W", Quote: "ETH", Guessed: true}},
+		},
+		{
+			name:   "two quote assets match, longest first",
+			source: "kraken", symbol: "XETHZEUR",
+			want: []domain.PairSplit{
+				{Base: "XETH", Quote: "ZEUR", Guessed: true},
+				{Base: "XETHZ", Quote: "EUR", Guessed: true},
+			},
+		},
+		{
+			name:   "bare quote asset",
+			source: "binance", symbol: "USDT",
+		},
+		{
+			name:   "trailing separator",
+			source: "kraken", symbol: "SOL-",
+		},
+		{
+			name:   "unknown source has no quote assets",
+			source: "coinbase", symbol: "BTCUSD",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := p.Splits(tc.source, tc.symbol); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Splits(%q, %q) = %+v, want %+v", tc.source, tc.symbol, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPairParserRefreshFailureKeepsPreviousPairs(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeTickers{tickers: []coingecko.ExchangeTicker{{Base: "PEPE", Target: "TRY"}}}
+	p, err := NewPairParser(logger.New("error"), client, []SourcePairs{{Source: "binance", Exchange: "binance"}})
+	if err != nil {
+		t.Fatalf("NewPairParser() error = %v", err)
+	}
+	if err := p.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	client.err = errors.New("provider down")
+	if err := p.Refresh(context.Background()); err == nil {
+		t.Fatalf("Refresh() error = nil, want the provider error")
+	}
+
+	want := []domain.PairSplit{{Base: "PEPE", Quote: "TRY"}}
+	if got := p.Splits("binance", "PEPETRY"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Splits(PEPETRY) after failed refresh = %+v, want %+v", got, want)
+	}
+}
@@ -1,39 +1,318 @@
 package resolver
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
 	inmemory "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/infra/in-memory"
+	"github.com/google/uuid"
 )
 
 type CoinIdResolver struct {
 	tenantSymbolRepo domain.TenantSymbolRepo
+	customAssetRepo  domain.CustomAssetRepo
+	contractRepo     domain.CoinContractRepo
+	catalogRepo      domain.CoinCatalogRepo
 	coinIdCache      *inmemory.CoinIdCache
+	normalizer       domain.SymbolNormalizer
+	pairs            domain.PairParser
+	chains           map[string]string
+	consensus        map[string]consensusRule
+	catalogFallback  map[string]bool
 }
 
-func NewCoinIdResolver(tenantSymbolRepo domain.TenantSymbolRepo, coinIdCache *inmemory.CoinIdCache) domain.CoinIdResolver {
+// Deps are the mapping stores and symbol rules symbols are resolved with.
+type Deps struct {
+	TenantSymbolRepo domain.TenantSymbolRepo
+	CustomAssetRepo  domain.CustomAssetRepo
+	ContractRepo     domain.CoinContractRepo
+	CatalogRepo      domain.CoinCatalogRepo
+	CoinIdCache      *inmemory.CoinIdCache
+	Normalizer       domain.SymbolNormalizer
+	Pairs            domain.PairParser
+}
+
+// NewCoinIdResolver builds the resolver; chains maps chain names clients send (e.g. "bsc")
+// to provider platform IDs (e.g. "binance-smart-chain"), consensus lists the sources that
+// auto-apply other tenants' mappings and catalogFallback those that auto-apply catalog matches.
+func NewCoinIdResolver(deps Deps, chains map[string]string, consensus []SourceConsensus, catalogFallback []string) (domain.CoinIdResolver, error) {
+	rules, err := newConsensusRules(consensus)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := make(map[string]bool, len(catalogFallback))
+	for i, s := range catalogFallback {
+		source := strings.TrimSpace(s)
+		if source == "" {
+			return nil, fmt.Errorf("resolver: catalog fallback at idx=%d has no source", i)
+		}
+		fallback[source] = true
+	}
+
+	aliases := make(map[string]string, len(chains))
+	for name, platform := range chains {
+		aliases[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(platform)
+	}
+
 	return &CoinIdResolver{
-		tenantSymbolRepo: tenantSymbolRepo,
-		coinIdCache:      coinIdCache,
+		tenantSymbolRepo: deps.TenantSymbolRepo,
+		customAssetRepo:  deps.CustomAssetRepo,
+		contractRepo:     deps.ContractRepo,
+		catalogRepo:      deps.CatalogRepo,
+		coinIdCache:      deps.CoinIdCache,
+		normalizer:       deps.Normalizer,
+		pairs:            deps.Pairs,
+		chains:           aliases,
+		consensus:        rules,
+		catalogFallback:  fallback,
+	}, nil
+}
+
+func (r *CoinIdResolver) Resolve(ctx context.Context, tenantID uuid.UUID, source string, assets []domain.AssetRef, legs []domain.AssetLeg) ([]domain.SymbolResolution, error) {
+	out := make([]domain.SymbolResolution, len(legs))
+	if len(legs) == 0 {
+		return out, nil
+	}
+
+	// per asset: the outcome that does not depend on the leg time
+	byAsset := make([]domain.SymbolResolution, len(assets))
+
+	var byContract []int
+	for i, a := range assets {
+		if strings.TrimSpace(a.ContractAddress) != "" {
+			byContract = append(byContract, i)
+		}
+	}
+	if err := r.resolveContracts(ctx, assets, byContract, byAsset); err != nil {
+		return nil, err
+	}
+
+	// lookups use the symbol as sent first, so explicit mappings of raw exchange tickers keep working
+	lookups := make([]symbolLookup, len(assets))
+	var all []string
+	for i, a := range assets {
+		symbol := a.Symbol
+		canonical := r.normalizer.Normalize(source, symbol)
+		byAsset[i].CanonicalSymbol = canonical
+		if strings.TrimSpace(a.ContractAddress) != "" {
+			continue
+		}
+
+		l := &lookups[i]
+		l.candidates = candidates(symbol, canonical)
+		all = append(all, l.candidates...)
+
+		// pair splits are fetched up front so the tenant lookup stays a single round trip
+		for _, split := range r.pairs.Splits(source, canonical) {
+			base := r.normalizer.Normalize(source, split.Base)
+			l.splits = append(l.splits, pairLookup{
+				split:      split,
+				base:       base,
+				candidates: candidates(split.Base, base),
+			})
+			all = append(all, l.splits[len(l.splits)-1].candidates...)
+		}
+	}
+
+	tenantIDs, err := r.tenantCoinIDs(ctx, tenantID, source, all)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var unmatchedLegs, unmatched []int
+	pending := make(map[int]bool)
+	for j, leg := range legs {
+		i := leg.Asset
+		a := assets[i]
+		out[j] = byAsset[i]
+		if strings.TrimSpace(a.ContractAddress) != "" {
+			continue
+		}
+
+		symbol := a.Symbol
+		l := lookups[i]
+		at := leg.At
+		if at.IsZero() {
+			at = now
+		}
+
+		if coinID, ok := r.match(tenantIDs, l.candidates, at); ok {
+			out[j].CoinID = coinID
+			continue
+		}
+
+		hits, coinIDs := r.matchPairs(tenantIDs, l.splits, at)
+		switch {
+		case len(coinIDs) == 1 && !hits[0].split.Guessed:
+			out[j].CoinID = hits[0].coinID
+			out[j].CanonicalSymbol = hits[0].base
+			out[j].Quote = hits[0].split.Quote
+			continue
+		case len(hits) > 0:
+			e := &domain.AmbiguousPairError{Pair: out[j].CanonicalSymbol}
+			for _, h := range hits {
+				e.Splits = append(e.Splits, h.split)
+			}
+			out[j].Err = e
+			continue
+		}
+
+		// the remaining fallbacks ignore mapping windows, so they run once per asset
+		unmatchedLegs = append(unmatchedLegs, j)
+		if pending[i] {
+			continue
+		}
+		pending[i] = true
+		unmatched = append(unmatched, i)
+		if byAsset[i].CanonicalSymbol != symbol {
+			byAsset[i].Err = fmt.Errorf("%w: %s (normalized %s)", apperr.ErrUnknownSymbol, symbol, byAsset[i].CanonicalSymbol)
+		} else {
+			byAsset[i].Err = fmt.Errorf("%w: %s", apperr.ErrUnknownSymbol, symbol)
+		}
+	}
+
+	if err := r.resolveFromCatalog(ctx, source, lookups, unmatched, byAsset); err != nil {
+		return nil, err
+	}
+	if err := r.resolveFromTenants(ctx, tenantID, source, lookups, unmatched, byAsset); err != nil {
+		return nil, err
+	}
+	for _, j := range unmatchedLegs {
+		out[j] = byAsset[legs[j].Asset]
 	}
+
+	return out, nil
 }
 
-func (r *CoinIdResolver) Resolve(symbol string) (string, error) {
-	if coinID, ok := r.coinIdCache.Get(symbol); ok {
-		return coinID, nil
+// resolveFromCatalog handles symbols no mapping knows: a symbol only one listed coin uses resolves
+// to it when the source opts in and is suggested otherwise, since a new listing can take over a
+// ticker the tenant meant for another coin; one shared by several coins fails with them as candidates.
+func (r *CoinIdResolver) resolveFromCatalog(ctx context.Context, source string, lookups []symbolLookup, idx []int, out []domain.SymbolResolution) error {
+	if len(idx) == 0 {
+		return nil
+	}
+
+	var symbols []string
+	for _, i := range idx {
+		symbols = append(symbols, lookups[i].candidates...)
+	}
+
+	coins, err := r.catalogRepo.GetActiveBySymbols(ctx, symbols)
+	if err != nil {
+		return fmt.Errorf("catalogRepo.GetActiveBySymbols: %w", err)
+	}
+	bySymbol := make(map[string][]domain.Coin, len(coins))
+	for _, c := range coins {
+		key := strings.ToUpper(c.Symbol)
+		bySymbol[key] = append(bySymbol[key], c)
 	}
 
-	// tenantUUID, err := domain.ParseTenantID(tenantID)
-	// if err != nil {
-	// 	return "", false
-	// }
+	for _, i := range idx {
+		for _, s := range lookups[i].candidates {
+			matches := bySymbol[strings.ToUpper(s)]
+			if len(matches) == 0 {
+				continue
+			}
+			switch {
+			case len(matches) == 1 && r.catalogFallback[source]:
+				out[i].CoinID = matches[0].ID
+				out[i].Err = nil
+			case len(matches) == 1:
+				c := matches[0]
+				out[i].Err = &domain.SuggestedCoinsError{Err: out[i].Err, Candidates: []domain.CoinCandidate{{CoinID: c.ID, Name: c.Name}}}
+			default:
+				out[i].Err = &domain.AmbiguousSymbolError{Symbol: s, Candidates: matches}
+			}
+			break
+		}
+	}
 
-	// ts, err := r.tenantSymbolRepo.GetByTenantSourceSymbol(nil, tenantUUID, source, symbol)
-	// if err != nil || ts.CoinID == "" {
-	// 	return "", false
-	// }
+	return nil
+}
+
+type symbolLookup struct {
+	candidates []string
+	splits     []pairLookup
+}
+
+type pairLookup struct {
+	split      domain.PairSplit
+	base       string // canonical base
+	candidates []string
+	coinID     string
+}
+
+func candidates(symbol, canonical string) []string {
+	if canonical == symbol {
+		return []string{symbol}
+	}
+	return []string{symbol, canonical}
+}
+
+// match tries the tenant's resolutions for every candidate before the global map.
+// Only mappings valid at the given time count.
+func (r *CoinIdResolver) match(tenantIDs map[string][]domain.SymbolMapping, candidates []string, at time.Time) (string, bool) {
+	for _, s := range candidates {
+		if coinID, ok := domain.MappingAt(tenantIDs[s], at); ok {
+			return coinID, true
+		}
+	}
+	for _, s := range candidates {
+		mappings, _ := r.coinIdCache.Get(s)
+		if coinID, ok := domain.MappingAt(mappings, at); ok {
+			return coinID, true
+		}
+	}
+	return "", false
+}
+
+// matchPairs returns the splits whose base resolves, and the distinct coins they resolve to.
+func (r *CoinIdResolver) matchPairs(tenantIDs map[string][]domain.SymbolMapping, splits []pairLookup, at time.Time) ([]pairLookup, map[string]struct{}) {
+	var hits []pairLookup
+	coinIDs := make(map[string]struct{})
+	for _, p := range splits {
+		coinID, ok := r.match(tenantIDs, p.candidates, at)
+		if !ok {
+			continue
+		}
+		p.coinID = coinID
+		hits = append(hits, p)
+		coinIDs[coinID] = struct{}{}
+	}
+	return hits, coinIDs
+}
+
+// tenantCoinIDs returns the tenant's own resolutions; custom assets win over symbol mappings
+// and hold at any time.
+func (r *CoinIdResolver) tenantCoinIDs(ctx context.Context, tenantID uuid.UUID, source string, symbols []string) (map[string][]domain.SymbolMapping, error) {
+	out := make(map[string][]domain.SymbolMapping)
+	if tenantID == uuid.Nil {
+		return out, nil
+	}
+
+	if source != "" {
+		mapped, err := r.tenantSymbolRepo.GetList(ctx, tenantID, source, symbols)
+		if err != nil {
+			return nil, fmt.Errorf("tenantSymbolRepo.GetList: %w", err)
+		}
+		for _, s := range mapped {
+			out[s.Symbol] = append(out[s.Symbol], s.Mapping())
+		}
+	}
+
+	custom, err := r.customAssetRepo.GetBySymbols(ctx, tenantID, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("customAssetRepo.GetBySymbols: %w", err)
+	}
+	for _, a := range custom {
+		out[a.Symbol] = []domain.SymbolMapping{{CoinID: a.CoinID()}}
+	}
 
-	// r.coinIdCache.Set(cacheKey, ts.CoinID)
-	return "", fmt.Errorf("symbol not found: %s", symbol)
+	return out, nil
 }
@@ -0,0 +1,152 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+// fakeCatalog lists active coins; lookups by symbol fold case like the real query.
+type fakeCatalog struct {
+	domain.CoinCatalogRepo
+	coins []domain.Coin
+}
+
+func (c fakeCatalog) GetActiveBySymbols(_ context.Context, symbols []string) ([]domain.Coin, error) {
+	var out []domain.Coin
+	for _, coin := range c.coins {
+		for _, s := range symbols {
+			if strings.EqualFold(coin.Symbol, s) {
+				out = append(out, coin)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c fakeCatalog) GetByIDs(_ context.Context, ids []string) ([]domain.Coin, error) {
+	var out []domain.Coin
+	for _, coin := range c.coins {
+		for _, id := range ids {
+			if coin.ID == id {
+				out = append(out, coin)
+			}
+		}
+	}
+	return out, nil
+}
+
+// fakeVotes answers CountMappings with fixed counts.
+type fakeVotes struct {
+	domain.TenantSymbolRepo
+	counts []domain.SymbolConsensus
+}
+
+func (v fakeVotes) CountMappings(context.Context, uuid.UUID, string, []string) ([]domain.SymbolConsensus, error) {
+	return v.counts, nil
+}
+
+func unknownSymbol(symbol string) domain.SymbolResolution {
+	return domain.SymbolResolution{CanonicalSymbol: symbol, Err: apperr.ErrUnknownSymbol}
+}
+
+func TestResolveFromCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := fakeCatalog{coins: []domain.Coin{
+		{ID: "pepe", Symbol: "pepe", Name: "Pepe"},
+		{ID: "uniswap", Symbol: "uni", Name: "Uniswap"},
+		{ID: "universe", Symbol: "UNI", Name: "Universe"},
+	}}
+	r, err := NewCoinIdResolver(Deps{CatalogRepo: catalog}, nil, nil, []string{" kraken "})
+	if err != nil {
+		t.Fatalf("NewCoinIdResolver() error = %v", err)
+	}
+	resolver := r.(*CoinIdResolver)
+
+	lookups := []symbolLookup{{candidates: []string{"PEPE"}}, {candidates: []string{"UNI"}}, {candidates: []string{"NOPE"}}}
+	resolve := func(source string) []domain.SymbolResolution {
+		t.Helper()
+		out := []domain.SymbolResolution{unknownSymbol("PEPE"), unknownSymbol("UNI"), unknownSymbol("NOPE")}
+		if err := resolver.resolveFromCatalog(context.Background(), source, lookups, []int{0, 1, 2}, out); err != nil {
+			t.Fatalf("resolveFromCatalog() error = %v", err)
+		}
+		return out
+	}
+
+	// an opted-in source takes the only coin with the symbol
+	out := resolve("kraken")
+	if out[0].CoinID != "pepe" || out[0].Err != nil {
+		t.Fatalf("PEPE on kraken = %+v, want pepe", out[0])
+	}
+
+	// any other source gets it as a suggestion and the symbol stays unknown
+	out = resolve("binance")
+	var suggested *domain.SuggestedCoinsError
+	if out[0].CoinID != "" || !errors.As(out[0].Err, &suggested) || !errors.Is(out[0].Err, apperr.ErrUnknownSymbol) {
+		t.Fatalf("PEPE on binance = %+v, want unknown with a suggestion", out[0])
+	}
+	if len(suggested.Candidates) != 1 || suggested.Candidates[0] != (domain.CoinCandidate{CoinID: "pepe", Name: "Pepe"}) {
+		t.Fatalf("suggestions = %+v, want pepe from the catalog", suggested.Candidates)
+	}
+
+	for _, source := range []string{"kraken", "binance"} {
+		out = resolve(source)
+		if !errors.Is(out[1].Err, apperr.ErrAmbiguousSymbol) {
+			t.Fatalf("UNI on %s = %+v, want ambiguous", source, out[1])
+		}
+		if out[2].Err != apperr.ErrUnknownSymbol {
+			t.Fatalf("NOPE on %s = %+v, want unknown as is", source, out[2])
+		}
+	}
+
+	if _, err := NewCoinIdResolver(Deps{}, nil, nil, []string{" "}); err == nil {
+		t.Fatalf("NewCoinIdResolver() with a blank catalog fallback source error = nil, want error")
+	}
+}
+
+func TestCatalogSuggestionAfterTenantVotes(t *testing.T) {
+	t.Parallel()
+
+	catalog := fakeCatalog{coins: []domain.Coin{{ID: "pepe", Symbol: "PEPE", Name: "Pepe"}, {ID: "pepe-2", Symbol: "PEPE2", Name: "Pepe 2.0"}}}
+	votes := fakeVotes{counts: []domain.SymbolConsensus{
+		{Symbol: "PEPE", CoinID: "pepe-2", Tenants: 2},
+		{Symbol: "PEPE", CoinID: "pepe", Tenants: 1},
+	}}
+	r, err := NewCoinIdResolver(Deps{CatalogRepo: catalog, TenantSymbolRepo: votes}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewCoinIdResolver() error = %v", err)
+	}
+	resolver := r.(*CoinIdResolver)
+
+	lookups := []symbolLookup{{candidates: []string{"PEPE"}}}
+	out := []domain.SymbolResolution{unknownSymbol("PEPE")}
+	ctx := context.Background()
+	if err := resolver.resolveFromCatalog(ctx, "binance", lookups, []int{0}, out); err != nil {
+		t.Fatalf("resolveFromCatalog() error = %v", err)
+	}
+	if err := resolver.resolveFromTenants(ctx, uuid.New(), "binance", lookups, []int{0}, out); err != nil {
+		t.Fatalf("resolveFromTenants() error = %v", err)
+	}
+
+	var suggested *domain.SuggestedCoinsError
+	if !errors.As(out[0].Err, &suggested) || !errors.Is(out[0].Err, apperr.ErrUnknownSymbol) {
+		t.Fatalf("PEPE = %+v, want unknown with suggestions", out[0])
+	}
+	if errors.As(suggested.Err, new(*domain.SuggestedCoinsError)) {
+		t.Fatalf("suggestions are nested: %v", out[0].Err)
+	}
+	var ids []string
+	for _, c := range suggested.Candidates {
+		ids = append(ids, c.CoinID)
+	}
+	if got := strings.Join(ids, ","); got != "pepe-2,pepe" {
+		t.Fatalf("suggested coins = %s, want pepe-2,pepe", got)
+	}
+}
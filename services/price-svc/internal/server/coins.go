@@ -0,0 +1,63 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *PriceServer) SearchCoins(ctx context.Context, req *v1.SearchCoinsRequest) (*v1.SearchCoinsResponse, error) {
+	coins, err := server.coinCatalogUC.Search(ctx, req.Query, int(req.Limit))
+	if err != nil {
+		server.log.Warn("SearchCoins: failed query=%q: %v", req.Query, err)
+		return nil, coinStatusError(err)
+	}
+
+	resp := &v1.SearchCoinsResponse{Coins: make([]*v1.Coin, 0, len(coins))}
+	for _, c := range coins {
+		resp.Coins = append(resp.Coins, toCoin(c))
+	}
+	return resp, nil
+}
+
+func (server *PriceServer) GetCoin(ctx context.Context, req *v1.GetCoinRequest) (*v1.GetCoinResponse, error) {
+	coin, err := server.coinCatalogUC.Get(ctx, req.CoinId)
+	if err != nil {
+		if !errors.Is(err, apperr.ErrNotFound) {
+			server.log.Warn("GetCoin: failed coin_id=%s: %v", req.CoinId, err)
+		}
+		return nil, coinStatusError(err)
+	}
+
+	return &v1.GetCoinResponse{Coin: toCoin(coin)}, nil
+}
+
+func coinStatusError(err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		return status.Error(codes.NotFound, "coin not found")
+	case errors.Is(err, apperr.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("coin catalog: %v", err))
+	}
+}
+
+func toCoin(c domain.Coin) *v1.Coin {
+	return &v1.Coin{
+		CoinId:    c.ID,
+		Symbol:    c.Symbol,
+		Name:      c.Name,
+		Platforms: c.Platforms,
+		FirstSeen: timestamppb.New(c.FirstSeen),
+		LastSeen:  timestamppb.New(c.LastSeen),
+		Delisted:  c.Delisted,
+	}
+}
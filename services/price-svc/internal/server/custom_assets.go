@@ -0,0 +1,180 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// customPricesOpenEnd stands in for an unset upper bound when listing custom prices.
+var customPricesOpenEnd = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+func (server *PriceServer) UpsertCustomAsset(ctx context.Context, req *v1.UpsertCustomAssetRequest) (*v1.UpsertCustomAssetResponse, error) {
+	tenantID, err := parseUUID(req.TenantId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+
+	asset, err := server.customAssetUC.UpsertAsset(ctx, domain.CustomAsset{
+		TenantID: tenantID,
+		Symbol:   req.Symbol,
+		Name:     req.Name,
+	})
+	if err != nil {
+		server.log.Error("UpsertCustomAsset: upsert failed tenant_id=%s symbol=%s: %v", tenantID, req.Symbol, err)
+		return nil, customAssetStatusError(err)
+	}
+
+	server.log.Info("UpsertCustomAsset: upserted tenant_id=%s asset_id=%s symbol=%s", tenantID, asset.ID, asset.Symbol)
+	return &v1.UpsertCustomAssetResponse{Asset: toCustomAsset(asset)}, nil
+}
+
+func (server *PriceServer) ListCustomAssets(ctx context.Context, req *v1.ListCustomAssetsRequest) (*v1.ListCustomAssetsResponse, error) {
+	tenantID, err := parseUUID(req.TenantId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+
+	assets, err := server.customAssetUC.ListAssets(ctx, tenantID)
+	if err != nil {
+		server.log.Error("ListCustomAssets: list failed tenant_id=%s: %v", tenantID, err)
+		return nil, customAssetStatusError(err)
+	}
+
+	resp := &v1.ListCustomAssetsResponse{Assets: make([]*v1.CustomAsset, 0, len(assets))}
+	for _, a := range assets {
+		resp.Assets = append(resp.Assets, toCustomAsset(a))
+	}
+	return resp, nil
+}
+
+func (server *PriceServer) DeleteCustomAsset(ctx context.Context, req *v1.DeleteCustomAssetRequest) (*v1.DeleteCustomAssetResponse, error) {
+	tenantID, assetID, err := parseCustomAssetRef(req.TenantId, req.AssetId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.customAssetUC.DeleteAsset(ctx, tenantID, assetID); err != nil {
+		return nil, customAssetStatusError(err)
+	}
+
+	server.log.Info("DeleteCustomAsset: deleted tenant_id=%s asset_id=%s", tenantID, assetID)
+	return &v1.DeleteCustomAssetResponse{}, nil
+}
+
+func (server *PriceServer) UpsertCustomAssetPrices(ctx context.Context, req *v1.UpsertCustomAssetPricesRequest) (*v1.UpsertCustomAssetPricesResponse, error) {
+	tenantID, assetID, err := parseCustomAssetRef(req.TenantId, req.AssetId)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]domain.CustomPricePoint, len(req.Prices))
+	for i, p := range req.Prices {
+		if p.TimeUtc == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "price %d: missing time_utc", i)
+		}
+		price, err := decimal.NewFromString(p.Price)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "price %d: invalid price %q: %v", i, p.Price, err)
+		}
+		points[i] = domain.CustomPricePoint{Time: p.TimeUtc.AsTime(), Price: price, Currency: p.Currency}
+	}
+
+	if err := server.customAssetUC.UpsertPrices(ctx, tenantID, assetID, points); err != nil {
+		server.log.Error("UpsertCustomAssetPrices: upsert failed asset_id=%s: %v", assetID, err)
+		return nil, customAssetStatusError(err)
+	}
+
+	server.log.Info("UpsertCustomAssetPrices: upserted tenant_id=%s asset_id=%s points=%d", tenantID, assetID, len(points))
+	return &v1.UpsertCustomAssetPricesResponse{Upserted: int32(len(points))}, nil
+}
+
+func (server *PriceServer) ListCustomAssetPrices(ctx context.Context, req *v1.ListCustomAssetPricesRequest) (*v1.ListCustomAssetPricesResponse, error) {
+	tenantID, assetID, err := parseCustomAssetRef(req.TenantId, req.AssetId)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := time.Unix(0, 0).UTC(), customPricesOpenEnd
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	points, err := server.customAssetUC.ListPrices(ctx, tenantID, assetID, from, to)
+	if err != nil {
+		return nil, customAssetStatusError(err)
+	}
+
+	resp := &v1.ListCustomAssetPricesResponse{Prices: make([]*v1.CustomPricePoint, 0, len(points))}
+	for _, p := range points {
+		resp.Prices = append(resp.Prices, &v1.CustomPricePoint{
+			TimeUtc:  timestamppb.New(p.Time),
+			Price:    p.Price.String(),
+			Currency: p.Currency,
+		})
+	}
+	return resp, nil
+}
+
+func (server *PriceServer) UploadCustomAssetPricesCsv(ctx context.Context, req *v1.UploadCustomAssetPricesCsvRequest) (*v1.UploadCustomAssetPricesCsvResponse, error) {
+	tenantID, err := parseUUID(req.TenantId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+
+	res, err := server.customAssetUC.ImportPricesCSV(ctx, tenantID, bytes.NewReader(req.Csv))
+	if err != nil {
+		server.log.Warn("UploadCustomAssetPricesCsv: import failed tenant_id=%s: %v", tenantID, err)
+		return nil, customAssetStatusError(err)
+	}
+
+	server.log.Info("UploadCustomAssetPricesCsv: imported tenant_id=%s rows=%d assets=%d", tenantID, res.Rows, res.Assets)
+	return &v1.UploadCustomAssetPricesCsvResponse{Rows: int32(res.Rows), Assets: int32(res.Assets)}, nil
+}
+
+func parseCustomAssetRef(tenant, asset string) (uuid.UUID, uuid.UUID, error) {
+	tenantID, err := parseUUID(tenant)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+	assetID, err := parseUUID(asset)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid asset ID: %v", err)
+	}
+	return tenantID, assetID, nil
+}
+
+func customAssetStatusError(err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		return status.Error(codes.NotFound, "custom asset not found")
+	case errors.Is(err, apperr.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("custom asset: %v", err))
+	}
+}
+
+func toCustomAsset(a domain.CustomAsset) *v1.CustomAsset {
+	return &v1.CustomAsset{
+		AssetId:   a.ID.String(),
+		Symbol:    a.Symbol,
+		Name:      a.Name,
+		CreatedAt: timestamppb.New(a.CreatedAt),
+		UpdatedAt: timestamppb.New(a.UpdatedAt),
+	}
+}
@@ -0,0 +1,263 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultJobWorkers      = 2
+	defaultJobChunkSize    = 500
+	defaultJobPollInterval = 2 * time.Second
+	defaultJobLease        = 2 * time.Minute
+	defaultJobMaxAttempts  = 5
+	defaultJobRetryBackoff = 30 * time.Second
+	maxJobRetryBackoff     = time.Hour
+)
+
+// JobRunner prices queued valuation jobs in chunks. Every chunk is saved together with the job's
+// progress and lease, so a job left behind by a stopped or crashed instance is picked up again
+// once its lease expires and continues after its last saved chunk. A failed run is retried with
+// exponential backoff; the job fails after maxAttempts runs. Every write carries the lease token
+// issued at claim, and a worker whose job was taken over by another one drops it.
+type JobRunner struct {
+	log    *logger.ZeroLogger
+	server *PriceServer
+	jobs   domain.ValuationJobUseCase
+
+	workers      int
+	chunkSize    int
+	pollInterval time.Duration
+	lease        time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+type JobRunnerOption func(*JobRunner)
+
+func WithJobWorkers(n int) JobRunnerOption {
+	return func(r *JobRunner) {
+		if n > 0 {
+			r.workers = n
+		}
+	}
+}
+
+func WithJobChunkSize(n int) JobRunnerOption {
+	return func(r *JobRunner) {
+		if n > 0 {
+			r.chunkSize = n
+		}
+	}
+}
+
+func WithJobPollInterval(d time.Duration) JobRunnerOption {
+	return func(r *JobRunner) {
+		if d > 0 {
+			r.pollInterval = d
+		}
+	}
+}
+
+func WithJobLease(d time.Duration) JobRunnerOption {
+	return func(r *JobRunner) {
+		if d > 0 {
+			r.lease = d
+		}
+	}
+}
+
+func WithJobMaxAttempts(n int) JobRunnerOption {
+	return func(r *JobRunner) {
+		if n > 0 {
+			r.maxAttempts = n
+		}
+	}
+}
+
+func WithJobRetryBackoff(d time.Duration) JobRunnerOption {
+	return func(r *JobRunner) {
+		if d > 0 {
+			r.retryBackoff = d
+		}
+	}
+}
+
+func NewJobRunner(log *logger.ZeroLogger, server *PriceServer, jobs domain.ValuationJobUseCase, opts ...JobRunnerOption) *JobRunner {
+	r := &JobRunner{
+		log:          log,
+		server:       server,
+		jobs:         jobs,
+		workers:      defaultJobWorkers,
+		chunkSize:    defaultJobChunkSize,
+		pollInterval: defaultJobPollInterval,
+		lease:        defaultJobLease,
+		maxAttempts:  defaultJobMaxAttempts,
+		retryBackoff: defaultJobRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run blocks until ctx is done and all workers have stopped.
+func (r *JobRunner) Run(ctx context.Context) error {
+	r.log.Info("start valuation job runner workers=%d chunk=%d", r.workers, r.chunkSize)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx)
+		}()
+	}
+	wg.Wait()
+
+	r.log.Info("valuation job runner is stopped")
+	return nil
+}
+
+func (r *JobRunner) work(ctx context.Context) {
+	for {
+		job, err := r.jobs.Claim(ctx, r.lease)
+		if err != nil && ctx.Err() == nil {
+			r.log.Error("JobRunner: claim failed: %v", err)
+		}
+
+		// after an interrupted job, wait before claiming again so a failing provider is not hammered
+		if job != nil && r.process(ctx, job) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+// process runs a job to the end and reports whether it finished (succeeded or failed).
+func (r *JobRunner) process(ctx context.Context, job *domain.ValuationJob) bool {
+	r.log.Info("JobRunner: running job_id=%s processed=%d/%d", job.ID, job.Processed, job.Total)
+
+	var settings v1.ValuateTransactionsRequest
+	if err := proto.Unmarshal(job.Request, &settings); err != nil {
+		r.finish(job, fmt.Errorf("corrupt request: %w", err))
+		return true
+	}
+
+	for {
+		items, err := r.jobs.NextItems(ctx, job.ID, r.chunkSize)
+		if err != nil {
+			return r.retryLater(ctx, job, err)
+		}
+		if len(items) == 0 {
+			r.finish(job, nil)
+			return true
+		}
+
+		req := proto.Clone(&settings).(*v1.ValuateTransactionsRequest)
+		req.Transactions = make([]*v1.TxToValuate, len(items))
+		for i, it := range items {
+			tx := &v1.TxToValuate{}
+			if err := proto.Unmarshal(it.Tx, tx); err != nil {
+				r.finish(job, fmt.Errorf("corrupt transaction at seq %d: %w", it.Seq, err))
+				return true
+			}
+			req.Transactions[i] = tx
+		}
+
+		resp, err := r.server.valuate(ctx, req)
+		if err != nil {
+			// a bad request or a snapshot taken with other settings will not succeed on retry
+			if c := status.Code(err); c == codes.InvalidArgument || c == codes.FailedPrecondition {
+				r.finish(job, err)
+				return true
+			}
+			return r.retryLater(ctx, job, err)
+		}
+
+		for i, tx := range resp.Transactions {
+			b, err := proto.Marshal(tx)
+			if err != nil {
+				r.finish(job, fmt.Errorf("marshal result at seq %d: %w", items[i].Seq, err))
+				return true
+			}
+			items[i].Result = b
+		}
+
+		if err := r.jobs.SaveResults(ctx, job.ID, job.LeaseToken, items, r.lease); err != nil {
+			return r.retryLater(ctx, job, err)
+		}
+	}
+}
+
+func (r *JobRunner) finish(job *domain.ValuationJob, jobErr error) {
+	if err := r.jobs.Finish(context.Background(), job.ID, job.LeaseToken, jobErr); err != nil {
+		if errors.Is(err, apperr.ErrLeaseLost) {
+			r.log.Warn("JobRunner: lease lost, dropping job job_id=%s", job.ID)
+			return
+		}
+		r.log.Error("JobRunner: finish failed job_id=%s: %v", job.ID, err)
+		return
+	}
+	if jobErr != nil {
+		r.log.Warn("JobRunner: job failed job_id=%s: %v", job.ID, jobErr)
+		return
+	}
+	r.log.Info("JobRunner: job done job_id=%s txs=%d", job.ID, job.Total)
+}
+
+// retryLater hands the job back to the queue, keeping saved chunks, and reports whether the job
+// finished instead. On shutdown the job is released without counting an attempt; otherwise it
+// waits out the backoff, and fails once it has used up maxAttempts.
+func (r *JobRunner) retryLater(ctx context.Context, job *domain.ValuationJob, cause error) bool {
+	if errors.Is(cause, apperr.ErrLeaseLost) {
+		r.log.Warn("JobRunner: lease lost, dropping job job_id=%s", job.ID)
+		return false
+	}
+
+	if ctx.Err() != nil {
+		r.log.Warn("JobRunner: job interrupted job_id=%s, will resume: %v", job.ID, cause)
+		if err := r.jobs.Release(context.Background(), job.ID, job.LeaseToken); err != nil {
+			r.log.Error("JobRunner: release failed job_id=%s: %v", job.ID, err)
+		}
+		return false
+	}
+
+	attempt := job.Attempts + 1
+	if attempt >= r.maxAttempts {
+		r.finish(job, fmt.Errorf("giving up after %d attempts: %w", attempt, cause))
+		return true
+	}
+
+	delay := retryBackoff(r.retryBackoff, job.Attempts)
+	r.log.Warn("JobRunner: job attempt %d/%d failed job_id=%s, retry in %s: %v", attempt, r.maxAttempts, job.ID, delay, cause)
+	if err := r.jobs.Retry(context.Background(), job.ID, job.LeaseToken, delay); err != nil {
+		r.log.Error("JobRunner: retry failed job_id=%s: %v", job.ID, err)
+	}
+	return false
+}
+
+// retryBackoff doubles base for every earlier failed attempt, up to maxJobRetryBackoff.
+func retryBackoff(base time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 0; i < attempts && d < maxJobRetryBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxJobRetryBackoff)
+}
@@ -0,0 +1,236 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeJobs holds the items of a single job and records what the runner did with it.
+type fakeJobs struct {
+	domain.ValuationJobUseCase
+
+	items   []domain.ValuationJobItem
+	saveErr error
+
+	submitted *domain.ValuationJob
+	saves     int
+	finished  bool
+	finishErr error
+	retryIn   time.Duration
+	released  bool
+}
+
+func (f *fakeJobs) Submit(_ context.Context, tenantID string, request []byte, txs [][]byte) (domain.ValuationJob, error) {
+	f.submitted = &domain.ValuationJob{ID: uuid.New(), TenantID: tenantID, Request: request, Total: len(txs)}
+	return *f.submitted, nil
+}
+
+func (f *fakeJobs) NextItems(_ context.Context, _ uuid.UUID, limit int) ([]domain.ValuationJobItem, error) {
+	var out []domain.ValuationJobItem
+	for _, it := range f.items {
+		if it.Result == nil && len(out) < limit {
+			out = append(out, domain.ValuationJobItem{Seq: it.Seq, Tx: it.Tx})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeJobs) SaveResults(_ context.Context, _, _ uuid.UUID, items []domain.ValuationJobItem, _ time.Duration) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.saves++
+	for _, it := range items {
+		f.items[it.Seq].Result = it.Result
+	}
+	return nil
+}
+
+func (f *fakeJobs) Finish(_ context.Context, _, _ uuid.UUID, jobErr error) error {
+	f.finished, f.finishErr = true, jobErr
+	return nil
+}
+
+func (f *fakeJobs) Retry(_ context.Context, _, _ uuid.UUID, delay time.Duration) error {
+	f.retryIn = delay
+	return nil
+}
+
+func (f *fakeJobs) Release(context.Context, uuid.UUID, uuid.UUID) error {
+	f.released = true
+	return nil
+}
+
+func newTestJob(t *testing.T, n int) (*domain.ValuationJob, *fakeJobs) {
+	t.Helper()
+
+	request, err := proto.Marshal(&v1.ValuateTransactionsRequest{FiatCurrency: "RUB"})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	jobs := &fakeJobs{}
+	for i := 0; i < n; i++ {
+		b, err := proto.Marshal(streamTx(fmt.Sprint(i)))
+		if err != nil {
+			t.Fatalf("marshal tx: %v", err)
+		}
+		jobs.items = append(jobs.items, domain.ValuationJobItem{Seq: i, Tx: b})
+	}
+	return &domain.ValuationJob{ID: uuid.New(), LeaseToken: uuid.New(), Request: request, Total: n}, jobs
+}
+
+func newTestRunner(t *testing.T, jobs *fakeJobs, resolverErr error) *JobRunner {
+	t.Helper()
+
+	server := newTestServer(t)
+	server.resolver = fakeResolver{err: resolverErr}
+	return NewJobRunner(logger.New("error"), server, jobs,
+		WithJobChunkSize(2),
+		WithJobMaxAttempts(3),
+		WithJobRetryBackoff(time.Minute),
+	)
+}
+
+func TestJobRunnerPricesEveryChunk(t *testing.T) {
+	t.Parallel()
+
+	job, jobs := newTestJob(t, 5)
+	if done := newTestRunner(t, jobs, nil).process(context.Background(), job); !done {
+		t.Fatalf("process() = false, want the job finished")
+	}
+
+	if jobs.saves != 3 {
+		t.Fatalf("saved chunks = %d, want 3", jobs.saves)
+	}
+	if !jobs.finished || jobs.finishErr != nil {
+		t.Fatalf("finished = %v err = %v, want succeeded", jobs.finished, jobs.finishErr)
+	}
+	for _, it := range jobs.items {
+		var tx v1.ValuatedTx
+		if err := proto.Unmarshal(it.Result, &tx); err != nil {
+			t.Fatalf("result seq=%d: %v", it.Seq, err)
+		}
+		if tx.TxId != fmt.Sprint(it.Seq) || tx.InFiat == nil {
+			t.Fatalf("result seq=%d = %v, want tx %d priced", it.Seq, &tx, it.Seq)
+		}
+	}
+}
+
+func TestJobRunnerResumesAfterSavedChunks(t *testing.T) {
+	t.Parallel()
+
+	job, jobs := newTestJob(t, 5)
+	// a previous run saved the first chunk before its lease expired
+	jobs.items[0].Result, jobs.items[1].Result = []byte{}, []byte{}
+	newTestRunner(t, jobs, nil).process(context.Background(), job)
+
+	if jobs.saves != 2 {
+		t.Fatalf("saved chunks = %d, want only the 2 left", jobs.saves)
+	}
+	if len(jobs.items[0].Result) != 0 {
+		t.Fatalf("saved item was priced again")
+	}
+}
+
+func TestJobRunnerFailedAttempts(t *testing.T) {
+	t.Parallel()
+
+	providerDown := errors.New("provider down")
+	cases := []struct {
+		name     string
+		attempts int
+		ctxDone  bool
+		saveErr  error
+		resolve  error
+		done     bool
+		retryIn  time.Duration
+		released bool
+		failed   string
+	}{
+		{name: "first failure waits the base backoff", resolve: providerDown, retryIn: time.Minute},
+		{name: "backoff doubles per attempt", attempts: 1, resolve: providerDown, retryIn: 2 * time.Minute},
+		{name: "last attempt fails the job", attempts: 2, resolve: providerDown, done: true, failed: "giving up after 3 attempts"},
+		{name: "shutdown releases without an attempt", ctxDone: true, resolve: providerDown, released: true},
+		{name: "lost lease drops the job", saveErr: fmt.Errorf("save: %w", apperr.ErrLeaseLost)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			job, jobs := newTestJob(t, 3)
+			job.Attempts = tc.attempts
+			jobs.saveErr = tc.saveErr
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.ctxDone {
+				cancel()
+			}
+
+			done := newTestRunner(t, jobs, tc.resolve).process(ctx, job)
+			if done != tc.done {
+				t.Fatalf("process() = %v, want %v", done, tc.done)
+			}
+			if jobs.retryIn != tc.retryIn {
+				t.Fatalf("retry in %s, want %s", jobs.retryIn, tc.retryIn)
+			}
+			if jobs.released != tc.released {
+				t.Fatalf("released = %v, want %v", jobs.released, tc.released)
+			}
+			if tc.failed == "" {
+				if jobs.finished {
+					t.Fatalf("job finished with %v, want it left to the queue", jobs.finishErr)
+				}
+				return
+			}
+			if !jobs.finished || jobs.finishErr == nil || !strings.Contains(jobs.finishErr.Error(), tc.failed) {
+				t.Fatalf("finish error = %v, want %q", jobs.finishErr, tc.failed)
+			}
+		})
+	}
+}
+
+func TestJobRunnerFailsBadRequestAtOnce(t *testing.T) {
+	t.Parallel()
+
+	job, jobs := newTestJob(t, 1)
+	b, _ := proto.Marshal(&v1.TxToValuate{TxId: "no-time"})
+	jobs.items[0].Tx = b
+
+	if done := newTestRunner(t, jobs, nil).process(context.Background(), job); !done {
+		t.Fatalf("process() = false, want the job finished")
+	}
+	if jobs.finishErr == nil || jobs.retryIn != 0 {
+		t.Fatalf("finish error = %v retry in %s, want failed without retry", jobs.finishErr, jobs.retryIn)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, time.Minute},
+		{3, 4 * time.Minute},
+		{20, maxJobRetryBackoff},
+	}
+	for _, tc := range cases {
+		if got := retryBackoff(30*time.Second, tc.attempts); got != tc.want {
+			t.Fatalf("retryBackoff(30s, %d) = %s, want %s", tc.attempts, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultJobResultsPageSize = 500
+	maxJobResultsPageSize     = 5000
+)
+
+func (server *PriceServer) SubmitValuationJob(ctx context.Context, req *v1.SubmitValuationJobRequest) (*v1.SubmitValuationJobResponse, error) {
+	r := req.GetRequest()
+	if r == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if err := server.checkFiat(r.FiatCurrency); err != nil {
+		return nil, err
+	}
+	if r.Lookup != nil && r.Lookup.Tolerance.AsDuration() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "lookup tolerance must not be negative")
+	}
+	tenant, err := jobTenant(r.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	// a job is priced long after the caller has gone, so whatever valuate would reject fails here
+	txs := make([][]byte, 0, len(r.Transactions))
+	for i, tx := range r.Transactions {
+		if err := checkJobTx(tx); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "transaction %d: %v", i, err)
+		}
+		b, err := proto.Marshal(tx)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "transaction %d: %v", i, err)
+		}
+		txs = append(txs, b)
+	}
+
+	// settings are stored once, transactions go to job items
+	settings := proto.Clone(r).(*v1.ValuateTransactionsRequest)
+	settings.Transactions = nil
+	request, err := proto.Marshal(settings)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "request: %v", err)
+	}
+
+	job, err := server.valuationJobUC.Submit(ctx, tenant, request, txs)
+	if err != nil {
+		server.log.Error("SubmitValuationJob: submit failed: %v", err)
+		return nil, jobStatusError(err)
+	}
+
+	server.log.Info("SubmitValuationJob: queued job_id=%s txs=%d", job.ID, job.Total)
+	return &v1.SubmitValuationJobResponse{Job: toValuationJob(job)}, nil
+}
+
+func (server *PriceServer) GetValuationJob(ctx context.Context, req *v1.GetValuationJobRequest) (*v1.GetValuationJobResponse, error) {
+	id, err := parseUUID(req.JobId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job ID: %v", err)
+	}
+	tenant, err := jobTenant(req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := server.valuationJobUC.Get(ctx, tenant, id)
+	if err != nil {
+		return nil, jobStatusError(err)
+	}
+
+	return &v1.GetValuationJobResponse{Job: toValuationJob(job)}, nil
+}
+
+func (server *PriceServer) ListValuationJobResults(ctx context.Context, req *v1.ListValuationJobResultsRequest) (*v1.ListValuationJobResultsResponse, error) {
+	id, err := parseUUID(req.JobId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job ID: %v", err)
+	}
+	tenant, err := jobTenant(req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize <= 0:
+		pageSize = defaultJobResultsPageSize
+	case pageSize > maxJobResultsPageSize:
+		pageSize = maxJobResultsPageSize
+	}
+
+	afterSeq := -1
+	if req.PageToken != "" {
+		afterSeq, err = strconv.Atoi(req.PageToken)
+		if err != nil || afterSeq < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	items, err := server.valuationJobUC.ListResults(ctx, tenant, id, afterSeq, pageSize)
+	if err != nil {
+		return nil, jobStatusError(err)
+	}
+
+	resp := &v1.ListValuationJobResultsResponse{
+		Transactions: make([]*v1.ValuatedTx, 0, len(items)),
+	}
+	for _, it := range items {
+		var tx v1.ValuatedTx
+		if err := proto.Unmarshal(it.Result, &tx); err != nil {
+			server.log.Error("ListValuationJobResults: corrupt result job_id=%s seq=%d: %v", id, it.Seq, err)
+			return nil, status.Errorf(codes.Internal, "corrupt result at seq %d", it.Seq)
+		}
+		resp.Transactions = append(resp.Transactions, &tx)
+		afterSeq = it.Seq
+	}
+
+	// a full page may have more behind it; an unfinished job may still produce more
+	if len(items) == pageSize {
+		resp.NextPageToken = strconv.Itoa(afterSeq)
+	} else if job, err := server.valuationJobUC.Get(ctx, tenant, id); err == nil && !isFinished(job.Status) {
+		resp.NextPageToken = req.PageToken
+		if len(items) > 0 {
+			resp.NextPageToken = strconv.Itoa(afterSeq)
+		}
+	}
+
+	return resp, nil
+}
+
+// jobTenant returns the tenant a job is stored under: the canonical UUID, or empty without a tenant.
+func jobTenant(s string) (string, error) {
+	id, err := parseTenantID(s)
+	if err != nil || id == uuid.Nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// checkJobTx rejects a transaction valuate would fail on. A leg without an amount is still
+// priced per unit, as in valuate, but a malformed amount is a caller error.
+func checkJobTx(tx *v1.TxToValuate) error {
+	if tx.TimeUtc == nil {
+		return errors.New("missing TimeUtc")
+	}
+	for _, leg := range []struct {
+		name string
+		m    *v1.MoneyLeg
+	}{{"in_money", tx.InMoney}, {"out_money", tx.OutMoney}, {"fee_money", tx.FeeMoney}} {
+		if leg.m == nil {
+			continue
+		}
+		if strings.TrimSpace(leg.m.Amount) == "" {
+			continue
+		}
+		if _, err := legAmount(leg.m.Amount); err != nil {
+			return fmt.Errorf("%s: %v", leg.name, err)
+		}
+	}
+	return nil
+}
+
+func isFinished(s domain.JobStatus) bool {
+	return s == domain.JobStatusSucceeded || s == domain.JobStatusFailed
+}
+
+func jobStatusError(err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		return status.Error(codes.NotFound, "valuation job not found")
+	case errors.Is(err, apperr.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("valuation job: %v", err))
+	}
+}
+
+func toValuationJob(j domain.ValuationJob) *v1.ValuationJob {
+	out := &v1.ValuationJob{
+		JobId:     j.ID.String(),
+		Status:    toValuationJobStatus(j.Status),
+		Total:     int32(j.Total),
+		Processed: int32(j.Processed),
+		Error:     j.Error,
+		CreatedAt: timestamppb.New(j.CreatedAt),
+		UpdatedAt: timestamppb.New(j.UpdatedAt),
+	}
+	if j.StartedAt != nil {
+		out.StartedAt = timestamppb.New(*j.StartedAt)
+	}
+	if j.FinishedAt != nil {
+		out.FinishedAt = timestamppb.New(*j.FinishedAt)
+	}
+	return out
+}
+
+func toValuationJobStatus(s domain.JobStatus) v1.ValuationJobStatus {
+	switch s {
+	case domain.JobStatusQueued:
+		return v1.ValuationJobStatus_VALUATION_JOB_STATUS_QUEUED
+	case domain.JobStatusRunning:
+		return v1.ValuationJobStatus_VALUATION_JOB_STATUS_RUNNING
+	case domain.JobStatusSucceeded:
+		return v1.ValuationJobStatus_VALUATION_JOB_STATUS_SUCCEEDED
+	case domain.JobStatusFailed:
+		return v1.ValuationJobStatus_VALUATION_JOB_STATUS_FAILED
+	default:
+		return v1.ValuationJobStatus_VALUATION_JOB_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,68 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubmitValuationJobChecksItems(t *testing.T) {
+	t.Parallel()
+
+	const tenant = "6f1c3a2e-1b7d-4c55-9a10-2d3b4c5d6e7f"
+
+	cases := []struct {
+		name   string
+		edit   func(r *v1.ValuateTransactionsRequest)
+		code   codes.Code
+		tenant string
+	}{
+		{name: "valid", edit: func(*v1.ValuateTransactionsRequest) {}, tenant: tenant},
+		{name: "tenant stored in canonical form", edit: func(r *v1.ValuateTransactionsRequest) {
+			r.TenantId = "6F1C3A2E-1B7D-4C55-9A10-2D3B4C5D6E7F"
+		}, tenant: tenant},
+		{name: "no tenant", edit: func(r *v1.ValuateTransactionsRequest) { r.TenantId = "" }},
+		{name: "leg without amount is priced per unit", edit: func(r *v1.ValuateTransactionsRequest) {
+			r.Transactions[1].FeeMoney = &v1.MoneyLeg{Symbol: "ETH"}
+		}, tenant: tenant},
+		{name: "invalid tenant", edit: func(r *v1.ValuateTransactionsRequest) { r.TenantId = "acme" }, code: codes.InvalidArgument},
+		{name: "unsupported fiat", edit: func(r *v1.ValuateTransactionsRequest) { r.FiatCurrency = "XYZ" }, code: codes.InvalidArgument},
+		{name: "missing time", edit: func(r *v1.ValuateTransactionsRequest) { r.Transactions[1].TimeUtc = nil }, code: codes.InvalidArgument},
+		{name: "malformed amount", edit: func(r *v1.ValuateTransactionsRequest) {
+			r.Transactions[1].OutMoney.Amount = "1,5"
+		}, code: codes.InvalidArgument},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &v1.ValuateTransactionsRequest{
+				TenantId:     tenant,
+				FiatCurrency: "RUB",
+				Transactions: []*v1.TxToValuate{streamTx("a"), streamTx("b")},
+			}
+			tc.edit(req)
+
+			jobs := &fakeJobs{}
+			server := newTestServer(t)
+			server.valuationJobUC = jobs
+
+			_, err := server.SubmitValuationJob(context.Background(), &v1.SubmitValuationJobRequest{Request: req})
+			if status.Code(err) != tc.code {
+				t.Fatalf("SubmitValuationJob() error = %v, want %s", err, tc.code)
+			}
+			if tc.code != codes.OK {
+				if jobs.submitted != nil {
+					t.Fatalf("rejected job was queued")
+				}
+				return
+			}
+			if jobs.submitted.TenantID != tc.tenant || jobs.submitted.Total != 2 {
+				t.Fatalf("queued tenant=%q total=%d, want %q and 2", jobs.submitted.TenantID, jobs.submitted.Total, tc.tenant)
+			}
+		})
+	}
+}
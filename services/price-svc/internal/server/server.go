@@ -2,15 +2,21 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
 	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type LegKind int
@@ -25,24 +31,70 @@ type slot struct {
 	txIdx  int
 	kind   LegKind
 	symbol string
-	coinID string
-	result **v1.FiatLeg
+	asset  domain.AssetRef
+	// canonical is the symbol after the source's normalization rules
+	canonical string
+	quote     string
+	consensus bool
+	coinID    string
+	at        time.Time
+	amount    *decimal.Decimal // nil when the leg's amount is missing or invalid
+	result    **v1.FiatLeg
+}
+
+// fiatSlot is a leg denominated in a fiat currency, valued through FX instead of a coin price.
+type fiatSlot struct {
+	txIdx    int
+	currency string
+	amount   *decimal.Decimal
+	result   **v1.FiatLeg
 }
 
 type PriceServer struct {
 	v1.UnimplementedPriceServer
-	log               *logger.ZeroLogger
-	resolver          domain.CoinIdResolver
-	historicalPriceUC domain.HistoricalPriceUseCase
-	tenantSymbolUC    domain.TenantSymbolUseCase
+	log                *logger.ZeroLogger
+	resolver           domain.CoinIdResolver
+	normalizer         domain.SymbolNormalizer
+	rounding           domain.RoundingPolicy
+	historicalPriceUC  domain.HistoricalPriceUseCase
+	tenantSymbolUC     domain.TenantSymbolUseCase
+	valuationJobUC     domain.ValuationJobUseCase
+	customAssetUC      domain.CustomAssetUseCase
+	coinMapReloader    domain.CoinMapReloader
+	coinCatalogUC      domain.CoinCatalogUseCase
+	unresolvedSymbolUC domain.UnresolvedSymbolUseCase
+	fx                 domain.FXProvider
+}
+
+// PriceServerDeps are the use cases and policies the RPCs are served from.
+type PriceServerDeps struct {
+	Resolver           domain.CoinIdResolver
+	Normalizer         domain.SymbolNormalizer
+	Rounding           domain.RoundingPolicy
+	HistoricalPriceUC  domain.HistoricalPriceUseCase
+	TenantSymbolUC     domain.TenantSymbolUseCase
+	ValuationJobUC     domain.ValuationJobUseCase
+	CustomAssetUC      domain.CustomAssetUseCase
+	CoinMapReloader    domain.CoinMapReloader
+	CoinCatalogUC      domain.CoinCatalogUseCase
+	UnresolvedSymbolUC domain.UnresolvedSymbolUseCase
+	FX                 domain.FXProvider
 }
 
-func NewPriceServer(log *logger.ZeroLogger, resolver domain.CoinIdResolver, historicalPriceUC domain.HistoricalPriceUseCase, tenantSymbolUC domain.TenantSymbolUseCase) *PriceServer {
+func NewPriceServer(log *logger.ZeroLogger, deps PriceServerDeps) *PriceServer {
 	return &PriceServer{
-		log:               log,
-		resolver:          resolver,
-		historicalPriceUC: historicalPriceUC,
-		tenantSymbolUC:    tenantSymbolUC,
+		log:                log,
+		resolver:           deps.Resolver,
+		normalizer:         deps.Normalizer,
+		rounding:           deps.Rounding,
+		historicalPriceUC:  deps.HistoricalPriceUC,
+		tenantSymbolUC:     deps.TenantSymbolUC,
+		valuationJobUC:     deps.ValuationJobUC,
+		customAssetUC:      deps.CustomAssetUC,
+		coinMapReloader:    deps.CoinMapReloader,
+		coinCatalogUC:      deps.CoinCatalogUC,
+		unresolvedSymbolUC: deps.UnresolvedSymbolUC,
+		fx:                 deps.FX,
 	}
 }
 
@@ -50,16 +102,42 @@ func (server *PriceServer) ValuateTransactionsBatch(ctx context.Context, req *v1
 	start := time.Now()
 	server.log.Info("ValuateTransactionsBatch: start txs=%d fiat=%s", len(req.Transactions), req.FiatCurrency)
 
+	resp, err := server.valuate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	server.log.Info("ValuateTransactionsBatch: done txs=%d duration=%s", len(req.Transactions), time.Since(start))
+	return resp, nil
+}
+
+// valuate prices one request worth of transactions; shared by the unary and streaming RPCs.
+func (server *PriceServer) valuate(ctx context.Context, req *v1.ValuateTransactionsRequest) (*v1.ValuateTransactionsResponse, error) {
+	if req.Lookup != nil && req.Lookup.Tolerance.AsDuration() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "lookup tolerance must not be negative")
+	}
+	if err := server.checkFiat(req.FiatCurrency); err != nil {
+		return nil, err
+	}
+
+	// without a tenant only the global symbol map applies
+	tenantID, err := parseTenantID(req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := &v1.ValuateTransactionsResponse{
 		Transactions: make([]*v1.ValuatedTx, len(req.Transactions)),
 	}
 
+	var legs []slot // coin legs waiting for symbol resolution
 	var slots []slot
-	var priceKeys []domain.PriceKey
+	var fiatSlots []fiatSlot
+	var priceKeys, fiatKeys []domain.PriceKey
 
 	for i, tx := range req.Transactions {
 		if tx.TimeUtc == nil {
-			server.log.Warn("ValuateTransactionsBatch: missing TimeUtc tx_idx=%d", i)
+			server.log.Warn("valuate: missing TimeUtc tx_idx=%d", i)
 			return nil, status.Errorf(codes.InvalidArgument, "transaction %d: missing TimeUtc", i)
 		}
 
@@ -74,58 +152,180 @@ func (server *PriceServer) ValuateTransactionsBatch(ctx context.Context, req *v1
 				return
 			}
 
-			coinID, err := server.resolver.Resolve(m.Symbol)
+			// the unit price is still worth returning without an amount, only the total is lost
+			amount, err := legAmount(m.Amount)
 			if err != nil {
+				server.log.Warn("valuate: tx_idx=%d symbol=%s: %v", i, m.Symbol, err)
 				out.Errors = append(out.Errors, &v1.AssetError{
-					Symbol:     m.Symbol,
-					Code:       v1.AssetErrorCode_ASSET_UNKNOWN,
-					Candidates: nil,
-					Message:    fmt.Sprintf("symbol to coinID resolution failed: %v", err),
+					Symbol:  legSymbol(m),
+					Code:    v1.AssetErrorCode_INVALID_AMOUNT,
+					Message: err.Error(),
+				})
+			}
+
+			// fiat detection runs on the canonical symbol, so source spellings such as "ZUSD" count
+			canonical := server.normalizer.Normalize(req.Source, m.Symbol)
+			if m.ContractAddress == "" && domain.IsFiat(canonical) {
+				currency := strings.ToUpper(canonical)
+				fiatSlots = append(fiatSlots, fiatSlot{
+					txIdx:    i,
+					currency: currency,
+					amount:   amount,
+					result:   result,
 				})
+				fiatKeys = append(fiatKeys, domain.PriceKey{CoinID: currency, BucketStartUtc: truncateDayUTC(tx.TimeUtc.AsTime())})
 				return
 			}
 
-			slots = append(slots, slot{
+			legs = append(legs, slot{
 				txIdx:  i,
 				kind:   kind,
-				symbol: m.Symbol,
-				coinID: coinID,
+				symbol: legSymbol(m),
+				asset: domain.AssetRef{
+					Symbol:          m.Symbol,
+					Chain:           m.Chain,
+					ContractAddress: m.ContractAddress,
+				},
+				at:     tx.TimeUtc.AsTime(),
+				amount: amount,
 				result: result,
 			})
-			priceKeys = append(priceKeys, domain.PriceKey{CoinID: coinID, BucketStartUtc: tx.TimeUtc.AsTime()})
 		}
 		add(LegIn, tx.InMoney, &out.InFiat)
 		add(LegOut, tx.OutMoney, &out.OutFiat)
 		add(LegFee, tx.FeeMoney, &out.FeeFiat)
 	}
 
+	// look every distinct asset up once per request; mapping windows still apply per leg
+	assetIdx := make(map[domain.AssetRef]int)
+	var assets []domain.AssetRef
+	assetLegs := make([]domain.AssetLeg, 0, len(legs))
+	for _, l := range legs {
+		idx, ok := assetIdx[l.asset]
+		if !ok {
+			idx = len(assets)
+			assetIdx[l.asset] = idx
+			assets = append(assets, l.asset)
+		}
+		assetLegs = append(assetLegs, domain.AssetLeg{Asset: idx, At: l.at})
+	}
+
+	opts := domain.ValuationOptions{
+		PricePoint: toDomainPricePoint(req.PricePoint),
+		Lookup:     toDomainLookupPolicy(req.Lookup),
+		SnapshotID: strings.TrimSpace(req.SnapshotId),
+		TenantID:   tenantID,
+	}
+
+	resolved, err := server.resolver.Resolve(ctx, tenantID, req.Source, assets, assetLegs)
+	if err != nil {
+		server.log.Error("valuate: symbol resolution failed: %v", err)
+		return nil, status.Errorf(codes.Internal, "symbol resolution failed: %v", err)
+	}
+	resolved, err = server.historicalPriceUC.PinResolutions(ctx, req.FiatCurrency, req.Source, assets, assetLegs, resolved, opts)
+	if err != nil {
+		server.log.Error("valuate: PinResolutions failed: %v", err)
+		return nil, valuationStatus("failed to pin symbol resolutions", err)
+	}
+
+	unresolved := newUnresolvedTally()
+	for j, l := range legs {
+		r := resolved[j]
+		if r.Err != nil {
+			out := resp.Transactions[l.txIdx]
+			out.Errors = append(out.Errors, resolutionError(l.symbol, r))
+			unresolved.add(l.asset, r)
+			continue
+		}
+
+		l.canonical = r.CanonicalSymbol
+		l.quote = r.Quote
+		l.consensus = r.Consensus
+		l.coinID = r.CoinID
+		slots = append(slots, l)
+		priceKeys = append(priceKeys, domain.PriceKey{CoinID: l.coinID, BucketStartUtc: l.at})
+	}
+
+	// the review queue is best effort and never fails a valuation
+	if err := server.unresolvedSymbolUC.Record(ctx, tenantID, req.Source, unresolved.symbols()); err != nil {
+		server.log.Warn("valuate: recording unresolved symbols failed: %v", err)
+	}
+
+	rounding := server.rounding.For(req.FiatCurrency)
+
+	if len(fiatSlots) > 0 {
+		rates, err := server.historicalPriceUC.GetFiatRates(ctx, req.FiatCurrency, fiatKeys, opts)
+		if err != nil {
+			server.log.Error("valuate: GetFiatRates failed: %v", err)
+			return nil, valuationStatus("failed to get fx rates", err)
+		}
+
+		for i, v := range rates {
+			s := fiatSlots[i]
+			if v.Err != nil {
+				out := resp.Transactions[s.txIdx]
+				out.Errors = append(out.Errors, &v1.AssetError{
+					Symbol:          s.currency,
+					Code:            v1.AssetErrorCode_RATE_NOT_FOUND,
+					Message:         v.Err.Error(),
+					CanonicalSymbol: s.currency,
+				})
+				continue
+			}
+			leg := fiatLeg(v.Fiat, s.amount, rounding, toPricingMethod(v.Method), v.LowerPrecision)
+			leg.Provenance = toProvenance(v.Provenance)
+			leg.CanonicalSymbol = s.currency
+			*s.result = leg
+		}
+	}
+
 	if len(slots) == 0 {
-		server.log.Info("ValuateTransactionsBatch: no slots txs=%d duration=%s", len(req.Transactions), time.Since(start))
 		return resp, nil
 	}
 
-	fiats, err := server.historicalPriceUC.GetHistoricalPrices(ctx, req.FiatCurrency, priceKeys)
+	fiats, err := server.historicalPriceUC.GetHistoricalPrices(ctx, req.FiatCurrency, priceKeys, opts)
 	if err != nil {
-		server.log.Error("ValuateTransactionsBatch: GetHistoricalPrices failed: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to get historical prices: %v", err)
+		server.log.Error("valuate: GetHistoricalPrices failed: %v", err)
+		return nil, valuationStatus("failed to get historical prices", err)
 	}
 
 	if len(fiats) != len(priceKeys) {
-		server.log.Error("ValuateTransactionsBatch: pricing invariant violated got=%d expected=%d", len(fiats), len(priceKeys))
+		server.log.Error("valuate: pricing invariant violated got=%d expected=%d", len(fiats), len(priceKeys))
 		return nil, status.Errorf(codes.Internal, "pricing invariant violated: got %d results for %d keys", len(fiats), len(priceKeys))
 	}
 
-	for i, fiat := range fiats {
+	for i, v := range fiats {
 		s := slots[i]
-		*s.result = &v1.FiatLeg{
-			Fiat: fiat.String(),
+		if v.Err != nil {
+			out := resp.Transactions[s.txIdx]
+			e := toAssetError(s.symbol, v.Err)
+			e.CanonicalSymbol = s.canonical
+			out.Errors = append(out.Errors, e)
+			continue
 		}
+		leg := fiatLeg(v.Fiat, s.amount, rounding, toPricingMethod(v.Method), v.LowerPrecision)
+		leg.Provenance = toProvenance(v.Provenance)
+		leg.CanonicalSymbol = s.canonical
+		leg.QuoteSymbol = s.quote
+		leg.Consensus = s.consensus
+		*s.result = leg
 	}
 
-	server.log.Info("ValuateTransactionsBatch: done txs=%d duration=%s", len(req.Transactions), time.Since(start))
 	return resp, nil
 }
 
+// checkFiat rejects a fiat currency no FX source publishes rates for, before anything is priced.
+func (server *PriceServer) checkFiat(currency string) error {
+	if currency == "" {
+		return status.Error(codes.InvalidArgument, "fiat_currency is required")
+	}
+	supported := server.fx.SupportedFiats()
+	if !slices.Contains(supported, strings.ToUpper(strings.TrimSpace(currency))) {
+		return status.Errorf(codes.InvalidArgument, "unsupported fiat_currency %q, supported: %s", currency, strings.Join(supported, ", "))
+	}
+	return nil
+}
+
 func (server PriceServer) UpsertTenantSymbol(ctx context.Context, req *v1.UpsertTenantSymbolRequest) (*v1.UpsertTenantSymbolResponse, error) {
 	tenantId, err := parseUUID(req.TenantId)
 	if err != nil {
@@ -139,11 +339,231 @@ func (server PriceServer) UpsertTenantSymbol(ctx context.Context, req *v1.Upsert
 		Symbol:   req.Symbol,
 		CoinID:   req.CoinId,
 	}
+	if req.ValidFrom != nil {
+		tenantSymbol.ValidFrom = req.ValidFrom.AsTime()
+	}
+	if req.ValidTo != nil {
+		tenantSymbol.ValidTo = req.ValidTo.AsTime()
+	}
 
-	server.tenantSymbolUC.Upsert(ctx, tenantSymbol)
+	if err := server.tenantSymbolUC.Upsert(ctx, tenantSymbol); err != nil {
+		server.log.Warn("UpsertTenantSymbol: failed tenant_id=%s source=%s symbol=%s: %v", tenantId.String(), req.Source, req.Symbol, err)
+		return nil, tenantSymbolStatusError(err)
+	}
 
 	server.log.Info("UpsertTenantSymbol: upserted tenant_id=%s source=%s symbol=%s", tenantId.String(), req.Source, req.Symbol)
-	return nil, status.Error(codes.Unimplemented, "method UpsertTenantSymbol not implemented")
+	return &v1.UpsertTenantSymbolResponse{}, nil
+}
+
+func tenantSymbolStatusError(err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, apperr.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("tenant symbol: %v", err))
+	}
+}
+
+// valuationStatus maps a failed valuation call to a gRPC status.
+func valuationStatus(msg string, err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrConflict):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", msg, err)
+	case errors.Is(err, apperr.ErrInvalidArgument):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+func (server *PriceServer) ReloadCoinMap(ctx context.Context, _ *v1.ReloadCoinMapRequest) (*v1.ReloadCoinMapResponse, error) {
+	n, err := server.coinMapReloader.Reload(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "coin map rejected, previous map kept: %v", err)
+	}
+
+	server.log.Info("ReloadCoinMap: reloaded coins=%d", n)
+	return &v1.ReloadCoinMapResponse{Coins: int32(n)}, nil
+}
+
+func toPricingMethod(m domain.PricingMethod) v1.PricingMethod {
+	switch m {
+	case domain.PricingMethodMarket:
+		return v1.PricingMethod_PRICING_METHOD_MARKET
+	case domain.PricingMethodPeg:
+		return v1.PricingMethod_PRICING_METHOD_PEG
+	case domain.PricingMethodMarketDepeg:
+		return v1.PricingMethod_PRICING_METHOD_MARKET_DEPEG
+	case domain.PricingMethodFX:
+		return v1.PricingMethod_PRICING_METHOD_FX
+	case domain.PricingMethodDerivative:
+		return v1.PricingMethod_PRICING_METHOD_DERIVATIVE
+	case domain.PricingMethodManual:
+		return v1.PricingMethod_PRICING_METHOD_MANUAL
+	case domain.PricingMethodPegUnchecked:
+		return v1.PricingMethod_PRICING_METHOD_PEG_UNCHECKED
+	default:
+		return v1.PricingMethod_PRICING_METHOD_UNSPECIFIED
+	}
+}
+
+// fiatLeg reports the unit price and, with a valid amount, the leg total. The total is rounded
+// from the unrounded unit price, so low-priced coins keep their value in it.
+func fiatLeg(unitPrice decimal.Decimal, amount *decimal.Decimal, rounding domain.RoundingRule, method v1.PricingMethod, lowerPrecision bool) *v1.FiatLeg {
+	leg := &v1.FiatLeg{
+		Fiat:           rounding.Apply(unitPrice).String(),
+		FiatUnrounded:  unitPrice.String(),
+		Method:         method,
+		LowerPrecision: lowerPrecision,
+	}
+	if amount != nil {
+		total := unitPrice.Mul(*amount)
+		leg.Total = rounding.Apply(total).String()
+		leg.TotalUnrounded = total.String()
+	}
+	return leg
+}
+
+// legAmount parses a leg's amount; a missing or malformed one fails only that leg.
+// parseTenantID returns uuid.Nil for an empty tenant ID.
+func parseTenantID(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+	id, err := parseUUID(s)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+	return id, nil
+}
+
+func legAmount(s string) (*decimal.Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("missing amount")
+	}
+	amount, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	return &amount, nil
+}
+
+func toProvenance(p domain.Provenance) *v1.Provenance {
+	out := &v1.Provenance{
+		GranularitySeconds: int32(p.GranularitySeconds),
+		Provider:           p.Provider,
+		FxRate:             p.FXRate.String(),
+	}
+	if !p.BucketStartUtc.IsZero() {
+		out.BucketStartUtc = timestamppb.New(p.BucketStartUtc)
+	}
+	if p.PriceUsd != nil {
+		out.PriceUsd = p.PriceUsd.String()
+	}
+	if !p.FetchedAt.IsZero() {
+		out.FetchedAt = timestamppb.New(p.FetchedAt)
+	}
+	if !p.FXEffectiveAt.IsZero() {
+		out.FxEffectiveDate = p.FXEffectiveAt.Format(time.DateOnly)
+	}
+	return out
+}
+
+// legSymbol names a leg in errors; legs sent by contract address only are named by the address.
+func legSymbol(m *v1.MoneyLeg) string {
+	if m.Symbol == "" {
+		return m.ContractAddress
+	}
+	return m.Symbol
+}
+
+func resolutionError(symbol string, r domain.SymbolResolution) *v1.AssetError {
+	e := &v1.AssetError{
+		Symbol:          symbol,
+		Code:            v1.AssetErrorCode_ASSET_UNKNOWN,
+		Candidates:      nil,
+		Message:         fmt.Sprintf("symbol to coinID resolution failed: %v", r.Err),
+		CanonicalSymbol: r.CanonicalSymbol,
+	}
+
+	var symbolErr *domain.AmbiguousSymbolError
+	if errors.As(r.Err, &symbolErr) {
+		e.Code = v1.AssetErrorCode_ASSET_AMBIGUOUS
+		for _, c := range symbolErr.Candidates {
+			e.Candidates = append(e.Candidates, &v1.CoinCandidate{CoinId: c.ID, Name: c.Name})
+		}
+	}
+
+	var suggestedErr *domain.SuggestedCoinsError
+	if errors.As(r.Err, &suggestedErr) {
+		for _, c := range suggestedErr.Candidates {
+			e.Candidates = append(e.Candidates, &v1.CoinCandidate{CoinId: c.CoinID, Name: c.Name, TenantCount: int32(c.Tenants)})
+		}
+	}
+
+	var pairErr *domain.AmbiguousPairError
+	if errors.As(r.Err, &pairErr) {
+		e.Code = v1.AssetErrorCode_ASSET_AMBIGUOUS
+		for _, s := range pairErr.Splits {
+			e.PairSplits = append(e.PairSplits, &v1.PairSplit{Base: s.Base, Quote: s.Quote, Guessed: s.Guessed})
+		}
+	}
+	return e
+}
+
+func toAssetError(symbol string, err error) *v1.AssetError {
+	e := &v1.AssetError{
+		Symbol:  symbol,
+		Code:    v1.AssetErrorCode_RATE_NOT_FOUND,
+		Message: err.Error(),
+	}
+	switch {
+	case errors.Is(err, apperr.ErrBeforeListing):
+		e.Reason = v1.RateNotFoundReason_RATE_NOT_FOUND_REASON_BEFORE_LISTING
+	case errors.Is(err, apperr.ErrNoProviderData):
+		e.Reason = v1.RateNotFoundReason_RATE_NOT_FOUND_REASON_NO_PROVIDER_DATA
+	case errors.Is(err, apperr.ErrPriceUnavailable):
+		// no specific reason
+	default:
+		e.Code = v1.AssetErrorCode_PROVIDER_ERROR
+	}
+	return e
+}
+
+func toDomainPricePoint(p v1.PricePoint) domain.PricePoint {
+	switch p {
+	case v1.PricePoint_PRICE_POINT_BUCKET_CLOSE:
+		return domain.PricePointBucketClose
+	case v1.PricePoint_PRICE_POINT_INTERPOLATED:
+		return domain.PricePointInterpolated
+	case v1.PricePoint_PRICE_POINT_DAILY_AVERAGE:
+		return domain.PricePointDailyAverage
+	default:
+		return domain.PricePointBucketOpen
+	}
+}
+
+func toDomainLookupPolicy(p *v1.LookupPolicy) domain.LookupPolicy {
+	if p == nil {
+		return domain.LookupPolicy{}
+	}
+	lookup := domain.LookupPolicy{
+		Nearest:       p.Nearest,
+		Tolerance:     p.Tolerance.AsDuration(),
+		AcceptCoarser: p.AcceptCoarser,
+	}
+	if p.AsOf != nil {
+		lookup.AsOf = p.AsOf.AsTime()
+	}
+	return lookup
+}
+
+func truncateDayUTC(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
 }
 
 func parseUUID(s string) (uuid.UUID, error) {
@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeFX publishes rates for a fixed set of currencies.
+type fakeFX struct {
+	domain.FXProvider
+	fiats []string
+}
+
+func (f fakeFX) SupportedFiats() []string { return f.fiats }
+
+func TestCheckFiat(t *testing.T) {
+	t.Parallel()
+
+	server := &PriceServer{fx: fakeFX{fiats: []string{"KZT", "RUB", "USD"}}}
+
+	for _, fiat := range []string{"RUB", "usd", " kzt "} {
+		if err := server.checkFiat(fiat); err != nil {
+			t.Fatalf("checkFiat(%q) error = %v, want nil", fiat, err)
+		}
+	}
+	for _, fiat := range []string{"", "EUR", "RUBX"} {
+		if err := server.checkFiat(fiat); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("checkFiat(%q) error = %v, want InvalidArgument", fiat, err)
+		}
+	}
+}
+
+func TestFiatLeg(t *testing.T) {
+	t.Parallel()
+
+	rub := domain.RoundingRule{Decimals: 2, Mode: domain.RoundingHalfUp}
+
+	cases := []struct {
+		name          string
+		unitPrice     string
+		amount        string // empty for a leg without a valid amount
+		wantFiat      string
+		wantTotal     string
+		wantUnrounded string
+	}{
+		{name: "whole coins", unitPrice: "5432.105", amount: "2", wantFiat: "5432.11", wantTotal: "10864.21", wantUnrounded: "10864.21"},
+		// the unit price rounds to zero, the total keeps its value
+		{name: "low-priced coin", unitPrice: "0.000123", amount: "1000000", wantFiat: "0", wantTotal: "123", wantUnrounded: "123"},
+		{name: "fraction of a coin", unitPrice: "100", amount: "0.00015", wantFiat: "100", wantTotal: "0.02", wantUnrounded: "0.015"},
+		{name: "no amount", unitPrice: "5432.105", wantFiat: "5432.11"},
+	}
+
+	for _, tc := range cases {
+		var amount *decimal.Decimal
+		if tc.amount != "" {
+			a := decimal.RequireFromString(tc.amount)
+			amount = &a
+		}
+		leg := fiatLeg(decimal.RequireFromString(tc.unitPrice), amount, rub, v1.PricingMethod_PRICING_METHOD_MARKET, false)
+		if leg.Fiat != tc.wantFiat || leg.FiatUnrounded != tc.unitPrice {
+			t.Fatalf("%s: fiat = %s (unrounded %s), want %s (unrounded %s)", tc.name, leg.Fiat, leg.FiatUnrounded, tc.wantFiat, tc.unitPrice)
+		}
+		if leg.Total != tc.wantTotal || leg.TotalUnrounded != tc.wantUnrounded {
+			t.Fatalf("%s: total = %q (unrounded %q), want %q (unrounded %q)", tc.name, leg.Total, leg.TotalUnrounded, tc.wantTotal, tc.wantUnrounded)
+		}
+	}
+}
+
+func TestLegAmount(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{"2": "2", " 0.5 ": "0.5", "-1.25": "-1.25", "1e-8": "0.00000001"} {
+		got, err := legAmount(in)
+		if err != nil || !got.Equal(decimal.RequireFromString(want)) {
+			t.Fatalf("legAmount(%q) = %v, %v, want %s", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "  ", "1,5", "abc"} {
+		if got, err := legAmount(in); err == nil {
+			t.Fatalf("legAmount(%q) = %v, want error", in, got)
+		}
+	}
+}
+
+func TestToProvenance(t *testing.T) {
+	t.Parallel()
+
+	bucket := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	price := decimal.RequireFromString("60000.5")
+	market := toProvenance(domain.Provenance{
+		BucketStartUtc:     bucket,
+		GranularitySeconds: 3600,
+		Provider:           domain.ProviderCoinGecko,
+		PriceUsd:           &price,
+		FetchedAt:          bucket.Add(time.Hour),
+		FXRate:             decimal.RequireFromString("90.5"),
+		FXEffectiveAt:      bucket.AddDate(0, 0, -1),
+	})
+	if !market.BucketStartUtc.AsTime().Equal(bucket) || market.GranularitySeconds != 3600 || market.Provider != domain.ProviderCoinGecko ||
+		market.PriceUsd != "60000.5" || !market.FetchedAt.AsTime().Equal(bucket.Add(time.Hour)) ||
+		market.FxRate != "90.5" || market.FxEffectiveDate != "2024-02-29" {
+		t.Fatalf("market provenance = %v", market)
+	}
+
+	// a pegged leg has no market bucket, its market fields stay unset
+	peg := toProvenance(domain.Provenance{FXRate: decimal.RequireFromString("90.5"), FXEffectiveAt: bucket})
+	if peg.BucketStartUtc != nil || peg.FetchedAt != nil || peg.PriceUsd != "" || peg.Provider != "" || peg.FxEffectiveDate != "2024-03-01" {
+		t.Fatalf("peg provenance = %v", peg)
+	}
+}
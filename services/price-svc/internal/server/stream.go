@@ -0,0 +1,244 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// streamQueueDepth is how many chunks are read ahead while batches are being priced.
+	streamQueueDepth = 4
+	// streamMaxBatchTxs caps how many queued transactions are merged into one pricing batch.
+	streamMaxBatchTxs = 2000
+	// streamWorkers is how many batches are priced ahead of the one being sent.
+	streamWorkers = 2
+)
+
+// streamChunk is one received chunk with the stream settings applied, or the reason it is
+// answered without being priced.
+type streamChunk struct {
+	req *v1.ValuateTransactionsRequest
+	err error
+}
+
+// streamResult answers one batch, one response per chunk.
+type streamResult chan []*v1.ValuateTransactionsResponse
+
+func (server *PriceServer) ValuateTransactionsStream(stream v1.Price_ValuateTransactionsStreamServer) error {
+	start := time.Now()
+	server.log.Info("ValuateTransactionsStream: start")
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	chunks := make(chan streamChunk, streamQueueDepth)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(chunks)
+		recvErr <- server.receiveChunks(ctx, stream, chunks)
+	}()
+
+	// batches are priced concurrently and queued here in order, so sending never waits on
+	// pricing of a later batch and pricing never waits on a slow client
+	pending := make(chan streamResult, streamWorkers)
+	go server.priceBatches(ctx, chunks, pending)
+
+	txs, failed := 0, 0
+	for result := range pending {
+		var resps []*v1.ValuateTransactionsResponse
+		select {
+		case resps = <-result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		for _, resp := range resps {
+			if err := stream.Send(resp); err != nil {
+				server.log.Warn("ValuateTransactionsStream: send failed: %v", err)
+				return err
+			}
+			if resp.ChunkError != nil {
+				failed++
+			}
+			txs += len(resp.Transactions)
+		}
+	}
+
+	if err := <-recvErr; err != nil {
+		return err
+	}
+
+	server.log.Info("ValuateTransactionsStream: done txs=%d failed_chunks=%d duration=%s", txs, failed, time.Since(start))
+	return nil
+}
+
+// priceBatches merges chunks that queued up into batches sharing one fetch plan and prices each
+// in its own goroutine; at most streamWorkers batches wait in pending for the sender.
+func (server *PriceServer) priceBatches(ctx context.Context, chunks <-chan streamChunk, pending chan<- streamResult) {
+	defer close(pending)
+
+	for first := range chunks {
+		batch := []streamChunk{first}
+		n := len(first.req.Transactions)
+	drain:
+		for n < streamMaxBatchTxs {
+			select {
+			case c, ok := <-chunks:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, c)
+				n += len(c.req.Transactions)
+			default:
+				break drain
+			}
+		}
+
+		result := make(streamResult, 1)
+		select {
+		case pending <- result:
+		case <-ctx.Done():
+			return
+		}
+		go func() {
+			result <- server.valuateBatch(ctx, batch)
+		}()
+	}
+}
+
+// valuateBatch prices the chunks of a batch together. When the merged request is rejected, the
+// chunks are priced one by one, so only the chunks at fault are answered with the error; any
+// other failure answers every chunk of the batch.
+func (server *PriceServer) valuateBatch(ctx context.Context, batch []streamChunk) []*v1.ValuateTransactionsResponse {
+	out := make([]*v1.ValuateTransactionsResponse, len(batch))
+
+	var valid []int
+	for i, c := range batch {
+		if c.err != nil {
+			out[i] = chunkError(c.err)
+			continue
+		}
+		valid = append(valid, i)
+	}
+	if len(valid) == 0 {
+		return out
+	}
+
+	merged := proto.Clone(batch[valid[0]].req).(*v1.ValuateTransactionsRequest)
+	merged.Transactions = nil
+	for _, i := range valid {
+		merged.Transactions = append(merged.Transactions, batch[i].req.Transactions...)
+	}
+
+	resp, err := server.valuate(ctx, merged)
+	if err != nil {
+		for _, i := range valid {
+			if len(valid) > 1 && status.Code(err) == codes.InvalidArgument {
+				out[i] = server.valuateChunk(ctx, batch[i].req)
+			} else {
+				out[i] = chunkError(err)
+			}
+		}
+		return out
+	}
+
+	// answer chunk by chunk, in the order they were received
+	offset := 0
+	for _, i := range valid {
+		k := len(batch[i].req.Transactions)
+		out[i] = &v1.ValuateTransactionsResponse{Transactions: resp.Transactions[offset : offset+k]}
+		offset += k
+	}
+	return out
+}
+
+func (server *PriceServer) valuateChunk(ctx context.Context, req *v1.ValuateTransactionsRequest) *v1.ValuateTransactionsResponse {
+	resp, err := server.valuate(ctx, req)
+	if err != nil {
+		return chunkError(err)
+	}
+	return resp
+}
+
+func chunkError(err error) *v1.ValuateTransactionsResponse {
+	st := status.Convert(err)
+	return &v1.ValuateTransactionsResponse{ChunkError: &v1.ChunkError{Code: int32(st.Code()), Message: st.Message()}}
+}
+
+// receiveChunks reads the client stream into chunks until EOF. Every chunk is valued with the
+// settings of the first one; a chunk changing them is answered with the error instead.
+func (server *PriceServer) receiveChunks(
+	ctx context.Context,
+	stream v1.Price_ValuateTransactionsStreamServer,
+	chunks chan<- streamChunk,
+) error {
+	var first *v1.ValuateTransactionsRequest
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var chunk streamChunk
+		if first == nil {
+			first = req
+			chunk.req = req
+		} else {
+			chunk.req = withStreamSettings(first, req)
+			if chunk.err = sameStreamSettings(first, req); chunk.err != nil {
+				server.log.Warn("ValuateTransactionsStream: %v", chunk.err)
+			}
+		}
+
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// withStreamSettings returns the chunk's transactions under the settings of the first chunk.
+func withStreamSettings(first, req *v1.ValuateTransactionsRequest) *v1.ValuateTransactionsRequest {
+	return &v1.ValuateTransactionsRequest{
+		TenantId:     first.TenantId,
+		Source:       first.Source,
+		FiatCurrency: first.FiatCurrency,
+		PricePoint:   first.PricePoint,
+		Lookup:       first.Lookup,
+		SnapshotId:   first.SnapshotId,
+		Transactions: req.Transactions,
+	}
+}
+
+func sameStreamSettings(first, req *v1.ValuateTransactionsRequest) error {
+	changed := func(field string) error {
+		return status.Errorf(codes.InvalidArgument, "chunk changes %s set by the first chunk", field)
+	}
+
+	switch {
+	case req.TenantId != "" && req.TenantId != first.TenantId:
+		return changed("tenant_id")
+	case req.Source != "" && req.Source != first.Source:
+		return changed("source")
+	case req.FiatCurrency != "" && req.FiatCurrency != first.FiatCurrency:
+		return changed("fiat_currency")
+	case req.PricePoint != v1.PricePoint_PRICE_POINT_UNSPECIFIED && req.PricePoint != first.PricePoint:
+		return changed("price_point")
+	case req.Lookup != nil && !proto.Equal(req.Lookup, first.Lookup):
+		return changed("lookup")
+	case req.SnapshotId != "" && req.SnapshotId != first.SnapshotId:
+		return changed("snapshot_id")
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/pricing"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeStream replays the given chunks and collects the responses.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*v1.ValuateTransactionsRequest
+	sent   []*v1.ValuateTransactionsResponse
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func (s *fakeStream) Recv() (*v1.ValuateTransactionsRequest, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	c := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return c, nil
+}
+
+func (s *fakeStream) Send(resp *v1.ValuateTransactionsResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+// upperNormalizer only folds case.
+type upperNormalizer struct{}
+
+func (upperNormalizer) Normalize(_, symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+// fakeResolver maps a symbol to its lower-case coin ID; "NOPE" is unknown. A set err fails
+// the whole lookup.
+type fakeResolver struct {
+	err error
+}
+
+func (r fakeResolver) Resolve(_ context.Context, _ uuid.UUID, _ string, assets []domain.AssetRef, legs []domain.AssetLeg) ([]domain.SymbolResolution, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	out := make([]domain.SymbolResolution, len(legs))
+	for i, l := range legs {
+		symbol := assets[l.Asset].Symbol
+		if symbol == "NOPE" {
+			out[i] = domain.SymbolResolution{CanonicalSymbol: symbol, Err: apperr.ErrUnknownSymbol}
+			continue
+		}
+		out[i] = domain.SymbolResolution{CoinID: strings.ToLower(symbol), CanonicalSymbol: symbol}
+	}
+	return out, nil
+}
+
+// fakePrices values every coin at 100 and every fiat leg at 90.
+type fakePrices struct {
+	domain.HistoricalPriceUseCase
+}
+
+func (fakePrices) GetHistoricalPrices(_ context.Context, _ string, keys []domain.PriceKey, _ domain.ValuationOptions) ([]domain.Valuation, error) {
+	out := make([]domain.Valuation, len(keys))
+	for i := range out {
+		out[i] = domain.Valuation{Fiat: decimal.NewFromInt(100), Method: domain.PricingMethodMarket}
+	}
+	return out, nil
+}
+
+func (fakePrices) GetFiatRates(_ context.Context, _ string, keys []domain.PriceKey, _ domain.ValuationOptions) ([]domain.Valuation, error) {
+	out := make([]domain.Valuation, len(keys))
+	for i := range out {
+		out[i] = domain.Valuation{Fiat: decimal.NewFromInt(90), Method: domain.PricingMethodFX}
+	}
+	return out, nil
+}
+
+func (fakePrices) PinResolutions(_ context.Context, _, _ string, _ []domain.AssetRef, _ []domain.AssetLeg, resolved []domain.SymbolResolution, _ domain.ValuationOptions) ([]domain.SymbolResolution, error) {
+	return resolved, nil
+}
+
+type fakeUnresolved struct {
+	domain.UnresolvedSymbolUseCase
+}
+
+func (fakeUnresolved) Record(context.Context, uuid.UUID, string, []domain.UnresolvedSymbol) error {
+	return nil
+}
+
+func newTestServer(t *testing.T) *PriceServer {
+	t.Helper()
+
+	rounding, err := pricing.NewRoundingPolicy(pricing.RoundingConfig{Default: pricing.RoundingRule{Decimals: 2}})
+	if err != nil {
+		t.Fatalf("NewRoundingPolicy() error = %v", err)
+	}
+	return NewPriceServer(logger.New("error"), PriceServerDeps{
+		Resolver:           fakeResolver{},
+		Normalizer:         upperNormalizer{},
+		Rounding:           rounding,
+		HistoricalPriceUC:  fakePrices{},
+		UnresolvedSymbolUC: fakeUnresolved{},
+		FX:                 fakeFX{fiats: []string{"RUB", "USD"}},
+	})
+}
+
+// streamTx is a BTC purchase paid in USD.
+func streamTx(id string) *v1.TxToValuate {
+	return &v1.TxToValuate{
+		TxId:     id,
+		TimeUtc:  timestamppb.New(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)),
+		InMoney:  &v1.MoneyLeg{Symbol: "BTC", Amount: "2"},
+		OutMoney: &v1.MoneyLeg{Symbol: "USD", Amount: "130000"},
+	}
+}
+
+func runStream(t *testing.T, chunks ...*v1.ValuateTransactionsRequest) []*v1.ValuateTransactionsResponse {
+	t.Helper()
+
+	stream := &fakeStream{ctx: context.Background(), chunks: chunks}
+	if err := newTestServer(t).ValuateTransactionsStream(stream); err != nil {
+		t.Fatalf("ValuateTransactionsStream() error = %v", err)
+	}
+	if len(stream.sent) != len(chunks) {
+		t.Fatalf("responses = %d, want one per chunk (%d)", len(stream.sent), len(chunks))
+	}
+	return stream.sent
+}
+
+func TestStreamAnswersFailedChunksAndGoesOn(t *testing.T) {
+	t.Parallel()
+
+	noTime := streamTx("e")
+	noTime.TimeUtc = nil
+	unknown := streamTx("g")
+	unknown.InMoney.Symbol = "NOPE"
+
+	sent := runStream(t,
+		&v1.ValuateTransactionsRequest{FiatCurrency: "RUB", Transactions: []*v1.TxToValuate{streamTx("a"), streamTx("b")}},
+		&v1.ValuateTransactionsRequest{FiatCurrency: "USD", Transactions: []*v1.TxToValuate{streamTx("c")}},
+		&v1.ValuateTransactionsRequest{Transactions: []*v1.TxToValuate{streamTx("d")}},
+		&v1.ValuateTransactionsRequest{Transactions: []*v1.TxToValuate{noTime}},
+		&v1.ValuateTransactionsRequest{FiatCurrency: "RUB", Transactions: []*v1.TxToValuate{streamTx("f"), unknown}},
+	)
+
+	want := []struct {
+		txIDs []string
+		code  codes.Code // OK when the chunk is priced
+	}{
+		{txIDs: []string{"a", "b"}},
+		{code: codes.InvalidArgument}, // changes fiat_currency
+		{txIDs: []string{"d"}},
+		{code: codes.InvalidArgument}, // tx without time
+		{txIDs: []string{"f", "g"}},   // an unknown symbol fails only its leg
+	}
+	for i, w := range want {
+		resp := sent[i]
+		if w.code != codes.OK {
+			if resp.ChunkError == nil || codes.Code(resp.ChunkError.Code) != w.code || len(resp.Transactions) != 0 {
+				t.Fatalf("chunk %d = %v, want chunk error %s", i, resp, w.code)
+			}
+			continue
+		}
+		if resp.ChunkError != nil {
+			t.Fatalf("chunk %d error = %v, want none", i, resp.ChunkError)
+		}
+		var ids []string
+		for _, tx := range resp.Transactions {
+			ids = append(ids, tx.TxId)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(w.txIDs) {
+			t.Fatalf("chunk %d txs = %v, want %v", i, ids, w.txIDs)
+		}
+	}
+
+	// settings come from the first chunk: RUB rates and prices, totals from the amounts
+	if leg := sent[2].Transactions[0].InFiat; leg == nil || leg.Fiat != "100" || leg.Total != "200" {
+		t.Fatalf("chunk 2 in leg = %v, want fiat 100 and total 200", leg)
+	}
+	if leg := sent[2].Transactions[0].OutFiat; leg == nil || leg.Total != "11700000" {
+		t.Fatalf("chunk 2 out leg = %v, want total 11700000", leg)
+	}
+	if tx := sent[4].Transactions[1]; tx.InFiat != nil || len(tx.Errors) != 1 || tx.Errors[0].Code != v1.AssetErrorCode_ASSET_UNKNOWN {
+		t.Fatalf("tx with unknown symbol = %v, want an ASSET_UNKNOWN error only", tx)
+	}
+}
+
+func TestStreamWithoutFiatFailsEveryChunk(t *testing.T) {
+	t.Parallel()
+
+	sent := runStream(t,
+		&v1.ValuateTransactionsRequest{Transactions: []*v1.TxToValuate{streamTx("a")}},
+		&v1.ValuateTransactionsRequest{Transactions: []*v1.TxToValuate{streamTx("b")}},
+	)
+	for i, resp := range sent {
+		if resp.ChunkError == nil || codes.Code(resp.ChunkError.Code) != codes.InvalidArgument {
+			t.Fatalf("chunk %d = %v, want InvalidArgument chunk error", i, resp)
+		}
+	}
+}
+
+func TestStreamKeepsChunkOrder(t *testing.T) {
+	t.Parallel()
+
+	// enough chunks to be merged and priced by several workers at once
+	chunks := make([]*v1.ValuateTransactionsRequest, 60)
+	for i := range chunks {
+		txs := make([]*v1.TxToValuate, 1+i%7)
+		for j := range txs {
+			txs[j] = streamTx(fmt.Sprintf("%d-%d", i, j))
+		}
+		chunks[i] = &v1.ValuateTransactionsRequest{FiatCurrency: "RUB", Transactions: txs}
+	}
+
+	sent := runStream(t, chunks...)
+	for i, resp := range sent {
+		if len(resp.Transactions) != 1+i%7 {
+			t.Fatalf("chunk %d has %d txs, want %d", i, len(resp.Transactions), 1+i%7)
+		}
+		for j, tx := range resp.Transactions {
+			if want := fmt.Sprintf("%d-%d", i, j); tx.TxId != want {
+				t.Fatalf("chunk %d tx %d = %s, want %s", i, j, tx.TxId, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *PriceServer) ImportTenantSymbols(ctx context.Context, req *v1.ImportTenantSymbolsRequest) (*v1.ImportTenantSymbolsResponse, error) {
+	tenantID, err := parseUUID(req.TenantId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+	format, err := toDomainTenantSymbolFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := server.tenantSymbolUC.Import(ctx, tenantID, format, bytes.NewReader(req.Payload), req.DryRun)
+	if err != nil {
+		server.log.Warn("ImportTenantSymbols: import failed tenant_id=%s: %v", tenantID, err)
+		return nil, tenantSymbolStatusError(err)
+	}
+
+	resp := &v1.ImportTenantSymbolsResponse{
+		Rows:    int32(res.Rows),
+		Issues:  make([]*v1.TenantSymbolIssue, 0, len(res.Issues)),
+		Applied: res.Applied,
+	}
+	for _, i := range res.Issues {
+		resp.Issues = append(resp.Issues, &v1.TenantSymbolIssue{
+			Row:     int32(i.Row),
+			Source:  i.Symbol.Source,
+			Symbol:  i.Symbol.Symbol,
+			CoinId:  i.Symbol.CoinID,
+			Code:    toTenantSymbolIssueCode(i.Kind),
+			Message: i.Message,
+		})
+	}
+
+	server.log.Info("ImportTenantSymbols: tenant_id=%s rows=%d issues=%d dry_run=%t applied=%t", tenantID, res.Rows, len(res.Issues), req.DryRun, res.Applied)
+	return resp, nil
+}
+
+func (server *PriceServer) ExportTenantSymbols(ctx context.Context, req *v1.ExportTenantSymbolsRequest) (*v1.ExportTenantSymbolsResponse, error) {
+	tenantID, err := parseUUID(req.TenantId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+	format, err := toDomainTenantSymbolFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	rows, err := server.tenantSymbolUC.Export(ctx, tenantID, req.Source, format, &buf)
+	if err != nil {
+		server.log.Error("ExportTenantSymbols: export failed tenant_id=%s source=%s: %v", tenantID, req.Source, err)
+		return nil, tenantSymbolStatusError(err)
+	}
+
+	return &v1.ExportTenantSymbolsResponse{Payload: buf.Bytes(), Rows: int32(rows)}, nil
+}
+
+func toDomainTenantSymbolFormat(f v1.TenantSymbolFormat) (domain.TenantSymbolFormat, error) {
+	switch f {
+	case v1.TenantSymbolFormat_TENANT_SYMBOL_FORMAT_UNSPECIFIED, v1.TenantSymbolFormat_TENANT_SYMBOL_FORMAT_CSV:
+		return domain.TenantSymbolFormatCSV, nil
+	case v1.TenantSymbolFormat_TENANT_SYMBOL_FORMAT_JSON:
+		return domain.TenantSymbolFormatJSON, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown format %v", f)
+	}
+}
+
+func toTenantSymbolIssueCode(k domain.TenantSymbolIssueKind) v1.TenantSymbolIssueCode {
+	switch k {
+	case domain.TenantSymbolIssueInvalid:
+		return v1.TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_INVALID
+	case domain.TenantSymbolIssueUnknownCoin:
+		return v1.TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_UNKNOWN_COIN
+	case domain.TenantSymbolIssueConflict:
+		return v1.TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_CONFLICT
+	default:
+		return v1.TenantSymbolIssueCode_TENANT_SYMBOL_ISSUE_CODE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,175 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultUnresolvedPageSize = 100
+	maxUnresolvedPageSize     = 1000
+)
+
+func (server *PriceServer) ListUnresolvedSymbols(ctx context.Context, req *v1.ListUnresolvedSymbolsRequest) (*v1.ListUnresolvedSymbolsResponse, error) {
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize <= 0:
+		pageSize = defaultUnresolvedPageSize
+	case pageSize > maxUnresolvedPageSize:
+		pageSize = maxUnresolvedPageSize
+	}
+
+	filter := domain.UnresolvedSymbolFilter{
+		Source: req.Source,
+		Limit:  pageSize,
+	}
+	if req.TenantId != "" {
+		id, err := parseUUID(req.TenantId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+		}
+		filter.TenantID = id
+	}
+	if req.PageToken != "" {
+		afterID, err := strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		filter.AfterID = afterID
+	}
+
+	symbols, err := server.unresolvedSymbolUC.List(ctx, filter)
+	if err != nil {
+		server.log.Error("ListUnresolvedSymbols: failed: %v", err)
+		return nil, unresolvedSymbolStatusError(err)
+	}
+
+	resp := &v1.ListUnresolvedSymbolsResponse{Symbols: make([]*v1.UnresolvedSymbol, 0, len(symbols))}
+	for _, s := range symbols {
+		resp.Symbols = append(resp.Symbols, toUnresolvedSymbol(s))
+	}
+	// a full page may be followed by more entries
+	if len(symbols) == pageSize {
+		resp.NextPageToken = strconv.FormatInt(symbols[len(symbols)-1].ID, 10)
+	}
+	return resp, nil
+}
+
+func (server *PriceServer) ResolveUnresolvedSymbol(ctx context.Context, req *v1.ResolveUnresolvedSymbolRequest) (*v1.ResolveUnresolvedSymbolResponse, error) {
+	tenantID, err := parseUUID(req.TenantId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tenant ID: %v", err)
+	}
+
+	s := domain.TenantSymbol{
+		TenantID: tenantID,
+		Source:   req.Source,
+		Symbol:   req.Symbol,
+		CoinID:   req.CoinId,
+	}
+	if req.ValidFrom != nil {
+		s.ValidFrom = req.ValidFrom.AsTime()
+	}
+	if req.ValidTo != nil {
+		s.ValidTo = req.ValidTo.AsTime()
+	}
+
+	if err := server.unresolvedSymbolUC.Resolve(ctx, s); err != nil {
+		server.log.Warn("ResolveUnresolvedSymbol: failed tenant_id=%s source=%s symbol=%s: %v", tenantID, req.Source, req.Symbol, err)
+		return nil, unresolvedSymbolStatusError(err)
+	}
+
+	server.log.Info("ResolveUnresolvedSymbol: resolved tenant_id=%s source=%s symbol=%s coin_id=%s", tenantID, req.Source, req.Symbol, req.CoinId)
+	return &v1.ResolveUnresolvedSymbolResponse{}, nil
+}
+
+func unresolvedSymbolStatusError(err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		return status.Error(codes.NotFound, "symbol is not in the unresolved queue")
+	case errors.Is(err, apperr.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, apperr.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("unresolved symbols: %v", err))
+	}
+}
+
+func toUnresolvedSymbol(s domain.UnresolvedSymbol) *v1.UnresolvedSymbol {
+	reason := v1.UnresolvedReason_UNRESOLVED_REASON_UNSPECIFIED
+	switch s.Reason {
+	case domain.UnresolvedUnknown:
+		reason = v1.UnresolvedReason_UNRESOLVED_REASON_UNKNOWN
+	case domain.UnresolvedAmbiguous:
+		reason = v1.UnresolvedReason_UNRESOLVED_REASON_AMBIGUOUS
+	}
+
+	return &v1.UnresolvedSymbol{
+		TenantId:        s.TenantID.String(),
+		Source:          s.Source,
+		Symbol:          s.Symbol,
+		CanonicalSymbol: s.CanonicalSymbol,
+		Reason:          reason,
+		Message:         s.Message,
+		Occurrences:     s.Occurrences,
+		FirstSeen:       timestamppb.New(s.FirstSeen),
+		LastSeen:        timestamppb.New(s.LastSeen),
+	}
+}
+
+// unresolvedTally counts failed legs per symbol of one valuation request.
+type unresolvedTally struct {
+	order []string
+	bySym map[string]*domain.UnresolvedSymbol
+}
+
+func newUnresolvedTally() *unresolvedTally {
+	return &unresolvedTally{bySym: make(map[string]*domain.UnresolvedSymbol)}
+}
+
+// add counts a leg that failed to resolve. Contract addresses are skipped: a tenant
+// symbol mapping cannot fix them.
+func (t *unresolvedTally) add(asset domain.AssetRef, r domain.SymbolResolution) {
+	if asset.ContractAddress != "" || asset.Symbol == "" {
+		return
+	}
+
+	var reason domain.UnresolvedReason
+	switch {
+	case errors.Is(r.Err, apperr.ErrAmbiguousSymbol):
+		reason = domain.UnresolvedAmbiguous
+	case errors.Is(r.Err, apperr.ErrUnknownSymbol):
+		reason = domain.UnresolvedUnknown
+	default:
+		return
+	}
+
+	s, ok := t.bySym[asset.Symbol]
+	if !ok {
+		s = &domain.UnresolvedSymbol{Symbol: asset.Symbol}
+		t.bySym[asset.Symbol] = s
+		t.order = append(t.order, asset.Symbol)
+	}
+	s.CanonicalSymbol = r.CanonicalSymbol
+	s.Reason = reason
+	s.Message = r.Err.Error()
+	s.Occurrences++
+}
+
+func (t *unresolvedTally) symbols() []domain.UnresolvedSymbol {
+	out := make([]domain.UnresolvedSymbol, 0, len(t.order))
+	for _, sym := range t.order {
+		out = append(out, *t.bySym[sym])
+	}
+	return out
+}
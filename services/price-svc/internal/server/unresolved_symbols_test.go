@@ -0,0 +1,142 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	v1 "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/gen/price/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeQueue serves queue entries with IDs 1..n and answers Resolve with err.
+type fakeQueue struct {
+	domain.UnresolvedSymbolUseCase
+	n      int
+	err    error
+	filter domain.UnresolvedSymbolFilter
+}
+
+func (q *fakeQueue) List(_ context.Context, filter domain.UnresolvedSymbolFilter) ([]domain.UnresolvedSymbol, error) {
+	q.filter = filter
+	var out []domain.UnresolvedSymbol
+	for id := filter.AfterID + 1; id <= int64(q.n) && len(out) < filter.Limit; id++ {
+		out = append(out, domain.UnresolvedSymbol{ID: id, Symbol: fmt.Sprint("S", id), Reason: domain.UnresolvedUnknown})
+	}
+	return out, nil
+}
+
+func (q *fakeQueue) Resolve(context.Context, domain.TenantSymbol) error {
+	return q.err
+}
+
+func TestUnresolvedTally(t *testing.T) {
+	t.Parallel()
+
+	ambiguous := &domain.AmbiguousSymbolError{Symbol: "UNI", Candidates: []domain.Coin{{ID: "uniswap"}, {ID: "universe"}}}
+	tally := newUnresolvedTally()
+	tally.add(domain.AssetRef{Symbol: "NEW"}, domain.SymbolResolution{CanonicalSymbol: "NEW", Err: apperr.ErrUnknownSymbol})
+	tally.add(domain.AssetRef{Symbol: "UNI"}, domain.SymbolResolution{CanonicalSymbol: "UNI", Err: ambiguous})
+	tally.add(domain.AssetRef{Symbol: "NEW"}, domain.SymbolResolution{CanonicalSymbol: "NEW", Err: apperr.ErrUnknownSymbol})
+	// a mapping cannot fix contract legs or other failures
+	tally.add(domain.AssetRef{Symbol: "USDC", ContractAddress: "0xdead"}, domain.SymbolResolution{Err: apperr.ErrUnknownSymbol})
+	tally.add(domain.AssetRef{ContractAddress: "0xdead"}, domain.SymbolResolution{Err: apperr.ErrUnknownSymbol})
+	tally.add(domain.AssetRef{Symbol: "BTC"}, domain.SymbolResolution{Err: errors.New("store down")})
+
+	want := []domain.UnresolvedSymbol{
+		{Symbol: "NEW", CanonicalSymbol: "NEW", Reason: domain.UnresolvedUnknown, Message: apperr.ErrUnknownSymbol.Error(), Occurrences: 2},
+		{Symbol: "UNI", CanonicalSymbol: "UNI", Reason: domain.UnresolvedAmbiguous, Message: ambiguous.Error(), Occurrences: 1},
+	}
+	if got := tally.symbols(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("symbols() = %+v, want %+v", got, want)
+	}
+}
+
+func TestListUnresolvedSymbolsPages(t *testing.T) {
+	t.Parallel()
+
+	tenant := uuid.New()
+	cases := []struct {
+		name     string
+		req      *v1.ListUnresolvedSymbolsRequest
+		queued   int
+		limit    int
+		symbols  int
+		next     string
+		filtered uuid.UUID
+		code     codes.Code
+	}{
+		{name: "default page size", req: &v1.ListUnresolvedSymbolsRequest{}, queued: 150, limit: 100, symbols: 100, next: "100"},
+		{name: "page size capped", req: &v1.ListUnresolvedSymbolsRequest{PageSize: 5000}, queued: 10, limit: 1000, symbols: 10},
+		{name: "full last page still has a token", req: &v1.ListUnresolvedSymbolsRequest{PageSize: 5, PageToken: "5"}, queued: 10, limit: 5, symbols: 5, next: "10"},
+		{name: "short page ends", req: &v1.ListUnresolvedSymbolsRequest{PageSize: 5, PageToken: "7"}, queued: 10, limit: 5, symbols: 3},
+		{name: "tenant filter", req: &v1.ListUnresolvedSymbolsRequest{TenantId: tenant.String(), PageSize: 5}, queued: 1, limit: 5, symbols: 1, filtered: tenant},
+		{name: "invalid tenant", req: &v1.ListUnresolvedSymbolsRequest{TenantId: "acme"}, code: codes.InvalidArgument},
+		{name: "invalid page token", req: &v1.ListUnresolvedSymbolsRequest{PageToken: "next"}, code: codes.InvalidArgument},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			queue := &fakeQueue{n: tc.queued}
+			server := newTestServer(t)
+			server.unresolvedSymbolUC = queue
+
+			resp, err := server.ListUnresolvedSymbols(context.Background(), tc.req)
+			if status.Code(err) != tc.code {
+				t.Fatalf("ListUnresolvedSymbols() error = %v, want %s", err, tc.code)
+			}
+			if tc.code != codes.OK {
+				return
+			}
+			if queue.filter.Limit != tc.limit || queue.filter.TenantID != tc.filtered {
+				t.Fatalf("filter = %+v, want limit %d and tenant %s", queue.filter, tc.limit, tc.filtered)
+			}
+			if len(resp.Symbols) != tc.symbols || resp.NextPageToken != tc.next {
+				t.Fatalf("page = %d symbols, token %q, want %d and %q", len(resp.Symbols), resp.NextPageToken, tc.symbols, tc.next)
+			}
+		})
+	}
+}
+
+func TestResolveUnresolvedSymbolStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		tenant string
+		err    error
+		code   codes.Code
+	}{
+		{name: "resolved", tenant: uuid.NewString()},
+		{name: "invalid tenant", tenant: "acme", code: codes.InvalidArgument},
+		{name: "not queued", tenant: uuid.NewString(), err: fmt.Errorf("resolve: %w", apperr.ErrNotFound), code: codes.NotFound},
+		{name: "invalid mapping", tenant: uuid.NewString(), err: fmt.Errorf("coin: %w", apperr.ErrInvalidArgument), code: codes.InvalidArgument},
+		{name: "overlapping window", tenant: uuid.NewString(), err: fmt.Errorf("window: %w", apperr.ErrConflict), code: codes.AlreadyExists},
+		{name: "store failure", tenant: uuid.NewString(), err: errors.New("store down"), code: codes.Internal},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newTestServer(t)
+			server.unresolvedSymbolUC = &fakeQueue{err: tc.err}
+
+			_, err := server.ResolveUnresolvedSymbol(context.Background(), &v1.ResolveUnresolvedSymbolRequest{
+				TenantId: tc.tenant,
+				Source:   "binance",
+				Symbol:   "NEW",
+				CoinId:   "new-coin",
+			})
+			if status.Code(err) != tc.code {
+				t.Fatalf("ResolveUnresolvedSymbol() error = %v, want %s", err, tc.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+)
+
+const (
+	defaultCoinSearchLimit = 20
+	maxCoinSearchLimit     = 100
+)
+
+// minListedShare guards against truncated provider responses: a list with fewer coins than this
+// share of the active catalog is rejected instead of delisting the rest.
+const minListedShare = 0.5
+
+type coinCatalogUC struct {
+	repo           domain.CoinCatalogRepo
+	cgClient       *coingecko.CGClient
+	contextTimeout time.Duration
+}
+
+func NewCoinCatalogUC(repo domain.CoinCatalogRepo, cgClient *coingecko.CGClient, timeout time.Duration) domain.CoinCatalogUseCase {
+	return &coinCatalogUC{
+		repo:           repo,
+		cgClient:       cgClient,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *coinCatalogUC) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.contextTimeout > 0 {
+		return context.WithTimeout(ctx, u.contextTimeout)
+	}
+	return ctx, func() {}
+}
+
+func (u *coinCatalogUC) Search(ctx context.Context, query string, limit int) ([]domain.Coin, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, fmt.Errorf("query is required: %w", apperr.ErrInvalidArgument)
+	}
+	if limit <= 0 {
+		limit = defaultCoinSearchLimit
+	}
+	limit = min(limit, maxCoinSearchLimit)
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Search(ctx, query, limit)
+}
+
+func (u *coinCatalogUC) Get(ctx context.Context, id string) (domain.Coin, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return domain.Coin{}, fmt.Errorf("coin ID is required: %w", apperr.ErrInvalidArgument)
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Get(ctx, id)
+}
+
+// Sync replaces the catalog with the provider's current coin list and logs what changed.
+func (u *coinCatalogUC) Sync(ctx context.Context) (domain.CatalogSync, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	listed, err := u.cgClient.CoinsList(ctx, true)
+	if err != nil {
+		return domain.CatalogSync{}, fmt.Errorf("CoinsList: %w", err)
+	}
+
+	current, err := u.repo.List(ctx)
+	if err != nil {
+		return domain.CatalogSync{}, err
+	}
+
+	coins := catalogCoins(listed)
+	if len(coins) == 0 {
+		return domain.CatalogSync{}, fmt.Errorf("CoinsList: no coins listed")
+	}
+
+	active := 0
+	for _, c := range current {
+		if !c.Delisted {
+			active++
+		}
+	}
+	if float64(len(coins)) < float64(active)*minListedShare {
+		return domain.CatalogSync{}, fmt.Errorf("CoinsList: only %d coins listed, catalog has %d active; refusing to delist the rest", len(coins), active)
+	}
+
+	changes, res := diffCatalog(current, coins)
+	res.Coins = len(coins)
+
+	if err := u.repo.Sync(ctx, coins, changes, time.Now().UTC()); err != nil {
+		return domain.CatalogSync{}, err
+	}
+	return res, nil
+}
+
+func (u *coinCatalogUC) SyncIfStale(ctx context.Context, maxAge time.Duration) (domain.CatalogSync, bool, error) {
+	last, err := u.repo.LastSync(ctx)
+	if err != nil {
+		return domain.CatalogSync{}, false, err
+	}
+	if !last.IsZero() && time.Since(last) < maxAge {
+		return domain.CatalogSync{}, false, nil
+	}
+
+	res, err := u.Sync(ctx)
+	if err != nil {
+		return domain.CatalogSync{}, false, err
+	}
+	return res, true, nil
+}
+
+func catalogCoins(listed []coingecko.CoinListItem) []domain.Coin {
+	seen := make(map[string]struct{}, len(listed))
+	out := make([]domain.Coin, 0, len(listed))
+	for _, c := range listed {
+		id := strings.TrimSpace(c.ID)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		out = append(out, domain.Coin{
+			ID:        id,
+			Symbol:    strings.TrimSpace(c.Symbol),
+			Name:      strings.TrimSpace(c.Name),
+			Platforms: c.Platforms,
+		})
+	}
+	return out
+}
+
+// diffCatalog compares the stored catalog with a fresh list.
+func diffCatalog(current, listed []domain.Coin) ([]domain.CoinChange, domain.CatalogSync) {
+	var res domain.CatalogSync
+	var changes []domain.CoinChange
+
+	byID := make(map[string]domain.Coin, len(current))
+	for _, c := range current {
+		byID[c.ID] = c
+	}
+
+	listedIDs := make(map[string]struct{}, len(listed))
+	for _, c := range listed {
+		listedIDs[c.ID] = struct{}{}
+
+		old, ok := byID[c.ID]
+		switch {
+		case !ok:
+			res.Added++
+			changes = append(changes, domain.CoinChange{CoinID: c.ID, Kind: domain.CoinAdded, NewSymbol: c.Symbol, NewName: c.Name})
+			continue
+		case old.Delisted:
+			res.Relisted++
+			changes = append(changes, domain.CoinChange{CoinID: c.ID, Kind: domain.CoinRelisted, NewSymbol: c.Symbol, NewName: c.Name})
+		}
+
+		if old.Symbol != c.Symbol || old.Name != c.Name {
+			res.Renamed++
+			changes = append(changes, domain.CoinChange{
+				CoinID:    c.ID,
+				Kind:      domain.CoinRenamed,
+				OldSymbol: old.Symbol,
+				OldName:   old.Name,
+				NewSymbol: c.Symbol,
+				NewName:   c.Name,
+			})
+		}
+	}
+
+	for _, c := range current {
+		if _, ok := listedIDs[c.ID]; ok || c.Delisted {
+			continue
+		}
+		res.Removed++
+		changes = append(changes, domain.CoinChange{CoinID: c.ID, Kind: domain.CoinRemoved, OldSymbol: c.Symbol, OldName: c.Name})
+	}
+
+	return changes, res
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+)
+
+// fakeCatalogRepo holds the stored catalog and records the last sync.
+type fakeCatalogRepo struct {
+	domain.CoinCatalogRepo
+	coins   []domain.Coin
+	synced  []domain.Coin
+	changes []domain.CoinChange
+}
+
+func (r *fakeCatalogRepo) List(context.Context) ([]domain.Coin, error) {
+	return r.coins, nil
+}
+
+func (r *fakeCatalogRepo) Sync(_ context.Context, coins []domain.Coin, changes []domain.CoinChange, _ time.Time) error {
+	r.synced, r.changes = coins, changes
+	return nil
+}
+
+// fakeCoinsList serves the provider's coin list.
+func fakeCoinsList(t *testing.T, listed []coingecko.CoinListItem) *coingecko.CGClient {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/coins/list" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(listed)
+	}))
+	t.Cleanup(srv.Close)
+	return newTestCGClient(t, srv.URL)
+}
+
+func TestDiffCatalog(t *testing.T) {
+	t.Parallel()
+
+	current := []domain.Coin{
+		{ID: "bitcoin", Symbol: "btc", Name: "Bitcoin"},
+		{ID: "matic-network", Symbol: "matic", Name: "Polygon"},
+		{ID: "terra-luna", Symbol: "luna", Name: "Terra", Delisted: true},
+		{ID: "ftx-token", Symbol: "ftt", Name: "FTX"},
+		{ID: "old-delisted", Symbol: "old", Name: "Old", Delisted: true},
+	}
+	listed := []domain.Coin{
+		{ID: "bitcoin", Symbol: "btc", Name: "Bitcoin"},
+		{ID: "matic-network", Symbol: "pol", Name: "POL (ex-MATIC)"},
+		{ID: "terra-luna", Symbol: "lunc", Name: "Terra Luna Classic"},
+		{ID: "pepe", Symbol: "pepe", Name: "Pepe"},
+	}
+
+	changes, res := diffCatalog(current, listed)
+	if want := (domain.CatalogSync{Added: 1, Removed: 1, Relisted: 1, Renamed: 2}); res != want {
+		t.Fatalf("diffCatalog() counts = %+v, want %+v", res, want)
+	}
+
+	want := []domain.CoinChange{
+		{CoinID: "matic-network", Kind: domain.CoinRenamed, OldSymbol: "matic", OldName: "Polygon", NewSymbol: "pol", NewName: "POL (ex-MATIC)"},
+		{CoinID: "terra-luna", Kind: domain.CoinRelisted, NewSymbol: "lunc", NewName: "Terra Luna Classic"},
+		{CoinID: "terra-luna", Kind: domain.CoinRenamed, OldSymbol: "luna", OldName: "Terra", NewSymbol: "lunc", NewName: "Terra Luna Classic"},
+		{CoinID: "pepe", Kind: domain.CoinAdded, NewSymbol: "pepe", NewName: "Pepe"},
+		{CoinID: "ftx-token", Kind: domain.CoinRemoved, OldSymbol: "ftt", OldName: "FTX"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("diffCatalog() changes = %+v, want %+v", changes, want)
+	}
+}
+
+func TestCatalogSyncShrinkGuard(t *testing.T) {
+	t.Parallel()
+
+	stored := []domain.Coin{
+		{ID: "a", Symbol: "a"}, {ID: "b", Symbol: "b"}, {ID: "c", Symbol: "c"}, {ID: "d", Symbol: "d"},
+		{ID: "e", Symbol: "e", Delisted: true}, {ID: "f", Symbol: "f", Delisted: true},
+	}
+	cases := []struct {
+		name   string
+		listed []coingecko.CoinListItem
+		synced bool
+	}{
+		{name: "half the active coins", listed: []coingecko.CoinListItem{{ID: "a"}, {ID: "b"}}, synced: true},
+		{name: "less than half", listed: []coingecko.CoinListItem{{ID: "a"}}},
+		{name: "blank and duplicate IDs do not count", listed: []coingecko.CoinListItem{{ID: "a"}, {ID: " "}, {ID: "a"}}},
+		{name: "empty list", listed: []coingecko.CoinListItem{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeCatalogRepo{coins: stored}
+			res, err := NewCoinCatalogUC(repo, fakeCoinsList(t, tc.listed), 0).Sync(context.Background())
+			if !tc.synced {
+				if err == nil || repo.synced != nil {
+					t.Fatalf("Sync() = %+v, %v, want refused without writing", res, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			if res.Coins != 2 || res.Removed != 2 || len(repo.synced) != 2 {
+				t.Fatalf("Sync() = %+v with %d coins stored, want 2 kept and 2 removed", res, len(repo.synced))
+			}
+		})
+	}
+}
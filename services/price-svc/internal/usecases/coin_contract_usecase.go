@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+)
+
+type coinContractUC struct {
+	repo           domain.CoinContractRepo
+	cgClient       *coingecko.CGClient
+	contextTimeout time.Duration
+}
+
+func NewCoinContractUC(repo domain.CoinContractRepo, cgClient *coingecko.CGClient, timeout time.Duration) domain.CoinContractUseCase {
+	return &coinContractUC{
+		repo:           repo,
+		cgClient:       cgClient,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *coinContractUC) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.contextTimeout > 0 {
+		return context.WithTimeout(ctx, u.contextTimeout)
+	}
+	return ctx, func() {}
+}
+
+// Sync replaces the cached contracts with the provider's current coin list.
+func (u *coinContractUC) Sync(ctx context.Context) (int, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	coins, err := u.cgClient.CoinsList(ctx, true)
+	if err != nil {
+		return 0, fmt.Errorf("CoinsList: %w", err)
+	}
+
+	contracts := coinContracts(coins)
+	if len(contracts) == 0 {
+		return 0, fmt.Errorf("CoinsList: no contracts listed")
+	}
+
+	if _, err := u.repo.Replace(ctx, contracts, time.Now().UTC()); err != nil {
+		return 0, err
+	}
+	return len(contracts), nil
+}
+
+func (u *coinContractUC) SyncIfStale(ctx context.Context, maxAge time.Duration) (int, error) {
+	last, err := u.repo.LastSync(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !last.IsZero() && time.Since(last) < maxAge {
+		return 0, nil
+	}
+	return u.Sync(ctx)
+}
+
+// coinContracts flattens the coin list; a contract listed for several coins keeps the first.
+func coinContracts(coins []coingecko.CoinListItem) []domain.CoinContract {
+	type key struct{ platform, address string }
+	seen := make(map[key]struct{})
+
+	var out []domain.CoinContract
+	for _, c := range coins {
+		for platform, addr := range c.Platforms {
+			platform = strings.TrimSpace(platform)
+			addr = domain.NormalizeContractAddress(addr)
+			if platform == "" || addr == "" {
+				continue
+			}
+
+			k := key{platform, addr}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+
+			out = append(out, domain.CoinContract{Platform: platform, ContractAddress: addr, CoinID: c.ID})
+		}
+	}
+	return out
+}
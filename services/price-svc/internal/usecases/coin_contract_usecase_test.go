@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+)
+
+func TestCoinContracts(t *testing.T) {
+	t.Parallel()
+
+	listed := []coingecko.CoinListItem{
+		{ID: "usd-coin", Platforms: map[string]string{
+			"ethereum": " 0xA0b86991C6218b36c1d19D4a2e9Eb0cE3606eB48 ",
+			"solana":   "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+			"":         "0x0000000000000000000000000000000000000001",
+			"tron":     " ",
+		}},
+		{ID: "bitcoin"},
+		// a second coin listing the same contract on the same chain is dropped
+		{ID: "usd-coin-clone", Platforms: map[string]string{"ethereum": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"}},
+	}
+
+	got := coinContracts(listed)
+	sort.Slice(got, func(i, j int) bool { return got[i].Platform < got[j].Platform })
+	want := []domain.CoinContract{
+		{Platform: "ethereum", ContractAddress: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", CoinID: "usd-coin"},
+		{Platform: "solana", ContractAddress: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", CoinID: "usd-coin"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("coinContracts() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// maxCSVErrors caps how many invalid rows are listed in an import error.
+const maxCSVErrors = 20
+
+var customPriceCSVHeader = []string{"symbol", "time_utc", "price", "currency"}
+
+type customAssetUC struct {
+	repo           domain.CustomAssetRepo
+	contextTimeout time.Duration
+}
+
+func NewCustomAssetUC(repo domain.CustomAssetRepo, timeout time.Duration) domain.CustomAssetUseCase {
+	return &customAssetUC{
+		repo:           repo,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *customAssetUC) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.contextTimeout > 0 {
+		return context.WithTimeout(ctx, u.contextTimeout)
+	}
+	return ctx, func() {}
+}
+
+func (u *customAssetUC) UpsertAsset(ctx context.Context, a domain.CustomAsset) (domain.CustomAsset, error) {
+	a.Symbol = strings.TrimSpace(a.Symbol)
+	a.Name = strings.TrimSpace(a.Name)
+	if a.TenantID == uuid.Nil {
+		return domain.CustomAsset{}, fmt.Errorf("tenant ID is required: %w", apperr.ErrInvalidArgument)
+	}
+	if a.Symbol == "" {
+		return domain.CustomAsset{}, fmt.Errorf("symbol is required: %w", apperr.ErrInvalidArgument)
+	}
+	if domain.IsFiat(a.Symbol) {
+		return domain.CustomAsset{}, fmt.Errorf("symbol %s is a fiat currency: %w", a.Symbol, apperr.ErrInvalidArgument)
+	}
+	if a.Name == "" {
+		a.Name = a.Symbol
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Upsert(ctx, a)
+}
+
+func (u *customAssetUC) ListAssets(ctx context.Context, tenantID uuid.UUID) ([]domain.CustomAsset, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.List(ctx, tenantID)
+}
+
+func (u *customAssetUC) DeleteAsset(ctx context.Context, tenantID, assetID uuid.UUID) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Delete(ctx, tenantID, assetID)
+}
+
+func (u *customAssetUC) UpsertPrices(ctx context.Context, tenantID, assetID uuid.UUID, points []domain.CustomPricePoint) error {
+	for i := range points {
+		p := &points[i]
+		p.AssetID = assetID
+		p.Time = p.Time.UTC()
+		p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+		if err := validateCustomPrice(p.Price, p.Currency); err != nil {
+			return fmt.Errorf("price %d: %v: %w", i, err, apperr.ErrInvalidArgument)
+		}
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	// the asset must belong to the tenant
+	if _, err := u.repo.Get(ctx, tenantID, assetID); err != nil {
+		return err
+	}
+
+	return u.repo.UpsertPrices(ctx, points)
+}
+
+func (u *customAssetUC) ListPrices(ctx context.Context, tenantID, assetID uuid.UUID, from, to time.Time) ([]domain.CustomPricePoint, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := u.repo.Get(ctx, tenantID, assetID); err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListPrices(ctx, assetID, from, to)
+}
+
+func (u *customAssetUC) ImportPricesCSV(ctx context.Context, tenantID uuid.UUID, r io.Reader) (domain.CustomPriceImport, error) {
+	if tenantID == uuid.Nil {
+		return domain.CustomPriceImport{}, fmt.Errorf("tenant ID is required: %w", apperr.ErrInvalidArgument)
+	}
+
+	rows, err := parseCustomPriceCSV(r)
+	if err != nil {
+		return domain.CustomPriceImport{}, err
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	if err := u.repo.ImportPrices(ctx, tenantID, rows); err != nil {
+		return domain.CustomPriceImport{}, err
+	}
+
+	assets := make(map[string]struct{})
+	for _, row := range rows {
+		assets[row.Symbol] = struct{}{}
+	}
+	return domain.CustomPriceImport{Rows: len(rows), Assets: len(assets)}, nil
+}
+
+// parseCustomPriceCSV reads the whole upload and reports every invalid row (up to maxCSVErrors) at once.
+func parseCustomPriceCSV(r io.Reader) ([]domain.CustomPriceRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(customPriceCSVHeader)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("csv is empty: %w", apperr.ErrInvalidArgument)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %v: %w", err, apperr.ErrInvalidArgument)
+	}
+	for i, name := range customPriceCSVHeader {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), name) {
+			return nil, fmt.Errorf("csv header must be %q: %w", strings.Join(customPriceCSVHeader, ","), apperr.ErrInvalidArgument)
+		}
+	}
+
+	var rows []domain.CustomPriceRow
+	var problems []string
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			problems = append(problems, err.Error())
+			if len(problems) >= maxCSVErrors {
+				break
+			}
+			continue
+		}
+
+		row, err := parseCustomPriceRecord(rec)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %v", line, err))
+			if len(problems) >= maxCSVErrors {
+				break
+			}
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("csv has invalid rows: %s: %w", strings.Join(problems, "; "), apperr.ErrInvalidArgument)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("csv has no rows: %w", apperr.ErrInvalidArgument)
+	}
+
+	return rows, nil
+}
+
+func parseCustomPriceRecord(rec []string) (domain.CustomPriceRow, error) {
+	symbol := strings.TrimSpace(rec[0])
+	if symbol == "" {
+		return domain.CustomPriceRow{}, errors.New("symbol is empty")
+	}
+	if domain.IsFiat(symbol) {
+		return domain.CustomPriceRow{}, fmt.Errorf("symbol %s is a fiat currency", symbol)
+	}
+
+	at, err := parseCSVTime(strings.TrimSpace(rec[1]))
+	if err != nil {
+		return domain.CustomPriceRow{}, err
+	}
+
+	price, err := decimal.NewFromString(strings.TrimSpace(rec[2]))
+	if err != nil {
+		return domain.CustomPriceRow{}, fmt.Errorf("price %q: %v", rec[2], err)
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(rec[3]))
+	if err := validateCustomPrice(price, currency); err != nil {
+		return domain.CustomPriceRow{}, err
+	}
+
+	return domain.CustomPriceRow{Symbol: symbol, Time: at, Price: price, Currency: currency}, nil
+}
+
+// parseCSVTime accepts RFC 3339 timestamps and plain dates (UTC midnight).
+func parseCSVTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("time_utc %q: want RFC 3339 or YYYY-MM-DD", s)
+}
+
+func validateCustomPrice(price decimal.Decimal, currency string) error {
+	if !price.IsPositive() {
+		return errors.New("price must be positive")
+	}
+	if !domain.IsFiat(currency) {
+		return fmt.Errorf("currency %q is not an ISO-4217 code", currency)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// fakeCustomRepo holds assets of several tenants and one price point per asset.
+type fakeCustomRepo struct {
+	domain.CustomAssetRepo
+
+	assets   []domain.CustomAsset
+	points   map[uuid.UUID]domain.CustomPricePoint
+	imported []domain.CustomPriceRow
+}
+
+func (r *fakeCustomRepo) List(_ context.Context, tenantID uuid.UUID) ([]domain.CustomAsset, error) {
+	var out []domain.CustomAsset
+	for _, a := range r.assets {
+		if a.TenantID == tenantID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeCustomRepo) GetPricesAt(_ context.Context, assetIDs []uuid.UUID, times []time.Time) ([]*domain.CustomPricePoint, error) {
+	out := make([]*domain.CustomPricePoint, len(assetIDs))
+	for i, id := range assetIDs {
+		if p, ok := r.points[id]; ok && !p.Time.After(times[i]) {
+			out[i] = &p
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeCustomRepo) ImportPrices(_ context.Context, _ uuid.UUID, rows []domain.CustomPriceRow) error {
+	r.imported = append(r.imported, rows...)
+	return nil
+}
+
+func TestValuateCustomAssetsOfTenant(t *testing.T) {
+	t.Parallel()
+
+	tenant, other := uuid.New(), uuid.New()
+	priced := domain.CustomAsset{ID: uuid.New(), TenantID: tenant, Symbol: "PRIV"}
+	unpriced := domain.CustomAsset{ID: uuid.New(), TenantID: tenant, Symbol: "NEW"}
+	foreign := domain.CustomAsset{ID: uuid.New(), TenantID: other, Symbol: "PRIV"}
+	point := func(a domain.CustomAsset) domain.CustomPricePoint {
+		return domain.CustomPricePoint{AssetID: a.ID, Time: valuationDay, Price: decimal.NewFromInt(2), Currency: "USD"}
+	}
+	repo := &fakeCustomRepo{
+		assets: []domain.CustomAsset{priced, unpriced, foreign},
+		points: map[uuid.UUID]domain.CustomPricePoint{priced.ID: point(priced), foreign.ID: point(foreign)},
+	}
+	uc := newTestPriceUC(t, HistoricalPriceDeps{CustomRepo: repo}, nil, nil)
+
+	keys := []domain.PriceKey{
+		{CoinID: priced.CoinID(), BucketStartUtc: valuationTx},
+		{CoinID: unpriced.CoinID(), BucketStartUtc: valuationTx},
+		{CoinID: foreign.CoinID(), BucketStartUtc: valuationTx},
+		{CoinID: "custom:not-a-uuid", BucketStartUtc: valuationTx},
+	}
+	value := func(tenantID uuid.UUID) []domain.Valuation {
+		t.Helper()
+		opts := storedOnly()
+		opts.TenantID = tenantID
+		vals, err := uc.GetHistoricalPrices(context.Background(), "RUB", keys, opts)
+		if err != nil {
+			t.Fatalf("GetHistoricalPrices() error = %v", err)
+		}
+		return vals
+	}
+
+	vals := value(tenant)
+	if vals[0].Err != nil || !vals[0].Fiat.Equal(decimal.NewFromInt(180)) || vals[0].Method != domain.PricingMethodManual {
+		t.Fatalf("owned asset = %+v, want 180 priced manually", vals[0])
+	}
+	for i, name := range []string{"owned asset without a point", "other tenant's asset", "malformed custom ID"} {
+		if v := vals[i+1]; !errors.Is(v.Err, apperr.ErrPriceUnavailable) {
+			t.Fatalf("%s = %+v, want ErrPriceUnavailable", name, v)
+		}
+	}
+
+	// without a tenant no custom asset is priced
+	for i, v := range value(uuid.Nil) {
+		if !errors.Is(v.Err, apperr.ErrPriceUnavailable) {
+			t.Fatalf("leg %d without tenant = %+v, want ErrPriceUnavailable", i, v)
+		}
+	}
+}
+
+func TestImportPricesCSV(t *testing.T) {
+	t.Parallel()
+
+	tenant := uuid.New()
+	cases := []struct {
+		name   string
+		csv    string
+		want   domain.CustomPriceImport
+		reject bool
+	}{
+		{
+			name: "valid rows",
+			csv: "Symbol, Time_UTC, Price, Currency\n" +
+				"PRIV,2024-03-01,1.5,usd\n" +
+				"PRIV,2024-03-02T12:00:00Z,1.6,USD\n" +
+				"NEW,2024-03-01,100,RUB\n",
+			want: domain.CustomPriceImport{Rows: 3, Assets: 2},
+		},
+		{name: "wrong header", csv: "symbol,price\nPRIV,1.5\n", reject: true},
+		{name: "header only", csv: "symbol,time_utc,price,currency\n", reject: true},
+		{
+			name: "one invalid row",
+			csv: "symbol,time_utc,price,currency\n" +
+				"PRIV,2024-03-01,1.5,USD\n" +
+				"PRIV,2024-03-02,-1,USD\n",
+			reject: true,
+		},
+		{name: "fiat symbol", csv: "symbol,time_utc,price,currency\nEUR,2024-03-01,1.1,USD\n", reject: true},
+		{name: "unknown currency", csv: "symbol,time_utc,price,currency\nPRIV,2024-03-01,1.5,XYZ\n", reject: true},
+		{name: "malformed time", csv: "symbol,time_utc,price,currency\nPRIV,01.03.2024,1.5,USD\n", reject: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeCustomRepo{}
+			got, err := NewCustomAssetUC(repo, 0).ImportPricesCSV(context.Background(), tenant, strings.NewReader(tc.csv))
+			if tc.reject {
+				if !errors.Is(err, apperr.ErrInvalidArgument) {
+					t.Fatalf("ImportPricesCSV() error = %v, want ErrInvalidArgument", err)
+				}
+				if len(repo.imported) != 0 {
+					t.Fatalf("rejected upload stored %d rows", len(repo.imported))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportPricesCSV() error = %v", err)
+			}
+			if got != tc.want || len(repo.imported) != tc.want.Rows {
+				t.Fatalf("import = %+v with %d rows stored, want %+v", got, len(repo.imported), tc.want)
+			}
+
+			currencies := make([]string, len(repo.imported))
+			for i, r := range repo.imported {
+				currencies[i] = r.Currency
+			}
+			sort.Strings(currencies)
+			if strings.Join(currencies, ",") != "RUB,USD,USD" {
+				t.Fatalf("stored currencies = %v, want normalised codes", currencies)
+			}
+		})
+	}
+
+	if _, err := NewCustomAssetUC(&fakeCustomRepo{}, 0).ImportPricesCSV(context.Background(), uuid.Nil, strings.NewReader(cases[0].csv)); !errors.Is(err, apperr.ErrInvalidArgument) {
+		t.Fatalf("ImportPricesCSV() without tenant error = %v, want ErrInvalidArgument", err)
+	}
+}
@@ -4,49 +4,76 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
 	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/metrics"
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 const USD = "usd"
 
-var precision = "3"
-
 type historicalPriceUC struct {
 	logger         logger.Logger
 	repo           domain.HistoricalPriceRepo
+	gapRepo        domain.PriceGapRepo
+	quarantineRepo domain.QuarantineRepo
 	fxProvider     domain.FXProvider
 	cgClient       *coingecko.CGClient
+	pegs           domain.PegTable
+	derivatives    domain.DerivativeRegistry
+	sanitizer      domain.PriceSanitizer
+	snapshotRepo   domain.SnapshotRepo
+	customRepo     domain.CustomAssetRepo
+	gapTTL         time.Duration
 	contextTimeout time.Duration
 }
 
-func NewHistoricalPriceUC(
-	logger logger.Logger,
-	repo domain.HistoricalPriceRepo,
-	fx domain.FXProvider,
-	cgClient *coingecko.CGClient,
-	timeout time.Duration,
-) domain.HistoricalPriceUseCase {
+// HistoricalPriceDeps are the stores, providers and pricing rules historical prices are built from.
+type HistoricalPriceDeps struct {
+	Logger         logger.Logger
+	Repo           domain.HistoricalPriceRepo
+	GapRepo        domain.PriceGapRepo
+	QuarantineRepo domain.QuarantineRepo
+	FX             domain.FXProvider
+	CGClient       *coingecko.CGClient
+	Pegs           domain.PegTable
+	Derivatives    domain.DerivativeRegistry
+	Sanitizer      domain.PriceSanitizer
+	SnapshotRepo   domain.SnapshotRepo
+	CustomRepo     domain.CustomAssetRepo
+}
+
+func NewHistoricalPriceUC(deps HistoricalPriceDeps, gapTTL time.Duration, timeout time.Duration) domain.HistoricalPriceUseCase {
 	return &historicalPriceUC{
-		logger:         logger,
-		repo:           repo,
-		fxProvider:     fx,
-		cgClient:       cgClient,
+		logger:         deps.Logger,
+		repo:           deps.Repo,
+		gapRepo:        deps.GapRepo,
+		quarantineRepo: deps.QuarantineRepo,
+		fxProvider:     deps.FX,
+		cgClient:       deps.CGClient,
+		pegs:           deps.Pegs,
+		derivatives:    deps.Derivatives,
+		sanitizer:      deps.Sanitizer,
+		snapshotRepo:   deps.SnapshotRepo,
+		customRepo:     deps.CustomRepo,
+		gapTTL:         gapTTL,
 		contextTimeout: timeout,
 	}
 }
 
-func (u *historicalPriceUC) GetHistoricalPrices(ctx context.Context, fiatCurrency string, priceKeys []domain.PriceKey) ([]domain.Fiat, error) {
+func (u *historicalPriceUC) GetHistoricalPrices(ctx context.Context, fiatCurrency string, priceKeys []domain.PriceKey, opts domain.ValuationOptions) ([]domain.Valuation, error) {
 	if fiatCurrency == "" {
 		return nil, apperr.ErrInvalidArgument
 	}
 
 	if len(priceKeys) == 0 {
-		return []domain.Fiat{}, nil
+		return []domain.Valuation{}, nil
 	}
 
 	if u.contextTimeout > 0 {
@@ -55,42 +82,424 @@ func (u *historicalPriceUC) GetHistoricalPrices(ctx context.Context, fiatCurrenc
 		defer cancel()
 	}
 
+	return u.withSnapshot(ctx, domain.SnapshotKindCoin, fiatCurrency, priceKeys, opts, u.valuateCoins)
+}
+
+func (u *historicalPriceUC) GetFiatRates(ctx context.Context, fiatCurrency string, legKeys []domain.PriceKey, opts domain.ValuationOptions) ([]domain.Valuation, error) {
+	if fiatCurrency == "" {
+		return nil, apperr.ErrInvalidArgument
+	}
+
+	if len(legKeys) == 0 {
+		return []domain.Valuation{}, nil
+	}
+
+	if u.contextTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+		defer cancel()
+	}
+
+	return u.withSnapshot(ctx, domain.SnapshotKindFiat, fiatCurrency, legKeys, opts, u.valuateFiat)
+}
+
+// valuateCoins prices coin legs from stored or freshly fetched market prices, pegs and derivative ratios.
+func (u *historicalPriceUC) valuateCoins(ctx context.Context, fiatCurrency string, priceKeys []domain.PriceKey, opts domain.ValuationOptions) ([]domain.Valuation, error) {
+	out := make([]domain.Valuation, len(priceKeys))
+
+	// pegged assets without depeg check never need a market price,
+	// derivative tokens are looked up through their underlying coin
+	pegs := make([]*domain.PegRule, len(priceKeys))
+	ratios := make([]*decimal.Decimal, len(priceKeys))
+	marketIdx := make([]int, 0, len(priceKeys))
+	marketKeys := make([]domain.PriceKey, 0, len(priceKeys))
+	// tenants' custom assets are priced from their own series only
+	custom := make([]bool, len(priceKeys))
+	var customIdx []int
+	for i, k := range priceKeys {
+		if domain.IsCustomCoinID(k.CoinID) {
+			custom[i] = true
+			customIdx = append(customIdx, i)
+			continue
+		}
+		if rule, ok := u.pegs.Lookup(k.CoinID); ok {
+			pegs[i] = &rule
+			if !rule.MarketFallback {
+				continue
+			}
+		}
+		if rule, ok := u.derivatives.Lookup(k.CoinID); ok {
+			ratio := rule.RatioAt(k.BucketStartUtc)
+			ratios[i] = &ratio
+			k.CoinID = rule.UnderlyingID
+			out[i].Method = domain.PricingMethodDerivative
+		}
+		marketIdx = append(marketIdx, i)
+		marketKeys = append(marketKeys, k)
+	}
+
+	marketUSD := make([]*decimal.Decimal, len(priceKeys))
+	if len(marketKeys) > 0 {
+		prices, err := u.getUSDPrices(ctx, marketKeys, opts)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range marketIdx {
+			if prices[j].err != nil {
+				out[i].Err = prices[j].err
+				continue
+			}
+			price := prices[j].usd
+			out[i].LowerPrecision = prices[j].lowerPrecision
+			if ratios[i] != nil {
+				price = price.Mul(*ratios[i])
+			}
+			marketUSD[i] = &price
+
+			src := prices[j].source
+			out[i].Provenance = domain.Provenance{
+				BucketStartUtc:     src.Time,
+				GranularitySeconds: deref(src.GranularitySeconds),
+				Provider:           src.Provider,
+				PriceUsd:           &price,
+				FetchedAt:          src.FetchedAt,
+			}
+		}
+	}
+
+	if len(customIdx) > 0 {
+		keys := make([]domain.PriceKey, len(customIdx))
+		for j, i := range customIdx {
+			keys[j] = priceKeys[i]
+		}
+		vals, err := u.valuateCustom(ctx, fiatCurrency, keys, opts.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range customIdx {
+			out[i] = vals[j]
+		}
+	}
+
+	for i, k := range priceKeys {
+		if custom[i] {
+			continue
+		}
+		day := truncateDayUTC(k.BucketStartUtc)
+
+		if peg := pegs[i]; peg != nil {
+			method := domain.PricingMethodPeg
+			if peg.MarketFallback && out[i].Err != nil {
+				// the depeg check has no market price to compare, so the peg is used unchecked
+				u.logger.Warn("no market price for depeg check coin=%s day=%s, using peg: %v", k.CoinID, day.Format(time.DateOnly), out[i].Err)
+				method = domain.PricingMethodPegUnchecked
+			}
+			pegged, err := u.pegHolds(ctx, day, *peg, marketUSD[i])
+			if err != nil {
+				return nil, err
+			}
+			if pegged {
+				rate, err := u.fiatRate(ctx, day, peg.Currency, fiatCurrency)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = domain.Valuation{
+					Fiat:       rate.Rate,
+					Method:     method,
+					Provenance: domain.Provenance{FXRate: rate.Rate, FXEffectiveAt: rate.EffectiveDate},
+				}
+				continue
+			}
+
+			u.logger.Warn("pegged asset deviates beyond tolerance coin=%s day=%s, using market price", k.CoinID, day.Format(time.DateOnly))
+			out[i].Method = domain.PricingMethodMarketDepeg
+		}
+
+		if out[i].Err != nil {
+			continue
+		}
+
+		rate, err := u.fiatRate(ctx, day, USD, fiatCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i].Fiat = marketUSD[i].Mul(rate.Rate)
+		out[i].Provenance.FXRate = rate.Rate
+		out[i].Provenance.FXEffectiveAt = rate.EffectiveDate
+	}
+
+	return out, nil
+}
+
+// valuateCustom prices custom assets with the tenant's latest point at or before the tx time,
+// converted from the point's currency; a missing point or rate fails only its own leg. An asset
+// of another tenant is never priced, whatever mapping led to it.
+func (u *historicalPriceUC) valuateCustom(ctx context.Context, fiatCurrency string, keys []domain.PriceKey, tenantID uuid.UUID) ([]domain.Valuation, error) {
+	owned := make(map[uuid.UUID]bool)
+	if tenantID != uuid.Nil {
+		assets, err := u.customRepo.List(ctx, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("customRepo.List: %w", err)
+		}
+		for _, a := range assets {
+			owned[a.ID] = true
+		}
+	}
+
+	out := make([]domain.Valuation, len(keys))
+
+	var idx []int
+	var assetIDs []uuid.UUID
+	var times []time.Time
+	for i, k := range keys {
+		id, _ := domain.ParseCustomCoinID(k.CoinID)
+		if !owned[id] {
+			out[i].Err = fmt.Errorf("%s is not a custom asset of the tenant: %w", k.CoinID, apperr.ErrPriceUnavailable)
+			continue
+		}
+		idx = append(idx, i)
+		assetIDs = append(assetIDs, id)
+		times = append(times, k.BucketStartUtc)
+	}
+	if len(idx) == 0 {
+		return out, nil
+	}
+
+	points, err := u.customRepo.GetPricesAt(ctx, assetIDs, times)
+	if err != nil {
+		return nil, fmt.Errorf("customRepo.GetPricesAt: %w", err)
+	}
+	if len(points) != len(idx) {
+		return nil, fmt.Errorf("pricing invariant violated: got %d custom prices for %d keys", len(points), len(idx))
+	}
+
+	for j, p := range points {
+		i := idx[j]
+		if p == nil {
+			out[i].Err = fmt.Errorf("custom asset %s has no price at or before %s: %w", assetIDs[j], times[j].Format(time.RFC3339), apperr.ErrPriceUnavailable)
+			continue
+		}
+
+		day := truncateDayUTC(keys[i].BucketStartUtc)
+		rate, err := u.fxProvider.GetRate(ctx, day, p.Currency, fiatCurrency)
+		if err != nil {
+			out[i].Err = fmt.Errorf("fx rate %s->%s at %s: %w", p.Currency, fiatCurrency, day.Format(time.DateOnly), err)
+			continue
+		}
+
+		prov := domain.Provenance{
+			BucketStartUtc: p.Time,
+			Provider:       domain.ProviderManual,
+			FXRate:         rate.Rate,
+			FXEffectiveAt:  rate.EffectiveDate,
+		}
+		if strings.EqualFold(p.Currency, USD) {
+			price := p.Price
+			prov.PriceUsd = &price
+		}
+
+		out[i] = domain.Valuation{
+			Fiat:       p.Price.Mul(rate.Rate),
+			Method:     domain.PricingMethodManual,
+			Provenance: prov,
+		}
+	}
+	return out, nil
+}
+
+// valuateFiat converts fiat legs through FX; a missing rate fails only its own leg.
+func (u *historicalPriceUC) valuateFiat(ctx context.Context, fiatCurrency string, legKeys []domain.PriceKey, _ domain.ValuationOptions) ([]domain.Valuation, error) {
+	out := make([]domain.Valuation, len(legKeys))
+	for i, k := range legKeys {
+		day := truncateDayUTC(k.BucketStartUtc)
+
+		rate, err := u.fxProvider.GetRate(ctx, day, k.CoinID, fiatCurrency)
+		if err != nil {
+			out[i].Err = fmt.Errorf("fx rate %s->%s at %s: %w", k.CoinID, fiatCurrency, day.Format(time.DateOnly), err)
+			continue
+		}
+
+		out[i] = domain.Valuation{
+			Fiat:       rate.Rate,
+			Method:     domain.PricingMethodFX,
+			Provenance: domain.Provenance{FXRate: rate.Rate, FXEffectiveAt: rate.EffectiveDate},
+		}
+	}
+	return out, nil
+}
+
+// marketPrice is a provider USD price together with how precisely it matched the lookup.
+type marketPrice struct {
+	usd            decimal.Decimal
+	lowerPrecision bool
+	err            error                  // no price for this key, the rest of the batch is still valid
+	source         domain.HistoricalPrice // stored bucket the price was read from
+}
+
+// getUSDPrices returns provider USD prices for the keys at the requested price point.
+func (u *historicalPriceUC) getUSDPrices(ctx context.Context, priceKeys []domain.PriceKey, opts domain.ValuationOptions) ([]marketPrice, error) {
 	now := time.Now().UTC()
+	// as of a past time, granularity and closed buckets are judged as they were then
+	if asOf := opts.Lookup.AsOf; !asOf.IsZero() && asOf.Before(now) {
+		now = asOf.UTC()
+	}
+	point := opts.PricePoint
 
 	type wanted struct {
-		coinID   string
-		txTime   time.Time
-		bucket   time.Time
-		dayStart time.Time
-		dayEnd   time.Time
-		desiredG time.Duration
+		coinID string
+		txTime time.Time
+		bucket time.Time
+		g      time.Duration
+		open   int // index in bucketKeys of the bucket containing txTime, -1 if not needed
+		close  int // index in bucketKeys of the following bucket, -1 if not needed
 	}
 
 	w := make([]wanted, len(priceKeys))
-	repoKeys := make([]domain.PriceKey, len(priceKeys))
+	var bucketKeys []domain.PriceKey
+	var grans []time.Duration
+	need := func(coinID string, bucket time.Time, g time.Duration) int {
+		bucketKeys = append(bucketKeys, domain.PriceKey{CoinID: coinID, BucketStartUtc: bucket})
+		grans = append(grans, g)
+		return len(bucketKeys) - 1
+	}
 
-	// compute desired granularity + bucketStart per key
 	for i, k := range priceKeys {
 		txTime := k.BucketStartUtc.UTC() // NOTE: this is actually tx time
-		desired := u.cgClient.GetGranularitySeconds(txTime, now)
-		bucket := floorToBucket(txTime, desired)
-		dayStart := truncateDayUTC(txTime)
-		dayEnd := dayStart.Add(24*time.Hour + 59*time.Minute + 59*time.Second)
+		g := u.cgClient.GetGranularitySeconds(txTime, now)
+		bucket := floorToBucket(txTime, g)
+		// the following bucket may not exist yet for recent transactions, open is the best we have then
+		closed := !bucket.Add(g).After(now)
 
-		w[i] = wanted{
-			coinID:   k.CoinID,
-			txTime:   txTime,
-			bucket:   bucket,
-			dayStart: dayStart,
-			dayEnd:   dayEnd,
-			desiredG: desired,
+		w[i] = wanted{coinID: k.CoinID, txTime: txTime, bucket: bucket, g: g, open: -1, close: -1}
+		switch {
+		case point == domain.PricePointBucketClose && closed:
+			w[i].close = need(k.CoinID, bucket.Add(g), g)
+		case point == domain.PricePointInterpolated && closed:
+			w[i].open = need(k.CoinID, bucket, g)
+			w[i].close = need(k.CoinID, bucket.Add(g), g)
+		default:
+			// daily average also loads the open bucket: it makes sure the day is fetched
+			w[i].open = need(k.CoinID, bucket, g)
+		}
+	}
+
+	prices, err := u.loadBuckets(ctx, bucketKeys, grans, opts.Lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]marketPrice, len(priceKeys))
+
+	if point == domain.PricePointDailyAverage {
+		dayKeys := make([]domain.PriceKey, len(priceKeys))
+		for i := range w {
+			dayKeys[i] = domain.PriceKey{CoinID: w[i].coinID, BucketStartUtc: truncateDayUTC(w[i].txTime)}
+		}
+
+		averages, err := u.repo.GetDailyAverageBatch(ctx, dayKeys, opts.Lookup.AsOf)
+		if err != nil {
+			return nil, fmt.Errorf("repo.GetDailyAverageBatch: %w", err)
+		}
+		if len(averages) != len(dayKeys) {
+			return nil, fmt.Errorf("pricing invariant violated: got %d averages for %d keys", len(averages), len(dayKeys))
+		}
+
+		for i, avg := range averages {
+			switch {
+			case prices[w[i].open].err != nil:
+				out[i] = marketPrice{err: prices[w[i].open].err}
+			case avg == nil:
+				out[i] = marketPrice{err: fmt.Errorf("coin=%s day=%s: %w", w[i].coinID, dayKeys[i].BucketStartUtc.Format(time.DateOnly), apperr.ErrPriceUnavailable)}
+			default:
+				// the open bucket tells provider and fetch time, the day is what was averaged
+				src := prices[w[i].open].source
+				src.Time = dayKeys[i].BucketStartUtc
+				day := int((24 * time.Hour).Seconds())
+				src.GranularitySeconds = &day
+				out[i] = marketPrice{usd: *avg, source: src}
+			}
+		}
+		return out, nil
+	}
+
+	for i, x := range w {
+		switch {
+		case x.open >= 0 && prices[x.open].err != nil:
+			out[i] = prices[x.open]
+		case x.close >= 0 && prices[x.close].err != nil:
+			out[i] = prices[x.close]
+		case x.open >= 0 && x.close >= 0:
+			openPrice, closePrice := prices[x.open].usd, prices[x.close].usd
+			elapsed := decimal.NewFromInt(int64(x.txTime.Sub(x.bucket) / time.Second))
+			span := decimal.NewFromInt(int64(x.g / time.Second))
+			out[i] = marketPrice{
+				usd:            openPrice.Add(closePrice.Sub(openPrice).Mul(elapsed).Div(span)),
+				lowerPrecision: prices[x.open].lowerPrecision || prices[x.close].lowerPrecision,
+				source:         prices[x.open].source,
+			}
+		case x.close >= 0:
+			out[i] = prices[x.close]
+		default:
+			out[i] = prices[x.open]
+		}
+	}
+
+	return out, nil
+}
+
+// fillContaining answers keys the nearest lookup missed with the coarser bucket containing them.
+func (u *historicalPriceUC) fillContaining(ctx context.Context, keys []domain.PriceKey, rows []domain.HistoricalPrice, asOf time.Time) ([]domain.HistoricalPrice, error) {
+	var missing []int
+	var missingKeys []domain.PriceKey
+	for i, p := range rows {
+		if p.PriceUsd == nil {
+			missing = append(missing, i)
+			missingKeys = append(missingKeys, keys[i])
+		}
+	}
+	if len(missing) == 0 {
+		return rows, nil
+	}
+
+	containing, err := u.repo.GetContainingBatch(ctx, missingKeys, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if len(containing) != len(missingKeys) {
+		return nil, fmt.Errorf("pricing invariant violated: got %d rows for %d keys", len(containing), len(missingKeys))
+	}
+	for j, i := range missing {
+		if containing[j].PriceUsd != nil {
+			rows[i] = containing[j]
+		}
+	}
+	return rows, nil
+}
+
+// loadBuckets reads bucket prices from the DB, fetching missing or too coarse days from the provider first.
+// grans holds the desired granularity per bucket key.
+func (u *historicalPriceUC) loadBuckets(ctx context.Context, repoKeys []domain.PriceKey, grans []time.Duration, lookup domain.LookupPolicy) ([]marketPrice, error) {
+	read := func() ([]domain.HistoricalPrice, error) {
+		switch {
+		case lookup.Nearest && lookup.AcceptCoarser:
+			rows, err := u.repo.GetNearestBatch(ctx, repoKeys, lookup.Tolerance, lookup.AsOf)
+			if err != nil {
+				return nil, err
+			}
+			return u.fillContaining(ctx, repoKeys, rows, lookup.AsOf)
+		case lookup.Nearest:
+			return u.repo.GetNearestBatch(ctx, repoKeys, lookup.Tolerance, lookup.AsOf)
+		case lookup.AcceptCoarser:
+			// a coarser bucket counts when it contains the wanted one, however far its start is
+			return u.repo.GetContainingBatch(ctx, repoKeys, lookup.AsOf)
+		default:
+			return u.repo.GetBatch(ctx, repoKeys, lookup.AsOf)
 		}
-		// repo expects bucket_start_utc
-		repoKeys[i] = domain.PriceKey{CoinID: k.CoinID, BucketStartUtc: bucket}
 	}
 
 	// read batch from DB (LEFT JOIN order-preserving)
-	rows, err := u.repo.GetBatch(ctx, repoKeys)
+	rows, err := read()
 	if err != nil {
 		return nil, fmt.Errorf("repo.GetBatch: %w", err)
 	}
@@ -98,41 +507,63 @@ func (u *historicalPriceUC) GetHistoricalPrices(ctx context.Context, fiatCurrenc
 		return nil, fmt.Errorf("pricing invariant violated: got %d rows for %d keys", len(rows), len(repoKeys))
 	}
 
-	// plan provider fetches for missing/upgrade
-	type fetchKey struct {
-		coinID   string
-		dayStart time.Time
-		dayEnd   time.Time
-		g        time.Duration
-	}
-	needFetch := make(map[fetchKey]struct{})
+	out := make([]marketPrice, len(rows))
+
+	// plan provider fetches for missing/upgrade; an as-of lookup only reads what was stored then
+	needFetch := make(map[fetchKey][]int)
+	readOnly := !lookup.AsOf.IsZero()
 
 	for i, p := range rows {
 		missing := p.PriceUsd == nil
 		upgrade := false
-		if !missing {
-			if *p.GranularitySeconds > int(w[i].desiredG.Seconds()) {
+		if !missing && !lookup.AcceptCoarser {
+			if *p.GranularitySeconds > int(grans[i].Seconds()) {
 				upgrade = true
 			}
 		}
-		if missing || upgrade {
-			needFetch[fetchKey{coinID: w[i].coinID, dayStart: w[i].dayStart, g: w[i].desiredG}] = struct{}{}
+		if (missing || upgrade) && !readOnly {
+			fk := fetchKey{coinID: repoKeys[i].CoinID, dayStart: truncateDayUTC(repoKeys[i].BucketStartUtc), g: grans[i]}
+			needFetch[fk] = append(needFetch[fk], i)
 		}
 	}
 
+	// days known to have no provider data are answered without calling the provider
+	noData, err := u.knownNoData(ctx, needFetch)
+	if err != nil {
+		return nil, err
+	}
+
 	// fetch day data from CoinGecko and upsert buckets
-	for fk := range needFetch {
-		if err := u.fetchAndUpsertDay(ctx, fk.coinID, fk.dayStart, fk.g); err != nil {
-			if errors.Is(err, apperr.ErrProviderUnavailable) || errors.Is(err, apperr.ErrProviderBadResponse) {
-				return nil, err
+	fetched := 0
+	for fk, idxs := range needFetch {
+		err, known := noData[fk]
+		if !known {
+			err = u.fetchAndUpsertDay(ctx, fk.coinID, fk.dayStart, fk.g)
+			if errors.Is(err, apperr.ErrNoProviderData) {
+				err = u.noteEmptyDay(ctx, fk.coinID, fk.dayStart, err)
+			}
+		}
+
+		switch {
+		case err == nil:
+			fetched++
+		case errors.Is(err, apperr.ErrNoProviderData) || errors.Is(err, apperr.ErrBeforeListing):
+			// an upgrade keeps its coarser row, a missing bucket has no price at all
+			for _, i := range idxs {
+				if rows[i].PriceUsd == nil {
+					out[i].err = err
+				}
 			}
+		case errors.Is(err, apperr.ErrProviderUnavailable) || errors.Is(err, apperr.ErrProviderBadResponse):
+			return nil, err
+		default:
 			return nil, fmt.Errorf("fetchAndUpsertDay: %w", err)
 		}
 	}
 
 	// re-read after upserts
-	if len(needFetch) > 0 {
-		rows, err = u.repo.GetBatch(ctx, repoKeys)
+	if fetched > 0 {
+		rows, err = read()
 		if err != nil {
 			return nil, fmt.Errorf("repo.GetBatch (after fetch): %w", err)
 		}
@@ -141,40 +572,72 @@ func (u *historicalPriceUC) GetHistoricalPrices(ctx context.Context, fiatCurrenc
 		}
 	}
 
-	out := make([]domain.Fiat, len(rows))
-
 	for i, p := range rows {
+		if out[i].err != nil {
+			continue
+		}
+		if p.PriceUsd == nil && readOnly {
+			out[i].err = fmt.Errorf("coin=%s bucket=%s as of %s: %w", repoKeys[i].CoinID, repoKeys[i].BucketStartUtc.Format(time.RFC3339), lookup.AsOf.Format(time.RFC3339), apperr.ErrPriceUnavailable)
+			continue
+		}
 		if p.PriceUsd == nil {
-			u.logger.Error("price still missing after fetch", "coinID", w[i].coinID, "bucket", w[i].bucket)
-			return nil, fmt.Errorf("coin=%s bucket=%s: %w", w[i].coinID, w[i].bucket.Format(time.RFC3339), apperr.ErrPriceUnavailable)
+			u.logger.Error("price still missing after fetch", "coinID", repoKeys[i].CoinID, "bucket", repoKeys[i].BucketStartUtc)
+			out[i].err = fmt.Errorf("coin=%s bucket=%s: %w", repoKeys[i].CoinID, repoKeys[i].BucketStartUtc.Format(time.RFC3339), apperr.ErrPriceUnavailable)
+			continue
 		}
 
-		rate, err := u.fxProvider.GetUSDtoFiatRate(ctx, w[i].dayStart, fiatCurrency)
-		if err != nil {
-			// distinguish unsupported fiat vs fx unavailable if your fxProvider does it
-			u.logger.Error("fxProvider.GetUSDtoFiatRate: fx rate fetch failed", "fiat", fiatCurrency, "day", w[i].dayStart, "error", err)
-			return nil, fmt.Errorf("fxProvider.GetUSDtoFiatRate: %w", err)
+		out[i] = marketPrice{
+			usd:            *p.PriceUsd,
+			lowerPrecision: !p.Time.Equal(repoKeys[i].BucketStartUtc) || *p.GranularitySeconds > int(grans[i].Seconds()),
+			source:         p,
 		}
-
-		usd := *p.PriceUsd
-		out[i] = usd.Mul(rate)
 	}
 
 	return out, nil
 }
 
+// pegHolds reports whether a pegged asset may be valued at its peg.
+// Without a market price (no depeg check configured, or no price to check against) the peg is trusted.
+func (u *historicalPriceUC) pegHolds(ctx context.Context, day time.Time, peg domain.PegRule, marketUSD *decimal.Decimal) (bool, error) {
+	if marketUSD == nil {
+		return true, nil
+	}
+
+	pegUSD, err := u.fiatRate(ctx, day, peg.Currency, USD)
+	if err != nil {
+		return false, err
+	}
+	if pegUSD.Rate.IsZero() {
+		return false, nil
+	}
+
+	deviation := marketUSD.Sub(pegUSD.Rate).Abs().Div(pegUSD.Rate)
+	return deviation.LessThanOrEqual(peg.Tolerance), nil
+}
+
+// fiatRate returns how much of fiat one unit of currency is worth on the given day.
+func (u *historicalPriceUC) fiatRate(ctx context.Context, day time.Time, currency, fiat string) (domain.FXQuote, error) {
+	rate, err := u.fxProvider.GetRate(ctx, day, currency, fiat)
+	if err != nil {
+		// distinguish unsupported fiat vs fx unavailable if your fxProvider does it
+		u.logger.Error("fxProvider.GetRate: fx rate fetch failed", "from", currency, "to", fiat, "day", day, "error", err)
+		return domain.FXQuote{}, fmt.Errorf("fxProvider.GetRate: %w", err)
+	}
+	return rate, nil
+}
+
 func (u *historicalPriceUC) fetchAndUpsertDay(ctx context.Context, coinID string, dayStartUTC time.Time, granularitySeconds time.Duration) error {
 
 	to := dayStartUTC.Add(24*time.Hour - time.Second)
 
 	// CoinGecko returns points; per our agreement we normalize sequentially into buckets without flooring by timestamp.
-	resp, err := u.cgClient.CoinsMarketChartRange(ctx, coinID, "usd", dayStartUTC, to, &precision)
+	resp, err := u.cgClient.CoinsMarketChartRange(ctx, coinID, "usd", dayStartUTC, to, u.cgClient.PrecisionFor(coinID))
 	if err != nil {
 		return fmt.Errorf("%w: %v", apperr.ErrProviderUnavailable, err)
 	}
 
 	if resp == nil || len(resp.Prices) == 0 {
-		return fmt.Errorf("%w: empty prices for coin=%s day=%s", apperr.ErrProviderBadResponse, coinID, dayStartUTC.Format(time.DateOnly))
+		return fmt.Errorf("%w: empty prices for coin=%s day=%s", apperr.ErrNoProviderData, coinID, dayStartUTC.Format(time.DateOnly))
 	}
 
 	// normalize points to buckets "by order"
@@ -184,9 +647,36 @@ func (u *historicalPriceUC) fetchAndUpsertDay(ctx context.Context, coinID string
 		return fmt.Errorf("%w: %v", apperr.ErrProviderBadResponse, err)
 	}
 
+	buckets, quarantined := u.sanitizer.Sanitize(buckets)
+	if len(quarantined) > 0 {
+		if err := u.quarantine(ctx, quarantined); err != nil {
+			return err
+		}
+	}
+
 	if err := u.repo.UpsertBatch(ctx, buckets); err != nil {
 		return fmt.Errorf("repo.UpsertBatch: %w", err)
 	}
 
 	return nil
 }
+
+// quarantine stores suspect provider points for review before the sanitized day is upserted.
+func (u *historicalPriceUC) quarantine(ctx context.Context, points []domain.QuarantinedPrice) error {
+	for _, q := range points {
+		u.logger.Warn("quarantined price coin=%s bucket=%s raw=%s reason=%s",
+			q.CoinID, q.BucketStartUtc.Format(time.RFC3339), q.RawPrice.String(), q.Reason)
+
+		metrics.PricesQuarantined.WithLabelValues(string(q.Reason)).Inc()
+		if q.SubstitutedPrice != nil {
+			metrics.PricesSubstituted.Inc()
+		} else {
+			metrics.PricesDropped.Inc()
+		}
+	}
+
+	if err := u.quarantineRepo.InsertBatch(ctx, points); err != nil {
+		return fmt.Errorf("quarantineRepo.InsertBatch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,481 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/coingecko"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/pricing"
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/pkg/logger"
+	"github.com/shopspring/decimal"
+)
+
+// valuationTx is old enough to be priced from daily buckets.
+var (
+	valuationTx  = time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	valuationDay = truncateDayUTC(valuationTx)
+)
+
+// fakePriceRepo answers reads from stored buckets keyed by coin and exact bucket start.
+type fakePriceRepo struct {
+	domain.HistoricalPriceRepo
+	rows map[domain.PriceKey]domain.HistoricalPrice
+}
+
+func (r *fakePriceRepo) store(coinID string, bucket time.Time, price string, granularity time.Duration) {
+	if r.rows == nil {
+		r.rows = make(map[domain.PriceKey]domain.HistoricalPrice)
+	}
+	v := decimal.RequireFromString(price)
+	g := int(granularity.Seconds())
+	r.rows[domain.PriceKey{CoinID: coinID, BucketStartUtc: bucket}] = domain.HistoricalPrice{
+		CoinID:             coinID,
+		Time:               bucket,
+		PriceUsd:           &v,
+		GranularitySeconds: &g,
+		Provider:           domain.ProviderCoinGecko,
+		FetchedAt:          bucket.AddDate(0, 0, 1),
+	}
+}
+
+func (r *fakePriceRepo) GetBatch(_ context.Context, keys []domain.PriceKey, _ time.Time) ([]domain.HistoricalPrice, error) {
+	out := make([]domain.HistoricalPrice, len(keys))
+	for i, k := range keys {
+		out[i] = r.rows[domain.PriceKey{CoinID: k.CoinID, BucketStartUtc: k.BucketStartUtc.UTC()}]
+	}
+	return out, nil
+}
+
+func (r *fakePriceRepo) GetNearestBatch(_ context.Context, keys []domain.PriceKey, tolerance time.Duration, _ time.Time) ([]domain.HistoricalPrice, error) {
+	out := make([]domain.HistoricalPrice, len(keys))
+	for i, k := range keys {
+		for key, p := range r.rows {
+			at := k.BucketStartUtc.UTC()
+			if key.CoinID == k.CoinID && !key.BucketStartUtc.After(at) && !key.BucketStartUtc.Before(at.Add(-tolerance)) &&
+				(out[i].PriceUsd == nil || key.BucketStartUtc.After(out[i].Time)) {
+				out[i] = p
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r *fakePriceRepo) GetContainingBatch(_ context.Context, keys []domain.PriceKey, _ time.Time) ([]domain.HistoricalPrice, error) {
+	out := make([]domain.HistoricalPrice, len(keys))
+	for i, k := range keys {
+		for key, p := range r.rows {
+			at := k.BucketStartUtc.UTC()
+			end := key.BucketStartUtc.Add(time.Duration(*p.GranularitySeconds) * time.Second)
+			if key.CoinID == k.CoinID && !key.BucketStartUtc.After(at) && end.After(at) &&
+				(out[i].PriceUsd == nil || key.BucketStartUtc.After(out[i].Time)) {
+				out[i] = p
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r *fakePriceRepo) GetDailyAverageBatch(_ context.Context, dayKeys []domain.PriceKey, _ time.Time) ([]*decimal.Decimal, error) {
+	out := make([]*decimal.Decimal, len(dayKeys))
+	for i, k := range dayKeys {
+		sum, n := decimal.Zero, 0
+		for key, p := range r.rows {
+			if key.CoinID == k.CoinID && truncateDayUTC(key.BucketStartUtc).Equal(k.BucketStartUtc) {
+				sum = sum.Add(*p.PriceUsd)
+				n++
+			}
+		}
+		if n > 0 {
+			avg := sum.Div(decimal.NewFromInt(int64(n)))
+			out[i] = &avg
+		}
+	}
+	return out, nil
+}
+
+// fakeFX quotes fixed rates keyed "BASE/QUOTE"; a currency is always worth one of itself.
+type fakeFX struct {
+	domain.FXProvider
+	rates map[string]string
+}
+
+func (f fakeFX) GetRate(_ context.Context, day time.Time, base, quote string) (domain.FXQuote, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if base == quote {
+		return domain.FXQuote{Rate: decimal.NewFromInt(1), EffectiveDate: day}, nil
+	}
+	r, ok := f.rates[base+"/"+quote]
+	if !ok {
+		return domain.FXQuote{}, fmt.Errorf("%s/%s: %w", base, quote, apperr.ErrFXUnavailable)
+	}
+	return domain.FXQuote{Rate: decimal.RequireFromString(r), EffectiveDate: day}, nil
+}
+
+// newTestCGClient builds a provider client for baseURL with the shipped granularity policy.
+func newTestCGClient(t *testing.T, baseURL string) *coingecko.CGClient {
+	t.Helper()
+
+	cg, err := coingecko.NewCGClient(coingecko.CGConfig{
+		BaseURL:           baseURL,
+		RateLimitPerMin:   6000,
+		GranularityPolicy: coingecko.GranularityPolicy{"5minutes": 24 * time.Hour, "1hour": 90 * 24 * time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("NewCGClient() error = %v", err)
+	}
+	return cg
+}
+
+// newTestPriceUC builds the use case over fake stores; deps left empty get working defaults.
+func newTestPriceUC(t *testing.T, deps HistoricalPriceDeps, pegs []pricing.PeggedAsset, derivatives []pricing.DerivativeAsset) *historicalPriceUC {
+	t.Helper()
+
+	pegTable, err := pricing.NewPegTable(pegs)
+	if err != nil {
+		t.Fatalf("NewPegTable() error = %v", err)
+	}
+	registry, err := pricing.NewDerivativeRegistry(derivatives)
+	if err != nil {
+		t.Fatalf("NewDerivativeRegistry() error = %v", err)
+	}
+
+	deps.Logger = logger.New("error")
+	if deps.CGClient == nil {
+		deps.CGClient = newTestCGClient(t, "http://coingecko.invalid")
+	}
+	deps.Pegs = pegTable
+	deps.Derivatives = registry
+	if deps.Repo == nil {
+		deps.Repo = &fakePriceRepo{}
+	}
+	if deps.FX == nil {
+		deps.FX = fakeFX{rates: map[string]string{"USD/RUB": "90"}}
+	}
+	return NewHistoricalPriceUC(deps, time.Hour, 0).(*historicalPriceUC)
+}
+
+// storedOnly reads only what is stored, so no test ever reaches the provider.
+func storedOnly() domain.ValuationOptions {
+	return domain.ValuationOptions{Lookup: domain.LookupPolicy{AsOf: time.Now().UTC()}}
+}
+
+func TestValuatePeggedAssets(t *testing.T) {
+	t.Parallel()
+
+	pegs := []pricing.PeggedAsset{
+		{CoinID: "tether", Peg: "USD"},
+		{CoinID: "usd-coin", Peg: "USD", Tolerance: 0.01, MarketFallback: true},
+	}
+
+	cases := []struct {
+		name       string
+		coinID     string
+		marketUSD  string // stored daily price, empty for none
+		wantFiat   string
+		wantMethod domain.PricingMethod
+	}{
+		{name: "no depeg check", coinID: "tether", wantFiat: "90", wantMethod: domain.PricingMethodPeg},
+		{name: "market within tolerance", coinID: "usd-coin", marketUSD: "0.995", wantFiat: "90", wantMethod: domain.PricingMethodPeg},
+		{name: "market beyond tolerance", coinID: "usd-coin", marketUSD: "0.9", wantFiat: "81", wantMethod: domain.PricingMethodMarketDepeg},
+		{name: "no market price to check", coinID: "usd-coin", wantFiat: "90", wantMethod: domain.PricingMethodPegUnchecked},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakePriceRepo{}
+			if tc.marketUSD != "" {
+				repo.store(tc.coinID, valuationDay, tc.marketUSD, 24*time.Hour)
+			}
+			uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo}, pegs, nil)
+
+			got, err := uc.GetHistoricalPrices(context.Background(), "RUB", []domain.PriceKey{{CoinID: tc.coinID, BucketStartUtc: valuationTx}}, storedOnly())
+			if err != nil {
+				t.Fatalf("GetHistoricalPrices() error = %v", err)
+			}
+			if got[0].Err != nil {
+				t.Fatalf("leg error = %v, want nil", got[0].Err)
+			}
+			if got[0].Method != tc.wantMethod || !got[0].Fiat.Equal(decimal.RequireFromString(tc.wantFiat)) {
+				t.Fatalf("valuation = %s via %d, want %s via %d", got[0].Fiat, got[0].Method, tc.wantFiat, tc.wantMethod)
+			}
+		})
+	}
+}
+
+func TestValuateDerivativeThroughUnderlying(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakePriceRepo{}
+	repo.store("ethereum", valuationDay, "3000", 24*time.Hour)
+	uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo}, nil, []pricing.DerivativeAsset{
+		{CoinID: "wrapped-steth", Underlying: "ethereum", Ratio: 1, Series: []pricing.RatioPoint{{From: valuationDay, Ratio: 1.15}}},
+	})
+
+	keys := []domain.PriceKey{
+		{CoinID: "wrapped-steth", BucketStartUtc: valuationTx},
+		{CoinID: "ethereum", BucketStartUtc: valuationTx},
+		{CoinID: "wrapped-steth", BucketStartUtc: valuationTx.AddDate(0, 0, 1)}, // underlying has no price that day
+	}
+	got, err := uc.GetHistoricalPrices(context.Background(), "RUB", keys, storedOnly())
+	if err != nil {
+		t.Fatalf("GetHistoricalPrices() error = %v", err)
+	}
+
+	if got[0].Err != nil || got[0].Method != domain.PricingMethodDerivative || !got[0].Fiat.Equal(decimal.RequireFromString("310500")) {
+		t.Fatalf("derivative leg = %s via %d (err %v), want 310500 via derivative", got[0].Fiat, got[0].Method, got[0].Err)
+	}
+	// provenance shows the underlying bucket and the price after the ratio
+	if p := got[0].Provenance; !p.BucketStartUtc.Equal(valuationDay) || p.PriceUsd == nil || !p.PriceUsd.Equal(decimal.RequireFromString("3450")) {
+		t.Fatalf("derivative provenance = %+v, want bucket %s and price 3450", p, valuationDay)
+	}
+	if got[1].Method != domain.PricingMethodMarket || !got[1].Fiat.Equal(decimal.RequireFromString("270000")) {
+		t.Fatalf("underlying leg = %s via %d, want 270000 via market", got[1].Fiat, got[1].Method)
+	}
+	if !errors.Is(got[2].Err, apperr.ErrPriceUnavailable) {
+		t.Fatalf("leg without underlying price error = %v, want %v", got[2].Err, apperr.ErrPriceUnavailable)
+	}
+}
+
+func TestValuatePricePoints(t *testing.T) {
+	t.Parallel()
+
+	// ten days later the tx is priced from hourly buckets, the 10:00 one contains it
+	repo := &fakePriceRepo{}
+	repo.store("bitcoin", valuationDay.Add(10*time.Hour), "100", time.Hour)
+	repo.store("bitcoin", valuationDay.Add(11*time.Hour), "110", time.Hour)
+	repo.store("bitcoin", valuationDay.Add(12*time.Hour), "120", time.Hour)
+	// twelve minutes later the 5-minute bucket from 10:40 is still open
+	recentTx := valuationDay.Add(10*time.Hour + 42*time.Minute)
+	repo.store("solana", valuationDay.Add(10*time.Hour+40*time.Minute), "101", 5*time.Minute)
+	uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo, FX: fakeFX{}}, nil, nil)
+
+	cases := []struct {
+		name   string
+		coinID string
+		point  domain.PricePoint
+		tx     time.Time
+		asOf   time.Time
+		want   string
+	}{
+		{name: "bucket open", point: domain.PricePointBucketOpen, tx: valuationTx, asOf: valuationTx.AddDate(0, 0, 10), want: "100"},
+		{name: "bucket close", point: domain.PricePointBucketClose, tx: valuationTx, asOf: valuationTx.AddDate(0, 0, 10), want: "110"},
+		{name: "interpolated halfway", point: domain.PricePointInterpolated, tx: valuationTx, asOf: valuationTx.AddDate(0, 0, 10), want: "105"},
+		{name: "daily average", point: domain.PricePointDailyAverage, tx: valuationTx, asOf: valuationTx.AddDate(0, 0, 10), want: "110"},
+		{name: "close of a bucket still open", coinID: "solana", point: domain.PricePointBucketClose, tx: recentTx, asOf: recentTx.Add(2 * time.Minute), want: "101"},
+		{name: "interpolated in a bucket still open", coinID: "solana", point: domain.PricePointInterpolated, tx: recentTx, asOf: recentTx.Add(2 * time.Minute), want: "101"},
+	}
+
+	for _, tc := range cases {
+		coinID := tc.coinID
+		if coinID == "" {
+			coinID = "bitcoin"
+		}
+		opts := domain.ValuationOptions{PricePoint: tc.point, Lookup: domain.LookupPolicy{AsOf: tc.asOf}}
+		got, err := uc.GetHistoricalPrices(context.Background(), "USD", []domain.PriceKey{{CoinID: coinID, BucketStartUtc: tc.tx}}, opts)
+		if err != nil {
+			t.Fatalf("%s: GetHistoricalPrices() error = %v", tc.name, err)
+		}
+		if got[0].Err != nil || !got[0].Fiat.Equal(decimal.RequireFromString(tc.want)) {
+			t.Fatalf("%s: valuation = %s (err %v), want %s", tc.name, got[0].Fiat, got[0].Err, tc.want)
+		}
+	}
+}
+
+func TestValuateDailyAverageProvenance(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakePriceRepo{}
+	repo.store("bitcoin", valuationDay, "100", 24*time.Hour)
+	uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo, FX: fakeFX{}}, nil, nil)
+
+	opts := storedOnly()
+	opts.PricePoint = domain.PricePointDailyAverage
+	got, err := uc.GetHistoricalPrices(context.Background(), "USD", []domain.PriceKey{
+		{CoinID: "bitcoin", BucketStartUtc: valuationTx},
+		{CoinID: "bitcoin", BucketStartUtc: valuationTx.AddDate(0, 0, 1)},
+	}, opts)
+	if err != nil {
+		t.Fatalf("GetHistoricalPrices() error = %v", err)
+	}
+
+	// the whole day was averaged, whatever bucket the tx fell in
+	if p := got[0].Provenance; !p.BucketStartUtc.Equal(valuationDay) || p.GranularitySeconds != 86400 {
+		t.Fatalf("provenance = %+v, want the day %s with 86400s", p, valuationDay)
+	}
+	if !errors.Is(got[1].Err, apperr.ErrPriceUnavailable) {
+		t.Fatalf("day without buckets error = %v, want %v", got[1].Err, apperr.ErrPriceUnavailable)
+	}
+}
+
+func TestValuateNearestAndCoarserLookups(t *testing.T) {
+	t.Parallel()
+
+	// hourly buckets are wanted ten days later; 10:00 is missing, 08:00 and the whole day are stored
+	repo := &fakePriceRepo{}
+	repo.store("bitcoin", valuationDay.Add(8*time.Hour), "80", time.Hour)
+	repo.store("bitcoin", valuationDay, "95", 24*time.Hour)
+	uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo, FX: fakeFX{}}, nil, nil)
+	asOf := valuationTx.AddDate(0, 0, 10)
+
+	cases := []struct {
+		name   string
+		lookup domain.LookupPolicy
+		want   string // empty when the leg has no price
+	}{
+		{name: "exact bucket only", lookup: domain.LookupPolicy{}},
+		{name: "nearest within tolerance", lookup: domain.LookupPolicy{Nearest: true, Tolerance: 2 * time.Hour}, want: "80"},
+		{name: "nearest beyond tolerance", lookup: domain.LookupPolicy{Nearest: true, Tolerance: time.Hour}},
+		{name: "coarser bucket containing it", lookup: domain.LookupPolicy{AcceptCoarser: true}, want: "95"},
+		{name: "nearest first, then coarser", lookup: domain.LookupPolicy{Nearest: true, Tolerance: 2 * time.Hour, AcceptCoarser: true}, want: "80"},
+		{name: "coarser when nearest misses", lookup: domain.LookupPolicy{Nearest: true, Tolerance: time.Hour, AcceptCoarser: true}, want: "95"},
+	}
+
+	for _, tc := range cases {
+		tc.lookup.AsOf = asOf
+		got, err := uc.GetHistoricalPrices(context.Background(), "USD", []domain.PriceKey{{CoinID: "bitcoin", BucketStartUtc: valuationTx}}, domain.ValuationOptions{Lookup: tc.lookup})
+		if err != nil {
+			t.Fatalf("%s: GetHistoricalPrices() error = %v", tc.name, err)
+		}
+		if tc.want == "" {
+			if !errors.Is(got[0].Err, apperr.ErrPriceUnavailable) {
+				t.Fatalf("%s: leg error = %v, want %v", tc.name, got[0].Err, apperr.ErrPriceUnavailable)
+			}
+			continue
+		}
+		if got[0].Err != nil || !got[0].Fiat.Equal(decimal.RequireFromString(tc.want)) {
+			t.Fatalf("%s: valuation = %s (err %v), want %s", tc.name, got[0].Fiat, got[0].Err, tc.want)
+		}
+		// any bucket but the exact one is reported as lower precision
+		if !got[0].LowerPrecision {
+			t.Fatalf("%s: LowerPrecision = false, want true", tc.name)
+		}
+	}
+}
+
+func TestValuationProvenance(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakePriceRepo{}
+	repo.store("bitcoin", valuationDay, "60000", 24*time.Hour)
+	uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo}, []pricing.PeggedAsset{{CoinID: "tether", Peg: "USD"}}, nil)
+
+	coins, err := uc.GetHistoricalPrices(context.Background(), "RUB", []domain.PriceKey{
+		{CoinID: "bitcoin", BucketStartUtc: valuationTx},
+		{CoinID: "tether", BucketStartUtc: valuationTx},
+	}, storedOnly())
+	if err != nil {
+		t.Fatalf("GetHistoricalPrices() error = %v", err)
+	}
+	fiats, err := uc.GetFiatRates(context.Background(), "RUB", []domain.PriceKey{{CoinID: "USD", BucketStartUtc: valuationDay}}, storedOnly())
+	if err != nil {
+		t.Fatalf("GetFiatRates() error = %v", err)
+	}
+
+	price := decimal.RequireFromString("60000")
+	ninety := decimal.RequireFromString("90")
+	cases := []struct {
+		name string
+		got  domain.Provenance
+		want domain.Provenance
+	}{
+		{name: "market leg", got: coins[0].Provenance, want: domain.Provenance{
+			BucketStartUtc:     valuationDay,
+			GranularitySeconds: 86400,
+			Provider:           domain.ProviderCoinGecko,
+			PriceUsd:           &price,
+			FetchedAt:          valuationDay.AddDate(0, 0, 1),
+			FXRate:             ninety,
+			FXEffectiveAt:      valuationDay,
+		}},
+		// pegged and fiat legs use no market bucket, only the FX rate
+		{name: "pegged leg", got: coins[1].Provenance, want: domain.Provenance{FXRate: ninety, FXEffectiveAt: valuationDay}},
+		{name: "fiat leg", got: fiats[0].Provenance, want: domain.Provenance{FXRate: ninety, FXEffectiveAt: valuationDay}},
+	}
+
+	for _, tc := range cases {
+		g, w := tc.got, tc.want
+		samePrice := (g.PriceUsd == nil) == (w.PriceUsd == nil) && (g.PriceUsd == nil || g.PriceUsd.Equal(*w.PriceUsd))
+		if !g.BucketStartUtc.Equal(w.BucketStartUtc) || g.GranularitySeconds != w.GranularitySeconds || g.Provider != w.Provider ||
+			!samePrice || !g.FetchedAt.Equal(w.FetchedAt) || !g.FXRate.Equal(w.FXRate) || !g.FXEffectiveAt.Equal(w.FXEffectiveAt) {
+			t.Fatalf("%s: provenance = %+v, want %+v", tc.name, g, w)
+		}
+	}
+}
+
+// revisionRepo answers market reads from the revisions fetched at or before asOf.
+type revisionRepo struct {
+	domain.HistoricalPriceRepo
+	revisions []domain.HistoricalPrice // in fetch order
+}
+
+func (r *revisionRepo) GetBatch(_ context.Context, keys []domain.PriceKey, asOf time.Time) ([]domain.HistoricalPrice, error) {
+	out := make([]domain.HistoricalPrice, len(keys))
+	for i, k := range keys {
+		for _, p := range r.revisions {
+			if p.CoinID == k.CoinID && p.Time.Equal(k.BucketStartUtc) && (asOf.IsZero() || !p.FetchedAt.After(asOf)) {
+				out[i] = p
+			}
+		}
+	}
+	return out, nil
+}
+
+func TestValuateAsOf(t *testing.T) {
+	t.Parallel()
+
+	revision := func(price string, fetched time.Time) domain.HistoricalPrice {
+		v := decimal.RequireFromString(price)
+		g := 86400
+		return domain.HistoricalPrice{CoinID: "bitcoin", Time: valuationDay, PriceUsd: &v, GranularitySeconds: &g, FetchedAt: fetched}
+	}
+	// fetched long after the day, so reads as of then look for daily buckets
+	firstFetch := valuationDay.AddDate(1, 0, 0)
+	correction := valuationDay.AddDate(1, 1, 0)
+	repo := &revisionRepo{revisions: []domain.HistoricalPrice{revision("60000", firstFetch), revision("60500", correction)}}
+
+	var calls atomic.Int32
+	cg := newTestCGClient(t, fakeMarketChart(t, valuationDay.AddDate(-1, 0, 0), &calls).URL)
+	uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo, GapRepo: newFakeGapRepo(), CGClient: cg}, nil, nil)
+
+	cases := []struct {
+		name string
+		asOf time.Time
+		want string // empty when the leg must fail
+	}{
+		{name: "before the first fetch", asOf: firstFetch.Add(-time.Second)},
+		{name: "first revision", asOf: firstFetch, want: "5400000"},
+		{name: "before the correction", asOf: correction.Add(-time.Second), want: "5400000"},
+		{name: "after the correction", asOf: correction.AddDate(0, 0, 1), want: "5445000"},
+	}
+	for _, tc := range cases {
+		opts := domain.ValuationOptions{Lookup: domain.LookupPolicy{AsOf: tc.asOf}}
+		vals, err := uc.GetHistoricalPrices(context.Background(), "RUB", []domain.PriceKey{{CoinID: "bitcoin", BucketStartUtc: valuationTx}}, opts)
+		if err != nil {
+			t.Fatalf("%s: GetHistoricalPrices() error = %v", tc.name, err)
+		}
+		v := vals[0]
+		if tc.want == "" {
+			if !errors.Is(v.Err, apperr.ErrPriceUnavailable) {
+				t.Fatalf("%s: leg = %+v, want ErrPriceUnavailable", tc.name, v)
+			}
+			continue
+		}
+		if v.Err != nil || !v.Fiat.Equal(decimal.RequireFromString(tc.want)) || v.Provenance.FetchedAt.After(tc.asOf) {
+			t.Fatalf("%s: leg = %s, %v (fetched %s), want %s", tc.name, v.Fiat, v.Err, v.Provenance.FetchedAt, tc.want)
+		}
+	}
+
+	// an as-of read answers "what we would have used then", so it never asks the provider
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("provider calls = %d, want none", n)
+	}
+}
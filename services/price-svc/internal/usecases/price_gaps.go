@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+)
+
+// fetchKey is one provider call: a full UTC day of a coin at the given granularity.
+type fetchKey struct {
+	coinID   string
+	dayStart time.Time
+	g        time.Duration
+}
+
+// listingProbeWindow is how far a listing probe reaches on each side of the day that came back
+// empty. Starting before the day tells a coin not yet listed from a gap in its history; a coin
+// whose prices stop for longer than the window before the day is taken as listed after the gap,
+// until the listing is probed again once gapTTL passes.
+const listingProbeWindow = 365 * 24 * time.Hour
+
+// knownNoData returns the planned fetches that are known to come back empty:
+// the day predates the coin's first available price, or an unexpired gap was recorded for it.
+// Listings are trusted for gapTTL, like gaps, and probed again afterwards.
+func (u *historicalPriceUC) knownNoData(ctx context.Context, plan map[fetchKey][]int) (map[fetchKey]error, error) {
+	out := make(map[fetchKey]error)
+	if len(plan) == 0 {
+		return out, nil
+	}
+
+	coinSet := make(map[string]struct{})
+	dayKeys := make([]domain.PriceKey, 0, len(plan))
+	for fk := range plan {
+		coinSet[fk.coinID] = struct{}{}
+		dayKeys = append(dayKeys, domain.PriceKey{CoinID: fk.coinID, BucketStartUtc: fk.dayStart})
+	}
+	coinIDs := make([]string, 0, len(coinSet))
+	for id := range coinSet {
+		coinIDs = append(coinIDs, id)
+	}
+
+	listings, err := u.gapRepo.GetListings(ctx, coinIDs)
+	if err != nil {
+		return nil, fmt.Errorf("gapRepo.GetListings: %w", err)
+	}
+	firstPrice := make(map[string]time.Time, len(listings))
+	for _, l := range listings {
+		if u.listingFresh(l) {
+			firstPrice[l.CoinID] = l.FirstPriceAt
+		}
+	}
+
+	gaps, err := u.gapRepo.GetActiveGaps(ctx, dayKeys)
+	if err != nil {
+		return nil, fmt.Errorf("gapRepo.GetActiveGaps: %w", err)
+	}
+	type gapKey struct {
+		coinID string
+		day    time.Time
+	}
+	gapReason := make(map[gapKey]string, len(gaps))
+	for _, g := range gaps {
+		gapReason[gapKey{coinID: g.CoinID, day: g.Day.UTC()}] = g.Reason
+	}
+
+	for fk := range plan {
+		if first, ok := firstPrice[fk.coinID]; ok && fk.dayStart.Before(truncateDayUTC(first)) {
+			out[fk] = fmt.Errorf("coin=%s day=%s, first price at %s: %w",
+				fk.coinID, fk.dayStart.Format(time.DateOnly), first.Format(time.DateOnly), apperr.ErrBeforeListing)
+			continue
+		}
+		if reason, ok := gapReason[gapKey{coinID: fk.coinID, day: fk.dayStart}]; ok {
+			out[fk] = fmt.Errorf("coin=%s day=%s: %s: %w", fk.coinID, fk.dayStart.Format(time.DateOnly), reason, apperr.ErrNoProviderData)
+		}
+	}
+
+	return out, nil
+}
+
+// noteEmptyDay handles an empty provider answer for a coin day. Without a fresh listing it probes the
+// listingProbeWindow around the day for the coin's first price, so every earlier day is answered
+// from coin_listings afterwards; otherwise the day is remembered as a gap until gapTTL passes.
+func (u *historicalPriceUC) noteEmptyDay(ctx context.Context, coinID string, dayStartUTC time.Time, cause error) error {
+	listings, err := u.gapRepo.GetListings(ctx, []string{coinID})
+	if err != nil {
+		return fmt.Errorf("gapRepo.GetListings: %w", err)
+	}
+
+	if len(listings) == 0 || !u.listingFresh(listings[0]) {
+		from, to := listingProbeRange(dayStartUTC, time.Now().UTC())
+		resp, err := u.cgClient.CoinsMarketChartRange(ctx, coinID, USD, from, to, nil)
+		if err != nil {
+			u.logger.Warn("listing probe failed coin=%s: %v", coinID, err)
+		} else if first, ok := probedFirstPrice(resp.Prices, from); ok {
+			if err := u.gapRepo.UpsertListing(ctx, domain.CoinListing{CoinID: coinID, FirstPriceAt: first}); err != nil {
+				return fmt.Errorf("gapRepo.UpsertListing: %w", err)
+			}
+			if dayStartUTC.Before(truncateDayUTC(first)) {
+				return fmt.Errorf("coin=%s day=%s, first price at %s: %w",
+					coinID, dayStartUTC.Format(time.DateOnly), first.Format(time.DateOnly), apperr.ErrBeforeListing)
+			}
+		}
+	}
+
+	// the provider may still publish today's prices, only past days are worth remembering
+	if dayStartUTC.Before(truncateDayUTC(time.Now())) {
+		gap := domain.PriceGap{
+			CoinID:    coinID,
+			Day:       dayStartUTC,
+			Reason:    "empty provider response",
+			ExpiresAt: time.Now().Add(u.gapTTL),
+		}
+		if err := u.gapRepo.UpsertGap(ctx, gap); err != nil {
+			return fmt.Errorf("gapRepo.UpsertGap: %w", err)
+		}
+	}
+
+	return cause
+}
+
+// listingProbeRange returns the listing probe window around an empty day, ending no later than now.
+func listingProbeRange(dayStartUTC, now time.Time) (time.Time, time.Time) {
+	from, to := dayStartUTC.Add(-listingProbeWindow), dayStartUTC.Add(listingProbeWindow)
+	if to.After(now) {
+		to = now
+	}
+	return from, to
+}
+
+// probedFirstPrice returns the first point of a listing probe started at from. Daily points of a
+// coin priced since before the probe start come within a day of it, so such a point proves no
+// listing date.
+func probedFirstPrice(prices [][]float64, from time.Time) (time.Time, bool) {
+	if len(prices) == 0 || len(prices[0]) < 2 {
+		return time.Time{}, false
+	}
+	first := time.UnixMilli(int64(prices[0][0])).UTC()
+	return first, first.After(from.Add(24 * time.Hour))
+}
+
+func (u *historicalPriceUC) listingFresh(l domain.CoinListing) bool {
+	return time.Since(l.CheckedAt) < u.gapTTL
+}
+
+func (u *historicalPriceUC) PurgeExpiredGaps(ctx context.Context) (int64, error) {
+	n, err := u.gapRepo.DeleteExpiredGaps(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("gapRepo.DeleteExpiredGaps: %w", err)
+	}
+	return n, nil
+}
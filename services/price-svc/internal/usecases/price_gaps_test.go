@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+)
+
+// fakeGapRepo keeps gaps and listings in memory; expired gaps are never returned.
+type fakeGapRepo struct {
+	gaps     map[domain.PriceKey]domain.PriceGap
+	listings map[string]domain.CoinListing
+}
+
+func newFakeGapRepo() *fakeGapRepo {
+	return &fakeGapRepo{gaps: make(map[domain.PriceKey]domain.PriceGap), listings: make(map[string]domain.CoinListing)}
+}
+
+func (r *fakeGapRepo) UpsertGap(_ context.Context, g domain.PriceGap) error {
+	r.gaps[domain.PriceKey{CoinID: g.CoinID, BucketStartUtc: g.Day}] = g
+	return nil
+}
+
+func (r *fakeGapRepo) GetActiveGaps(_ context.Context, dayKeys []domain.PriceKey) ([]domain.PriceGap, error) {
+	var out []domain.PriceGap
+	for _, k := range dayKeys {
+		if g, ok := r.gaps[k]; ok && g.ExpiresAt.After(time.Now()) {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeGapRepo) DeleteExpiredGaps(context.Context) (int64, error) {
+	var n int64
+	for k, g := range r.gaps {
+		if !g.ExpiresAt.After(time.Now()) {
+			delete(r.gaps, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeGapRepo) UpsertListing(_ context.Context, l domain.CoinListing) error {
+	l.CheckedAt = time.Now()
+	r.listings[l.CoinID] = l
+	return nil
+}
+
+func (r *fakeGapRepo) GetListings(_ context.Context, coinIDs []string) ([]domain.CoinListing, error) {
+	var out []domain.CoinListing
+	for _, id := range coinIDs {
+		if l, ok := r.listings[id]; ok {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// fakeMarketChart serves market_chart/range with daily points from firstDay on, counting calls.
+func fakeMarketChart(t *testing.T, firstDay time.Time, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var from, to int64
+		fmt.Sscan(r.URL.Query().Get("from"), &from)
+		fmt.Sscan(r.URL.Query().Get("to"), &to)
+
+		day := truncateDayUTC(time.Unix(from, 0))
+		if day.Before(firstDay) {
+			day = firstDay
+		}
+		fmt.Fprint(w, `{"prices":[`)
+		for sep := ""; !day.After(time.Unix(to, 0)); day = day.AddDate(0, 0, 1) {
+			fmt.Fprintf(w, "%s[%d,1.5]", sep, day.UnixMilli())
+			sep = ","
+		}
+		fmt.Fprint(w, `]}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestKnownNoData(t *testing.T) {
+	t.Parallel()
+
+	listed := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	gaps := newFakeGapRepo()
+	gaps.listings["fresh"] = domain.CoinListing{CoinID: "fresh", FirstPriceAt: listed.Add(12 * time.Hour), CheckedAt: time.Now()}
+	gaps.listings["stale"] = domain.CoinListing{CoinID: "stale", FirstPriceAt: listed, CheckedAt: time.Now().Add(-2 * time.Hour)}
+	gaps.gaps[domain.PriceKey{CoinID: "fresh", BucketStartUtc: listed.AddDate(0, 0, 5)}] = domain.PriceGap{CoinID: "fresh", Day: listed.AddDate(0, 0, 5), ExpiresAt: time.Now().Add(time.Hour)}
+	gaps.gaps[domain.PriceKey{CoinID: "fresh", BucketStartUtc: listed.AddDate(0, 0, 6)}] = domain.PriceGap{CoinID: "fresh", Day: listed.AddDate(0, 0, 6), ExpiresAt: time.Now().Add(-time.Hour)}
+	uc := newTestPriceUC(t, HistoricalPriceDeps{GapRepo: gaps}, nil, nil)
+
+	cases := []struct {
+		name string
+		key  fetchKey
+		want error // nil when the day must be fetched
+	}{
+		{name: "day before a fresh listing", key: fetchKey{coinID: "fresh", dayStart: listed.AddDate(0, 0, -1)}, want: apperr.ErrBeforeListing},
+		{name: "listing day itself", key: fetchKey{coinID: "fresh", dayStart: listed}},
+		{name: "day before a stale listing", key: fetchKey{coinID: "stale", dayStart: listed.AddDate(0, 0, -1)}},
+		{name: "active gap", key: fetchKey{coinID: "fresh", dayStart: listed.AddDate(0, 0, 5)}, want: apperr.ErrNoProviderData},
+		{name: "expired gap", key: fetchKey{coinID: "fresh", dayStart: listed.AddDate(0, 0, 6)}},
+	}
+
+	plan := make(map[fetchKey][]int)
+	for i, tc := range cases {
+		plan[tc.key] = []int{i}
+	}
+	got, err := uc.knownNoData(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("knownNoData() error = %v", err)
+	}
+	for _, tc := range cases {
+		err, known := got[tc.key]
+		if tc.want == nil && known {
+			t.Fatalf("%s: known no data (%v), want a fetch", tc.name, err)
+		}
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			t.Fatalf("%s: error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestNoteEmptyDayProbesListing(t *testing.T) {
+	t.Parallel()
+
+	// the coin is first priced 30 days after the day that came back empty
+	empty := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	listed := empty.AddDate(0, 0, 30)
+	var calls atomic.Int32
+	gaps := newFakeGapRepo()
+	uc := newTestPriceUC(t, HistoricalPriceDeps{GapRepo: gaps, CGClient: newTestCGClient(t, fakeMarketChart(t, listed, &calls).URL)}, nil, nil)
+
+	err := uc.noteEmptyDay(context.Background(), "newcoin", empty, apperr.ErrNoProviderData)
+	if !errors.Is(err, apperr.ErrBeforeListing) {
+		t.Fatalf("noteEmptyDay() error = %v, want %v", err, apperr.ErrBeforeListing)
+	}
+	if l, ok := gaps.listings["newcoin"]; !ok || !l.FirstPriceAt.Equal(listed) {
+		t.Fatalf("listing = %+v, %v, want first price at %s", l, ok, listed)
+	}
+	if len(gaps.gaps) != 0 {
+		t.Fatalf("gaps = %v, want none before the listing", gaps.gaps)
+	}
+
+	// a fresh listing answers later empty days without another probe
+	if err := uc.noteEmptyDay(context.Background(), "newcoin", listed.AddDate(0, 0, 10), apperr.ErrNoProviderData); !errors.Is(err, apperr.ErrNoProviderData) {
+		t.Fatalf("noteEmptyDay() after listing error = %v, want %v", err, apperr.ErrNoProviderData)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("provider calls = %d, want 1", n)
+	}
+	if len(gaps.gaps) != 1 {
+		t.Fatalf("gaps = %v, want the empty day after the listing", gaps.gaps)
+	}
+}
+
+func TestNoteEmptyDayGapInHistory(t *testing.T) {
+	t.Parallel()
+
+	// prices exist since long before the probe window, so the empty day is a gap, not a listing
+	var calls atomic.Int32
+	gaps := newFakeGapRepo()
+	uc := newTestPriceUC(t, HistoricalPriceDeps{GapRepo: gaps, CGClient: newTestCGClient(t, fakeMarketChart(t, time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), &calls).URL)}, nil, nil)
+
+	empty := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := uc.noteEmptyDay(context.Background(), "bitcoin", empty, apperr.ErrNoProviderData); !errors.Is(err, apperr.ErrNoProviderData) {
+		t.Fatalf("noteEmptyDay() error = %v, want %v", err, apperr.ErrNoProviderData)
+	}
+	if _, ok := gaps.listings["bitcoin"]; ok {
+		t.Fatalf("listing recorded for a coin priced before the probe window")
+	}
+	if _, ok := gaps.gaps[domain.PriceKey{CoinID: "bitcoin", BucketStartUtc: empty}]; !ok {
+		t.Fatalf("gaps = %v, want the empty day", gaps.gaps)
+	}
+}
+
+func TestListingProbeRange(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	if from, to := listingProbeRange(day, now); !from.Equal(day.Add(-listingProbeWindow)) || !to.Equal(day.Add(listingProbeWindow)) {
+		t.Fatalf("listingProbeRange(%s) = %s..%s, want the window on both sides", day, from, to)
+	}
+	recent := now.AddDate(0, 0, -3)
+	if _, to := listingProbeRange(recent, now); !to.Equal(now) {
+		t.Fatalf("listingProbeRange(%s) ends %s, want now %s", recent, to, now)
+	}
+}
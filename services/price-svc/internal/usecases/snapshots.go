@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+)
+
+type valuateFunc func(ctx context.Context, fiatCurrency string, keys []domain.PriceKey, opts domain.ValuationOptions) ([]domain.Valuation, error)
+
+// withSnapshot answers keys already valued under opts.SnapshotID from the snapshot and values
+// only the rest, storing them there. Failed legs are not stored, so they may succeed on a retry.
+// Without a snapshot ID it just values the keys.
+func (u *historicalPriceUC) withSnapshot(ctx context.Context, kind domain.SnapshotKind, fiatCurrency string, keys []domain.PriceKey, opts domain.ValuationOptions, valuate valuateFunc) ([]domain.Valuation, error) {
+	if opts.SnapshotID == "" {
+		return valuate(ctx, fiatCurrency, keys, opts)
+	}
+
+	if err := u.ensureSnapshot(ctx, fiatCurrency, opts); err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.Valuation, len(keys))
+
+	stored, err := u.snapshotRepo.GetValuations(ctx, opts.TenantID, opts.SnapshotID, kind, keys)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotRepo.GetValuations: %w", err)
+	}
+
+	var missingIdx []int
+	var missingKeys []domain.PriceKey
+	for i, v := range stored {
+		if v != nil {
+			out[i] = *v
+			continue
+		}
+		missingIdx = append(missingIdx, i)
+		missingKeys = append(missingKeys, keys[i])
+	}
+	if len(missingKeys) == 0 {
+		return out, nil
+	}
+
+	fresh, err := valuate(ctx, fiatCurrency, missingKeys, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(fresh) != len(missingKeys) {
+		return nil, fmt.Errorf("pricing invariant violated: got %d valuations for %d keys", len(fresh), len(missingKeys))
+	}
+
+	var saveKeys []domain.PriceKey
+	var saveVals []domain.Valuation
+	for j, v := range fresh {
+		if v.Err == nil {
+			saveKeys = append(saveKeys, missingKeys[j])
+			saveVals = append(saveVals, v)
+		}
+	}
+	if err := u.snapshotRepo.SaveValuations(ctx, opts.TenantID, opts.SnapshotID, kind, saveKeys, saveVals); err != nil {
+		return nil, fmt.Errorf("snapshotRepo.SaveValuations: %w", err)
+	}
+
+	// a concurrent call may have stored some keys first; its values win, so every caller sees the same numbers
+	saved, err := u.snapshotRepo.GetValuations(ctx, opts.TenantID, opts.SnapshotID, kind, missingKeys)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotRepo.GetValuations (after save): %w", err)
+	}
+	for j, i := range missingIdx {
+		if saved[j] != nil {
+			out[i] = *saved[j]
+			continue
+		}
+		out[i] = fresh[j]
+	}
+
+	return out, nil
+}
+
+func (u *historicalPriceUC) PinResolutions(ctx context.Context, fiatCurrency, source string, assets []domain.AssetRef, legs []domain.AssetLeg, resolved []domain.SymbolResolution, opts domain.ValuationOptions) ([]domain.SymbolResolution, error) {
+	if opts.SnapshotID == "" || len(legs) == 0 {
+		return resolved, nil
+	}
+	if len(resolved) != len(legs) {
+		return nil, fmt.Errorf("resolution invariant violated: got %d resolutions for %d legs", len(resolved), len(legs))
+	}
+
+	if u.contextTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+		defer cancel()
+	}
+
+	if err := u.ensureSnapshot(ctx, fiatCurrency, opts); err != nil {
+		return nil, err
+	}
+
+	// store first and read back: a concurrent call may have pinned some legs first, and its
+	// coins win, so every caller prices the same ones
+	if err := u.snapshotRepo.SaveResolutions(ctx, opts.TenantID, opts.SnapshotID, source, assets, legs, resolved); err != nil {
+		return nil, fmt.Errorf("snapshotRepo.SaveResolutions: %w", err)
+	}
+	pinned, err := u.snapshotRepo.GetResolutions(ctx, opts.TenantID, opts.SnapshotID, source, assets, legs)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotRepo.GetResolutions: %w", err)
+	}
+
+	out := make([]domain.SymbolResolution, len(legs))
+	for i, p := range pinned {
+		if p != nil {
+			out[i] = *p
+			continue
+		}
+		out[i] = resolved[i]
+	}
+	return out, nil
+}
+
+// ensureSnapshot creates the snapshot on first use and checks later calls use the same settings,
+// otherwise the stored values would not answer what was asked.
+func (u *historicalPriceUC) ensureSnapshot(ctx context.Context, fiatCurrency string, opts domain.ValuationOptions) error {
+	lookup := opts.Lookup
+	lookup.Tolerance = lookup.Tolerance.Truncate(time.Second)
+	lookup.AsOf = lookup.AsOf.Truncate(time.Microsecond)
+
+	if err := u.snapshotRepo.Create(ctx, domain.ValuationSnapshot{
+		TenantID:     opts.TenantID,
+		ID:           opts.SnapshotID,
+		FiatCurrency: fiatCurrency,
+		PricePoint:   opts.PricePoint,
+		Lookup:       lookup,
+	}); err != nil {
+		return fmt.Errorf("snapshotRepo.Create: %w", err)
+	}
+
+	snap, err := u.snapshotRepo.Get(ctx, opts.TenantID, opts.SnapshotID)
+	if err != nil {
+		return fmt.Errorf("snapshotRepo.Get: %w", err)
+	}
+
+	if !snap.Matches(fiatCurrency, opts) {
+		return fmt.Errorf("%w: snapshot %s was taken with fiat=%s and different valuation settings", apperr.ErrConflict, snap.ID, snap.FiatCurrency)
+	}
+
+	return nil
+}
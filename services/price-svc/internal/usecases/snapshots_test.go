@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// fakeSnapshotRepo keeps snapshots in memory with the write-once rules of the real tables.
+type fakeSnapshotRepo struct {
+	mu          sync.Mutex
+	snapshots   map[snapshotRef]domain.ValuationSnapshot
+	valuations  map[snapshotValKey]domain.Valuation
+	resolutions map[snapshotLegKey]domain.SymbolResolution
+}
+
+type snapshotRef struct {
+	tenant uuid.UUID
+	id     string
+}
+
+type snapshotValKey struct {
+	snapshotRef
+	kind domain.SnapshotKind
+	key  domain.PriceKey
+}
+
+type snapshotLegKey struct {
+	snapshotRef
+	source string
+	asset  domain.AssetRef
+	at     time.Time
+}
+
+func newFakeSnapshotRepo() *fakeSnapshotRepo {
+	return &fakeSnapshotRepo{
+		snapshots:   make(map[snapshotRef]domain.ValuationSnapshot),
+		valuations:  make(map[snapshotValKey]domain.Valuation),
+		resolutions: make(map[snapshotLegKey]domain.SymbolResolution),
+	}
+}
+
+func (r *fakeSnapshotRepo) Create(_ context.Context, s domain.ValuationSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ref := snapshotRef{s.TenantID, s.ID}
+	if _, ok := r.snapshots[ref]; !ok {
+		r.snapshots[ref] = s
+	}
+	return nil
+}
+
+func (r *fakeSnapshotRepo) Get(_ context.Context, tenantID uuid.UUID, id string) (domain.ValuationSnapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.snapshots[snapshotRef{tenantID, id}]
+	if !ok {
+		return domain.ValuationSnapshot{}, apperr.ErrNotFound
+	}
+	return s, nil
+}
+
+func (r *fakeSnapshotRepo) GetValuations(_ context.Context, tenantID uuid.UUID, snapshotID string, kind domain.SnapshotKind, keys []domain.PriceKey) ([]*domain.Valuation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*domain.Valuation, len(keys))
+	for i, k := range keys {
+		if v, ok := r.valuations[snapshotValKey{snapshotRef{tenantID, snapshotID}, kind, k}]; ok {
+			out[i] = &v
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeSnapshotRepo) SaveValuations(_ context.Context, tenantID uuid.UUID, snapshotID string, kind domain.SnapshotKind, keys []domain.PriceKey, vals []domain.Valuation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, k := range keys {
+		key := snapshotValKey{snapshotRef{tenantID, snapshotID}, kind, k}
+		if _, ok := r.valuations[key]; !ok {
+			r.valuations[key] = vals[i]
+		}
+	}
+	return nil
+}
+
+func (r *fakeSnapshotRepo) GetResolutions(_ context.Context, tenantID uuid.UUID, snapshotID, source string, assets []domain.AssetRef, legs []domain.AssetLeg) ([]*domain.SymbolResolution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*domain.SymbolResolution, len(legs))
+	for i, l := range legs {
+		if res, ok := r.resolutions[snapshotLegKey{snapshotRef{tenantID, snapshotID}, source, assets[l.Asset], l.At}]; ok {
+			out[i] = &res
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeSnapshotRepo) SaveResolutions(_ context.Context, tenantID uuid.UUID, snapshotID, source string, assets []domain.AssetRef, legs []domain.AssetLeg, resolved []domain.SymbolResolution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, l := range legs {
+		key := snapshotLegKey{snapshotRef{tenantID, snapshotID}, source, assets[l.Asset], l.At}
+		if _, ok := r.resolutions[key]; !ok && resolved[i].Err == nil {
+			r.resolutions[key] = resolved[i]
+		}
+	}
+	return nil
+}
+
+// snapshotOpts reads stored prices only, at a fixed time so every call matches the snapshot.
+func snapshotOpts(tenantID uuid.UUID, id string) domain.ValuationOptions {
+	return domain.ValuationOptions{
+		Lookup:     domain.LookupPolicy{AsOf: valuationDay.AddDate(1, 0, 0)},
+		SnapshotID: id,
+		TenantID:   tenantID,
+	}
+}
+
+func TestSnapshotKeepsValues(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakePriceRepo{}
+	repo.store("bitcoin", valuationDay, "60000", 24*time.Hour)
+	uc := newTestPriceUC(t, HistoricalPriceDeps{Repo: repo, SnapshotRepo: newFakeSnapshotRepo()}, nil, nil)
+
+	tenantA, tenantB := uuid.New(), uuid.New()
+	keys := []domain.PriceKey{{CoinID: "bitcoin", BucketStartUtc: valuationTx}}
+	value := func(fiat string, opts domain.ValuationOptions) (decimal.Decimal, error) {
+		t.Helper()
+		vals, err := uc.GetHistoricalPrices(context.Background(), fiat, keys, opts)
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+		if vals[0].Err != nil {
+			t.Fatalf("leg error = %v", vals[0].Err)
+		}
+		return vals[0].Fiat, nil
+	}
+
+	first, err := value("RUB", snapshotOpts(tenantA, "report-2024"))
+	if err != nil {
+		t.Fatalf("first valuation error = %v", err)
+	}
+
+	// a re-fetched price changes fresh valuations but not the snapshot
+	repo.store("bitcoin", valuationDay, "61000", 24*time.Hour)
+	again, err := value("RUB", snapshotOpts(tenantA, "report-2024"))
+	if err != nil || !again.Equal(first) {
+		t.Fatalf("re-valuation = %s, %v, want %s", again, err, first)
+	}
+
+	// another tenant reusing the ID gets its own snapshot, with its own settings
+	other, err := value("USD", snapshotOpts(tenantB, "report-2024"))
+	if err != nil || !other.Equal(decimal.RequireFromString("61000")) {
+		t.Fatalf("other tenant's valuation = %s, %v, want 61000", other, err)
+	}
+
+	// the same tenant reusing the ID with other settings is refused
+	if _, err := value("USD", snapshotOpts(tenantA, "report-2024")); !errors.Is(err, apperr.ErrConflict) {
+		t.Fatalf("valuation with other fiat error = %v, want ErrConflict", err)
+	}
+}
+
+func TestPinResolutions(t *testing.T) {
+	t.Parallel()
+
+	uc := newTestPriceUC(t, HistoricalPriceDeps{SnapshotRepo: newFakeSnapshotRepo()}, nil, nil)
+	tenant := uuid.New()
+
+	assets := []domain.AssetRef{{Symbol: "UNI"}, {Symbol: "NEW"}}
+	legs := []domain.AssetLeg{{Asset: 0, At: valuationTx}, {Asset: 1, At: valuationTx}, {Asset: 0, At: valuationDay}}
+	pin := func(opts domain.ValuationOptions, resolved ...domain.SymbolResolution) []domain.SymbolResolution {
+		t.Helper()
+		out, err := uc.PinResolutions(context.Background(), "RUB", "binance", assets, legs, resolved, opts)
+		if err != nil {
+			t.Fatalf("PinResolutions() error = %v", err)
+		}
+		return out
+	}
+	unknown := domain.SymbolResolution{CanonicalSymbol: "NEW", Err: apperr.ErrUnknownSymbol}
+
+	opts := snapshotOpts(tenant, "report-2024")
+	pin(opts,
+		domain.SymbolResolution{CoinID: "uniswap", CanonicalSymbol: "UNI"},
+		unknown,
+		domain.SymbolResolution{CoinID: "uniswap", CanonicalSymbol: "UNI"},
+	)
+
+	// the tenant remapped UNI and NEW became known since
+	got := pin(opts,
+		domain.SymbolResolution{CoinID: "universe-token", CanonicalSymbol: "UNI"},
+		domain.SymbolResolution{CoinID: "new-coin", CanonicalSymbol: "NEW"},
+		domain.SymbolResolution{CoinID: "universe-token", CanonicalSymbol: "UNI"},
+	)
+	want := []string{"uniswap", "new-coin", "uniswap"}
+	for i, w := range want {
+		if got[i].CoinID != w || got[i].Err != nil {
+			t.Fatalf("leg %d = %+v, want pinned to %s", i, got[i], w)
+		}
+	}
+
+	// a mapping removed since still resolves to the pinned coin
+	got = pin(opts, unknown, unknown, unknown)
+	if got[0].CoinID != "uniswap" || got[0].Err != nil || got[1].CoinID != "new-coin" {
+		t.Fatalf("legs = %+v, want the pinned coins", got)
+	}
+
+	// outside the snapshot, or for another tenant, nothing is pinned
+	for _, o := range []domain.ValuationOptions{{}, snapshotOpts(uuid.New(), "report-2024")} {
+		got = pin(o, unknown, unknown, unknown)
+		if !errors.Is(got[0].Err, apperr.ErrUnknownSymbol) {
+			t.Fatalf("leg 0 under %+v = %+v, want resolved as is", o, got[0])
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+// maxImportIssues caps how many issues one import reports.
+const maxImportIssues = 1000
+
+var tenantSymbolCSVHeader = []string{"source", "symbol", "coin_id", "valid_from", "valid_to"}
+
+// tenantSymbolRecord is one mapping as bulk imports read and exports write it; empty bounds are open.
+type tenantSymbolRecord struct {
+	Source    string `json:"source"`
+	Symbol    string `json:"symbol"`
+	CoinID    string `json:"coin_id"`
+	ValidFrom string `json:"valid_from,omitempty"`
+	ValidTo   string `json:"valid_to,omitempty"`
+}
+
+type tenantSymbolKey struct {
+	source string
+	symbol string
+}
+
+func (u *tenantSymbolUC) Import(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	format domain.TenantSymbolFormat,
+	r io.Reader,
+	dryRun bool,
+) (domain.TenantSymbolImport, error) {
+	if tenantID == uuid.Nil {
+		return domain.TenantSymbolImport{}, fmt.Errorf("tenant ID is required: %w", apperr.ErrInvalidArgument)
+	}
+
+	records, err := readTenantSymbolRecords(format, r)
+	if err != nil {
+		return domain.TenantSymbolImport{}, err
+	}
+	if len(records) == 0 {
+		return domain.TenantSymbolImport{}, fmt.Errorf("payload has no mappings: %w", apperr.ErrInvalidArgument)
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	res := domain.TenantSymbolImport{Rows: len(records)}
+	symbols := make([]domain.TenantSymbol, len(records))
+	valid := make([]bool, len(records))
+	report := func(i int, kind domain.TenantSymbolIssueKind, msg string) {
+		valid[i] = false
+		if len(res.Issues) < maxImportIssues {
+			res.Issues = append(res.Issues, domain.TenantSymbolIssue{Row: i + 1, Symbol: symbols[i], Kind: kind, Message: msg})
+		}
+	}
+
+	var coinIDs []string
+	for i, rec := range records {
+		s, err := rec.toDomain(tenantID)
+		if err == nil {
+			err = checkTenantSymbol(&s)
+		}
+		symbols[i] = s
+		if err != nil {
+			report(i, domain.TenantSymbolIssueInvalid, err.Error())
+			continue
+		}
+		valid[i] = true
+		coinIDs = append(coinIDs, s.CoinID)
+	}
+
+	foreign, err := foreignCustomCoins(ctx, u.customAssetRepository, tenantID, coinIDs)
+	if err != nil {
+		return domain.TenantSymbolImport{}, err
+	}
+	unknown, err := unknownCoins(ctx, u.catalogRepository, coinIDs)
+	if err != nil {
+		return domain.TenantSymbolImport{}, err
+	}
+	for i, s := range symbols {
+		if !valid[i] {
+			continue
+		}
+		if _, ok := foreign[s.CoinID]; ok {
+			report(i, domain.TenantSymbolIssueUnknownCoin, fmt.Sprintf("coin ID %s is not a custom asset of the tenant", s.CoinID))
+		} else if _, ok := unknown[s.CoinID]; ok {
+			report(i, domain.TenantSymbolIssueUnknownCoin, fmt.Sprintf("coin ID %s is not in the coin catalog", s.CoinID))
+		}
+	}
+
+	stored, err := u.tenantSymbolRepository.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return domain.TenantSymbolImport{}, err
+	}
+	for i, msg := range importConflicts(stored, symbols, valid) {
+		report(i, domain.TenantSymbolIssueConflict, msg)
+	}
+
+	sort.SliceStable(res.Issues, func(i, j int) bool { return res.Issues[i].Row < res.Issues[j].Row })
+	if len(res.Issues) > 0 || dryRun {
+		return res, nil
+	}
+
+	// the check above ran outside the write transaction; the exclusion constraint catches
+	// mappings written since, and the import is then rejected like a checked conflict
+	if err := u.tenantSymbolRepository.Import(ctx, symbols); err != nil {
+		var conflict *domain.ImportConflictError
+		if !errors.As(err, &conflict) || conflict.Row < 1 || conflict.Row > len(symbols) {
+			return domain.TenantSymbolImport{}, err
+		}
+		report(conflict.Row-1, domain.TenantSymbolIssueConflict, fmt.Sprintf("symbol %s overlaps a mapping written during the import", conflict.Symbol))
+		return res, nil
+	}
+	res.Applied = true
+	return res, nil
+}
+
+// importConflicts returns per row index why a valid row conflicts with a stored mapping or an
+// earlier row. Rows replace the stored mapping with their valid_from, so it is skipped.
+func importConflicts(stored, symbols []domain.TenantSymbol, valid []bool) map[int]string {
+	existing := make(map[tenantSymbolKey][]domain.TenantSymbol)
+	for _, e := range stored {
+		k := tenantSymbolKey{e.Source, e.Symbol}
+		existing[k] = append(existing[k], e)
+	}
+
+	replaced := make(map[tenantSymbolKey][]time.Time)
+	for i, s := range symbols {
+		if valid[i] {
+			k := tenantSymbolKey{s.Source, s.Symbol}
+			replaced[k] = append(replaced[k], s.ValidFrom)
+		}
+	}
+
+	out := make(map[int]string)
+	accepted := make(map[tenantSymbolKey][]int)
+	for i, s := range symbols {
+		if !valid[i] {
+			continue
+		}
+		k := tenantSymbolKey{s.Source, s.Symbol}
+
+		if msg := rowConflict(s, symbols, accepted[k]); msg != "" {
+			out[i] = msg
+			continue
+		}
+		if msg := storedConflict(s, existing[k], replaced[k]); msg != "" {
+			out[i] = msg
+			continue
+		}
+		accepted[k] = append(accepted[k], i)
+	}
+	return out
+}
+
+func rowConflict(s domain.TenantSymbol, symbols []domain.TenantSymbol, earlier []int) string {
+	for _, j := range earlier {
+		if symbols[j].ValidFrom.Equal(s.ValidFrom) {
+			return fmt.Sprintf("duplicates row %d", j+1)
+		}
+		if symbols[j].Mapping().Overlaps(s.Mapping()) {
+			return fmt.Sprintf("window overlaps row %d", j+1)
+		}
+	}
+	return ""
+}
+
+func storedConflict(s domain.TenantSymbol, stored []domain.TenantSymbol, replaced []time.Time) string {
+	for _, e := range stored {
+		if containsTime(replaced, e.ValidFrom) {
+			continue
+		}
+		if e.Mapping().Overlaps(s.Mapping()) {
+			return fmt.Sprintf("symbol %s already maps to %s in an overlapping window", s.Symbol, e.CoinID)
+		}
+	}
+	return ""
+}
+
+func containsTime(times []time.Time, t time.Time) bool {
+	for _, x := range times {
+		if x.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *tenantSymbolUC) Export(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	source string,
+	format domain.TenantSymbolFormat,
+	w io.Writer,
+) (int, error) {
+	if tenantID == uuid.Nil {
+		return 0, fmt.Errorf("tenant ID is required: %w", apperr.ErrInvalidArgument)
+	}
+	if format != domain.TenantSymbolFormatCSV && format != domain.TenantSymbolFormatJSON {
+		return 0, fmt.Errorf("unknown format %q: %w", format, apperr.ErrInvalidArgument)
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	var symbols []domain.TenantSymbol
+	var err error
+	if source = strings.TrimSpace(source); source == "" {
+		symbols, err = u.tenantSymbolRepository.ListByTenant(ctx, tenantID)
+	} else {
+		symbols, err = u.tenantSymbolRepository.GetListBySource(ctx, tenantID, source)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	records := make([]tenantSymbolRecord, len(symbols))
+	for i, s := range symbols {
+		records[i] = tenantSymbolRecord{
+			Source:    s.Source,
+			Symbol:    s.Symbol,
+			CoinID:    s.CoinID,
+			ValidFrom: formatValidityBound(s.ValidFrom),
+			ValidTo:   formatValidityBound(s.ValidTo),
+		}
+	}
+
+	if err := writeTenantSymbolRecords(format, w, records); err != nil {
+		return 0, fmt.Errorf("write %s: %w", format, err)
+	}
+	return len(records), nil
+}
+
+func readTenantSymbolRecords(format domain.TenantSymbolFormat, r io.Reader) ([]tenantSymbolRecord, error) {
+	switch format {
+	case domain.TenantSymbolFormatCSV:
+		return readTenantSymbolCSV(r)
+	case domain.TenantSymbolFormatJSON:
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		var records []tenantSymbolRecord
+		if err := dec.Decode(&records); err != nil {
+			return nil, fmt.Errorf("json: %v: %w", err, apperr.ErrInvalidArgument)
+		}
+		return records, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q: %w", format, apperr.ErrInvalidArgument)
+	}
+}
+
+func readTenantSymbolCSV(r io.Reader) ([]tenantSymbolRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(tenantSymbolCSVHeader)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("csv is empty: %w", apperr.ErrInvalidArgument)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %v: %w", err, apperr.ErrInvalidArgument)
+	}
+	for i, name := range tenantSymbolCSVHeader {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), name) {
+			return nil, fmt.Errorf("csv header must be %q: %w", strings.Join(tenantSymbolCSVHeader, ","), apperr.ErrInvalidArgument)
+		}
+	}
+
+	var records []tenantSymbolRecord
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: %v: %w", err, apperr.ErrInvalidArgument)
+		}
+		records = append(records, tenantSymbolRecord{
+			Source:    rec[0],
+			Symbol:    rec[1],
+			CoinID:    rec[2],
+			ValidFrom: rec[3],
+			ValidTo:   rec[4],
+		})
+	}
+	return records, nil
+}
+
+func writeTenantSymbolRecords(format domain.TenantSymbolFormat, w io.Writer, records []tenantSymbolRecord) error {
+	if format == domain.TenantSymbolFormatJSON {
+		return json.NewEncoder(w).Encode(records)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(tenantSymbolCSVHeader); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if err := cw.Write([]string{rec.Source, rec.Symbol, rec.CoinID, rec.ValidFrom, rec.ValidTo}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (rec tenantSymbolRecord) toDomain(tenantID uuid.UUID) (domain.TenantSymbol, error) {
+	s := domain.TenantSymbol{
+		TenantID: tenantID,
+		Source:   rec.Source,
+		Symbol:   rec.Symbol,
+		CoinID:   rec.CoinID,
+	}
+
+	var err error
+	if s.ValidFrom, err = parseValidityBound("valid_from", rec.ValidFrom); err != nil {
+		return s, err
+	}
+	if s.ValidTo, err = parseValidityBound("valid_to", rec.ValidTo); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// parseValidityBound reads a bound like parseCSVTime; empty is an open bound.
+func parseValidityBound(name, v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := parseCSVTime(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s %q: want RFC 3339 or YYYY-MM-DD", name, v)
+	}
+	return t, nil
+}
+
+func formatValidityBound(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
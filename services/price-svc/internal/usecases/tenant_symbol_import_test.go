@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+var (
+	importJan = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	importFeb = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	importMar = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func importRow(symbol, coinID string, from, to time.Time) domain.TenantSymbol {
+	return domain.TenantSymbol{Source: "binance", Symbol: symbol, CoinID: coinID, ValidFrom: from, ValidTo: to}
+}
+
+func TestImportConflicts(t *testing.T) {
+	t.Parallel()
+
+	open := time.Time{}
+	cases := []struct {
+		name    string
+		stored  []domain.TenantSymbol
+		symbols []domain.TenantSymbol
+		invalid []int // rows already rejected by validation
+		want    map[int]string
+	}{
+		{
+			name:    "distinct symbols",
+			symbols: []domain.TenantSymbol{importRow("BTC", "bitcoin", open, open), importRow("ETH", "ethereum", open, open)},
+			want:    map[int]string{},
+		},
+		{
+			name:    "same symbol and valid_from twice",
+			symbols: []domain.TenantSymbol{importRow("BTC", "bitcoin", importJan, open), importRow("BTC", "wrapped-bitcoin", importJan, importFeb)},
+			want:    map[int]string{1: "duplicates row 1"},
+		},
+		{
+			name:    "rows with overlapping windows",
+			symbols: []domain.TenantSymbol{importRow("BTC", "bitcoin", importJan, importMar), importRow("BTC", "wrapped-bitcoin", importFeb, open)},
+			want:    map[int]string{1: "window overlaps row 1"},
+		},
+		{
+			name:    "ticker migration in one import",
+			symbols: []domain.TenantSymbol{importRow("LUNA", "terra-luna", open, importFeb), importRow("LUNA", "terra-luna-2", importFeb, open)},
+			want:    map[int]string{},
+		},
+		{
+			name:    "same symbol on another source",
+			symbols: []domain.TenantSymbol{importRow("BTC", "bitcoin", open, open), {Source: "kraken", Symbol: "BTC", CoinID: "bitcoin"}},
+			want:    map[int]string{},
+		},
+		{
+			name:    "invalid row does not conflict",
+			symbols: []domain.TenantSymbol{importRow("BTC", "", importJan, open), importRow("BTC", "bitcoin", importJan, open)},
+			invalid: []int{0},
+			want:    map[int]string{},
+		},
+		{
+			name:    "overlaps a stored mapping",
+			stored:  []domain.TenantSymbol{importRow("BTC", "bitcoin", open, importFeb)},
+			symbols: []domain.TenantSymbol{importRow("BTC", "wrapped-bitcoin", importJan, open)},
+			want:    map[int]string{0: "symbol BTC already maps to bitcoin in an overlapping window"},
+		},
+		{
+			name:    "replaces the stored mapping with the same valid_from",
+			stored:  []domain.TenantSymbol{importRow("BTC", "bitcoin", importJan, open)},
+			symbols: []domain.TenantSymbol{importRow("BTC", "wrapped-bitcoin", importJan, open)},
+			want:    map[int]string{},
+		},
+		{
+			// the stored open mapping is replaced by row 1, so row 2 only has to fit row 1
+			name:    "replaced stored mapping does not block later rows",
+			stored:  []domain.TenantSymbol{importRow("BTC", "bitcoin", open, open)},
+			symbols: []domain.TenantSymbol{importRow("BTC", "bitcoin", open, importFeb), importRow("BTC", "wrapped-bitcoin", importFeb, open)},
+			want:    map[int]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			valid := make([]bool, len(tc.symbols))
+			for i := range valid {
+				valid[i] = true
+			}
+			for _, i := range tc.invalid {
+				valid[i] = false
+			}
+
+			if got := importConflicts(tc.stored, tc.symbols, valid); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("importConflicts() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadTenantSymbolRecords(t *testing.T) {
+	t.Parallel()
+
+	csv := "Source, Symbol, Coin_ID, Valid_From, Valid_To\n" +
+		"binance,LUNA,terra-luna,,2022-05-28\n" +
+		"binance,LUNA,terra-luna-2,2022-05-28T00:00:00Z,\n"
+	records, err := readTenantSymbolRecords(domain.TenantSymbolFormatCSV, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("readTenantSymbolRecords(csv) error = %v", err)
+	}
+	want := []tenantSymbolRecord{
+		{Source: "binance", Symbol: "LUNA", CoinID: "terra-luna", ValidTo: "2022-05-28"},
+		{Source: "binance", Symbol: "LUNA", CoinID: "terra-luna-2", ValidFrom: "2022-05-28T00:00:00Z"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("csv records = %+v, want %+v", records, want)
+	}
+
+	bad := []struct {
+		name    string
+		format  domain.TenantSymbolFormat
+		payload string
+	}{
+		{name: "empty csv", format: domain.TenantSymbolFormatCSV},
+		{name: "csv header in another order", format: domain.TenantSymbolFormatCSV, payload: "symbol,source,coin_id,valid_from,valid_to\n"},
+		{name: "csv row missing a column", format: domain.TenantSymbolFormatCSV, payload: "source,symbol,coin_id,valid_from,valid_to\nbinance,BTC,bitcoin\n"},
+		{name: "json with an unknown field", format: domain.TenantSymbolFormatJSON, payload: `[{"source":"binance","symbol":"BTC","coinid":"bitcoin"}]`},
+		{name: "unknown format", format: "xml", payload: "<mappings/>"},
+	}
+	for _, tc := range bad {
+		if _, err := readTenantSymbolRecords(tc.format, strings.NewReader(tc.payload)); !errors.Is(err, apperr.ErrInvalidArgument) {
+			t.Fatalf("%s: readTenantSymbolRecords() error = %v, want %v", tc.name, err, apperr.ErrInvalidArgument)
+		}
+	}
+}
+
+func TestValidityBoundRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// an export must import back to the same window, sub-second bounds included
+	for _, bound := range []time.Time{
+		{},
+		importFeb,
+		time.Date(2024, 9, 4, 12, 30, 0, 123456789, time.UTC),
+		time.Date(2024, 9, 4, 15, 30, 0, 500000000, time.FixedZone("UTC+3", 3*60*60)),
+	} {
+		got, err := parseValidityBound("valid_from", formatValidityBound(bound))
+		if err != nil {
+			t.Fatalf("parseValidityBound(formatValidityBound(%s)) error = %v", bound, err)
+		}
+		if !got.Equal(bound) {
+			t.Fatalf("round trip of %s = %s", bound, got)
+		}
+	}
+}
+
+// fakeTenantSymbols starts empty and records what was written.
+type fakeTenantSymbols struct {
+	domain.TenantSymbolRepo
+	written []domain.TenantSymbol
+}
+
+func (r *fakeTenantSymbols) GetList(context.Context, uuid.UUID, string, []string) ([]domain.TenantSymbol, error) {
+	return nil, nil
+}
+
+func (r *fakeTenantSymbols) ListByTenant(context.Context, uuid.UUID) ([]domain.TenantSymbol, error) {
+	return nil, nil
+}
+
+func (r *fakeTenantSymbols) Upsert(_ context.Context, s domain.TenantSymbol) error {
+	r.written = append(r.written, s)
+	return nil
+}
+
+func (r *fakeTenantSymbols) Import(_ context.Context, symbols []domain.TenantSymbol) error {
+	r.written = append(r.written, symbols...)
+	return nil
+}
+
+func TestMappingToCustomAssets(t *testing.T) {
+	t.Parallel()
+
+	tenant, other := uuid.New(), uuid.New()
+	owned := domain.CustomAsset{ID: uuid.New(), TenantID: tenant, Symbol: "PRIV"}
+	foreign := domain.CustomAsset{ID: uuid.New(), TenantID: other, Symbol: "PRIV"}
+	custom := &fakeCustomRepo{assets: []domain.CustomAsset{owned, foreign}}
+
+	cases := []struct {
+		name   string
+		coinID string
+		reject bool
+	}{
+		{name: "own asset", coinID: owned.CoinID()},
+		{name: "provider coin", coinID: "bitcoin"},
+		{name: "other tenant's asset", coinID: foreign.CoinID(), reject: true},
+		{name: "malformed custom ID", coinID: "custom:priv", reject: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeTenantSymbols{}
+			uc := NewTenantSymbolUC(repo, nil, custom, 0)
+
+			err := uc.Upsert(context.Background(), domain.TenantSymbol{TenantID: tenant, Source: "binance", Symbol: "PRIV", CoinID: tc.coinID})
+			if tc.reject != errors.Is(err, apperr.ErrInvalidArgument) || (!tc.reject && err != nil) {
+				t.Fatalf("Upsert() error = %v, want rejected %v", err, tc.reject)
+			}
+
+			payload := "source,symbol,coin_id,valid_from,valid_to\nbinance,PRIV," + tc.coinID + ",,\n"
+			res, err := uc.Import(context.Background(), tenant, domain.TenantSymbolFormatCSV, strings.NewReader(payload), false)
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if !tc.reject {
+				if len(res.Issues) != 0 || !res.Applied {
+					t.Fatalf("import = %+v, want applied", res)
+				}
+				return
+			}
+			if res.Applied || len(res.Issues) != 1 || res.Issues[0].Kind != domain.TenantSymbolIssueUnknownCoin {
+				t.Fatalf("import = %+v, want one unknown-coin issue", res)
+			}
+			if len(repo.written) != 0 {
+				t.Fatalf("rejected mapping was written: %+v", repo.written)
+			}
+		})
+	}
+}
@@ -2,40 +2,200 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
 	"github.com/google/uuid"
 )
 
 type tenantSymbolUC struct {
 	tenantSymbolRepository domain.TenantSymbolRepo
+	catalogRepository      domain.CoinCatalogRepo
+	customAssetRepository  domain.CustomAssetRepo
 	contextTimeout         time.Duration
 }
 
-func NewTenantSymbolUC(tenantSymbolRepository domain.TenantSymbolRepo, timeout time.Duration) domain.TenantSymbolUseCase {
+// NewTenantSymbolUC builds the usecase; catalogRepository is nil when catalog syncing is disabled,
+// and provider coin IDs are then not checked. Custom asset coin IDs are always checked.
+func NewTenantSymbolUC(
+	tenantSymbolRepository domain.TenantSymbolRepo,
+	catalogRepository domain.CoinCatalogRepo,
+	customAssetRepository domain.CustomAssetRepo,
+	timeout time.Duration,
+) domain.TenantSymbolUseCase {
 	return &tenantSymbolUC{
 		tenantSymbolRepository: tenantSymbolRepository,
+		catalogRepository:      catalogRepository,
+		customAssetRepository:  customAssetRepository,
 		contextTimeout:         timeout,
 	}
 }
 
+func (u *tenantSymbolUC) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.contextTimeout > 0 {
+		return context.WithTimeout(ctx, u.contextTimeout)
+	}
+	return ctx, func() {}
+}
+
+// Upsert stores a mapping after checking it with validateTenantSymbol.
 func (u *tenantSymbolUC) Upsert(ctx context.Context, s domain.TenantSymbol) error {
-	// Implementation goes here
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	if err := validateTenantSymbol(ctx, u.tenantSymbolRepository, u.catalogRepository, u.customAssetRepository, &s); err != nil {
+		return err
+	}
+
+	return u.tenantSymbolRepository.Upsert(ctx, s)
+}
+
+// validateTenantSymbol trims s and checks the coin against the catalog, or against the tenant's
+// own assets for a custom asset coin ID. A mapping with the same valid_from replaces the stored
+// one; a window overlapping another mapping of the symbol is a conflict.
+func validateTenantSymbol(ctx context.Context, symbols domain.TenantSymbolRepo, catalog domain.CoinCatalogRepo, custom domain.CustomAssetRepo, s *domain.TenantSymbol) error {
+	if err := checkTenantSymbol(s); err != nil {
+		return err
+	}
+
+	foreign, err := foreignCustomCoins(ctx, custom, s.TenantID, []string{s.CoinID})
+	if err != nil {
+		return err
+	}
+	if _, ok := foreign[s.CoinID]; ok {
+		return fmt.Errorf("coin ID %s is not a custom asset of the tenant: %w", s.CoinID, apperr.ErrInvalidArgument)
+	}
+
+	unknown, err := unknownCoins(ctx, catalog, []string{s.CoinID})
+	if err != nil {
+		return err
+	}
+	if _, ok := unknown[s.CoinID]; ok {
+		return fmt.Errorf("coin ID %s is not in the coin catalog: %w", s.CoinID, apperr.ErrInvalidArgument)
+	}
+
+	existing, err := symbols.GetList(ctx, s.TenantID, s.Source, []string{s.Symbol})
+	if err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if e.ValidFrom.Equal(s.ValidFrom) {
+			continue // replaced by this upsert
+		}
+		if e.Mapping().Overlaps(s.Mapping()) {
+			return fmt.Errorf("symbol %s already maps to %s in an overlapping window: %w", s.Symbol, e.CoinID, apperr.ErrConflict)
+		}
+	}
+
 	return nil
 }
 
-func (u *tenantSymbolUC) Delete(ctx context.Context, tenantID uuid.UUID, source, symbol string) error {
-	// Implementation goes here
+// foreignCustomCoins returns the custom asset coin IDs that do not name one of the tenant's
+// assets. Mapping a symbol to another tenant's asset would price it from that tenant's series.
+func foreignCustomCoins(ctx context.Context, custom domain.CustomAssetRepo, tenantID uuid.UUID, coinIDs []string) (map[string]struct{}, error) {
+	var customIDs []string
+	for _, id := range coinIDs {
+		if domain.IsCustomCoinID(id) {
+			customIDs = append(customIDs, id)
+		}
+	}
+	if len(customIDs) == 0 {
+		return nil, nil
+	}
+
+	owned := make(map[string]struct{})
+	if custom != nil {
+		assets, err := custom.List(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range assets {
+			owned[a.CoinID()] = struct{}{}
+		}
+	}
+
+	foreign := make(map[string]struct{})
+	for _, id := range customIDs {
+		if _, ok := owned[id]; !ok {
+			foreign[id] = struct{}{}
+		}
+	}
+	return foreign, nil
+}
+
+// unknownCoins returns the provider coin IDs missing from the catalog. Nothing is checked when
+// catalog is nil or was never synced: a catalog that is not kept up to date would reject valid
+// coins. Custom asset coin IDs are left to foreignCustomCoins.
+func unknownCoins(ctx context.Context, catalog domain.CoinCatalogRepo, coinIDs []string) (map[string]struct{}, error) {
+	coinIDs = slices.DeleteFunc(slices.Clone(coinIDs), domain.IsCustomCoinID)
+	if catalog == nil || len(coinIDs) == 0 {
+		return nil, nil
+	}
+
+	synced, err := catalog.LastSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if synced.IsZero() {
+		return nil, nil
+	}
+
+	coins, err := catalog.GetByIDs(ctx, coinIDs)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(coins))
+	for _, c := range coins {
+		known[c.ID] = struct{}{}
+	}
+
+	unknown := make(map[string]struct{})
+	for _, id := range coinIDs {
+		if _, ok := known[id]; !ok {
+			unknown[id] = struct{}{}
+		}
+	}
+	return unknown, nil
+}
+
+// checkTenantSymbol trims s and checks the fields that need no lookup.
+func checkTenantSymbol(s *domain.TenantSymbol) error {
+	s.Source = strings.TrimSpace(s.Source)
+	s.Symbol = strings.TrimSpace(s.Symbol)
+	s.CoinID = strings.TrimSpace(s.CoinID)
+	if s.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required: %w", apperr.ErrInvalidArgument)
+	}
+	if s.Source == "" || s.Symbol == "" || s.CoinID == "" {
+		return fmt.Errorf("source, symbol and coin ID are required: %w", apperr.ErrInvalidArgument)
+	}
+	if !s.ValidFrom.IsZero() && !s.ValidTo.IsZero() && !s.ValidFrom.Before(s.ValidTo) {
+		return fmt.Errorf("valid_from must be before valid_to: %w", apperr.ErrInvalidArgument)
+	}
 	return nil
 }
 
+func (u *tenantSymbolUC) Delete(ctx context.Context, tenantID uuid.UUID, source, symbol string) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.tenantSymbolRepository.Delete(ctx, tenantID, source, symbol)
+}
+
 func (u *tenantSymbolUC) GetList(ctx context.Context, tenantID uuid.UUID, source string, symbols []string) ([]domain.TenantSymbol, error) {
-	// Implementation goes here
-	return nil, nil
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.tenantSymbolRepository.GetList(ctx, tenantID, source, symbols)
 }
 
 func (u *tenantSymbolUC) GetListBySource(ctx context.Context, tenantID uuid.UUID, source string) ([]domain.TenantSymbol, error) {
-	// Implementation goes here
-	return nil, nil
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.tenantSymbolRepository.GetListBySource(ctx, tenantID, source)
 }
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+type unresolvedSymbolUC struct {
+	repo              domain.UnresolvedSymbolRepo
+	tenantSymbolRepo  domain.TenantSymbolRepo
+	catalogRepository domain.CoinCatalogRepo
+	customAssetRepo   domain.CustomAssetRepo
+	contextTimeout    time.Duration
+}
+
+// NewUnresolvedSymbolUC builds the usecase; catalogRepository is nil when catalog syncing is
+// disabled, and provider coin IDs are then not checked.
+func NewUnresolvedSymbolUC(
+	repo domain.UnresolvedSymbolRepo,
+	tenantSymbolRepo domain.TenantSymbolRepo,
+	catalogRepository domain.CoinCatalogRepo,
+	customAssetRepo domain.CustomAssetRepo,
+	timeout time.Duration,
+) domain.UnresolvedSymbolUseCase {
+	return &unresolvedSymbolUC{
+		repo:              repo,
+		tenantSymbolRepo:  tenantSymbolRepo,
+		catalogRepository: catalogRepository,
+		customAssetRepo:   customAssetRepo,
+		contextTimeout:    timeout,
+	}
+}
+
+func (u *unresolvedSymbolUC) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.contextTimeout > 0 {
+		return context.WithTimeout(ctx, u.contextTimeout)
+	}
+	return ctx, func() {}
+}
+
+// Record queues symbols only for a tenant and source, the scope a tenant mapping can fix.
+func (u *unresolvedSymbolUC) Record(ctx context.Context, tenantID uuid.UUID, source string, symbols []domain.UnresolvedSymbol) error {
+	if tenantID == uuid.Nil || source == "" || len(symbols) == 0 {
+		return nil
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Record(ctx, tenantID, source, symbols)
+}
+
+func (u *unresolvedSymbolUC) List(ctx context.Context, filter domain.UnresolvedSymbolFilter) ([]domain.UnresolvedSymbol, error) {
+	if filter.AfterID < 0 || filter.Limit <= 0 {
+		return nil, fmt.Errorf("invalid page: %w", apperr.ErrInvalidArgument)
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.List(ctx, filter)
+}
+
+func (u *unresolvedSymbolUC) Resolve(ctx context.Context, s domain.TenantSymbol) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	if err := validateTenantSymbol(ctx, u.tenantSymbolRepo, u.catalogRepository, u.customAssetRepo, &s); err != nil {
+		return err
+	}
+
+	return u.repo.Resolve(ctx, s)
+}
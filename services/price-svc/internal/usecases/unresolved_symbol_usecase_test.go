@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+// fakeQueue records what reached the queue store.
+type fakeQueue struct {
+	domain.UnresolvedSymbolRepo
+	recorded []domain.UnresolvedSymbol
+	listed   *domain.UnresolvedSymbolFilter
+	resolved []domain.TenantSymbol
+}
+
+func (q *fakeQueue) Record(_ context.Context, _ uuid.UUID, _ string, symbols []domain.UnresolvedSymbol) error {
+	q.recorded = append(q.recorded, symbols...)
+	return nil
+}
+
+func (q *fakeQueue) List(_ context.Context, filter domain.UnresolvedSymbolFilter) ([]domain.UnresolvedSymbol, error) {
+	q.listed = &filter
+	return nil, nil
+}
+
+func (q *fakeQueue) Resolve(_ context.Context, s domain.TenantSymbol) error {
+	q.resolved = append(q.resolved, s)
+	return nil
+}
+
+// overlappingSymbols holds one open-ended mapping per symbol asked for.
+type overlappingSymbols struct {
+	domain.TenantSymbolRepo
+}
+
+func (overlappingSymbols) GetList(_ context.Context, tenantID uuid.UUID, source string, symbols []string) ([]domain.TenantSymbol, error) {
+	out := make([]domain.TenantSymbol, len(symbols))
+	for i, s := range symbols {
+		out[i] = domain.TenantSymbol{TenantID: tenantID, Source: source, Symbol: s, CoinID: "stored", ValidFrom: importJan}
+	}
+	return out, nil
+}
+
+func TestUnresolvedRecordNeedsTenantAndSource(t *testing.T) {
+	t.Parallel()
+
+	tenant := uuid.New()
+	symbols := []domain.UnresolvedSymbol{{Symbol: "NEW", Reason: domain.UnresolvedUnknown, Occurrences: 2}}
+	cases := []struct {
+		name   string
+		tenant uuid.UUID
+		source string
+		queued int
+	}{
+		{name: "tenant and source", tenant: tenant, source: "binance", queued: 1},
+		{name: "no tenant", source: "binance"},
+		{name: "no source", tenant: tenant},
+	}
+	for _, tc := range cases {
+		queue := &fakeQueue{}
+		uc := NewUnresolvedSymbolUC(queue, &fakeTenantSymbols{}, nil, nil, 0)
+		if err := uc.Record(context.Background(), tc.tenant, tc.source, symbols); err != nil {
+			t.Fatalf("%s: Record() error = %v", tc.name, err)
+		}
+		if len(queue.recorded) != tc.queued {
+			t.Fatalf("%s: queued %d symbols, want %d", tc.name, len(queue.recorded), tc.queued)
+		}
+	}
+}
+
+func TestUnresolvedListChecksPage(t *testing.T) {
+	t.Parallel()
+
+	queue := &fakeQueue{}
+	uc := NewUnresolvedSymbolUC(queue, &fakeTenantSymbols{}, nil, nil, 0)
+
+	for _, f := range []domain.UnresolvedSymbolFilter{{Limit: 0}, {AfterID: -1, Limit: 10}} {
+		if _, err := uc.List(context.Background(), f); !errors.Is(err, apperr.ErrInvalidArgument) {
+			t.Fatalf("List(%+v) error = %v, want ErrInvalidArgument", f, err)
+		}
+	}
+	if queue.listed != nil {
+		t.Fatalf("invalid page reached the store")
+	}
+
+	want := domain.UnresolvedSymbolFilter{TenantID: uuid.New(), Source: "kraken", AfterID: 7, Limit: 10}
+	if _, err := uc.List(context.Background(), want); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if queue.listed == nil || *queue.listed != want {
+		t.Fatalf("store filter = %+v, want %+v", queue.listed, want)
+	}
+}
+
+func TestUnresolvedResolveValidatesMapping(t *testing.T) {
+	t.Parallel()
+
+	tenant := uuid.New()
+	owned := domain.CustomAsset{ID: uuid.New(), TenantID: tenant, Symbol: "PRIV"}
+	custom := &fakeCustomRepo{assets: []domain.CustomAsset{owned, {ID: uuid.New(), TenantID: uuid.New()}}}
+
+	cases := []struct {
+		name    string
+		symbols domain.TenantSymbolRepo
+		mapping domain.TenantSymbol
+		err     error
+	}{
+		{name: "valid", symbols: &fakeTenantSymbols{}, mapping: domain.TenantSymbol{TenantID: tenant, Source: " binance ", Symbol: " NEW ", CoinID: "new-coin"}},
+		{name: "own custom asset", symbols: &fakeTenantSymbols{}, mapping: domain.TenantSymbol{TenantID: tenant, Source: "binance", Symbol: "PRIV", CoinID: owned.CoinID()}},
+		{name: "other tenant's custom asset", symbols: &fakeTenantSymbols{}, mapping: domain.TenantSymbol{TenantID: tenant, Source: "binance", Symbol: "PRIV", CoinID: custom.assets[1].CoinID()}, err: apperr.ErrInvalidArgument},
+		{name: "no coin", symbols: &fakeTenantSymbols{}, mapping: domain.TenantSymbol{TenantID: tenant, Source: "binance", Symbol: "NEW"}, err: apperr.ErrInvalidArgument},
+		{name: "no tenant", symbols: &fakeTenantSymbols{}, mapping: domain.TenantSymbol{Source: "binance", Symbol: "NEW", CoinID: "new-coin"}, err: apperr.ErrInvalidArgument},
+		{name: "overlaps a stored mapping", symbols: overlappingSymbols{}, mapping: domain.TenantSymbol{TenantID: tenant, Source: "binance", Symbol: "NEW", CoinID: "new-coin"}, err: apperr.ErrConflict},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			queue := &fakeQueue{}
+			err := NewUnresolvedSymbolUC(queue, tc.symbols, nil, custom, 0).Resolve(context.Background(), tc.mapping)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) || len(queue.resolved) != 0 {
+					t.Fatalf("Resolve() error = %v with %d written, want %v and nothing written", err, len(queue.resolved), tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if len(queue.resolved) != 1 || queue.resolved[0].Source != "binance" || queue.resolved[0].Symbol != strings.TrimSpace(tc.mapping.Symbol) {
+				t.Fatalf("resolved = %+v, want the trimmed mapping", queue.resolved)
+			}
+		})
+	}
+}
@@ -39,6 +39,7 @@ func normalizeByOrder(
 			Time:               bucket,
 			PriceUsd:           &dec,
 			GranularitySeconds: &g,
+			Provider:           domain.ProviderCoinGecko,
 		})
 	}
 	return out, nil
@@ -57,3 +58,11 @@ func floorToBucket(t time.Time, granularity time.Duration) time.Time {
 	floored := (sec / g) * g
 	return time.Unix(floored, 0).UTC()
 }
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
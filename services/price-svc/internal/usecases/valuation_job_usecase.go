@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain"
+	apperr "github.com/NightRunner/CryptoTax-Go/services/price-svc/internal/domain/error"
+	"github.com/google/uuid"
+)
+
+type valuationJobUC struct {
+	repo           domain.ValuationJobRepo
+	contextTimeout time.Duration
+}
+
+func NewValuationJobUC(repo domain.ValuationJobRepo, timeout time.Duration) domain.ValuationJobUseCase {
+	return &valuationJobUC{
+		repo:           repo,
+		contextTimeout: timeout,
+	}
+}
+
+func (u *valuationJobUC) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if u.contextTimeout > 0 {
+		return context.WithTimeout(ctx, u.contextTimeout)
+	}
+	return ctx, func() {}
+}
+
+func (u *valuationJobUC) Submit(ctx context.Context, tenantID string, request []byte, txs [][]byte) (domain.ValuationJob, error) {
+	if len(txs) == 0 {
+		return domain.ValuationJob{}, fmt.Errorf("job has no transactions: %w", apperr.ErrInvalidArgument)
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	job := domain.ValuationJob{
+		ID:       uuid.New(),
+		TenantID: tenantID,
+		Status:   domain.JobStatusQueued,
+		Request:  request,
+		Total:    len(txs),
+	}
+	if err := u.repo.Create(ctx, job, txs); err != nil {
+		return domain.ValuationJob{}, fmt.Errorf("repo.Create: %w", err)
+	}
+
+	return u.repo.Get(ctx, tenantID, job.ID)
+}
+
+func (u *valuationJobUC) Get(ctx context.Context, tenantID string, id uuid.UUID) (domain.ValuationJob, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Get(ctx, tenantID, id)
+}
+
+func (u *valuationJobUC) ListResults(ctx context.Context, tenantID string, id uuid.UUID, afterSeq, limit int) ([]domain.ValuationJobItem, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := u.repo.Get(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+	return u.repo.ListResults(ctx, id, afterSeq, limit)
+}
+
+func (u *valuationJobUC) Claim(ctx context.Context, lease time.Duration) (*domain.ValuationJob, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Claim(ctx, lease)
+}
+
+func (u *valuationJobUC) NextItems(ctx context.Context, id uuid.UUID, limit int) ([]domain.ValuationJobItem, error) {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.ListPending(ctx, id, limit)
+}
+
+func (u *valuationJobUC) SaveResults(ctx context.Context, id, leaseToken uuid.UUID, items []domain.ValuationJobItem, lease time.Duration) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.SaveResults(ctx, id, leaseToken, items, lease)
+}
+
+func (u *valuationJobUC) Finish(ctx context.Context, id, leaseToken uuid.UUID, jobErr error) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	if jobErr != nil {
+		return u.repo.Finish(ctx, id, leaseToken, domain.JobStatusFailed, jobErr.Error())
+	}
+	return u.repo.Finish(ctx, id, leaseToken, domain.JobStatusSucceeded, "")
+}
+
+func (u *valuationJobUC) Release(ctx context.Context, id, leaseToken uuid.UUID) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Release(ctx, id, leaseToken)
+}
+
+func (u *valuationJobUC) Retry(ctx context.Context, id, leaseToken uuid.UUID, delay time.Duration) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	return u.repo.Retry(ctx, id, leaseToken, delay)
+}
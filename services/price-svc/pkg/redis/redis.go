@@ -23,7 +23,9 @@ type Redis struct {
 	jitter time.Duration
 }
 
-func New(ctx context.Context, url string, jitter time.Duration, opts ...Option) (Cache, error) {
+var _ Cache = (*Redis)(nil)
+
+func New(ctx context.Context, url string, jitter time.Duration, opts ...Option) (*Redis, error) {
 	redisConfig, err := redis.ParseURL(url)
 
 	if err != nil {
@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type TokenBucket interface {
+	TakeToken(ctx context.Context, key string, ratePerSec float64, burst int) (time.Duration, error)
+}
+
+var _ TokenBucket = (*Redis)(nil)
+
+// tokenBucketScript refills the bucket by elapsed time (Redis clock, so all replicas agree)
+// and takes one token. Returns 0 on success or milliseconds to wait before the next attempt.
+var tokenBucketScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+else
+  wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+
+// TakeToken takes one token from the bucket shared by every client of the same key.
+// A zero duration means the token was taken, otherwise the caller should retry after it.
+func (r *Redis) TakeToken(ctx context.Context, key string, ratePerSec float64, burst int) (time.Duration, error) {
+	if ratePerSec <= 0 || burst <= 0 {
+		return 0, fmt.Errorf("token bucket %q: bad rate=%v burst=%d", key, ratePerSec, burst)
+	}
+
+	ms, err := tokenBucketScript.Run(ctx, r.client, []string{key}, ratePerSec, burst).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("token bucket %q: %w", key, err)
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
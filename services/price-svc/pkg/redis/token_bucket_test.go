@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTakeTokenRejectsBadLimits(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		rate  float64
+		burst int
+	}{
+		{0, 10},
+		{-1, 10},
+		{1, 0},
+		{1, -5},
+	}
+
+	r := &Redis{}
+	for _, tc := range cases {
+		if _, err := r.TakeToken(context.Background(), "ratelimit:test", tc.rate, tc.burst); err == nil {
+			t.Fatalf("TakeToken(rate=%v, burst=%d) error = nil, want error", tc.rate, tc.burst)
+		}
+	}
+}
+
+// TestTakeTokenBucket runs the script against the Redis in REDIS_URL.
+func TestTakeTokenBucket(t *testing.T) {
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		t.Skip("REDIS_URL is not set")
+	}
+
+	ctx := context.Background()
+	r, err := New(ctx, url, 0)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer r.Close()
+
+	cases := []struct {
+		name  string
+		rate  float64
+		burst int
+		// takes are made back to back; want reports whether each got a token at once
+		want []bool
+	}{
+		{name: "burst then wait", rate: 1, burst: 3, want: []bool{true, true, true, false}},
+		{name: "single token", rate: 0.5, burst: 1, want: []bool{true, false, false}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := fmt.Sprintf("ratelimit:test:%s:%d", t.Name(), time.Now().UnixNano())
+			defer r.Del(ctx, key)
+
+			for i, want := range tc.want {
+				wait, err := r.TakeToken(ctx, key, tc.rate, tc.burst)
+				if err != nil {
+					t.Fatalf("take %d: TakeToken() error = %v", i, err)
+				}
+				if got := wait == 0; got != want {
+					t.Fatalf("take %d: got token = %v (wait %s), want %v", i, got, wait, want)
+				}
+				if !want && wait > time.Duration(float64(time.Second)/tc.rate)+time.Millisecond {
+					t.Fatalf("take %d: wait = %s, longer than one refill at rate %v", i, wait, tc.rate)
+				}
+			}
+		})
+	}
+}